@@ -35,7 +35,7 @@ func InitMysql(db databaseInterface.Database) {
 		}
 
 		if models.GetConfig().Migrate {
-			logger.Info("Migrate flag is enabled — running AutoMigrate, CreateIndexes, and seed data")
+			logger.Info("Migrate flag is enabled — running AutoMigrate, data migrations, CreateIndexes, and seed data")
 
 			if err := database.Orm().AutoMigrate(
 				&models.User{},
@@ -50,11 +50,52 @@ func InitMysql(db databaseInterface.Database) {
 				&models.ItemSales{},
 				&models.AuditLog{},
 				&models.FiberAllocation{},
+				&models.Product{},
+				&models.PriceBoard{},
+				&models.PriceList{},
+				&models.PriceListItem{},
+				&models.PriceListTier{},
+				&models.PurchaseOrder{},
+				&models.PurchaseOrderItem{},
+				&models.GoodsReceipt{},
+				&models.GoodsReceiptItem{},
+				&models.SalesOrder{},
+				&models.SalesOrderItem{},
+				&models.DeliveryNote{},
+				&models.DeliveryNoteItem{},
+				&models.DeliveryNoteFiber{},
+				&models.DeliveryStatusHistory{},
+				&models.SupplierReturn{},
+				&models.PurchaseCost{},
+				&models.PurchaseCostAllocation{},
+				&models.Notification{},
+				&models.Location{},
+				&models.StockTransfer{},
+				&models.StockTransferItem{},
+				&models.StockTransferFiber{},
+				&models.TemperatureReading{},
+				&models.TemperatureReadingSort{},
+				&models.ProcessingOrder{},
+				&models.ProcessingInput{},
+				&models.ProcessingOutput{},
+				&models.Account{},
+				&models.JournalEntry{},
+				&models.JournalLine{},
+				&models.AccountingPeriod{},
+				&models.CashAccount{},
+				&models.CashTransfer{},
+				&models.BankStatement{},
+				&models.BankStatementLine{},
+				&models.ExpenseCategory{},
+				&models.CostCenter{},
+				&models.RecurringExpense{},
+				&models.PaymentAttachment{},
 			); err != nil {
 				logger.Error("Error when migrate table, with err: %s", err)
 				return
 			}
 
+			RunDataMigrations(database.Orm())
 			CreateIndexes(database.Orm())
 			autoInitSuperAdmin(database.Orm())
 		} else {
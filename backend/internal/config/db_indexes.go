@@ -42,6 +42,13 @@ func CreateIndexes(db *gorm.DB) {
 		// Covers: LATERAL sub-queries ORDER BY created_at DESC LIMIT 1
 		`CREATE INDEX IF NOT EXISTS idx_payment_sales_id_created ON payment (sales_id, created_at DESC) WHERE deleted = false`,
 		`CREATE INDEX IF NOT EXISTS idx_payment_purchase_id_created ON payment (purchase_id, created_at DESC) WHERE deleted = false`,
+		// Covers: DeleteCashAccount usage check
+		`CREATE INDEX IF NOT EXISTS idx_payment_cash_account_id ON payment (cash_account_id) WHERE deleted = false`,
+		// Covers: GetMonthlyExpenseBreakdown, DeleteExpenseCategory (usage check)
+		`CREATE INDEX IF NOT EXISTS idx_payment_type_created ON payment (type, created_at) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_expense_category_id ON payment (expense_category_id) WHERE deleted = false`,
+		// Covers: GetMonthlyExpenseBreakdown (cost center filter), DeleteCostCenter (usage check)
+		`CREATE INDEX IF NOT EXISTS idx_payment_cost_center_id ON payment (cost_center_id) WHERE deleted = false`,
 
 		// =====================================================
 		// stock_entries table
@@ -58,6 +65,8 @@ func CreateIndexes(db *gorm.DB) {
 		`CREATE INDEX IF NOT EXISTS idx_stock_items_created_at ON stock_items (created_at) WHERE deleted = false`,
 		// Covers: applyKeywordFilter (item_name ILIKE search)
 		`CREATE INDEX IF NOT EXISTS idx_stock_items_item_name ON stock_items (item_name) WHERE deleted = false`,
+		// Covers: UpdateProduct rename, product analytics
+		`CREATE INDEX IF NOT EXISTS idx_stock_items_product_id ON stock_items (product_id) WHERE deleted = false`,
 
 		// =====================================================
 		// stock_sorts table
@@ -72,13 +81,188 @@ func CreateIndexes(db *gorm.DB) {
 		`CREATE INDEX IF NOT EXISTS idx_stock_sorts_sorted_name ON stock_sorts (sorted_item_name) WHERE deleted = false`,
 		// Covers: GetAnalyticStats (current_weight SUM where not shrinkage)
 		`CREATE INDEX IF NOT EXISTS idx_stock_sorts_current_weight ON stock_sorts (current_weight) WHERE deleted = false AND is_shrinkage = false`,
+		// Covers: GetTopPerformingItems, GetProductDistributionData, DeleteProduct usage check
+		`CREATE INDEX IF NOT EXISTS idx_stock_sorts_product_id ON stock_sorts (product_id) WHERE deleted = false`,
+
+		// =====================================================
+		// products table
+		// =====================================================
+		// Covers: productResolver code lookup, unique active product codes
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_products_code ON products (code) WHERE deleted = false`,
+
+		// =====================================================
+		// price_boards table
+		// =====================================================
+		// Covers: effectivePrice, GetPriceBoard (latest price on or before a date)
+		`CREATE INDEX IF NOT EXISTS idx_price_boards_product_date ON price_boards (product_id, grade, price_date DESC) WHERE deleted = false`,
+
+		// =====================================================
+		// price_lists / price_list_items / price_list_tiers tables
+		// =====================================================
+		// Covers: applyCustomerPriceList, validatePriceList (active list per customer and period)
+		`CREATE INDEX IF NOT EXISTS idx_price_lists_customer_valid ON price_lists (customer_id, valid_from DESC) WHERE deleted = false`,
+		// Covers: price list item lookups per list
+		`CREATE INDEX IF NOT EXISTS idx_price_list_items_list_id ON price_list_items (price_list_id) WHERE deleted = false`,
+		// Covers: fetchPriceListTiers
+		`CREATE INDEX IF NOT EXISTS idx_price_list_tiers_item_id ON price_list_tiers (price_list_item_id) WHERE deleted = false`,
+
+		// =====================================================
+		// purchase_orders / goods_receipts tables
+		// =====================================================
+		// Covers: GetAllPurchaseOrders, GetPurchaseVariance (supplier + date filters)
+		`CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_date ON purchase_orders (supplier_id, order_date DESC) WHERE deleted = false`,
+		// Covers: GetAllPurchaseOrders (status filter)
+		`CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders (status) WHERE deleted = false`,
+		// Covers: purchase order item lookups and outstanding weight check in ReceiveGoods
+		`CREATE INDEX IF NOT EXISTS idx_purchase_order_items_order_id ON purchase_order_items (purchase_order_id) WHERE deleted = false`,
+		// Covers: receipts per purchase order
+		`CREATE INDEX IF NOT EXISTS idx_goods_receipts_order_id ON goods_receipts (purchase_order_id) WHERE deleted = false`,
+		// Covers: received weight and amount per order line
+		`CREATE INDEX IF NOT EXISTS idx_goods_receipt_items_order_item_id ON goods_receipt_items (purchase_order_item_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_goods_receipt_items_receipt_id ON goods_receipt_items (goods_receipt_id) WHERE deleted = false`,
+
+		// =====================================================
+		// sales_orders / sales_order_items tables
+		// =====================================================
+		// Covers: GetAllSalesOrders, ExpireSalesOrders (open orders by expiry)
+		`CREATE INDEX IF NOT EXISTS idx_sales_orders_status_expires ON sales_orders (status, expires_at) WHERE deleted = false`,
+		// Covers: GetAllSalesOrders (customer filter)
+		`CREATE INDEX IF NOT EXISTS idx_sales_orders_customer_id ON sales_orders (customer_id) WHERE deleted = false`,
+		// Covers: sales order lines per order
+		`CREATE INDEX IF NOT EXISTS idx_sales_order_items_order_id ON sales_order_items (sales_order_id) WHERE deleted = false`,
+		// Covers: reservedSortWeights, GetAllStockSorts (reserved weight per sort)
+		`CREATE INDEX IF NOT EXISTS idx_sales_order_items_stock_sort_id ON sales_order_items (stock_sort_id) WHERE deleted = false`,
+
+		// =====================================================
+		// delivery_notes and related tables
+		// =====================================================
+		// Covers: CreateDeliveryNote (active note per sale), GetAllDeliveryNotes (sale filter)
+		`CREATE INDEX IF NOT EXISTS idx_delivery_notes_sale_id ON delivery_notes (sale_id) WHERE deleted = false`,
+		// Covers: GetAllDeliveryNotes (status + date filters)
+		`CREATE INDEX IF NOT EXISTS idx_delivery_notes_status_date ON delivery_notes (status, delivery_date DESC) WHERE deleted = false`,
+		// Covers: items, fibers and history per delivery note
+		`CREATE INDEX IF NOT EXISTS idx_delivery_note_items_note_id ON delivery_note_items (delivery_note_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_delivery_note_fibers_note_id ON delivery_note_fibers (delivery_note_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_delivery_status_histories_note_id ON delivery_status_histories (delivery_note_id, created_at)`,
+
+		// =====================================================
+		// supplier_returns table
+		// =====================================================
+		// Covers: GetAllSupplierReturns (supplier filter + date ordering), GetSupplierPerformance
+		`CREATE INDEX IF NOT EXISTS idx_supplier_returns_supplier_date ON supplier_returns (supplier_id, return_date DESC) WHERE deleted = false`,
+		// Covers: GetAllSupplierReturns (purchase filter)
+		`CREATE INDEX IF NOT EXISTS idx_supplier_returns_purchase_id ON supplier_returns (purchase_id) WHERE deleted = false`,
+
+		// =====================================================
+		// purchase_costs and purchase_cost_allocations tables
+		// =====================================================
+		// Covers: GetLandedCosts, allocateLandedCosts (cost lines per purchase)
+		`CREATE INDEX IF NOT EXISTS idx_purchase_costs_purchase_id ON purchase_costs (purchase_id) WHERE deleted = false`,
+		// Covers: allocateLandedCosts (reset), GetLandedCosts (allocations per purchase)
+		`CREATE INDEX IF NOT EXISTS idx_purchase_cost_allocations_purchase_id ON purchase_cost_allocations (purchase_id) WHERE deleted = false`,
+
+		// =====================================================
+		// notifications table
+		// =====================================================
+		// Covers: GetAllNotifications (unread filter + newest first), unread badge count
+		`CREATE INDEX IF NOT EXISTS idx_notifications_read_created ON notifications (is_read, created_at DESC) WHERE deleted = false`,
+		// Covers: notify (dedupe per type and referenced record)
+		`CREATE INDEX IF NOT EXISTS idx_notifications_reference ON notifications (type, reference_type, reference_id) WHERE deleted = false`,
+
+		// =====================================================
+		// locations and stock_transfers tables
+		// =====================================================
+		// Covers: GetAllStockSorts, GetStockDistributionData, location summaries (stock per location)
+		`CREATE INDEX IF NOT EXISTS idx_stock_sorts_location_id ON stock_sorts (location_id) WHERE deleted = false`,
+		// Covers: GetAllFibers (location filter), location summaries
+		`CREATE INDEX IF NOT EXISTS idx_fibers_location_id ON fibers (location_id) WHERE deleted = false`,
+		// Covers: GetAllStockTransfers (date ordering)
+		`CREATE INDEX IF NOT EXISTS idx_stock_transfers_date ON stock_transfers (transfer_date DESC) WHERE deleted = false`,
+		// Covers: transfer lines and fibers per document
+		`CREATE INDEX IF NOT EXISTS idx_stock_transfer_items_transfer_id ON stock_transfer_items (stock_transfer_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_stock_transfer_fibers_transfer_id ON stock_transfer_fibers (stock_transfer_id) WHERE deleted = false`,
+
+		// =====================================================
+		// temperature_readings and temperature_reading_sorts tables
+		// =====================================================
+		// Covers: GetAllTemperatureReadings (location/fiber filter + newest first)
+		`CREATE INDEX IF NOT EXISTS idx_temperature_readings_location ON temperature_readings (location_id, recorded_at DESC) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_temperature_readings_fiber ON temperature_readings (fiber_id, recorded_at DESC) WHERE deleted = false`,
+		// Covers: temperatureHistory (fiber readings per sale)
+		`CREATE INDEX IF NOT EXISTS idx_temperature_readings_sale_id ON temperature_readings (sale_id) WHERE deleted = false`,
+		// Covers: temperatureHistory (readings per stock sort)
+		`CREATE INDEX IF NOT EXISTS idx_temperature_reading_sorts_sort ON temperature_reading_sorts (stock_sort_id, reading_id)`,
+		// Covers: linked_sorts count per reading
+		`CREATE INDEX IF NOT EXISTS idx_temperature_reading_sorts_reading ON temperature_reading_sorts (reading_id)`,
+		// Covers: sortsAtLocation (transfers after a reading per sort)
+		`CREATE INDEX IF NOT EXISTS idx_stock_transfer_items_target_sort ON stock_transfer_items (target_sort_id) WHERE deleted = false`,
+
+		// =====================================================
+		// processing_orders, processing_inputs and processing_outputs tables
+		// =====================================================
+		// Covers: GetAllProcessingOrders, GetProcessingYield (date range)
+		`CREATE INDEX IF NOT EXISTS idx_processing_orders_date ON processing_orders (process_date DESC) WHERE deleted = false`,
+		// Covers: processing lines per order
+		`CREATE INDEX IF NOT EXISTS idx_processing_inputs_order_id ON processing_inputs (processing_order_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_processing_outputs_order_id ON processing_outputs (processing_order_id) WHERE deleted = false`,
+		// Covers: DeleteProcessingOrder (produced sorts processed again)
+		`CREATE INDEX IF NOT EXISTS idx_processing_inputs_sort_id ON processing_inputs (stock_sort_id) WHERE deleted = false`,
+
+		// =====================================================
+		// accounts, journal_entries and journal_lines tables
+		// =====================================================
+		// Covers: postings resolving system accounts by code
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_code ON accounts (code) WHERE deleted = false`,
+		// Covers: GetAllJournalEntries, GetTrialBalance, GetGeneralLedger (date range)
+		`CREATE INDEX IF NOT EXISTS idx_journal_entries_date ON journal_entries (entry_date) WHERE deleted = false`,
+		// Covers: reversing the postings of a deleted document
+		`CREATE INDEX IF NOT EXISTS idx_journal_entries_source_id ON journal_entries (source_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_journal_entries_reference_id ON journal_entries (reference_id) WHERE deleted = false`,
+		// Covers: lines per entry and per account
+		`CREATE INDEX IF NOT EXISTS idx_journal_lines_entry_id ON journal_lines (journal_entry_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_journal_lines_account_id ON journal_lines (account_id) WHERE deleted = false`,
+
+		// =====================================================
+		// accounting_periods table
+		// =====================================================
+		// Covers: checkPeriodsOpen, one row per month
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_accounting_periods_period ON accounting_periods (period) WHERE deleted = false`,
+
+		// =====================================================
+		// cash_accounts / cash_transfers tables
+		// =====================================================
+		// Covers: cashLedgerCode, cashAccountCodes
+		`CREATE INDEX IF NOT EXISTS idx_cash_accounts_ledger_account_id ON cash_accounts (ledger_account_id) WHERE deleted = false`,
+		// Covers: GetAllCashTransfers (date range, newest first)
+		`CREATE INDEX IF NOT EXISTS idx_cash_transfers_date ON cash_transfers (transfer_date DESC) WHERE deleted = false`,
+		// Covers: GetAllCashTransfers (account filter)
+		`CREATE INDEX IF NOT EXISTS idx_cash_transfers_from_account_id ON cash_transfers (from_account_id) WHERE deleted = false`,
+		`CREATE INDEX IF NOT EXISTS idx_cash_transfers_to_account_id ON cash_transfers (to_account_id) WHERE deleted = false`,
+
+		// =====================================================
+		// bank_statements / bank_statement_lines tables
+		// =====================================================
+		// Covers: GetAllBankStatements (bank account filter)
+		`CREATE INDEX IF NOT EXISTS idx_bank_statements_cash_account_id ON bank_statements (cash_account_id) WHERE deleted = false`,
+		// Covers: GetAllStatementLines (review queue per statement and status)
+		`CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement_status ON bank_statement_lines (statement_id, status) WHERE deleted = false`,
+		// Covers: GetAllStatementLines (review queue across statements)
+		`CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_status ON bank_statement_lines (status, transaction_date) WHERE deleted = false`,
+
+		// =====================================================
+		// recurring_expenses / payment_attachments tables
+		// =====================================================
+		// Covers: RunRecurringExpenses (due templates)
+		`CREATE INDEX IF NOT EXISTS idx_recurring_expenses_next_run_date ON recurring_expenses (next_run_date) WHERE deleted = false AND is_active = true`,
+		// Covers: GetPaymentAttachments
+		`CREATE INDEX IF NOT EXISTS idx_payment_attachments_payment_id ON payment_attachments (payment_id) WHERE deleted = false`,
 
 		// =====================================================
 		// fibers table
 		// =====================================================
 		// Covers: GetAllFibers, GetAvailableFibers, GetAllUsedFibers (status filter)
 		`CREATE INDEX IF NOT EXISTS idx_fibers_status ON fibers (status) WHERE deleted = false`,
-		// Covers: fetchFiberList (sale_id + deleted lookup)
+		// Covers: fiber lookups by the sale currently holding them
 		`CREATE INDEX IF NOT EXISTS idx_fibers_sale_id ON fibers (sale_id) WHERE deleted = false`,
 		// Covers: GetFibersByStockSort
 		`CREATE INDEX IF NOT EXISTS idx_fibers_stock_sort_id ON fibers (stock_sort_id) WHERE deleted = false`,
@@ -92,6 +276,8 @@ func CreateIndexes(db *gorm.DB) {
 		`CREATE INDEX IF NOT EXISTS idx_fiber_alloc_fiber_id ON fiber_allocations (fiber_id) WHERE deleted = false`,
 		// Covers: JOIN fiber_allocations ON stock_sort_id
 		`CREATE INDEX IF NOT EXISTS idx_fiber_alloc_stock_sort_id ON fiber_allocations (stock_sort_id) WHERE deleted = false`,
+		// Covers: fetchFiberList, releaseFibers, sales supplier detail JOIN/NOT EXISTS on sale_id
+		`CREATE INDEX IF NOT EXISTS idx_fiber_alloc_sale_id ON fiber_allocations (sale_id, fiber_id) WHERE deleted = false`,
 
 		// =====================================================
 		// sales table
@@ -0,0 +1,408 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+)
+
+// RunDataMigrations runs one-off data migrations that AutoMigrate cannot
+// express. Every step checks its own precondition so it is safe to call on
+// every startup.
+func RunDataMigrations(db *gorm.DB) {
+	migrateSaleFiberList(db)
+	backfillFiberAllocationWeights(db)
+	migrateProductCatalog(db)
+	seedChartOfAccounts(db)
+	seedMainCashAccount(db)
+	seedExpenseCategories(db)
+}
+
+// migrateSaleFiberList backfills fiber_allocations from the legacy
+// comma-joined sales.fiber_list column and then drops the column, leaving
+// fiber_allocations as the only sale-to-fiber relation. The list carried no
+// weights, so rows start at 0 and backfillFiberAllocationWeights fills them.
+func migrateSaleFiberList(db *gorm.DB) {
+	if !db.Migrator().HasColumn("sales", "fiber_list") {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO fiber_allocations (uuid, fiber_id, sale_id, stock_sort_id, weight, deleted, created_at, updated_at)
+			SELECT
+				gen_random_uuid()::text,
+				l.fiber_id,
+				s.uuid,
+				COALESCE(f.stock_sort_id, ''),
+				0,
+				s.deleted,
+				s.created_at,
+				NOW()
+			FROM sales s
+			CROSS JOIN LATERAL (
+				SELECT DISTINCT TRIM(x) AS fiber_id
+				FROM unnest(string_to_array(s.fiber_list, ',')) AS x
+			) l
+			LEFT JOIN fibers f ON f.uuid = l.fiber_id
+			WHERE s.fiber_list IS NOT NULL
+			  AND s.fiber_list <> ''
+			  AND l.fiber_id <> ''
+			  AND NOT EXISTS (
+				  SELECT 1 FROM fiber_allocations fa
+				  WHERE fa.sale_id = s.uuid AND fa.fiber_id = l.fiber_id
+			  )
+		`).Error; err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn("sales", "fiber_list")
+	})
+	if err != nil {
+		logger.Error("Failed to migrate sales.fiber_list into fiber_allocations: %v", err)
+		return
+	}
+
+	logger.Info("Migrated sales.fiber_list into fiber_allocations")
+}
+
+// backfillFiberAllocationWeights gives the zero-weight allocations left by
+// migrateSaleFiberList a weight, so legacy sales pass the allocation checks
+// when edited. Each sort's sold weight on the sale is split evenly across the
+// fibers holding it, with the remainder going to the first fibers. Fibers that
+// never recorded a sort take the sale's sort when the sale sold only one.
+func backfillFiberAllocationWeights(db *gorm.DB) {
+	var pending int64
+	if err := db.Table("fiber_allocations").
+		Where("weight = 0 AND deleted = false").
+		Count(&pending).Error; err != nil {
+		logger.Error("Failed to check fiber allocation weights: %v", err)
+		return
+	}
+
+	if pending == 0 {
+		return
+	}
+
+	result := db.Exec(`
+		UPDATE fiber_allocations fa
+		SET stock_sort_id = w.stock_sort_id,
+		    weight = w.weight,
+		    updated_at = NOW()
+		FROM (
+			SELECT
+				z.uuid,
+				z.stock_sort_id,
+				sold.weight / z.n + CASE WHEN z.rn <= sold.weight % z.n THEN 1 ELSE 0 END AS weight
+			FROM (
+				SELECT
+					a.uuid,
+					a.sale_id,
+					a.stock_sort_id,
+					COUNT(*) OVER (PARTITION BY a.sale_id, a.stock_sort_id) AS n,
+					ROW_NUMBER() OVER (PARTITION BY a.sale_id, a.stock_sort_id ORDER BY a.fiber_id) AS rn
+				FROM (
+					SELECT
+						fa.uuid,
+						fa.sale_id,
+						fa.fiber_id,
+						COALESCE(NULLIF(fa.stock_sort_id, ''), (
+							SELECT MIN(i.stock_sort_id)
+							FROM item_sales i
+							WHERE i.sale_id = fa.sale_id AND i.deleted = false
+							HAVING COUNT(DISTINCT i.stock_sort_id) = 1
+						), '') AS stock_sort_id
+					FROM fiber_allocations fa
+					WHERE fa.weight = 0 AND fa.deleted = false
+				) a
+			) z
+			CROSS JOIN LATERAL (
+				SELECT COALESCE(SUM(i.weight), 0) AS weight
+				FROM item_sales i
+				WHERE i.sale_id = z.sale_id
+				  AND i.stock_sort_id = z.stock_sort_id
+				  AND i.deleted = false
+			) sold
+			WHERE sold.weight > 0
+		) w
+		WHERE fa.uuid = w.uuid
+	`)
+	if result.Error != nil {
+		logger.Error("Failed to backfill fiber allocation weights: %v", result.Error)
+		return
+	}
+
+	logger.Info("Backfilled weights on %d of %d fiber allocations", result.RowsAffected, pending)
+}
+
+// migrateProductCatalog maps free-text stock item and sort names onto catalog
+// products. Names are grouped by their letters and digits, upper-cased, so
+// "Tuna A", "tuna a" and "TUNA-A" become one product; the most common spelling
+// becomes the product name. Names without any letters or digits go to a
+// placeholder product, so every row ends up mapped and later startups skip the
+// migration. The normalisation must match service.normalizeProductCode.
+func migrateProductCatalog(db *gorm.DB) {
+	const (
+		normalize   = `UPPER(regexp_replace(%s, '[^A-Za-z0-9]', '', 'g'))`
+		unnamedCode = "TANPANAMA"
+		unnamedName = "Tanpa Nama"
+	)
+
+	var unmapped int64
+	if err := db.Raw(`
+		SELECT
+			(SELECT COUNT(*) FROM stock_items WHERE COALESCE(product_id, '') = '') +
+			(SELECT COUNT(*) FROM stock_sorts WHERE COALESCE(product_id, '') = '')
+	`).Scan(&unmapped).Error; err != nil {
+		logger.Error("Failed to check unmapped stock names: %v", err)
+		return
+	}
+
+	if unmapped == 0 {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(`
+			INSERT INTO products (uuid, code, name, unit, deleted, created_at, updated_at)
+			SELECT
+				gen_random_uuid()::text,
+				n.code,
+				n.name,
+				'KG',
+				false,
+				NOW(),
+				NOW()
+			FROM (
+				SELECT
+					%[1]s AS code,
+					mode() WITHIN GROUP (ORDER BY TRIM(x.name)) AS name
+				FROM (
+					SELECT item_name AS name FROM stock_items WHERE COALESCE(product_id, '') = ''
+					UNION ALL
+					SELECT sorted_item_name AS name FROM stock_sorts WHERE COALESCE(product_id, '') = ''
+				) x
+				WHERE %[1]s <> ''
+				GROUP BY %[1]s
+			) n
+			WHERE NOT EXISTS (
+				SELECT 1 FROM products p
+				WHERE p.deleted = false
+				  AND (p.code = n.code OR %[2]s = n.code)
+			)
+		`, fmt.Sprintf(normalize, "x.name"), fmt.Sprintf(normalize, "p.name"))).Error; err != nil {
+			return err
+		}
+
+		for _, target := range []struct{ table, column string }{
+			{"stock_items", "item_name"},
+			{"stock_sorts", "sorted_item_name"},
+		} {
+			if err := tx.Exec(fmt.Sprintf(`
+				UPDATE %[1]s t
+				SET product_id = (
+					SELECT p.uuid FROM products p
+					WHERE p.deleted = false
+					  AND (p.code = %[2]s OR %[3]s = %[2]s)
+					ORDER BY p.id
+					LIMIT 1
+				)
+				WHERE COALESCE(t.product_id, '') = ''
+				  AND %[2]s <> ''
+			`, target.table, fmt.Sprintf(normalize, "t."+target.column), fmt.Sprintf(normalize, "p.name"))).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO products (uuid, code, name, unit, deleted, created_at, updated_at)
+			SELECT gen_random_uuid()::text, ?, ?, 'KG', false, NOW(), NOW()
+			WHERE NOT EXISTS (SELECT 1 FROM products WHERE code = ? AND deleted = false)
+			  AND (
+				EXISTS (SELECT 1 FROM stock_items WHERE COALESCE(product_id, '') = '')
+				OR EXISTS (SELECT 1 FROM stock_sorts WHERE COALESCE(product_id, '') = '')
+			  )
+		`, unnamedCode, unnamedName, unnamedCode).Error; err != nil {
+			return err
+		}
+
+		for _, table := range []string{"stock_items", "stock_sorts"} {
+			if err := tx.Exec(fmt.Sprintf(`
+				UPDATE %s
+				SET product_id = (
+					SELECT uuid FROM products
+					WHERE code = ? AND deleted = false
+					ORDER BY id
+					LIMIT 1
+				)
+				WHERE COALESCE(product_id, '') = ''
+			`, table), unnamedCode).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to map stock names onto the product catalog: %v", err)
+		return
+	}
+
+	logger.Info("Mapped stock item and sort names onto the product catalog")
+}
+
+// seedChartOfAccounts creates the system accounts that generated journal
+// entries post to. Existing accounts are left as they are, so renamed system
+// accounts keep their names.
+func seedChartOfAccounts(db *gorm.DB) {
+	accounts := []struct{ code, name, accountType string }{
+		{constants.AccountCodeCash, "Kas", constants.AccountAsset},
+		{constants.AccountCodeReceivable, "Piutang Usaha", constants.AccountAsset},
+		{constants.AccountCodeInventory, "Persediaan", constants.AccountAsset},
+		{constants.AccountCodeSupplierAdvance, "Uang Muka Pemasok", constants.AccountAsset},
+		{constants.AccountCodePayable, "Hutang Usaha", constants.AccountLiability},
+		{constants.AccountCodeCustomerDeposit, "Uang Muka Pelanggan", constants.AccountLiability},
+		{constants.AccountCodeOwnerEquity, "Modal Pemilik", constants.AccountEquity},
+		{constants.AccountCodeSales, "Penjualan", constants.AccountRevenue},
+		{constants.AccountCodeOtherIncome, "Pendapatan Lain-lain", constants.AccountRevenue},
+		{constants.AccountCodeCostOfGoodsSold, "Harga Pokok Penjualan", constants.AccountExpense},
+		{constants.AccountCodeShrinkage, "Beban Susut Persediaan", constants.AccountExpense},
+		{constants.AccountCodeOperatingExpense, "Beban Operasional", constants.AccountExpense},
+	}
+
+	now := time.Now()
+	for _, a := range accounts {
+		account := models.Account{
+			Uuid:        uuid.New().String(),
+			Code:        a.code,
+			Name:        a.name,
+			AccountType: a.accountType,
+			IsSystem:    true,
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := db.Where("code = ? AND deleted = false", a.code).FirstOrCreate(&account).Error; err != nil {
+			logger.Error("Failed to seed account %s: %v", a.code, err)
+			return
+		}
+	}
+}
+
+// seedMainCashAccount links the main cash ledger account to a cash account so
+// payments have somewhere to be paid from on a fresh install.
+func seedMainCashAccount(db *gorm.DB) {
+	var count int64
+	if err := db.Model(&models.CashAccount{}).Where("deleted = false").Count(&count).Error; err != nil {
+		logger.Error("Failed to check cash accounts: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	var ledger models.Account
+	if err := db.Where("code = ? AND deleted = false", constants.AccountCodeCash).First(&ledger).Error; err != nil {
+		logger.Error("Failed to fetch main cash ledger account: %v", err)
+		return
+	}
+
+	now := time.Now()
+	account := models.CashAccount{
+		Uuid:            uuid.New().String(),
+		Name:            "Kas Utama",
+		AccountType:     constants.CashAccountCash,
+		LedgerAccountId: ledger.Uuid,
+		IsActive:        true,
+		Deleted:         false,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := db.Create(&account).Error; err != nil {
+		logger.Error("Failed to seed main cash account: %v", err)
+		return
+	}
+
+	logger.Info("Created main cash account")
+}
+
+// seedExpenseCategories creates the common expense categories, each with its
+// own expense account after the operating expense account, on a fresh
+// install. Once any category exists the list is left to the users.
+func seedExpenseCategories(db *gorm.DB) {
+	var count int64
+	if err := db.Model(&models.ExpenseCategory{}).Count(&count).Error; err != nil {
+		logger.Error("Failed to check expense categories: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	categories := []struct{ name, description string }{
+		{"Es Batu", "Pembelian es untuk penyimpanan dan pengiriman"},
+		{"Bahan Bakar", "BBM kendaraan dan genset"},
+		{"Gaji dan Upah", "Gaji karyawan dan upah harian"},
+		{"Listrik dan Air", "Tagihan listrik, air dan telepon"},
+		{"Sewa", "Sewa gudang, cold storage dan kendaraan"},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var lastCode string
+		if err := tx.Model(&models.Account{}).
+			Select("COALESCE(MAX(code), ?)", constants.AccountCodeOperatingExpense).
+			Where("code LIKE ?", constants.AccountCodeOperatingExpense[:2]+"__").
+			Scan(&lastCode).Error; err != nil {
+			return err
+		}
+		var next int
+		if _, err := fmt.Sscan(lastCode, &next); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, c := range categories {
+			next++
+			ledger := models.Account{
+				Uuid:        uuid.New().String(),
+				Code:        fmt.Sprintf("%d", next),
+				Name:        "Beban " + c.name,
+				AccountType: constants.AccountExpense,
+				Description: c.description,
+				IsSystem:    true,
+				Deleted:     false,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			if err := tx.Create(&ledger).Error; err != nil {
+				return err
+			}
+
+			category := models.ExpenseCategory{
+				Uuid:            uuid.New().String(),
+				Name:            c.name,
+				Description:     c.description,
+				LedgerAccountId: ledger.Uuid,
+				IsActive:        true,
+				Deleted:         false,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			}
+			if err := tx.Create(&category).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to seed expense categories: %v", err)
+		return
+	}
+
+	logger.Info("Created default expense categories")
+}
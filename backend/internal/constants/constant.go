@@ -12,6 +12,117 @@ const (
 	PaymentNotMadeYet = "PAYMENT_NOT_MADE_YET"
 	Income            = "INCOME"
 	Expense           = "EXPENSE"
+	ScanTypeFiber     = "FIBER"
+	ScanTypeStock     = "STOCK"
+	ScanTypeStockSort = "STOCK_SORT"
+	ScanTypeSale      = "SALE"
+	PriceKindSale     = "SALE"
+	PriceKindPurchase = "PURCHASE"
+	PriceBelowCost    = "BELOW_COST"
+	PriceOutOfBand    = "OUT_OF_BAND"
+
+	PurchaseOrderOpen      = "OPEN"
+	PurchaseOrderPartial   = "PARTIAL"
+	PurchaseOrderReceived  = "RECEIVED"
+	PurchaseOrderCancelled = "CANCELLED"
+
+	SalesOrderQuotation = "QUOTATION"
+	SalesOrderOrder     = "ORDER"
+	SalesOrderOpen      = "OPEN"
+	SalesOrderConverted = "CONVERTED"
+	SalesOrderExpired   = "EXPIRED"
+	SalesOrderCancelled = "CANCELLED"
+
+	DeliveryPending   = "PENDING"
+	DeliveryInTransit = "IN_TRANSIT"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryFailed    = "FAILED"
+
+	LandedCostIce       = "ICE"
+	LandedCostTransport = "TRANSPORT"
+	LandedCostLabour    = "LABOUR"
+	LandedCostOther     = "OTHER"
+	AllocateByWeight    = "WEIGHT"
+	AllocateByValue     = "VALUE"
+
+	TraceForward  = "FORWARD"
+	TraceBackward = "BACKWARD"
+
+	StockFresh                  = "FRESH"
+	StockNearExpiry             = "NEAR_EXPIRY"
+	StockExpired                = "EXPIRED"
+	NotificationStockNearExpiry = "STOCK_NEAR_EXPIRY"
+	NotificationStockExpired    = "STOCK_EXPIRED"
+	ReferenceStockSort          = "STOCK_SORT"
+
+	LocationColdRoom    = "COLD_ROOM"
+	LocationMarketStall = "MARKET_STALL"
+	LocationWarehouse   = "WAREHOUSE"
+
+	TemperatureManual                = "MANUAL"
+	TemperatureLogger                = "LOGGER"
+	NotificationTemperatureExcursion = "TEMPERATURE_EXCURSION"
+	ReferenceTemperatureReading      = "TEMPERATURE_READING"
+
+	ProcessFillet   = "FILLET"
+	ProcessGut      = "GUT"
+	ProcessFreeze   = "FREEZE"
+	ProcessOther    = "OTHER"
+	OutputPrimary   = "PRIMARY"
+	OutputByProduct = "BY_PRODUCT"
+	OutputWaste     = "WASTE"
+
+	AccountAsset     = "ASSET"
+	AccountLiability = "LIABILITY"
+	AccountEquity    = "EQUITY"
+	AccountRevenue   = "REVENUE"
+	AccountExpense   = "EXPENSE"
+
+	AccountCodeCash             = "1100"
+	AccountCodeReceivable       = "1200"
+	AccountCodeInventory        = "1300"
+	AccountCodeSupplierAdvance  = "1400"
+	AccountCodePayable          = "2100"
+	AccountCodeCustomerDeposit  = "2200"
+	AccountCodeOwnerEquity      = "3100"
+	AccountCodeSales            = "4100"
+	AccountCodeOtherIncome      = "4900"
+	AccountCodeCostOfGoodsSold  = "5100"
+	AccountCodeShrinkage        = "5200"
+	AccountCodeOperatingExpense = "6100"
+
+	JournalPurchase       = "PURCHASE"
+	JournalSale           = "SALE"
+	JournalPayment        = "PAYMENT"
+	JournalSupplierReturn = "SUPPLIER_RETURN"
+	JournalManual         = "MANUAL"
+	JournalCashTransfer   = "CASH_TRANSFER"
+	JournalPurchaseCost   = "PURCHASE_COST"
+	JournalStockWriteOff  = "STOCK_WRITE_OFF"
+
+	PeriodOpen   = "OPEN"
+	PeriodClosed = "CLOSED"
+
+	CashAccountCash = "CASH"
+	CashAccountBank = "BANK"
+
+	PaymentMethodCash     = "CASH"
+	PaymentMethodTransfer = "TRANSFER"
+	PaymentMethodGiro     = "GIRO"
+
+	StatementFormatCSV   = "CSV"
+	StatementFormatMT940 = "MT940"
+	StatementCredit      = "CREDIT"
+	StatementDebit       = "DEBIT"
+	StatementUnmatched   = "UNMATCHED"
+	StatementSuggested   = "SUGGESTED"
+	StatementMatched     = "MATCHED"
+	StatementIgnored     = "IGNORED"
+	MatchSale            = "SALE"
+	MatchPurchase        = "PURCHASE"
+
+	RecurringWeekly  = "WEEKLY"
+	RecurringMonthly = "MONTHLY"
 )
 
 var JakartaTz = time.FixedZone("Asia/Jakarta", 7*60*60)
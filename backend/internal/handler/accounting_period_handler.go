@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"dashboard-app/pkg/jwt"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type AccountingPeriod struct {
+	accountingPeriodRepository repository.AccountingPeriodRepository
+	*baseHandler.BaseHandler
+}
+
+func NewAccountingPeriodHandler(accountingPeriodRepository repository.AccountingPeriodRepository, validate *validator.Validate) *AccountingPeriod {
+	return &AccountingPeriod{
+		accountingPeriodRepository: accountingPeriodRepository,
+		BaseHandler:                baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllPeriods godoc
+// @Summary Get accounting periods
+// @Description Close state of every month in a year; months never closed are OPEN
+// @Tags accounting-periods
+// @Accept json
+// @Produce json
+// @Param year query string false "Year (YYYY); defaults to the current year"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.AccountingPeriodResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounting-periods [get]
+func (h *AccountingPeriod) GetAllPeriods(c *gin.Context) {
+	var filter models.AccountingPeriodFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch periods
+	data, err := h.accountingPeriodRepository.GetAllPeriods(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch accounting periods")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Accounting periods retrieved successfully", data)
+}
+
+// ClosePeriod godoc
+// @Summary Close an accounting period
+// @Description Lock a finished month: sales, purchases, stock entries, payments and journal entries dated in it can no longer be created, edited or deleted
+// @Tags accounting-periods
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period path string true "Period (YYYY-MM)"
+// @Param request body models.ClosePeriodRequest false "Close notes"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.AccountingPeriodResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 401 {object} models.HTTPResponseError
+// @Failure 403 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounting-periods/{period}/close [post]
+func (h *AccountingPeriod) ClosePeriod(c *gin.Context) {
+	userID, err := jwt.ValidateToken(jwt.GetHeader(c))
+	if err != nil {
+		h.SendError(c, http.StatusUnauthorized, "Invalid authentication token", err)
+		return
+	}
+
+	var req models.ClosePeriodRequest
+
+	// Bind and validate request
+	if c.Request.ContentLength > 0 {
+		if err = h.BindAndValidate(c, &req); err != nil {
+			return // Error already sent
+		}
+	}
+
+	// Close period
+	period := c.Param("period")
+	data, err := h.accountingPeriodRepository.ClosePeriod(userID, period, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to close accounting period")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Accounting period %s closed successfully", period), data)
+}
+
+// ReopenPeriod godoc
+// @Summary Reopen an accounting period
+// @Description Unlock a closed month. Only a SUPER_ADMIN may reopen, and a reason is required; the action is kept in the audit trail
+// @Tags accounting-periods
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param period path string true "Period (YYYY-MM)"
+// @Param request body models.ReopenPeriodRequest true "Reopen reason"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.AccountingPeriodResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 401 {object} models.HTTPResponseError
+// @Failure 403 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounting-periods/{period}/reopen [post]
+func (h *AccountingPeriod) ReopenPeriod(c *gin.Context) {
+	userID, err := jwt.ValidateToken(jwt.GetHeader(c))
+	if err != nil {
+		h.SendError(c, http.StatusUnauthorized, "Invalid authentication token", err)
+		return
+	}
+
+	var req models.ReopenPeriodRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Reopen period
+	period := c.Param("period")
+	data, err := h.accountingPeriodRepository.ReopenPeriod(userID, period, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to reopen accounting period")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Accounting period %s reopened successfully", period), data)
+}
+
+// RegisterRoutes registers all accounting period routes
+func (h *AccountingPeriod) RegisterRoutes(router *gin.RouterGroup) {
+	periods := router.Group("/accounting-periods")
+	{
+		periods.GET("", h.GetAllPeriods)
+		periods.POST("/:period/close", h.ClosePeriod)
+		periods.POST("/:period/reopen", h.ReopenPeriod)
+	}
+}
@@ -118,6 +118,7 @@ func (h *Analytic) GetSalesTrend(c *gin.Context) {
 // @Tags analytics
 // @Accept json
 // @Produce json
+// @Param location_id query string false "Only count stock remaining in this location"
 // @Success 200 {object} models.HTTPResponseSuccess{data=[]models.StockDistributionData}
 // @Failure 500 {object} models.HTTPResponseError
 // @Router /analytics/distribution [get]
@@ -139,6 +140,67 @@ func (h *Analytic) GetStockDistribution(c *gin.Context) {
 	h.SendSuccess(c, http.StatusOK, "Stock distribution retrieved successfully", data)
 }
 
+// GetProductDistribution godoc
+// @Summary Get product distribution
+// @Description Retrieve remaining stock weight grouped by catalog product
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.StockDistributionData}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /analytics/product/distribution [get]
+func (h *Analytic) GetProductDistribution(c *gin.Context) {
+	var filter models.AnalyticStatsFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch product distribution data
+	data, err := h.analyticRepository.GetProductDistributionData(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch product distribution")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Product distribution retrieved successfully", data)
+}
+
+// GetTopPerformingItems godoc
+// @Summary Get top performing products
+// @Description Retrieve best-selling catalog products by revenue
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of products" default(10)
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.ItemPerformance}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /analytics/product/top [get]
+func (h *Analytic) GetTopPerformingItems(c *gin.Context) {
+	var filter models.TopItemsFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 10
+	}
+
+	// Fetch top items
+	data, err := h.analyticRepository.GetTopPerformingItems(filter.Limit)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch top performing products")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Top performing products retrieved successfully", data)
+}
+
 // GetSupplierPerformance godoc
 // @Summary Get supplier performance
 // @Description Retrieve performance metrics for all suppliers
@@ -269,6 +331,44 @@ func (h *Analytic) SalesSupplierDetailWithPurchaseData(c *gin.Context) {
 	h.SendSuccess(c, http.StatusOK, "Get sales supplier detail retrieved successfully", data)
 }
 
+// GetExpenseBreakdown godoc
+// @Summary Get monthly expense breakdown
+// @Description Operational expenses of a year per month and expense category; uncategorized expenses are grouped as "Tanpa Kategori"
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param year query string false "Year (YYYY), defaults to the current year"
+// @Param cost_center_id query string false "Only expenses of this cost center"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ExpenseBreakdownResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /analytics/expense/breakdown [get]
+func (h *Analytic) GetExpenseBreakdown(c *gin.Context) {
+	var filter models.ExpenseBreakdownFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	if filter.Year == "" {
+		filter.Year = strconv.Itoa(time.Now().Year())
+	}
+	if _, err := strconv.Atoi(filter.Year); err != nil || len(filter.Year) != 4 {
+		h.SendError(c, http.StatusBadRequest, "Invalid year format. Use YYYY", nil)
+		return
+	}
+
+	// Fetch expense breakdown
+	data, err := h.analyticRepository.GetMonthlyExpenseBreakdown(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch expense breakdown")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Expense breakdown for %s retrieved successfully", filter.Year), data)
+}
+
 // RegisterRoutes registers all analytics routes
 func (h *Analytic) RegisterRoutes(router *gin.RouterGroup) {
 	analytics := router.Group("/analytics")
@@ -280,11 +380,16 @@ func (h *Analytic) RegisterRoutes(router *gin.RouterGroup) {
 		// Trends and distribution
 		analytics.GET("/sales/trend", h.GetSalesTrend)
 		analytics.GET("/stock/distribution", h.GetStockDistribution)
+		analytics.GET("/product/distribution", h.GetProductDistribution)
+		analytics.GET("/product/top", h.GetTopPerformingItems)
 
 		// Performance metrics
 		analytics.GET("/supplier/performance", h.GetSupplierPerformance)
 		analytics.GET("/customer/performance", h.GetCustomerPerformance)
 		analytics.GET("/sales/supplier", h.GetSalesSupplierDetail)
 		analytics.GET("/sales/supplier/purchase", h.SalesSupplierDetailWithPurchaseData)
+
+		// Expenses
+		analytics.GET("/expense/breakdown", h.GetExpenseBreakdown)
 	}
 }
@@ -0,0 +1,415 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"dashboard-app/pkg/jwt"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type BankStatement struct {
+	bankStatementRepository repository.BankStatementRepository
+	*baseHandler.BaseHandler
+}
+
+func NewBankStatementHandler(bankStatementRepository repository.BankStatementRepository, validate *validator.Validate) *BankStatement {
+	return &BankStatement{
+		bankStatementRepository: bankStatementRepository,
+		BaseHandler:             baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// ImportBankStatement godoc
+// @Summary Import a bank statement
+// @Description Import a CSV export (columns date, description, reference and either amount or debit and credit) or an MT940 file (.sta, .mt940, .940, .txt) into a bank account. Transactions already imported are skipped, nothing is imported if any row is invalid, and lines that clearly pay an open sale or purchase are suggested for review.
+// @Tags bank-statements
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or MT940 file"
+// @Param cash_account_id formData string true "Bank account the statement belongs to"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.BankStatementImportResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 401 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 422 {object} models.HTTPResponseSuccess{data=models.BankStatementImportResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statements/import [post]
+func (h *BankStatement) ImportBankStatement(c *gin.Context) {
+	userID, err := jwt.ValidateToken(jwt.GetHeader(c))
+	if err != nil {
+		h.SendError(c, http.StatusUnauthorized, "Invalid authentication token", err)
+		return
+	}
+
+	cashAccountID := c.PostForm("cash_account_id")
+	if cashAccountID == "" {
+		h.SendError(c, http.StatusBadRequest, "cash_account_id is required", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "Failed to open file", err)
+		return
+	}
+	defer file.Close()
+
+	// Import statement
+	data, err := h.bankStatementRepository.ImportBankStatement(userID, cashAccountID, fileHeader.Filename, file)
+	if err != nil {
+		h.HandleError(c, err, "Failed to import bank statement")
+		return
+	}
+
+	if !data.Success {
+		h.SendSuccess(c, http.StatusUnprocessableEntity,
+			fmt.Sprintf("%d of %d row(s) are invalid, nothing was imported", data.InvalidRows, data.TotalRows), data)
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated,
+		fmt.Sprintf("Imported %d transaction(s), %d suggested match(es), %d duplicate(s) skipped",
+			data.CreatedCount, data.SuggestedCount, data.DuplicateRows), data)
+}
+
+// GetAllBankStatements godoc
+// @Summary Get imported bank statements
+// @Description Imported statements with how many lines are unmatched, suggested, matched and ignored
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param cash_account_id query string false "Filter by bank account"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statements [get]
+func (h *BankStatement) GetAllBankStatements(c *gin.Context) {
+	var filter models.BankStatementFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch statements
+	data, err := h.bankStatementRepository.GetAllBankStatements(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch bank statements")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statements retrieved successfully", data)
+}
+
+// GetBankStatementByID godoc
+// @Summary Get bank statement by ID
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param statementId path string true "Bank statement ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statements/{statementId} [get]
+func (h *BankStatement) GetBankStatementByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	statementID, err := h.GetUUIDParam(c, "statementId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch statement
+	data, err := h.bankStatementRepository.GetBankStatementById(statementID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch bank statement")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Bank statement %s retrieved successfully", statementID), data)
+}
+
+// DeleteBankStatement godoc
+// @Summary Delete a bank statement
+// @Description Only statements without confirmed matches can be deleted
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param statementId path string true "Bank statement ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statements/{statementId} [delete]
+func (h *BankStatement) DeleteBankStatement(c *gin.Context) {
+	// Get and validate UUID parameter
+	statementID, err := h.GetUUIDParam(c, "statementId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete statement
+	if err = h.bankStatementRepository.DeleteBankStatement(statementID); err != nil {
+		h.HandleError(c, err, "Failed to delete bank statement")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statement deleted successfully", nil)
+}
+
+// MatchBankStatement godoc
+// @Summary Rerun matching of a bank statement
+// @Description Look again for open sales and purchases paid by the statement's unmatched lines, e.g. after sales were entered late
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param statementId path string true "Bank statement ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statements/{statementId}/match [post]
+func (h *BankStatement) MatchBankStatement(c *gin.Context) {
+	// Get and validate UUID parameter
+	statementID, err := h.GetUUIDParam(c, "statementId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Rerun matching
+	data, err := h.bankStatementRepository.MatchBankStatement(statementID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to match bank statement")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statement matched successfully", data)
+}
+
+// GetAllStatementLines godoc
+// @Summary Get bank statement lines
+// @Description Review queue of statement lines; filter on SUGGESTED to review proposed matches
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param statement_id query string false "Filter by bank statement"
+// @Param status query string false "Filter by status (UNMATCHED, SUGGESTED, MATCHED, IGNORED)"
+// @Param direction query string false "Filter by direction (CREDIT, DEBIT)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementLinePaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statement-lines [get]
+func (h *BankStatement) GetAllStatementLines(c *gin.Context) {
+	var filter models.BankStatementLineFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch lines
+	data, err := h.bankStatementRepository.GetAllStatementLines(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch bank statement lines")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statement lines retrieved successfully", data)
+}
+
+// GetLineCandidates godoc
+// @Summary Get match candidates of a statement line
+// @Description Open sales (money in) or purchases (money out) the line could pay, best match first, with the reasons for each score
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param lineId path string true "Bank statement line ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.MatchCandidate}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statement-lines/{lineId}/candidates [get]
+func (h *BankStatement) GetLineCandidates(c *gin.Context) {
+	// Get and validate UUID parameter
+	lineID, err := h.GetUUIDParam(c, "lineId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch candidates
+	data, err := h.bankStatementRepository.GetLineCandidates(lineID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch match candidates")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Match candidates retrieved successfully", data)
+}
+
+// ConfirmLineMatch godoc
+// @Summary Confirm the match of a statement line
+// @Description Record the line as a transfer payment on the suggested sale or purchase, or on the one given in the body
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param lineId path string true "Bank statement line ID"
+// @Param request body models.ConfirmMatchRequest false "Sale or purchase to match instead of the suggestion"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementLineResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 401 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statement-lines/{lineId}/confirm [post]
+func (h *BankStatement) ConfirmLineMatch(c *gin.Context) {
+	userID, err := jwt.ValidateToken(jwt.GetHeader(c))
+	if err != nil {
+		h.SendError(c, http.StatusUnauthorized, "Invalid authentication token", err)
+		return
+	}
+
+	// Get and validate UUID parameter
+	lineID, err := h.GetUUIDParam(c, "lineId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.ConfirmMatchRequest
+
+	// Bind and validate request
+	if c.Request.ContentLength > 0 {
+		if err = h.BindAndValidate(c, &req); err != nil {
+			return // Error already sent
+		}
+	}
+
+	// Confirm match
+	data, err := h.bankStatementRepository.ConfirmLineMatch(userID, lineID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to confirm match")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Match confirmed and payment recorded successfully", data)
+}
+
+// IgnoreLine godoc
+// @Summary Ignore a statement line
+// @Description Leave a line out of reconciliation, e.g. bank fees or interest
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param lineId path string true "Bank statement line ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementLineResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statement-lines/{lineId}/ignore [post]
+func (h *BankStatement) IgnoreLine(c *gin.Context) {
+	// Get and validate UUID parameter
+	lineID, err := h.GetUUIDParam(c, "lineId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Ignore line
+	data, err := h.bankStatementRepository.IgnoreLine(lineID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to ignore bank statement line")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statement line ignored successfully", data)
+}
+
+// ResetLine godoc
+// @Summary Reset a statement line
+// @Description Reject the suggested match or take back an ignore; the line becomes UNMATCHED
+// @Tags bank-statements
+// @Accept json
+// @Produce json
+// @Param lineId path string true "Bank statement line ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BankStatementLineResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /bank-statement-lines/{lineId}/reset [post]
+func (h *BankStatement) ResetLine(c *gin.Context) {
+	// Get and validate UUID parameter
+	lineID, err := h.GetUUIDParam(c, "lineId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Reset line
+	data, err := h.bankStatementRepository.ResetLine(lineID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to reset bank statement line")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Bank statement line reset successfully", data)
+}
+
+// RegisterRoutes registers all bank statement routes
+func (h *BankStatement) RegisterRoutes(router *gin.RouterGroup) {
+	statements := router.Group("/bank-statements")
+	{
+		statements.GET("", h.GetAllBankStatements)
+		statements.POST("/import", h.ImportBankStatement)
+		statements.GET("/:statementId", h.GetBankStatementByID)
+		statements.DELETE("/:statementId", h.DeleteBankStatement)
+		statements.POST("/:statementId/match", h.MatchBankStatement)
+	}
+
+	lines := router.Group("/bank-statement-lines")
+	{
+		lines.GET("", h.GetAllStatementLines)
+		lines.GET("/:lineId/candidates", h.GetLineCandidates)
+		lines.POST("/:lineId/confirm", h.ConfirmLineMatch)
+		lines.POST("/:lineId/ignore", h.IgnoreLine)
+		lines.POST("/:lineId/reset", h.ResetLine)
+	}
+}
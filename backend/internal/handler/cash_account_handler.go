@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type CashAccount struct {
+	cashAccountRepository repository.CashAccountRepository
+	*baseHandler.BaseHandler
+}
+
+func NewCashAccountHandler(cashAccountRepository repository.CashAccountRepository, validate *validator.Validate) *CashAccount {
+	return &CashAccount{
+		cashAccountRepository: cashAccountRepository,
+		BaseHandler:           baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllCashAccounts godoc
+// @Summary Get cash and bank accounts
+// @Description Retrieve all cash boxes and bank accounts with their current balance
+// @Tags cash-accounts
+// @Accept json
+// @Produce json
+// @Param account_type query string false "Filter by type (CASH, BANK)"
+// @Param active_only query bool false "Only active accounts"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.CashAccountResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-accounts [get]
+func (h *CashAccount) GetAllCashAccounts(c *gin.Context) {
+	var filter models.CashAccountFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch cash accounts
+	data, err := h.cashAccountRepository.GetAllCashAccounts(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch cash accounts")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash accounts retrieved successfully", data)
+}
+
+// CreateCashAccount godoc
+// @Summary Create a cash or bank account
+// @Description Also opens the asset account in the chart of accounts that holds its balance
+// @Tags cash-accounts
+// @Accept json
+// @Produce json
+// @Param account body models.CashAccountRequest true "Cash account data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.CashAccountResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-accounts [post]
+func (h *CashAccount) CreateCashAccount(c *gin.Context) {
+	var req models.CashAccountRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create cash account
+	data, err := h.cashAccountRepository.CreateCashAccount(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create cash account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Cash account created successfully", data)
+}
+
+// UpdateCashAccount godoc
+// @Summary Update a cash or bank account
+// @Tags cash-accounts
+// @Accept json
+// @Produce json
+// @Param cashAccountId path string true "Cash account ID"
+// @Param account body models.CashAccountRequest true "Cash account data"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.CashAccountResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-accounts/{cashAccountId} [put]
+func (h *CashAccount) UpdateCashAccount(c *gin.Context) {
+	// Get and validate UUID parameter
+	cashAccountID, err := h.GetUUIDParam(c, "cashAccountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.CashAccountRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update cash account
+	data, err := h.cashAccountRepository.UpdateCashAccount(cashAccountID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update cash account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash account updated successfully", data)
+}
+
+// DeleteCashAccount godoc
+// @Summary Delete a cash or bank account
+// @Description Only accounts without payments or journal lines can be deleted; deactivate the others
+// @Tags cash-accounts
+// @Accept json
+// @Produce json
+// @Param cashAccountId path string true "Cash account ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-accounts/{cashAccountId} [delete]
+func (h *CashAccount) DeleteCashAccount(c *gin.Context) {
+	// Get and validate UUID parameter
+	cashAccountID, err := h.GetUUIDParam(c, "cashAccountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete cash account
+	if err = h.cashAccountRepository.DeleteCashAccount(cashAccountID); err != nil {
+		h.HandleError(c, err, "Failed to delete cash account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash account deleted successfully", nil)
+}
+
+// GetCashAccountStatement godoc
+// @Summary Get cash account statement
+// @Description Opening balance, movements with running balance and closing balance of one cash or bank account
+// @Tags cash-accounts
+// @Accept json
+// @Produce json
+// @Param cashAccountId path string true "Cash account ID"
+// @Param start_date query string false "Entry date from (YYYY-MM-DD)"
+// @Param end_date query string false "Entry date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.AccountLedger}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-accounts/{cashAccountId}/statement [get]
+func (h *CashAccount) GetCashAccountStatement(c *gin.Context) {
+	// Get and validate UUID parameter
+	cashAccountID, err := h.GetUUIDParam(c, "cashAccountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var filter models.LedgerFilter
+
+	// Bind query parameters
+	if err = h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch statement
+	data, err := h.cashAccountRepository.GetCashAccountStatement(cashAccountID, filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch cash account statement")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Statement of cash account %s retrieved successfully", cashAccountID), data)
+}
+
+// GetAllCashTransfers godoc
+// @Summary Get transfers between cash accounts
+// @Tags cash-transfers
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param account_id query string false "Filter by source or destination cash account"
+// @Param start_date query string false "Transfer date from (YYYY-MM-DD)"
+// @Param end_date query string false "Transfer date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.CashTransferPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-transfers [get]
+func (h *CashAccount) GetAllCashTransfers(c *gin.Context) {
+	var filter models.CashTransferFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch transfers
+	data, err := h.cashAccountRepository.GetAllCashTransfers(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch cash transfers")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash transfers retrieved successfully", data)
+}
+
+// CreateCashTransfer godoc
+// @Summary Transfer money between cash accounts
+// @Description Moves money from one cash or bank account to another and posts the journal entry
+// @Tags cash-transfers
+// @Accept json
+// @Produce json
+// @Param transfer body models.CashTransferRequest true "Transfer data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.CashTransferResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-transfers [post]
+func (h *CashAccount) CreateCashTransfer(c *gin.Context) {
+	var req models.CashTransferRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create transfer
+	data, err := h.cashAccountRepository.CreateCashTransfer(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create cash transfer")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Cash transfer created successfully", data)
+}
+
+// DeleteCashTransfer godoc
+// @Summary Delete a cash transfer
+// @Description Cancels the transfer and reverses its journal entry
+// @Tags cash-transfers
+// @Accept json
+// @Produce json
+// @Param transferId path string true "Transfer ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cash-transfers/{transferId} [delete]
+func (h *CashAccount) DeleteCashTransfer(c *gin.Context) {
+	// Get and validate UUID parameter
+	transferID, err := h.GetUUIDParam(c, "transferId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete transfer
+	if err = h.cashAccountRepository.DeleteCashTransfer(transferID); err != nil {
+		h.HandleError(c, err, "Failed to delete cash transfer")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash transfer deleted successfully", nil)
+}
+
+// RegisterRoutes registers all cash account routes
+func (h *CashAccount) RegisterRoutes(router *gin.RouterGroup) {
+	accounts := router.Group("/cash-accounts")
+	{
+		accounts.GET("", h.GetAllCashAccounts)
+		accounts.POST("", h.CreateCashAccount)
+		accounts.PUT("/:cashAccountId", h.UpdateCashAccount)
+		accounts.DELETE("/:cashAccountId", h.DeleteCashAccount)
+		accounts.GET("/:cashAccountId/statement", h.GetCashAccountStatement)
+	}
+
+	transfers := router.Group("/cash-transfers")
+	{
+		transfers.GET("", h.GetAllCashTransfers)
+		transfers.POST("", h.CreateCashTransfer)
+		transfers.DELETE("/:transferId", h.DeleteCashTransfer)
+	}
+}
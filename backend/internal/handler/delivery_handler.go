@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Delivery struct {
+	deliveryRepository repository.DeliveryRepository
+	*baseHandler.BaseHandler
+}
+
+func NewDeliveryHandler(deliveryRepository repository.DeliveryRepository, validate *validator.Validate) *Delivery {
+	return &Delivery{
+		deliveryRepository: deliveryRepository,
+		BaseHandler:        baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllDeliveryNotes godoc
+// @Summary Get all delivery notes
+// @Description Retrieve paginated delivery notes with optional filters
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param sale_id query string false "Filter by sale ID"
+// @Param status query string false "Filter by status (PENDING, IN_TRANSIT, DELIVERED, FAILED)"
+// @Param delivery_date query string false "Filter by delivery date (YYYY-MM-DD)"
+// @Param keyword query string false "Search driver, vehicle plate or recipient"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.DeliveryNotePaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes [get]
+func (h *Delivery) GetAllDeliveryNotes(c *gin.Context) {
+	var filter models.DeliveryNoteFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch delivery notes
+	data, err := h.deliveryRepository.GetAllDeliveryNotes(c.Request.Context(), filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch delivery notes")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Delivery notes retrieved successfully", data)
+}
+
+// GetDeliveryNoteByID godoc
+// @Summary Get delivery note by ID
+// @Description Retrieve a delivery note with its items, fibers and status history
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.DeliveryNoteResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId} [get]
+func (h *Delivery) GetDeliveryNoteByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch delivery note
+	data, err := h.deliveryRepository.GetDeliveryNoteById(c.Request.Context(), noteID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch delivery note")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Delivery note %s retrieved successfully", noteID), data)
+}
+
+// CreateDeliveryNote godoc
+// @Summary Create a delivery note from a sale
+// @Description Generate a delivery note with the sale's items and fibers; the shipping address defaults to the customer's
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param deliveryNote body models.DeliveryNoteRequest true "Delivery note data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.DeliveryNoteResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes [post]
+func (h *Delivery) CreateDeliveryNote(c *gin.Context) {
+	var req models.DeliveryNoteRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create delivery note
+	data, err := h.deliveryRepository.CreateDeliveryNote(c.Request.Context(), req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create delivery note")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Delivery note created successfully", data)
+}
+
+// UpdateDeliveryNote godoc
+// @Summary Update a delivery note
+// @Description Update delivery date, address, recipient, driver and vehicle of an undelivered note
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Param deliveryNote body models.UpdateDeliveryNoteRequest true "Updated delivery note data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId} [put]
+func (h *Delivery) UpdateDeliveryNote(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.UpdateDeliveryNoteRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update delivery note
+	if err = h.deliveryRepository.UpdateDeliveryNote(c.Request.Context(), noteID, req); err != nil {
+		h.HandleError(c, err, "Failed to update delivery note")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Delivery note updated successfully", nil)
+}
+
+// UpdateDeliveryStatus godoc
+// @Summary Update delivery status
+// @Description Move a delivery note to a new status; every change is kept in the status history
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Param status body models.DeliveryStatusRequest true "New status"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId}/status [put]
+func (h *Delivery) UpdateDeliveryStatus(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.DeliveryStatusRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update status
+	if err = h.deliveryRepository.UpdateDeliveryStatus(c.Request.Context(), noteID, req); err != nil {
+		h.HandleError(c, err, "Failed to update delivery status")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Delivery status updated successfully", nil)
+}
+
+// DeleteDeliveryNote godoc
+// @Summary Delete a delivery note
+// @Description Soft delete a pending or failed delivery note
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId} [delete]
+func (h *Delivery) DeleteDeliveryNote(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete delivery note
+	if err = h.deliveryRepository.DeleteDeliveryNote(c.Request.Context(), noteID); err != nil {
+		h.HandleError(c, err, "Failed to delete delivery note")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Delivery note deleted successfully", nil)
+}
+
+// UploadDeliveryProof godoc
+// @Summary Upload proof of delivery
+// @Description Upload the signed delivery photo (JPEG, PNG or WEBP); marks the note as delivered
+// @Tags deliveries
+// @Accept multipart/form-data
+// @Produce json
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Param file formData file true "Signed photo"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.DeliveryNoteResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 413 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId}/proof [post]
+func (h *Delivery) UploadDeliveryProof(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	if maxSize := models.GetConfig().Storage.MaxUploadSize; maxSize > 0 && fileHeader.Size > maxSize {
+		h.SendError(c, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("File is larger than %d MB", maxSize/(1024*1024)), nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "Failed to open file", err)
+		return
+	}
+	defer file.Close()
+
+	// Store proof of delivery
+	data, err := h.deliveryRepository.UploadDeliveryProof(c.Request.Context(), noteID, file)
+	if err != nil {
+		h.HandleError(c, err, "Failed to upload proof of delivery")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Proof of delivery uploaded successfully", data)
+}
+
+// GetDeliveryProof godoc
+// @Summary Download proof of delivery
+// @Description Return the stored signed delivery photo
+// @Tags deliveries
+// @Produce image/jpeg,image/png,image/webp
+// @Param deliveryNoteId path string true "Delivery note ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /delivery-notes/{deliveryNoteId}/proof [get]
+func (h *Delivery) GetDeliveryProof(c *gin.Context) {
+	// Get and validate UUID parameter
+	noteID, err := h.GetUUIDParam(c, "deliveryNoteId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Locate stored photo
+	path, err := h.deliveryRepository.GetDeliveryProofPath(c.Request.Context(), noteID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch proof of delivery")
+		return
+	}
+
+	c.File(path)
+}
+
+// RegisterRoutes registers all delivery note routes
+func (h *Delivery) RegisterRoutes(router *gin.RouterGroup) {
+	notes := router.Group("/delivery-notes")
+	{
+		notes.GET("", h.GetAllDeliveryNotes)
+		notes.POST("", h.CreateDeliveryNote)
+		notes.GET("/:deliveryNoteId", h.GetDeliveryNoteByID)
+		notes.PUT("/:deliveryNoteId", h.UpdateDeliveryNote)
+		notes.DELETE("/:deliveryNoteId", h.DeleteDeliveryNote)
+		notes.PUT("/:deliveryNoteId/status", h.UpdateDeliveryStatus)
+		notes.POST("/:deliveryNoteId/proof", h.UploadDeliveryProof)
+		notes.GET("/:deliveryNoteId/proof", h.GetDeliveryProof)
+	}
+}
@@ -0,0 +1,442 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Expense struct {
+	expenseRepository repository.ExpenseRepository
+	*baseHandler.BaseHandler
+}
+
+func NewExpenseHandler(expenseRepository repository.ExpenseRepository, validate *validator.Validate) *Expense {
+	return &Expense{
+		expenseRepository: expenseRepository,
+		BaseHandler:       baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllExpenseCategories godoc
+// @Summary Get expense categories
+// @Description Retrieve all expense categories with the code of their expense account
+// @Tags expense-categories
+// @Accept json
+// @Produce json
+// @Param active_only query bool false "Only active categories"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.ExpenseCategoryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /expense-categories [get]
+func (h *Expense) GetAllExpenseCategories(c *gin.Context) {
+	var filter models.ExpenseFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch expense categories
+	data, err := h.expenseRepository.GetAllExpenseCategories(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch expense categories")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Expense categories retrieved successfully", data)
+}
+
+// CreateExpenseCategory godoc
+// @Summary Create an expense category
+// @Description Also opens the expense account in the chart of accounts that the category's expenses post to
+// @Tags expense-categories
+// @Accept json
+// @Produce json
+// @Param category body models.ExpenseCategoryRequest true "Expense category data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.ExpenseCategoryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /expense-categories [post]
+func (h *Expense) CreateExpenseCategory(c *gin.Context) {
+	var req models.ExpenseCategoryRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create expense category
+	data, err := h.expenseRepository.CreateExpenseCategory(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create expense category")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Expense category created successfully", data)
+}
+
+// UpdateExpenseCategory godoc
+// @Summary Update an expense category
+// @Tags expense-categories
+// @Accept json
+// @Produce json
+// @Param categoryId path string true "Expense category ID"
+// @Param category body models.ExpenseCategoryRequest true "Expense category data"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ExpenseCategoryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /expense-categories/{categoryId} [put]
+func (h *Expense) UpdateExpenseCategory(c *gin.Context) {
+	// Get and validate UUID parameter
+	categoryID, err := h.GetUUIDParam(c, "categoryId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.ExpenseCategoryRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update expense category
+	data, err := h.expenseRepository.UpdateExpenseCategory(categoryID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update expense category")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Expense category updated successfully", data)
+}
+
+// DeleteExpenseCategory godoc
+// @Summary Delete an expense category
+// @Description Only categories without payments, templates or journal lines can be deleted; deactivate the others
+// @Tags expense-categories
+// @Accept json
+// @Produce json
+// @Param categoryId path string true "Expense category ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /expense-categories/{categoryId} [delete]
+func (h *Expense) DeleteExpenseCategory(c *gin.Context) {
+	// Get and validate UUID parameter
+	categoryID, err := h.GetUUIDParam(c, "categoryId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete expense category
+	if err = h.expenseRepository.DeleteExpenseCategory(categoryID); err != nil {
+		h.HandleError(c, err, "Failed to delete expense category")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Expense category deleted successfully", nil)
+}
+
+// GetAllCostCenters godoc
+// @Summary Get cost centers
+// @Tags cost-centers
+// @Accept json
+// @Produce json
+// @Param active_only query bool false "Only active cost centers"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.CostCenterResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cost-centers [get]
+func (h *Expense) GetAllCostCenters(c *gin.Context) {
+	var filter models.ExpenseFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch cost centers
+	data, err := h.expenseRepository.GetAllCostCenters(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch cost centers")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cost centers retrieved successfully", data)
+}
+
+// CreateCostCenter godoc
+// @Summary Create a cost center
+// @Tags cost-centers
+// @Accept json
+// @Produce json
+// @Param costCenter body models.CostCenterRequest true "Cost center data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.CostCenterResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cost-centers [post]
+func (h *Expense) CreateCostCenter(c *gin.Context) {
+	var req models.CostCenterRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create cost center
+	data, err := h.expenseRepository.CreateCostCenter(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create cost center")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Cost center created successfully", data)
+}
+
+// UpdateCostCenter godoc
+// @Summary Update a cost center
+// @Tags cost-centers
+// @Accept json
+// @Produce json
+// @Param costCenterId path string true "Cost center ID"
+// @Param costCenter body models.CostCenterRequest true "Cost center data"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.CostCenterResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cost-centers/{costCenterId} [put]
+func (h *Expense) UpdateCostCenter(c *gin.Context) {
+	// Get and validate UUID parameter
+	costCenterID, err := h.GetUUIDParam(c, "costCenterId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.CostCenterRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update cost center
+	data, err := h.expenseRepository.UpdateCostCenter(costCenterID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update cost center")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cost center updated successfully", data)
+}
+
+// DeleteCostCenter godoc
+// @Summary Delete a cost center
+// @Description Only cost centers without payments or templates can be deleted; deactivate the others
+// @Tags cost-centers
+// @Accept json
+// @Produce json
+// @Param costCenterId path string true "Cost center ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /cost-centers/{costCenterId} [delete]
+func (h *Expense) DeleteCostCenter(c *gin.Context) {
+	// Get and validate UUID parameter
+	costCenterID, err := h.GetUUIDParam(c, "costCenterId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete cost center
+	if err = h.expenseRepository.DeleteCostCenter(costCenterID); err != nil {
+		h.HandleError(c, err, "Failed to delete cost center")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cost center deleted successfully", nil)
+}
+
+// GetAllRecurringExpenses godoc
+// @Summary Get recurring expense templates
+// @Tags recurring-expenses
+// @Accept json
+// @Produce json
+// @Param active_only query bool false "Only active templates"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.RecurringExpenseResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /recurring-expenses [get]
+func (h *Expense) GetAllRecurringExpenses(c *gin.Context) {
+	var filter models.ExpenseFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch recurring expenses
+	data, err := h.expenseRepository.GetAllRecurringExpenses(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch recurring expenses")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Recurring expenses retrieved successfully", data)
+}
+
+// CreateRecurringExpense godoc
+// @Summary Create a recurring expense template
+// @Description The template is booked as an expense payment on every due date, weekly or monthly
+// @Tags recurring-expenses
+// @Accept json
+// @Produce json
+// @Param template body models.RecurringExpenseRequest true "Recurring expense data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.RecurringExpenseResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /recurring-expenses [post]
+func (h *Expense) CreateRecurringExpense(c *gin.Context) {
+	var req models.RecurringExpenseRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create recurring expense
+	data, err := h.expenseRepository.CreateRecurringExpense(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create recurring expense")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Recurring expense created successfully", data)
+}
+
+// UpdateRecurringExpense godoc
+// @Summary Update a recurring expense template
+// @Tags recurring-expenses
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Recurring expense ID"
+// @Param template body models.RecurringExpenseRequest true "Recurring expense data"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.RecurringExpenseResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /recurring-expenses/{templateId} [put]
+func (h *Expense) UpdateRecurringExpense(c *gin.Context) {
+	// Get and validate UUID parameter
+	templateID, err := h.GetUUIDParam(c, "templateId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.RecurringExpenseRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update recurring expense
+	data, err := h.expenseRepository.UpdateRecurringExpense(templateID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update recurring expense")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Recurring expense updated successfully", data)
+}
+
+// DeleteRecurringExpense godoc
+// @Summary Delete a recurring expense template
+// @Description Payments already booked from the template are kept
+// @Tags recurring-expenses
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Recurring expense ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /recurring-expenses/{templateId} [delete]
+func (h *Expense) DeleteRecurringExpense(c *gin.Context) {
+	// Get and validate UUID parameter
+	templateID, err := h.GetUUIDParam(c, "templateId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete recurring expense
+	if err = h.expenseRepository.DeleteRecurringExpense(templateID); err != nil {
+		h.HandleError(c, err, "Failed to delete recurring expense")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Recurring expense deleted successfully", nil)
+}
+
+// RunRecurringExpenses godoc
+// @Summary Book due recurring expenses
+// @Description Books every active template due today or earlier; the same run also happens periodically in the background
+// @Tags recurring-expenses
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.RecurringExpenseRunResponse}
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /recurring-expenses/run [post]
+func (h *Expense) RunRecurringExpenses(c *gin.Context) {
+	// Book due templates
+	data, err := h.expenseRepository.RunRecurringExpenses()
+	if err != nil {
+		h.HandleError(c, err, "Failed to run recurring expenses")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Booked %d recurring expense payment(s)", data.PaymentsCreated), data)
+}
+
+// RegisterRoutes registers all expense routes
+func (h *Expense) RegisterRoutes(router *gin.RouterGroup) {
+	categories := router.Group("/expense-categories")
+	{
+		categories.GET("", h.GetAllExpenseCategories)
+		categories.POST("", h.CreateExpenseCategory)
+		categories.PUT("/:categoryId", h.UpdateExpenseCategory)
+		categories.DELETE("/:categoryId", h.DeleteExpenseCategory)
+	}
+
+	costCenters := router.Group("/cost-centers")
+	{
+		costCenters.GET("", h.GetAllCostCenters)
+		costCenters.POST("", h.CreateCostCenter)
+		costCenters.PUT("/:costCenterId", h.UpdateCostCenter)
+		costCenters.DELETE("/:costCenterId", h.DeleteCostCenter)
+	}
+
+	recurring := router.Group("/recurring-expenses")
+	{
+		recurring.GET("", h.GetAllRecurringExpenses)
+		recurring.POST("", h.CreateRecurringExpense)
+		recurring.POST("/run", h.RunRecurringExpenses)
+		recurring.PUT("/:templateId", h.UpdateRecurringExpense)
+		recurring.DELETE("/:templateId", h.DeleteRecurringExpense)
+	}
+}
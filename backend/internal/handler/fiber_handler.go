@@ -32,6 +32,7 @@ func NewFiberHandler(fiberRepository repository.FiberRepository, validate *valid
 // @Param size query int false "Page size" default(10)
 // @Param name query string false "Filter by fiber name"
 // @Param status query string false "Filter by status (FREE, USED)"
+// @Param location_id query string false "Filter by location ID"
 // @Success 200 {object} models.HTTPResponseSuccess{data=models.FiberPaginationResponse}
 // @Failure 400 {object} models.HTTPResponseError
 // @Failure 500 {object} models.HTTPResponseError
@@ -334,10 +335,92 @@ func (h *Fiber) BulkMarkAvailable(c *gin.Context) {
 	})
 }
 
+// BulkCreateFibers godoc
+// @Summary Bulk create fibers
+// @Description Create many fibers at once from a list and/or a name pattern (e.g. F-001..F-200). Nothing is created if any row is invalid.
+// @Tags fibers
+// @Accept json
+// @Produce json
+// @Param fibers body models.BulkFiberRequest true "Fibers or name pattern"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.BulkFiberResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 422 {object} models.HTTPResponseSuccess{data=models.BulkFiberResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /fibers/bulk [post]
+func (h *Fiber) BulkCreateFibers(c *gin.Context) {
+	var req models.BulkFiberRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	if len(req.Data) == 0 && req.Pattern == nil {
+		h.SendError(c, http.StatusBadRequest, "Either data or pattern is required", nil)
+		return
+	}
+
+	// Create fibers
+	data, err := h.fiberRepository.BulkCreateFibers(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create fibers")
+		return
+	}
+
+	h.sendBulkResult(c, data)
+}
+
+// ImportFibers godoc
+// @Summary Import fibers from file
+// @Description Create fibers from a CSV or XLSX file with columns name, fiber_type, capacity, status. Nothing is created if any row is invalid.
+// @Tags fibers
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.BulkFiberResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 422 {object} models.HTTPResponseSuccess{data=models.BulkFiberResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /fibers/import [post]
+func (h *Fiber) ImportFibers(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "Failed to open file", err)
+		return
+	}
+	defer file.Close()
+
+	// Import fibers
+	data, err := h.fiberRepository.ImportFibers(fileHeader.Filename, file)
+	if err != nil {
+		h.HandleError(c, err, "Failed to import fibers")
+		return
+	}
+
+	h.sendBulkResult(c, data)
+}
+
 // =====================================================
 // HELPER METHODS
 // =====================================================
 
+// sendBulkResult sends the per-row report, using 422 when nothing was created
+func (h *Fiber) sendBulkResult(c *gin.Context, data *models.BulkFiberResponse) {
+	if !data.Success {
+		h.SendSuccess(c, http.StatusUnprocessableEntity,
+			fmt.Sprintf("%d of %d row(s) are invalid, no fibers were created", data.InvalidRows, data.TotalRows), data)
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, fmt.Sprintf("Successfully created %d fiber(s)", data.CreatedCount), data)
+}
+
 // isValidFiberStatus checks if the fiber status is valid
 func (h *Fiber) isValidFiberStatus(status string) bool {
 	validStatuses := map[string]bool{
@@ -364,6 +447,8 @@ func (h *Fiber) RegisterRoutes(router *gin.RouterGroup) {
 		fibers.PUT("/:fiberId/mark", h.MarkFiberAvailable)
 
 		// Bulk operations
+		fibers.POST("/bulk", h.BulkCreateFibers)
+		fibers.POST("/import", h.ImportFibers)
 		fibers.PATCH("/bulk/mark-available", h.BulkMarkAvailable)
 	}
 }
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/xuri/excelize/v2"
+	"net/http"
+)
+
+type FinancialStatement struct {
+	financialStatementRepository repository.FinancialStatementRepository
+	*baseHandler.BaseHandler
+}
+
+func NewFinancialStatementHandler(financialStatementRepository repository.FinancialStatementRepository, validate *validator.Validate) *FinancialStatement {
+	return &FinancialStatement{
+		financialStatementRepository: financialStatementRepository,
+		BaseHandler:                  baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetProfitLoss godoc
+// @Summary Get profit and loss statement
+// @Description Revenue, cost of goods sold and operating expenses for a month or custom period, compared with the period before
+// @Tags financial-statements
+// @Accept json
+// @Produce json
+// @Param month query string false "Calendar month (YYYY-MM); defaults to the current month"
+// @Param start_date query string false "Custom period from (YYYY-MM-DD)"
+// @Param end_date query string false "Custom period to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProfitLossResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/profit-loss [get]
+func (h *FinancialStatement) GetProfitLoss(c *gin.Context) {
+	data, ok := h.fetchProfitLoss(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Profit and loss retrieved successfully", data)
+}
+
+// ExportProfitLoss godoc
+// @Summary Export profit and loss statement
+// @Tags financial-statements
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param month query string false "Calendar month (YYYY-MM); defaults to the current month"
+// @Param start_date query string false "Custom period from (YYYY-MM-DD)"
+// @Param end_date query string false "Custom period to (YYYY-MM-DD)"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/profit-loss/export [get]
+func (h *FinancialStatement) ExportProfitLoss(c *gin.Context) {
+	data, ok := h.fetchProfitLoss(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	sheet := newStatementSheet("Profit & Loss",
+		fmt.Sprintf("%s - %s", data.StartDate, data.EndDate),
+		fmt.Sprintf("%s - %s", data.PreviousStartDate, data.PreviousEndDate),
+	)
+	sheet.section(data.Revenue)
+	sheet.section(data.CostOfGoodsSold)
+	sheet.total("Gross profit", data.GrossProfit)
+	sheet.section(data.Expenses)
+	sheet.total("Net profit", data.NetProfit)
+
+	sheet.write(c, fmt.Sprintf("profit_loss_%s_%s.xlsx", data.StartDate, data.EndDate))
+}
+
+// GetBalanceSheet godoc
+// @Summary Get balance sheet
+// @Description Assets, liabilities and equity at a date, compared with an earlier date
+// @Tags financial-statements
+// @Accept json
+// @Produce json
+// @Param date query string false "Balance sheet date (YYYY-MM-DD); defaults to today"
+// @Param compare_date query string false "Comparison date (YYYY-MM-DD); defaults to one month earlier"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.BalanceSheetResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/balance-sheet [get]
+func (h *FinancialStatement) GetBalanceSheet(c *gin.Context) {
+	data, ok := h.fetchBalanceSheet(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Balance sheet retrieved successfully", data)
+}
+
+// ExportBalanceSheet godoc
+// @Summary Export balance sheet
+// @Tags financial-statements
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param date query string false "Balance sheet date (YYYY-MM-DD); defaults to today"
+// @Param compare_date query string false "Comparison date (YYYY-MM-DD); defaults to one month earlier"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/balance-sheet/export [get]
+func (h *FinancialStatement) ExportBalanceSheet(c *gin.Context) {
+	data, ok := h.fetchBalanceSheet(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	sheet := newStatementSheet("Balance Sheet", data.Date, data.CompareDate)
+	sheet.section(data.Assets)
+	sheet.section(data.Liabilities)
+	sheet.section(data.Equity)
+	sheet.total("Total liabilities and equity", data.TotalLiabilitiesAndEquity)
+
+	sheet.write(c, fmt.Sprintf("balance_sheet_%s.xlsx", data.Date))
+}
+
+// GetCashFlowStatement godoc
+// @Summary Get cash flow statement
+// @Description Cash received and paid by operating, investing and financing activity for a month or custom period, compared with the period before
+// @Tags financial-statements
+// @Accept json
+// @Produce json
+// @Param month query string false "Calendar month (YYYY-MM); defaults to the current month"
+// @Param start_date query string false "Custom period from (YYYY-MM-DD)"
+// @Param end_date query string false "Custom period to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.CashFlowStatementResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/cash-flow [get]
+func (h *FinancialStatement) GetCashFlowStatement(c *gin.Context) {
+	data, ok := h.fetchCashFlow(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Cash flow statement retrieved successfully", data)
+}
+
+// ExportCashFlowStatement godoc
+// @Summary Export cash flow statement
+// @Tags financial-statements
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param month query string false "Calendar month (YYYY-MM); defaults to the current month"
+// @Param start_date query string false "Custom period from (YYYY-MM-DD)"
+// @Param end_date query string false "Custom period to (YYYY-MM-DD)"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /financial-statements/cash-flow/export [get]
+func (h *FinancialStatement) ExportCashFlowStatement(c *gin.Context) {
+	data, ok := h.fetchCashFlow(c)
+	if !ok {
+		return // Error already sent
+	}
+
+	sheet := newStatementSheet("Cash Flow",
+		fmt.Sprintf("%s - %s", data.StartDate, data.EndDate),
+		fmt.Sprintf("%s - %s", data.PreviousStartDate, data.PreviousEndDate),
+	)
+	sheet.total("Opening cash", data.OpeningCash)
+	for _, section := range data.Sections {
+		sheet.section(section)
+	}
+	sheet.total("Net change in cash", data.NetChange)
+	sheet.total("Closing cash", data.ClosingCash)
+
+	sheet.write(c, fmt.Sprintf("cash_flow_%s_%s.xlsx", data.StartDate, data.EndDate))
+}
+
+func (h *FinancialStatement) fetchProfitLoss(c *gin.Context) (*models.ProfitLossResponse, bool) {
+	var filter models.StatementPeriodFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return nil, false
+	}
+
+	data, err := h.financialStatementRepository.GetProfitLoss(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch profit and loss")
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (h *FinancialStatement) fetchBalanceSheet(c *gin.Context) (*models.BalanceSheetResponse, bool) {
+	var filter models.BalanceSheetFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return nil, false
+	}
+
+	data, err := h.financialStatementRepository.GetBalanceSheet(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch balance sheet")
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (h *FinancialStatement) fetchCashFlow(c *gin.Context) (*models.CashFlowStatementResponse, bool) {
+	var filter models.StatementPeriodFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return nil, false
+	}
+
+	data, err := h.financialStatementRepository.GetCashFlowStatement(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch cash flow statement")
+		return nil, false
+	}
+
+	return data, true
+}
+
+// statementSheet lays a statement out as code, account, current period,
+// previous period, change and change percent columns.
+type statementSheet struct {
+	f     *excelize.File
+	name  string
+	row   int
+	bold  int
+	money int
+}
+
+func newStatementSheet(name, current, previous string) *statementSheet {
+	f := excelize.NewFile()
+	_ = f.SetSheetName("Sheet1", name)
+
+	bold, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	money, _ := f.NewStyle(&excelize.Style{NumFmt: 3})
+
+	s := &statementSheet{f: f, name: name, row: 1, bold: bold, money: money}
+	s.values(true, name)
+	s.row++
+	s.values(true, "Code", "Account", current, previous, "Change", "Change %")
+
+	for i, width := range []float64{10, 32, 24, 24, 18, 12} {
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		_ = f.SetColWidth(name, col, col, width)
+	}
+	_ = f.SetColStyle(name, "C:E", money)
+
+	return s
+}
+
+func (s *statementSheet) values(bold bool, values ...any) {
+	for col, value := range values {
+		cell, _ := excelize.CoordinatesToCellName(col+1, s.row)
+		_ = s.f.SetCellValue(s.name, cell, value)
+	}
+	if bold {
+		start, _ := excelize.CoordinatesToCellName(1, s.row)
+		end, _ := excelize.CoordinatesToCellName(6, s.row)
+		_ = s.f.SetCellStyle(s.name, start, end, s.bold)
+	}
+	s.row++
+}
+
+func (s *statementSheet) section(section models.StatementSection) {
+	s.row++
+	s.values(true, "", section.Name)
+	for _, line := range section.Lines {
+		s.values(false, line.AccountCode, line.AccountName, line.Amount, line.PreviousAmount, line.Change, line.ChangePercent)
+	}
+	s.values(true, "", "Total "+section.Name, section.Total, section.PreviousTotal, section.Change, section.ChangePercent)
+}
+
+func (s *statementSheet) total(label string, total models.StatementTotal) {
+	s.row++
+	s.values(true, "", label, total.Amount, total.PreviousAmount, total.Change, total.ChangePercent)
+}
+
+func (s *statementSheet) write(c *gin.Context, filename string) {
+	c.Header(
+		"Content-Type",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	)
+	c.Header(
+		"Content-Disposition",
+		`attachment; filename="`+filename+`"`,
+	)
+
+	_ = s.f.Write(c.Writer)
+}
+
+// RegisterRoutes registers all financial statement routes
+func (h *FinancialStatement) RegisterRoutes(router *gin.RouterGroup) {
+	statements := router.Group("/financial-statements")
+	{
+		statements.GET("/profit-loss", h.GetProfitLoss)
+		statements.GET("/profit-loss/export", h.ExportProfitLoss)
+		statements.GET("/balance-sheet", h.GetBalanceSheet)
+		statements.GET("/balance-sheet/export", h.ExportBalanceSheet)
+		statements.GET("/cash-flow", h.GetCashFlowStatement)
+		statements.GET("/cash-flow/export", h.ExportCashFlowStatement)
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Label sheet layout on A4 portrait, in millimetres.
+const (
+	labelColumns     = 3
+	labelRows        = 8
+	labelWidth       = 63.0
+	labelHeight      = 34.0
+	labelMarginLeft  = 10.5
+	labelMarginTop   = 12.5
+	labelQRSize      = 28.0
+	labelQRPadding   = 3.0
+	labelQRImageSize = 256
+)
+
+type Label struct {
+	labelRepository repository.LabelRepository
+	*baseHandler.BaseHandler
+}
+
+func NewLabelHandler(labelRepository repository.LabelRepository, validate *validator.Validate) *Label {
+	return &Label{
+		labelRepository: labelRepository,
+		BaseHandler:     baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetFiberLabels godoc
+// @Summary Print fiber labels
+// @Description Render a PDF sheet of QR code labels encoding fiber UUIDs
+// @Tags fibers
+// @Produce application/pdf
+// @Param ids query string false "Comma separated fiber IDs"
+// @Param status query string false "Filter by status (FREE, USED)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /fibers/labels [get]
+func (h *Label) GetFiberLabels(c *gin.Context) {
+	var filter models.FiberLabelFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch labels
+	labels, err := h.labelRepository.GetFiberLabels(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch fiber labels")
+		return
+	}
+
+	filename := fmt.Sprintf("fiber_labels_%s.pdf", time.Now().Format("2006-01-02"))
+	h.sendLabelSheet(c, filename, labels)
+}
+
+// GetStockLabels godoc
+// @Summary Print stock labels
+// @Description Render a PDF sheet of QR code labels for a stock entry and its sorts
+// @Tags stocks
+// @Produce application/pdf
+// @Param stockId path string true "Stock Entry ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stocks/{stockId}/labels [get]
+func (h *Label) GetStockLabels(c *gin.Context) {
+	// Get and validate UUID parameter
+	stockID, err := h.GetUUIDParam(c, "stockId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch labels
+	labels, err := h.labelRepository.GetStockLabels(stockID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch stock labels")
+		return
+	}
+
+	filename := fmt.Sprintf("%s_labels.pdf", strings.ToLower(labels[0].Code))
+	h.sendLabelSheet(c, filename, labels)
+}
+
+// LookupCode godoc
+// @Summary Resolve a scanned code
+// @Description Resolve a scanned QR code (fiber UUID, stock sort UUID, sale UUID, STOCK or SELL code) to its record
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Param code query string true "Scanned code"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ScanLookupResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /labels/lookup [get]
+func (h *Label) LookupCode(c *gin.Context) {
+	var filter models.ScanLookupFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	if strings.TrimSpace(filter.Code) == "" {
+		h.SendError(c, http.StatusBadRequest, "Code is required", nil)
+		return
+	}
+
+	// Resolve code
+	data, err := h.labelRepository.LookupCode(c.Request.Context(), filter.Code)
+	if err != nil {
+		h.HandleError(c, err, "Failed to resolve code")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Code %s resolved to %s", filter.Code, data.Type), data)
+}
+
+// =====================================================
+// HELPER METHODS
+// =====================================================
+
+// sendLabelSheet renders the labels as an A4 PDF sheet and writes it to the response
+func (h *Label) sendLabelSheet(c *gin.Context, filename string, labels []models.Label) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+
+	perPage := labelColumns * labelRows
+	imageOptions := gofpdf.ImageOptions{ImageType: "PNG"}
+
+	for i, label := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		slot := i % perPage
+		x := labelMarginLeft + float64(slot%labelColumns)*labelWidth
+		y := labelMarginTop + float64(slot/labelColumns)*labelHeight
+
+		png, err := qrcode.Encode(label.Code, qrcode.Medium, labelQRImageSize)
+		if err != nil {
+			h.SendError(c, http.StatusInternalServerError, "Failed to generate QR code", err)
+			return
+		}
+
+		imageName := fmt.Sprintf("qr-%d", i)
+		pdf.RegisterImageOptionsReader(imageName, imageOptions, bytes.NewReader(png))
+		pdf.ImageOptions(imageName, x+labelQRPadding, y+labelQRPadding, labelQRSize, labelQRSize, false, imageOptions, 0, "")
+
+		// Cut guide
+		pdf.SetDrawColor(200, 200, 200)
+		pdf.Rect(x, y, labelWidth, labelHeight, "D")
+
+		textX := x + labelQRSize + 2*labelQRPadding
+		textWidth := labelWidth - labelQRSize - 3*labelQRPadding
+
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.SetXY(textX, y+labelQRPadding+4)
+		pdf.MultiCell(textWidth, 5, label.Title, "", "L", false)
+
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetX(textX)
+		pdf.MultiCell(textWidth, 4, label.Subtitle, "", "L", false)
+
+		pdf.SetFont("Helvetica", "", 5)
+		pdf.SetXY(x+labelQRPadding, y+labelQRSize+labelQRPadding)
+		pdf.CellFormat(labelWidth-2*labelQRPadding, 3, label.Code, "", 0, "L", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		h.SendError(c, http.StatusInternalServerError, "Failed to render label sheet", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// RegisterRoutes registers all label routes
+func (h *Label) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/fibers/labels", h.GetFiberLabels)
+	router.GET("/stocks/:stockId/labels", h.GetStockLabels)
+	router.GET("/labels/lookup", h.LookupCode)
+}
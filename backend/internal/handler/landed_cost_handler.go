@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type LandedCost struct {
+	landedCostRepository repository.LandedCostRepository
+	*baseHandler.BaseHandler
+}
+
+func NewLandedCostHandler(landedCostRepository repository.LandedCostRepository, validate *validator.Validate) *LandedCost {
+	return &LandedCost{
+		landedCostRepository: landedCostRepository,
+		BaseHandler:          baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetLandedCosts godoc
+// @Summary Get landed costs of a purchase
+// @Description Retrieve cost lines (ice, transport, labour) and how they are allocated to the purchase's stock items
+// @Tags landed-costs
+// @Accept json
+// @Produce json
+// @Param purchaseId path string true "Purchase ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.LandedCostResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchases/{purchaseId}/costs [get]
+func (h *LandedCost) GetLandedCosts(c *gin.Context) {
+	// Get and validate UUID parameter
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch landed costs
+	data, err := h.landedCostRepository.GetLandedCosts(purchaseID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch landed costs")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Landed costs retrieved successfully", data)
+}
+
+// CreatePurchaseCost godoc
+// @Summary Add a landed cost line
+// @Description Add an ice, transport, labour or other cost to a purchase, allocated to stock items by weight or value
+// @Tags landed-costs
+// @Accept json
+// @Produce json
+// @Param purchaseId path string true "Purchase ID"
+// @Param cost body models.PurchaseCostRequest true "Cost line"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.LandedCostResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchases/{purchaseId}/costs [post]
+func (h *LandedCost) CreatePurchaseCost(c *gin.Context) {
+	// Get and validate UUID parameter
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.PurchaseCostRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create cost line
+	data, err := h.landedCostRepository.CreatePurchaseCost(purchaseID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create purchase cost")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Purchase cost created successfully", data)
+}
+
+// UpdatePurchaseCost godoc
+// @Summary Update a landed cost line
+// @Description Change a cost line and reallocate the purchase's landed costs
+// @Tags landed-costs
+// @Accept json
+// @Produce json
+// @Param purchaseId path string true "Purchase ID"
+// @Param costId path string true "Cost line ID"
+// @Param cost body models.PurchaseCostRequest true "Cost line"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.LandedCostResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchases/{purchaseId}/costs/{costId} [put]
+func (h *LandedCost) UpdatePurchaseCost(c *gin.Context) {
+	// Get and validate UUID parameters
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+	costID, err := h.GetUUIDParam(c, "costId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.PurchaseCostRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update cost line
+	data, err := h.landedCostRepository.UpdatePurchaseCost(purchaseID, costID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update purchase cost")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase cost updated successfully", data)
+}
+
+// DeletePurchaseCost godoc
+// @Summary Delete a landed cost line
+// @Description Remove a cost line and reallocate the purchase's landed costs
+// @Tags landed-costs
+// @Accept json
+// @Produce json
+// @Param purchaseId path string true "Purchase ID"
+// @Param costId path string true "Cost line ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchases/{purchaseId}/costs/{costId} [delete]
+func (h *LandedCost) DeletePurchaseCost(c *gin.Context) {
+	// Get and validate UUID parameters
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+	costID, err := h.GetUUIDParam(c, "costId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete cost line
+	if err = h.landedCostRepository.DeletePurchaseCost(purchaseID, costID); err != nil {
+		h.HandleError(c, err, "Failed to delete purchase cost")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase cost deleted successfully", nil)
+}
+
+// RegisterRoutes registers landed cost routes nested under purchases
+func (h *LandedCost) RegisterRoutes(router *gin.RouterGroup) {
+	costs := router.Group("/purchases/:purchaseId/costs")
+	{
+		costs.GET("", h.GetLandedCosts)
+		costs.POST("", h.CreatePurchaseCost)
+		costs.PUT("/:costId", h.UpdatePurchaseCost)
+		costs.DELETE("/:costId", h.DeletePurchaseCost)
+	}
+}
@@ -0,0 +1,408 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Ledger struct {
+	ledgerRepository repository.LedgerRepository
+	*baseHandler.BaseHandler
+}
+
+func NewLedgerHandler(ledgerRepository repository.LedgerRepository, validate *validator.Validate) *Ledger {
+	return &Ledger{
+		ledgerRepository: ledgerRepository,
+		BaseHandler:      baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllAccounts godoc
+// @Summary Get chart of accounts
+// @Description Retrieve all accounts with their current balance on the normal side
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param account_type query string false "Filter by type (ASSET, LIABILITY, EQUITY, REVENUE, EXPENSE)"
+// @Param keyword query string false "Search by code or name"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.AccountResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounts [get]
+func (h *Ledger) GetAllAccounts(c *gin.Context) {
+	var filter models.AccountFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch accounts
+	data, err := h.ledgerRepository.GetAllAccounts(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch accounts")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Accounts retrieved successfully", data)
+}
+
+// CreateAccount godoc
+// @Summary Create an account
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param account body models.AccountRequest true "Account data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.AccountResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounts [post]
+func (h *Ledger) CreateAccount(c *gin.Context) {
+	var req models.AccountRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create account
+	data, err := h.ledgerRepository.CreateAccount(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Account created successfully", data)
+}
+
+// UpdateAccount godoc
+// @Summary Update an account
+// @Description System accounts only accept a new name and description
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param account body models.AccountRequest true "Account data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounts/{accountId} [put]
+func (h *Ledger) UpdateAccount(c *gin.Context) {
+	// Get and validate UUID parameter
+	accountID, err := h.GetUUIDParam(c, "accountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.AccountRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update account
+	if err = h.ledgerRepository.UpdateAccount(accountID, req); err != nil {
+		h.HandleError(c, err, "Failed to update account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Account updated successfully", nil)
+}
+
+// DeleteAccount godoc
+// @Summary Delete an account
+// @Description Only non-system accounts without journal lines can be deleted
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounts/{accountId} [delete]
+func (h *Ledger) DeleteAccount(c *gin.Context) {
+	// Get and validate UUID parameter
+	accountID, err := h.GetUUIDParam(c, "accountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete account
+	if err = h.ledgerRepository.DeleteAccount(accountID); err != nil {
+		h.HandleError(c, err, "Failed to delete account")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Account deleted successfully", nil)
+}
+
+// GetAccountStatement godoc
+// @Summary Get account statement
+// @Description Opening balance, movements with running balance and closing balance of one account; user_id narrows it to one customer or supplier
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param accountId path string true "Account ID"
+// @Param user_id query string false "Filter by customer or supplier"
+// @Param start_date query string false "Entry date from (YYYY-MM-DD)"
+// @Param end_date query string false "Entry date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.AccountLedger}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /accounts/{accountId}/statement [get]
+func (h *Ledger) GetAccountStatement(c *gin.Context) {
+	// Get and validate UUID parameter
+	accountID, err := h.GetUUIDParam(c, "accountId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var filter models.LedgerFilter
+
+	// Bind query parameters
+	if err = h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch statement
+	data, err := h.ledgerRepository.GetAccountStatement(accountID, filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch account statement")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Statement of account %s retrieved successfully", accountID), data)
+}
+
+// GetAllJournalEntries godoc
+// @Summary Get journal entries
+// @Tags journal-entries
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param source_type query string false "Filter by source (PURCHASE, SALE, PAYMENT, SUPPLIER_RETURN, MANUAL)"
+// @Param reference_id query string false "Filter by purchase or sale ID"
+// @Param account_id query string false "Filter by account ID"
+// @Param start_date query string false "Entry date from (YYYY-MM-DD)"
+// @Param end_date query string false "Entry date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.JournalEntryPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /journal-entries [get]
+func (h *Ledger) GetAllJournalEntries(c *gin.Context) {
+	var filter models.JournalEntryFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch journal entries
+	data, err := h.ledgerRepository.GetAllJournalEntries(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch journal entries")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Journal entries retrieved successfully", data)
+}
+
+// GetJournalEntryByID godoc
+// @Summary Get journal entry by ID
+// @Tags journal-entries
+// @Accept json
+// @Produce json
+// @Param entryId path string true "Journal entry ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.JournalEntryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /journal-entries/{entryId} [get]
+func (h *Ledger) GetJournalEntryByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	entryID, err := h.GetUUIDParam(c, "entryId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch journal entry
+	data, err := h.ledgerRepository.GetJournalEntryById(entryID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch journal entry")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Journal entry %s retrieved successfully", entryID), data)
+}
+
+// CreateJournalEntry godoc
+// @Summary Create a manual journal entry
+// @Description Each line carries either a debit or a credit; total debit must equal total credit
+// @Tags journal-entries
+// @Accept json
+// @Produce json
+// @Param entry body models.JournalEntryRequest true "Journal entry data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.JournalEntryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /journal-entries [post]
+func (h *Ledger) CreateJournalEntry(c *gin.Context) {
+	var req models.JournalEntryRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create journal entry
+	data, err := h.ledgerRepository.CreateJournalEntry(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create journal entry")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Journal entry created successfully", data)
+}
+
+// ReverseJournalEntry godoc
+// @Summary Reverse a manual journal entry
+// @Description Post a mirror entry dated today; generated entries follow their document and cannot be reversed here
+// @Tags journal-entries
+// @Accept json
+// @Produce json
+// @Param entryId path string true "Journal entry ID"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.JournalEntryResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /journal-entries/{entryId}/reverse [post]
+func (h *Ledger) ReverseJournalEntry(c *gin.Context) {
+	// Get and validate UUID parameter
+	entryID, err := h.GetUUIDParam(c, "entryId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Reverse journal entry
+	data, err := h.ledgerRepository.ReverseJournalEntry(entryID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to reverse journal entry")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Journal entry reversed successfully", data)
+}
+
+// GetTrialBalance godoc
+// @Summary Get trial balance
+// @Description Net balance of every account as of a date, on its debit or credit side
+// @Tags ledger
+// @Accept json
+// @Produce json
+// @Param end_date query string false "Balances as of (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.TrialBalanceResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /ledger/trial-balance [get]
+func (h *Ledger) GetTrialBalance(c *gin.Context) {
+	var filter models.TrialBalanceFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch trial balance
+	data, err := h.ledgerRepository.GetTrialBalance(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch trial balance")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Trial balance retrieved successfully", data)
+}
+
+// GetGeneralLedger godoc
+// @Summary Get general ledger
+// @Description Opening balance, movements and closing balance per account for a period
+// @Tags ledger
+// @Accept json
+// @Produce json
+// @Param account_id query string false "Filter by account ID"
+// @Param user_id query string false "Filter by customer or supplier"
+// @Param start_date query string false "Entry date from (YYYY-MM-DD)"
+// @Param end_date query string false "Entry date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.GeneralLedgerResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /ledger/general-ledger [get]
+func (h *Ledger) GetGeneralLedger(c *gin.Context) {
+	var filter models.LedgerFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch general ledger
+	data, err := h.ledgerRepository.GetGeneralLedger(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch general ledger")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "General ledger retrieved successfully", data)
+}
+
+// RegisterRoutes registers all ledger routes
+func (h *Ledger) RegisterRoutes(router *gin.RouterGroup) {
+	accounts := router.Group("/accounts")
+	{
+		accounts.GET("", h.GetAllAccounts)
+		accounts.POST("", h.CreateAccount)
+		accounts.PUT("/:accountId", h.UpdateAccount)
+		accounts.DELETE("/:accountId", h.DeleteAccount)
+		accounts.GET("/:accountId/statement", h.GetAccountStatement)
+	}
+
+	journal := router.Group("/journal-entries")
+	{
+		journal.GET("", h.GetAllJournalEntries)
+		journal.POST("", h.CreateJournalEntry)
+		journal.GET("/:entryId", h.GetJournalEntryByID)
+		journal.POST("/:entryId/reverse", h.ReverseJournalEntry)
+	}
+
+	ledger := router.Group("/ledger")
+	{
+		ledger.GET("/trial-balance", h.GetTrialBalance)
+		ledger.GET("/general-ledger", h.GetGeneralLedger)
+	}
+}
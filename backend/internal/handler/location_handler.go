@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Location struct {
+	locationRepository repository.LocationRepository
+	*baseHandler.BaseHandler
+}
+
+func NewLocationHandler(locationRepository repository.LocationRepository, validate *validator.Validate) *Location {
+	return &Location{
+		locationRepository: locationRepository,
+		BaseHandler:        baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllLocations godoc
+// @Summary Get all locations
+// @Description Retrieve cold rooms, warehouses and market stalls with the stock and fibers stored in them
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.LocationResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /locations [get]
+func (h *Location) GetAllLocations(c *gin.Context) {
+	// Fetch locations
+	data, err := h.locationRepository.GetAllLocations()
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch locations")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Locations retrieved successfully", data)
+}
+
+// GetLocationByID godoc
+// @Summary Get location by ID
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.LocationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /locations/{locationId} [get]
+func (h *Location) GetLocationByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	locationID, err := h.GetUUIDParam(c, "locationId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch location
+	data, err := h.locationRepository.GetLocationById(locationID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch location")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Location %s retrieved successfully", locationID), data)
+}
+
+// CreateLocation godoc
+// @Summary Create a location
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param location body models.LocationRequest true "Location data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.LocationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /locations [post]
+func (h *Location) CreateLocation(c *gin.Context) {
+	var req models.LocationRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create location
+	data, err := h.locationRepository.CreateLocation(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create location")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Location created successfully", data)
+}
+
+// UpdateLocation godoc
+// @Summary Update a location
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Param location body models.LocationRequest true "Location data"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.LocationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /locations/{locationId} [put]
+func (h *Location) UpdateLocation(c *gin.Context) {
+	// Get and validate UUID parameter
+	locationID, err := h.GetUUIDParam(c, "locationId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.LocationRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update location
+	data, err := h.locationRepository.UpdateLocation(locationID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to update location")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Location updated successfully", data)
+}
+
+// DeleteLocation godoc
+// @Summary Delete a location
+// @Description Delete a location that no longer holds stock or fibers
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Param locationId path string true "Location ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /locations/{locationId} [delete]
+func (h *Location) DeleteLocation(c *gin.Context) {
+	// Get and validate UUID parameter
+	locationID, err := h.GetUUIDParam(c, "locationId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete location
+	if err = h.locationRepository.DeleteLocation(locationID); err != nil {
+		h.HandleError(c, err, "Failed to delete location")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Location deleted successfully", nil)
+}
+
+// RegisterRoutes registers all location routes
+func (h *Location) RegisterRoutes(router *gin.RouterGroup) {
+	locations := router.Group("/locations")
+	{
+		locations.GET("", h.GetAllLocations)
+		locations.POST("", h.CreateLocation)
+		locations.GET("/:locationId", h.GetLocationByID)
+		locations.PUT("/:locationId", h.UpdateLocation)
+		locations.DELETE("/:locationId", h.DeleteLocation)
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Notification struct {
+	notificationRepository repository.NotificationRepository
+	*baseHandler.BaseHandler
+}
+
+func NewNotificationHandler(notificationRepository repository.NotificationRepository, validate *validator.Validate) *Notification {
+	return &Notification{
+		notificationRepository: notificationRepository,
+		BaseHandler:            baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllNotifications godoc
+// @Summary Get notifications
+// @Description Retrieve the paginated in-app alert feed, newest first
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param type query string false "Filter by type (STOCK_NEAR_EXPIRY, STOCK_EXPIRED, TEMPERATURE_EXCURSION)"
+// @Param unread_only query bool false "Only unread notifications"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.NotificationPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /notifications [get]
+func (h *Notification) GetAllNotifications(c *gin.Context) {
+	var filter models.NotificationFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch notifications
+	data, err := h.notificationRepository.GetAllNotifications(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch notifications")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Notifications retrieved successfully", data)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param notificationId path string true "Notification ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /notifications/{notificationId}/read [put]
+func (h *Notification) MarkNotificationRead(c *gin.Context) {
+	// Get and validate UUID parameter
+	notificationID, err := h.GetUUIDParam(c, "notificationId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Mark as read
+	if err = h.notificationRepository.MarkNotificationRead(notificationID); err != nil {
+		h.HandleError(c, err, "Failed to mark notification as read")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Notification marked as read", nil)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /notifications/read-all [put]
+func (h *Notification) MarkAllNotificationsRead(c *gin.Context) {
+	// Mark all as read
+	count, err := h.notificationRepository.MarkAllNotificationsRead()
+	if err != nil {
+		h.HandleError(c, err, "Failed to mark notifications as read")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("%d notifications marked as read", count), nil)
+}
+
+// DeleteNotification godoc
+// @Summary Delete a notification
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param notificationId path string true "Notification ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /notifications/{notificationId} [delete]
+func (h *Notification) DeleteNotification(c *gin.Context) {
+	// Get and validate UUID parameter
+	notificationID, err := h.GetUUIDParam(c, "notificationId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete notification
+	if err = h.notificationRepository.DeleteNotification(notificationID); err != nil {
+		h.HandleError(c, err, "Failed to delete notification")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Notification deleted successfully", nil)
+}
+
+// RegisterRoutes registers all notification routes
+func (h *Notification) RegisterRoutes(router *gin.RouterGroup) {
+	notifications := router.Group("/notifications")
+	{
+		notifications.GET("", h.GetAllNotifications)
+		notifications.PUT("/read-all", h.MarkAllNotificationsRead)
+		notifications.PUT("/:notificationId/read", h.MarkNotificationRead)
+		notifications.DELETE("/:notificationId", h.DeleteNotification)
+	}
+}
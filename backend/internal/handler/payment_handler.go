@@ -299,6 +299,147 @@ func (h *Payment) CreatePaymentFromDepositBySalesId(c *gin.Context) {
 	h.SendSuccess(c, http.StatusCreated, fmt.Sprintf("Payment created for purchase %s", req.SalesId), nil)
 }
 
+// UploadPaymentAttachment godoc
+// @Summary Upload a payment receipt
+// @Description Attach a receipt or invoice scan (JPEG, PNG, WEBP or PDF) to a payment
+// @Tags payments
+// @Accept multipart/form-data
+// @Produce json
+// @Param paymentId path string true "Payment ID"
+// @Param file formData file true "Receipt"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.PaymentAttachmentResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 413 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /payment/{paymentId}/attachments [post]
+func (h *Payment) UploadPaymentAttachment(c *gin.Context) {
+	// Get and validate UUID parameter
+	paymentID, err := h.GetUUIDParam(c, "paymentId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	if maxSize := models.GetConfig().Storage.MaxUploadSize; maxSize > 0 && fileHeader.Size > maxSize {
+		h.SendError(c, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("File is larger than %d MB", maxSize/(1024*1024)), nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "Failed to open file", err)
+		return
+	}
+	defer file.Close()
+
+	// Store receipt
+	data, err := h.paymentRepository.UploadPaymentAttachment(paymentID, fileHeader.Filename, file)
+	if err != nil {
+		h.HandleError(c, err, "Failed to upload receipt")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Receipt uploaded successfully", data)
+}
+
+// GetPaymentAttachments godoc
+// @Summary Get payment receipts
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param paymentId path string true "Payment ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.PaymentAttachmentResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /payment/{paymentId}/attachments [get]
+func (h *Payment) GetPaymentAttachments(c *gin.Context) {
+	// Get and validate UUID parameter
+	paymentID, err := h.GetUUIDParam(c, "paymentId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch receipts
+	data, err := h.paymentRepository.GetPaymentAttachments(paymentID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch receipts")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Receipts of payment %s retrieved successfully", paymentID), data)
+}
+
+// DownloadPaymentAttachment godoc
+// @Summary Download a payment receipt
+// @Tags payments
+// @Produce image/jpeg,image/png,image/webp,application/pdf
+// @Param paymentId path string true "Payment ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /payment/{paymentId}/attachments/{attachmentId} [get]
+func (h *Payment) DownloadPaymentAttachment(c *gin.Context) {
+	// Get and validate UUID parameters
+	paymentID, err := h.GetUUIDParam(c, "paymentId")
+	if err != nil {
+		return // Error already sent
+	}
+	attachmentID, err := h.GetUUIDParam(c, "attachmentId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Locate stored receipt
+	attachment, err := h.paymentRepository.GetPaymentAttachment(paymentID, attachmentID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch receipt")
+		return
+	}
+
+	c.FileAttachment(attachment.FilePath, attachment.FileName)
+}
+
+// DeletePaymentAttachment godoc
+// @Summary Delete a payment receipt
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param paymentId path string true "Payment ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /payment/{paymentId}/attachments/{attachmentId} [delete]
+func (h *Payment) DeletePaymentAttachment(c *gin.Context) {
+	// Get and validate UUID parameters
+	paymentID, err := h.GetUUIDParam(c, "paymentId")
+	if err != nil {
+		return // Error already sent
+	}
+	attachmentID, err := h.GetUUIDParam(c, "attachmentId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete receipt
+	if err = h.paymentRepository.DeletePaymentAttachment(paymentID, attachmentID); err != nil {
+		h.HandleError(c, err, "Failed to delete receipt")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Receipt deleted successfully", nil)
+}
+
 // =====================================================
 // HELPER METHODS
 // =====================================================
@@ -347,5 +488,9 @@ func (h *Payment) RegisterRoutes(router *gin.RouterGroup) {
 		payment.POST("/purchase/deposit", h.CreatePaymentFromDepositByPurchaseId)
 		payment.GET("/user/deposit/:userId", h.GetUserBalanceDeposit)
 		payment.POST("/sale/deposit", h.CreatePaymentFromDepositBySalesId)
+		payment.GET("/:paymentId/attachments", h.GetPaymentAttachments)
+		payment.POST("/:paymentId/attachments", h.UploadPaymentAttachment)
+		payment.GET("/:paymentId/attachments/:attachmentId", h.DownloadPaymentAttachment)
+		payment.DELETE("/:paymentId/attachments/:attachmentId", h.DeletePaymentAttachment)
 	}
 }
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Price struct {
+	priceRepository repository.PriceRepository
+	*baseHandler.BaseHandler
+}
+
+func NewPriceHandler(priceRepository repository.PriceRepository, validate *validator.Validate) *Price {
+	return &Price{
+		priceRepository: priceRepository,
+		BaseHandler:     baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetPriceBoard godoc
+// @Summary Get price board
+// @Description Retrieve the buying and selling price per product and grade effective on a date
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param date query string false "Board date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.PriceBoardResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /prices/board [get]
+func (h *Price) GetPriceBoard(c *gin.Context) {
+	var filter models.PriceBoardFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch price board
+	data, err := h.priceRepository.GetPriceBoard(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch price board")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price board retrieved successfully", data)
+}
+
+// SetPriceBoard godoc
+// @Summary Set daily prices
+// @Description Set the buying and selling prices for a day; prices already set for that day are replaced
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param prices body models.PriceBoardRequest true "Daily prices"
+// @Success 201 {object} models.HTTPResponseSuccess{data=[]models.PriceBoardResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /prices/board [post]
+func (h *Price) SetPriceBoard(c *gin.Context) {
+	var req models.PriceBoardRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Save prices
+	data, err := h.priceRepository.SetPriceBoard(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to save price board")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, fmt.Sprintf("Saved %d price(s)", len(req.Prices)), data)
+}
+
+// GetPriceHistory godoc
+// @Summary Get price history
+// @Description Retrieve daily board prices, optionally for one product and grade within a date range
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param product_id query string false "Product ID"
+// @Param grade query string false "Grade"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.PriceBoardResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /prices/history [get]
+func (h *Price) GetPriceHistory(c *gin.Context) {
+	var filter models.PriceHistoryFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	if filter.StartDate != "" && !h.IsValidDate(filter.StartDate) {
+		h.SendError(c, http.StatusBadRequest, "Invalid start_date format, use YYYY-MM-DD", nil)
+		return
+	}
+	if filter.EndDate != "" && !h.IsValidDate(filter.EndDate) {
+		h.SendError(c, http.StatusBadRequest, "Invalid end_date format, use YYYY-MM-DD", nil)
+		return
+	}
+
+	// Fetch history
+	data, err := h.priceRepository.GetPriceHistory(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch price history")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price history retrieved successfully", data)
+}
+
+// SuggestPrice godoc
+// @Summary Suggest a line price
+// @Description Default buy price for a product, or sell price and cost for a stock sort, from the price board
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param product_id query string false "Product ID (purchase lines)"
+// @Param stock_sort_id query string false "Stock sort ID (sale lines)"
+// @Param grade query string false "Grade, defaults to the product grade"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.PriceSuggestion}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /prices/suggest [get]
+func (h *Price) SuggestPrice(c *gin.Context) {
+	var filter models.PriceSuggestFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch suggestion
+	data, err := h.priceRepository.SuggestPrice(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to suggest price")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price suggestion retrieved successfully", data)
+}
+
+// CheckPrices godoc
+// @Summary Check line prices
+// @Description Return warnings for sale lines below cost or outside the band, and purchase lines outside the band
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param lines body models.PriceCheckRequest true "Sale and purchase lines"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.PriceWarning}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /prices/check [post]
+func (h *Price) CheckPrices(c *gin.Context) {
+	var req models.PriceCheckRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Check prices
+	data, err := h.priceRepository.CheckPrices(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to check prices")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Found %d price warning(s)", len(data)), data)
+}
+
+// RegisterRoutes registers all price routes
+func (h *Price) RegisterRoutes(router *gin.RouterGroup) {
+	prices := router.Group("/prices")
+	{
+		prices.GET("/board", h.GetPriceBoard)
+		prices.POST("/board", h.SetPriceBoard)
+		prices.GET("/history", h.GetPriceHistory)
+		prices.GET("/suggest", h.SuggestPrice)
+		prices.POST("/check", h.CheckPrices)
+	}
+}
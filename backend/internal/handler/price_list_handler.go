@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type PriceList struct {
+	priceListRepository repository.PriceListRepository
+	*baseHandler.BaseHandler
+}
+
+func NewPriceListHandler(priceListRepository repository.PriceListRepository, validate *validator.Validate) *PriceList {
+	return &PriceList{
+		priceListRepository: priceListRepository,
+		BaseHandler:         baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllPriceLists godoc
+// @Summary Get all customer price lists
+// @Description Retrieve paginated customer price lists with items and volume tiers
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param customer_id query string false "Filter by customer"
+// @Param active_on query string false "Only lists valid on this date (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.PriceListPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists [get]
+func (h *PriceList) GetAllPriceLists(c *gin.Context) {
+	var filter models.PriceListFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch price lists
+	data, err := h.priceListRepository.GetAllPriceLists(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch price lists")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price lists retrieved successfully", data)
+}
+
+// GetPriceListByID godoc
+// @Summary Get price list by ID
+// @Description Retrieve a single customer price list
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param priceListId path string true "Price list ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.PriceListResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists/{priceListId} [get]
+func (h *PriceList) GetPriceListByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	priceListID, err := h.GetUUIDParam(c, "priceListId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch price list
+	data, err := h.priceListRepository.GetPriceListById(priceListID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch price list")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Price list %s retrieved successfully", priceListID), data)
+}
+
+// CreatePriceList godoc
+// @Summary Create a customer price list
+// @Description Create a price list for a BUYER with per-product prices and volume discount tiers
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param priceList body models.PriceListRequest true "Price list data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.PriceListResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists [post]
+func (h *PriceList) CreatePriceList(c *gin.Context) {
+	var req models.PriceListRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create price list
+	data, err := h.priceListRepository.CreatePriceList(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create price list")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Price list created successfully", data)
+}
+
+// UpdatePriceList godoc
+// @Summary Update a customer price list
+// @Description Replace the header, items and tiers of a price list
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param priceListId path string true "Price list ID"
+// @Param priceList body models.PriceListRequest true "Updated price list data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists/{priceListId} [put]
+func (h *PriceList) UpdatePriceList(c *gin.Context) {
+	// Get and validate UUID parameter
+	priceListID, err := h.GetUUIDParam(c, "priceListId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.PriceListRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update price list
+	if err = h.priceListRepository.UpdatePriceList(priceListID, req); err != nil {
+		h.HandleError(c, err, "Failed to update price list")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price list updated successfully", nil)
+}
+
+// DeletePriceList godoc
+// @Summary Delete a customer price list
+// @Description Soft delete a price list with its items and tiers
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param priceListId path string true "Price list ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists/{priceListId} [delete]
+func (h *PriceList) DeletePriceList(c *gin.Context) {
+	// Get and validate UUID parameter
+	priceListID, err := h.GetUUIDParam(c, "priceListId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete price list
+	if err = h.priceListRepository.DeletePriceList(priceListID); err != nil {
+		h.HandleError(c, err, "Failed to delete price list")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price list deleted successfully", nil)
+}
+
+// ApplyPriceList godoc
+// @Summary Preview customer prices for sale lines
+// @Description Price sale lines from the customer's list valid on the sale date; lines with manual_price are left unchanged
+// @Tags price-lists
+// @Accept json
+// @Produce json
+// @Param request body models.PriceListApplyRequest true "Customer, sale date and sale lines"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.ItemSalesRequest}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /price-lists/apply [post]
+func (h *PriceList) ApplyPriceList(c *gin.Context) {
+	var req models.PriceListApplyRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Apply price list
+	data, err := h.priceListRepository.ApplyPriceList(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to apply price list")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Price list applied successfully", data)
+}
+
+// RegisterRoutes registers all price list routes
+func (h *PriceList) RegisterRoutes(router *gin.RouterGroup) {
+	priceLists := router.Group("/price-lists")
+	{
+		priceLists.GET("", h.GetAllPriceLists)
+		priceLists.POST("", h.CreatePriceList)
+		priceLists.POST("/apply", h.ApplyPriceList)
+		priceLists.GET("/:priceListId", h.GetPriceListByID)
+		priceLists.PUT("/:priceListId", h.UpdatePriceList)
+		priceLists.DELETE("/:priceListId", h.DeletePriceList)
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Processing struct {
+	processingRepository repository.ProcessingRepository
+	*baseHandler.BaseHandler
+}
+
+func NewProcessingHandler(processingRepository repository.ProcessingRepository, validate *validator.Validate) *Processing {
+	return &Processing{
+		processingRepository: processingRepository,
+		BaseHandler:          baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllProcessingOrders godoc
+// @Summary Get all processing orders
+// @Description Retrieve paginated processing orders with their inputs and outputs
+// @Tags processing-orders
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param process_type query string false "Filter by process type (FILLET, GUT, FREEZE, OTHER)"
+// @Param operator query string false "Filter by operator name"
+// @Param start_date query string false "Process date from (YYYY-MM-DD)"
+// @Param end_date query string false "Process date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProcessingOrderPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /processing-orders [get]
+func (h *Processing) GetAllProcessingOrders(c *gin.Context) {
+	var filter models.ProcessingOrderFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch processing orders
+	data, err := h.processingRepository.GetAllProcessingOrders(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch processing orders")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Processing orders retrieved successfully", data)
+}
+
+// GetProcessingOrderByID godoc
+// @Summary Get processing order by ID
+// @Tags processing-orders
+// @Accept json
+// @Produce json
+// @Param processingOrderId path string true "Processing order ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProcessingOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /processing-orders/{processingOrderId} [get]
+func (h *Processing) GetProcessingOrderByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	processingOrderID, err := h.GetUUIDParam(c, "processingOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch processing order
+	data, err := h.processingRepository.GetProcessingOrderById(processingOrderID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch processing order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Processing order %s retrieved successfully", processingOrderID), data)
+}
+
+// CreateProcessingOrder godoc
+// @Summary Create a processing order
+// @Description Consume weight from stock sorts and produce new sorts; input weight not covered by outputs is recorded as waste
+// @Tags processing-orders
+// @Accept json
+// @Produce json
+// @Param processing body models.ProcessingOrderRequest true "Processing order data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.ProcessingOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /processing-orders [post]
+func (h *Processing) CreateProcessingOrder(c *gin.Context) {
+	var req models.ProcessingOrderRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create processing order
+	data, err := h.processingRepository.CreateProcessingOrder(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create processing order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Processing order created successfully", data)
+}
+
+// DeleteProcessingOrder godoc
+// @Summary Delete a processing order
+// @Description Remove the produced sorts and return the consumed weight to the input sorts
+// @Tags processing-orders
+// @Accept json
+// @Produce json
+// @Param processingOrderId path string true "Processing order ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /processing-orders/{processingOrderId} [delete]
+func (h *Processing) DeleteProcessingOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	processingOrderID, err := h.GetUUIDParam(c, "processingOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete processing order
+	if err = h.processingRepository.DeleteProcessingOrder(processingOrderID); err != nil {
+		h.HandleError(c, err, "Failed to delete processing order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Processing order deleted successfully", nil)
+}
+
+// GetProcessingYield godoc
+// @Summary Get processing yield report
+// @Description Input, output and waste weights with yield percentages per process type and operator
+// @Tags processing-orders
+// @Accept json
+// @Produce json
+// @Param process_type query string false "Filter by process type (FILLET, GUT, FREEZE, OTHER)"
+// @Param operator query string false "Filter by operator name"
+// @Param start_date query string false "Process date from (YYYY-MM-DD)"
+// @Param end_date query string false "Process date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProcessingYieldResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /processing-orders/yield [get]
+func (h *Processing) GetProcessingYield(c *gin.Context) {
+	var filter models.ProcessingYieldFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch yield report
+	data, err := h.processingRepository.GetProcessingYield(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch processing yield")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Processing yield retrieved successfully", data)
+}
+
+// RegisterRoutes registers all processing order routes
+func (h *Processing) RegisterRoutes(router *gin.RouterGroup) {
+	processing := router.Group("/processing-orders")
+	{
+		processing.GET("", h.GetAllProcessingOrders)
+		processing.POST("", h.CreateProcessingOrder)
+		processing.GET("/yield", h.GetProcessingYield)
+		processing.GET("/:processingOrderId", h.GetProcessingOrderByID)
+		processing.DELETE("/:processingOrderId", h.DeleteProcessingOrder)
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Product struct {
+	productRepository repository.ProductRepository
+	*baseHandler.BaseHandler
+}
+
+func NewProductHandler(productRepository repository.ProductRepository, validate *validator.Validate) *Product {
+	return &Product{
+		productRepository: productRepository,
+		BaseHandler:       baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllProducts godoc
+// @Summary Get all products
+// @Description Retrieve paginated product catalog with optional filters
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param keyword query string false "Search by name or code"
+// @Param species query string false "Filter by species"
+// @Param grade query string false "Filter by grade"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProductPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /products [get]
+func (h *Product) GetAllProducts(c *gin.Context) {
+	var filter models.ProductFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch products
+	data, err := h.productRepository.GetAllProducts(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch products")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Products retrieved successfully", data)
+}
+
+// GetProductByID godoc
+// @Summary Get product by ID
+// @Description Retrieve a single catalog product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.ProductResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /products/{productId} [get]
+func (h *Product) GetProductByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	productID, err := h.GetUUIDParam(c, "productId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch product
+	data, err := h.productRepository.GetProductById(productID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch product")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Product %s retrieved successfully", productID), data)
+}
+
+// CreateProduct godoc
+// @Summary Create a new product
+// @Description Add a product (species, grade, size class, unit) to the catalog
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body models.ProductRequest true "Product data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.ProductResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /products [post]
+func (h *Product) CreateProduct(c *gin.Context) {
+	var req models.ProductRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create product
+	data, err := h.productRepository.CreateProduct(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create product")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Product created successfully", data)
+}
+
+// UpdateProduct godoc
+// @Summary Update product
+// @Description Update a catalog product; linked stock item and sort names follow the new name
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Param product body models.ProductRequest true "Updated product data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /products/{productId} [put]
+func (h *Product) UpdateProduct(c *gin.Context) {
+	// Get and validate UUID parameter
+	productID, err := h.GetUUIDParam(c, "productId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.ProductRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update product
+	if err = h.productRepository.UpdateProduct(productID, req); err != nil {
+		h.HandleError(c, err, "Failed to update product")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Product updated successfully", nil)
+}
+
+// DeleteProduct godoc
+// @Summary Delete product
+// @Description Soft delete a catalog product that has no remaining stock
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /products/{productId} [delete]
+func (h *Product) DeleteProduct(c *gin.Context) {
+	// Get and validate UUID parameter
+	productID, err := h.GetUUIDParam(c, "productId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete product
+	if err = h.productRepository.DeleteProduct(productID); err != nil {
+		h.HandleError(c, err, "Failed to delete product")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Product deleted successfully", nil)
+}
+
+// RegisterRoutes registers all product routes
+func (h *Product) RegisterRoutes(router *gin.RouterGroup) {
+	products := router.Group("/products")
+	{
+		products.GET("", h.GetAllProducts)
+		products.POST("", h.CreateProduct)
+		products.GET("/:productId", h.GetProductByID)
+		products.PUT("/:productId", h.UpdateProduct)
+		products.DELETE("/:productId", h.DeleteProduct)
+	}
+}
@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type PurchaseOrder struct {
+	purchaseOrderRepository repository.PurchaseOrderRepository
+	*baseHandler.BaseHandler
+}
+
+func NewPurchaseOrderHandler(purchaseOrderRepository repository.PurchaseOrderRepository, validate *validator.Validate) *PurchaseOrder {
+	return &PurchaseOrder{
+		purchaseOrderRepository: purchaseOrderRepository,
+		BaseHandler:             baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllPurchaseOrders godoc
+// @Summary Get all purchase orders
+// @Description Retrieve paginated purchase orders with items, received weights and goods receipts
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param supplier_id query string false "Filter by supplier ID"
+// @Param status query string false "Filter by status (OPEN, PARTIAL, RECEIVED, CANCELLED)"
+// @Param start_date query string false "Order date from (YYYY-MM-DD)"
+// @Param end_date query string false "Order date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.PurchaseOrderPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders [get]
+func (h *PurchaseOrder) GetAllPurchaseOrders(c *gin.Context) {
+	var filter models.PurchaseOrderFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch purchase orders
+	data, err := h.purchaseOrderRepository.GetAllPurchaseOrders(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch purchase orders")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase orders retrieved successfully", data)
+}
+
+// GetPurchaseOrderByID godoc
+// @Summary Get purchase order by ID
+// @Description Retrieve a single purchase order with its items and goods receipts
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrderId path string true "Purchase order ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.PurchaseOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/{purchaseOrderId} [get]
+func (h *PurchaseOrder) GetPurchaseOrderByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "purchaseOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch purchase order
+	data, err := h.purchaseOrderRepository.GetPurchaseOrderById(orderID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch purchase order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Purchase order %s retrieved successfully", orderID), data)
+}
+
+// CreatePurchaseOrder godoc
+// @Summary Create a purchase order
+// @Description Order expected items, weights and prices from a supplier
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrder body models.PurchaseOrderRequest true "Purchase order data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.PurchaseOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders [post]
+func (h *PurchaseOrder) CreatePurchaseOrder(c *gin.Context) {
+	var req models.PurchaseOrderRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create purchase order
+	data, err := h.purchaseOrderRepository.CreatePurchaseOrder(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create purchase order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Purchase order created successfully", data)
+}
+
+// UpdatePurchaseOrder godoc
+// @Summary Update a purchase order
+// @Description Replace the header and items of an open purchase order without receipts
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrderId path string true "Purchase order ID"
+// @Param purchaseOrder body models.PurchaseOrderRequest true "Updated purchase order data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/{purchaseOrderId} [put]
+func (h *PurchaseOrder) UpdatePurchaseOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "purchaseOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.PurchaseOrderRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update purchase order
+	if err = h.purchaseOrderRepository.UpdatePurchaseOrder(orderID, req); err != nil {
+		h.HandleError(c, err, "Failed to update purchase order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase order updated successfully", nil)
+}
+
+// CancelPurchaseOrder godoc
+// @Summary Cancel a purchase order
+// @Description Cancel an open or partially received purchase order; received stock is kept
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrderId path string true "Purchase order ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/{purchaseOrderId}/cancel [post]
+func (h *PurchaseOrder) CancelPurchaseOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "purchaseOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Cancel purchase order
+	if err = h.purchaseOrderRepository.CancelPurchaseOrder(orderID); err != nil {
+		h.HandleError(c, err, "Failed to cancel purchase order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase order cancelled successfully", nil)
+}
+
+// DeletePurchaseOrder godoc
+// @Summary Delete a purchase order
+// @Description Soft delete a purchase order that has no goods receipts
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrderId path string true "Purchase order ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/{purchaseOrderId} [delete]
+func (h *PurchaseOrder) DeletePurchaseOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "purchaseOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete purchase order
+	if err = h.purchaseOrderRepository.DeletePurchaseOrder(orderID); err != nil {
+		h.HandleError(c, err, "Failed to delete purchase order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase order deleted successfully", nil)
+}
+
+// ReceiveGoods godoc
+// @Summary Receive goods against a purchase order
+// @Description Record a partial or full goods receipt; creates the purchase, stock entry and stock items
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param purchaseOrderId path string true "Purchase order ID"
+// @Param receipt body models.GoodsReceiptRequest true "Received items"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.GoodsReceiptResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/{purchaseOrderId}/receipts [post]
+func (h *PurchaseOrder) ReceiveGoods(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "purchaseOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.GoodsReceiptRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Receive goods
+	data, err := h.purchaseOrderRepository.ReceiveGoods(orderID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to receive goods")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Goods received successfully", data)
+}
+
+// GetPurchaseVariance godoc
+// @Summary Purchase variance report
+// @Description Ordered versus received weight and price per supplier and product
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param supplier_id query string false "Filter by supplier ID"
+// @Param start_date query string false "Order date from (YYYY-MM-DD)"
+// @Param end_date query string false "Order date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=[]models.PurchaseVarianceResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /purchase-orders/variance [get]
+func (h *PurchaseOrder) GetPurchaseVariance(c *gin.Context) {
+	var filter models.PurchaseVarianceFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch variance report
+	data, err := h.purchaseOrderRepository.GetPurchaseVariance(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch purchase variance")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase variance retrieved successfully", data)
+}
+
+// RegisterRoutes registers all purchase order routes
+func (h *PurchaseOrder) RegisterRoutes(router *gin.RouterGroup) {
+	orders := router.Group("/purchase-orders")
+	{
+		orders.GET("", h.GetAllPurchaseOrders)
+		orders.POST("", h.CreatePurchaseOrder)
+		orders.GET("/variance", h.GetPurchaseVariance)
+		orders.GET("/:purchaseOrderId", h.GetPurchaseOrderByID)
+		orders.PUT("/:purchaseOrderId", h.UpdatePurchaseOrder)
+		orders.DELETE("/:purchaseOrderId", h.DeletePurchaseOrder)
+		orders.POST("/:purchaseOrderId/cancel", h.CancelPurchaseOrder)
+		orders.POST("/:purchaseOrderId/receipts", h.ReceiveGoods)
+	}
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type SalesOrder struct {
+	salesOrderRepository repository.SalesOrderRepository
+	*baseHandler.BaseHandler
+}
+
+func NewSalesOrderHandler(salesOrderRepository repository.SalesOrderRepository, validate *validator.Validate) *SalesOrder {
+	return &SalesOrder{
+		salesOrderRepository: salesOrderRepository,
+		BaseHandler:          baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllSalesOrders godoc
+// @Summary Get all quotations and sales orders
+// @Description Retrieve paginated quotations and sales orders with their reserved lines
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param type query string false "Filter by type (QUOTATION, ORDER)"
+// @Param status query string false "Filter by status (OPEN, CONVERTED, EXPIRED, CANCELLED)"
+// @Param customer_id query string false "Filter by customer"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.SalesOrderPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders [get]
+func (h *SalesOrder) GetAllSalesOrders(c *gin.Context) {
+	var filter models.SalesOrderFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch sales orders
+	data, err := h.salesOrderRepository.GetAllSalesOrders(c.Request.Context(), filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch sales orders")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Sales orders retrieved successfully", data)
+}
+
+// GetSalesOrderByID godoc
+// @Summary Get quotation or sales order by ID
+// @Description Retrieve a single quotation or sales order
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrderId path string true "Sales order ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.SalesOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders/{salesOrderId} [get]
+func (h *SalesOrder) GetSalesOrderByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "salesOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch sales order
+	data, err := h.salesOrderRepository.GetSalesOrderById(c.Request.Context(), orderID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch sales order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Sales order %s retrieved successfully", orderID), data)
+}
+
+// CreateSalesOrder godoc
+// @Summary Create a quotation or sales order
+// @Description Reserve stock sort weight for a buyer without deducting it; the reservation expires automatically
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrder body models.SalesOrderRequest true "Quotation or sales order data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.SalesOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders [post]
+func (h *SalesOrder) CreateSalesOrder(c *gin.Context) {
+	var req models.SalesOrderRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create sales order
+	data, err := h.salesOrderRepository.CreateSalesOrder(c.Request.Context(), req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create sales order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Sales order created successfully", data)
+}
+
+// UpdateSalesOrder godoc
+// @Summary Update a quotation or sales order
+// @Description Replace the lines of an open quotation or sales order
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrderId path string true "Sales order ID"
+// @Param salesOrder body models.SalesOrderRequest true "Updated quotation or sales order data"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders/{salesOrderId} [put]
+func (h *SalesOrder) UpdateSalesOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "salesOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.SalesOrderRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Update sales order
+	if err = h.salesOrderRepository.UpdateSalesOrder(c.Request.Context(), orderID, req); err != nil {
+		h.HandleError(c, err, "Failed to update sales order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Sales order updated successfully", nil)
+}
+
+// ConfirmSalesOrder godoc
+// @Summary Confirm a quotation
+// @Description Turn an open quotation into a sales order and extend its reservation
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrderId path string true "Sales order ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders/{salesOrderId}/confirm [post]
+func (h *SalesOrder) ConfirmSalesOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "salesOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Confirm quotation
+	if err = h.salesOrderRepository.ConfirmSalesOrder(c.Request.Context(), orderID); err != nil {
+		h.HandleError(c, err, "Failed to confirm quotation")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Quotation confirmed successfully", nil)
+}
+
+// CancelSalesOrder godoc
+// @Summary Cancel a quotation or sales order
+// @Description Cancel an open quotation or sales order and release its reserved weight
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrderId path string true "Sales order ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders/{salesOrderId}/cancel [post]
+func (h *SalesOrder) CancelSalesOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "salesOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Cancel sales order
+	if err = h.salesOrderRepository.CancelSalesOrder(c.Request.Context(), orderID); err != nil {
+		h.HandleError(c, err, "Failed to cancel sales order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Sales order cancelled successfully", nil)
+}
+
+// ConvertSalesOrder godoc
+// @Summary Convert a quotation or sales order into a sale
+// @Description Create the sale from the reserved lines at the quoted prices, with optional add-ons and fiber allocations
+// @Tags sales-orders
+// @Accept json
+// @Produce json
+// @Param salesOrderId path string true "Sales order ID"
+// @Param request body models.SalesOrderConvertRequest true "Sale date, add-ons and fiber allocations"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.SalesOrderResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /sales-orders/{salesOrderId}/convert [post]
+func (h *SalesOrder) ConvertSalesOrder(c *gin.Context) {
+	// Get and validate UUID parameter
+	orderID, err := h.GetUUIDParam(c, "salesOrderId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	var req models.SalesOrderConvertRequest
+
+	// Bind and validate request
+	if err = h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Convert into sale
+	data, err := h.salesOrderRepository.ConvertSalesOrder(c.Request.Context(), orderID, req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to convert sales order")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Sales order converted successfully", data)
+}
+
+// RegisterRoutes registers all quotation and sales order routes
+func (h *SalesOrder) RegisterRoutes(router *gin.RouterGroup) {
+	orders := router.Group("/sales-orders")
+	{
+		orders.GET("", h.GetAllSalesOrders)
+		orders.POST("", h.CreateSalesOrder)
+		orders.GET("/:salesOrderId", h.GetSalesOrderByID)
+		orders.PUT("/:salesOrderId", h.UpdateSalesOrder)
+		orders.POST("/:salesOrderId/confirm", h.ConfirmSalesOrder)
+		orders.POST("/:salesOrderId/cancel", h.CancelSalesOrder)
+		orders.POST("/:salesOrderId/convert", h.ConvertSalesOrder)
+	}
+}
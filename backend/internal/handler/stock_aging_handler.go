@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type StockAging struct {
+	stockAgingRepository repository.StockAgingRepository
+	*baseHandler.BaseHandler
+}
+
+func NewStockAgingHandler(stockAgingRepository repository.StockAgingRepository, validate *validator.Validate) *StockAging {
+	return &StockAging{
+		stockAgingRepository: stockAgingRepository,
+		BaseHandler:          baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetStockAging godoc
+// @Summary Get stock aging report
+// @Description List sorts with remaining weight that are near or past their product's maximum age
+// @Tags stock-aging
+// @Accept json
+// @Produce json
+// @Param status query string false "Filter by status (NEAR_EXPIRY, EXPIRED)"
+// @Param product_id query string false "Filter by product ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.StockAgingResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-aging [get]
+func (h *StockAging) GetStockAging(c *gin.Context) {
+	var filter models.StockAgingFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Fetch aging report
+	data, err := h.stockAgingRepository.GetStockAging(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch stock aging")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Stock aging retrieved successfully", data)
+}
+
+// CheckStockAging godoc
+// @Summary Run the stock aging check
+// @Description Raise notifications for stock nearing or past expiry; the same check also runs periodically
+// @Tags stock-aging
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.StockAgingCheckResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-aging/check [post]
+func (h *StockAging) CheckStockAging(c *gin.Context) {
+	// Run aging check
+	data, err := h.stockAgingRepository.CheckStockAging()
+	if err != nil {
+		h.HandleError(c, err, "Failed to check stock aging")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Stock aging checked successfully", data)
+}
+
+// WriteOffExpiredStock godoc
+// @Summary Write off expired stock
+// @Description Move the unreserved remaining weight of expired sorts into shrinkage; all expired sorts when no IDs are given
+// @Tags stock-aging
+// @Accept json
+// @Produce json
+// @Param request body models.WriteOffExpiredRequest false "Sorts to write off"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.WriteOffExpiredResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-aging/write-off [post]
+func (h *StockAging) WriteOffExpiredStock(c *gin.Context) {
+	var req models.WriteOffExpiredRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Write off expired stock
+	data, err := h.stockAgingRepository.WriteOffExpiredStock(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to write off expired stock")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Expired stock written off successfully", data)
+}
+
+// RegisterRoutes registers all stock aging routes
+func (h *StockAging) RegisterRoutes(router *gin.RouterGroup) {
+	aging := router.Group("/stock-aging")
+	{
+		aging.GET("", h.GetStockAging)
+		aging.POST("/check", h.CheckStockAging)
+		aging.POST("/write-off", h.WriteOffExpiredStock)
+	}
+}
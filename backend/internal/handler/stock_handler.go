@@ -34,6 +34,7 @@ func NewStockHandler(stockRepository repository.StockRepository, validate *valid
 // @Param purchase_date query string false "Filter by purchase date"
 // @Param age_in_day query string false "Filter by age (LT_1, GT_1, GT_10, GT_30)"
 // @Param keyword query string false "Search keyword"
+// @Param location_id query string false "Only entries with stock in this location"
 // @Success 200 {object} models.HTTPResponseSuccess{data=models.StockResponse}
 // @Failure 400 {object} models.HTTPResponseError
 // @Failure 500 {object} models.HTTPResponseError
@@ -205,12 +206,20 @@ func (h *Stock) GetStockItemByID(c *gin.Context) {
 // @Tags stock
 // @Accept json
 // @Produce json
+// @Param location_id query string false "Filter by location ID"
 // @Success 200 {object} models.HTTPResponseSuccess{data=[]models.StockSortResponse}
 // @Failure 500 {object} models.HTTPResponseError
 // @Router /stocks/sorts [get]
 func (h *Stock) GetAllStockSorts(c *gin.Context) {
+	var filter models.StockSortFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
 	// Fetch all stock sorts
-	data, err := h.stockRepository.GetAllStockSorts()
+	data, err := h.stockRepository.GetAllStockSorts(filter)
 	if err != nil {
 		h.HandleError(c, err, "Failed to fetch stock sorts")
 		return
@@ -260,7 +269,7 @@ func (h *Stock) CreateStockSort(c *gin.Context) {
 
 // UpdateStockSort godoc
 // @Summary Update stock sorts for an item
-// @Description Update sorted items for a stock item
+// @Description Edit sorts in place by UUID, add new ones and remove unsold ones; current weight keeps what was already sold off
 // @Tags stock
 // @Accept json
 // @Produce json
@@ -269,6 +278,7 @@ func (h *Stock) CreateStockSort(c *gin.Context) {
 // @Param sorts body models.SubmitSortRequest true "Updated sort data"
 // @Success 200 {object} models.HTTPResponseSuccess
 // @Failure 400 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
 // @Failure 500 {object} models.HTTPResponseError
 // @Router /stocks/{stockId}/items/{itemId}/sorts [put]
 func (h *Stock) UpdateStockSort(c *gin.Context) {
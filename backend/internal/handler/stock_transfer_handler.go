@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type StockTransfer struct {
+	stockTransferRepository repository.StockTransferRepository
+	*baseHandler.BaseHandler
+}
+
+func NewStockTransferHandler(stockTransferRepository repository.StockTransferRepository, validate *validator.Validate) *StockTransfer {
+	return &StockTransfer{
+		stockTransferRepository: stockTransferRepository,
+		BaseHandler:             baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllStockTransfers godoc
+// @Summary Get all stock transfers
+// @Description Retrieve paginated transfer documents between locations
+// @Tags stock-transfers
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param location_id query string false "Transfers from or to this location"
+// @Param start_date query string false "Transfer date from (YYYY-MM-DD)"
+// @Param end_date query string false "Transfer date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.StockTransferPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-transfers [get]
+func (h *StockTransfer) GetAllStockTransfers(c *gin.Context) {
+	var filter models.StockTransferFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch stock transfers
+	data, err := h.stockTransferRepository.GetAllStockTransfers(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch stock transfers")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Stock transfers retrieved successfully", data)
+}
+
+// GetStockTransferByID godoc
+// @Summary Get stock transfer by ID
+// @Tags stock-transfers
+// @Accept json
+// @Produce json
+// @Param transferId path string true "Stock transfer ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.StockTransferResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-transfers/{transferId} [get]
+func (h *StockTransfer) GetStockTransferByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	transferID, err := h.GetUUIDParam(c, "transferId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch stock transfer
+	data, err := h.stockTransferRepository.GetStockTransferById(transferID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch stock transfer")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Stock transfer %s retrieved successfully", transferID), data)
+}
+
+// CreateStockTransfer godoc
+// @Summary Transfer stock between locations
+// @Description Move sorted weight and fibers to another location; partial weights are split into a new sort at the destination
+// @Tags stock-transfers
+// @Accept json
+// @Produce json
+// @Param transfer body models.StockTransferRequest true "Stock transfer data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.StockTransferResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-transfers [post]
+func (h *StockTransfer) CreateStockTransfer(c *gin.Context) {
+	var req models.StockTransferRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create stock transfer
+	data, err := h.stockTransferRepository.CreateStockTransfer(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create stock transfer")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Stock transfer created successfully", data)
+}
+
+// DeleteStockTransfer godoc
+// @Summary Delete a stock transfer
+// @Description Move the transferred stock and fibers back to their source location
+// @Tags stock-transfers
+// @Accept json
+// @Produce json
+// @Param transferId path string true "Stock transfer ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /stock-transfers/{transferId} [delete]
+func (h *StockTransfer) DeleteStockTransfer(c *gin.Context) {
+	// Get and validate UUID parameter
+	transferID, err := h.GetUUIDParam(c, "transferId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete stock transfer
+	if err = h.stockTransferRepository.DeleteStockTransfer(transferID); err != nil {
+		h.HandleError(c, err, "Failed to delete stock transfer")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Stock transfer deleted successfully", nil)
+}
+
+// RegisterRoutes registers all stock transfer routes
+func (h *StockTransfer) RegisterRoutes(router *gin.RouterGroup) {
+	transfers := router.Group("/stock-transfers")
+	{
+		transfers.GET("", h.GetAllStockTransfers)
+		transfers.POST("", h.CreateStockTransfer)
+		transfers.GET("/:transferId", h.GetStockTransferByID)
+		transfers.DELETE("/:transferId", h.DeleteStockTransfer)
+	}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type SupplierReturn struct {
+	supplierReturnRepository repository.SupplierReturnRepository
+	*baseHandler.BaseHandler
+}
+
+func NewSupplierReturnHandler(supplierReturnRepository repository.SupplierReturnRepository, validate *validator.Validate) *SupplierReturn {
+	return &SupplierReturn{
+		supplierReturnRepository: supplierReturnRepository,
+		BaseHandler:              baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllSupplierReturns godoc
+// @Summary Get all supplier returns
+// @Description Retrieve paginated returns of rejected fish to suppliers
+// @Tags supplier-returns
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param supplier_id query string false "Filter by supplier ID"
+// @Param purchase_id query string false "Filter by purchase ID"
+// @Param start_date query string false "Return date from (YYYY-MM-DD)"
+// @Param end_date query string false "Return date to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.SupplierReturnPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /supplier-returns [get]
+func (h *SupplierReturn) GetAllSupplierReturns(c *gin.Context) {
+	var filter models.SupplierReturnFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch supplier returns
+	data, err := h.supplierReturnRepository.GetAllSupplierReturns(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch supplier returns")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Supplier returns retrieved successfully", data)
+}
+
+// GetSupplierReturnByID godoc
+// @Summary Get supplier return by ID
+// @Description Retrieve a single supplier return document
+// @Tags supplier-returns
+// @Accept json
+// @Produce json
+// @Param supplierReturnId path string true "Supplier return ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.SupplierReturnResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /supplier-returns/{supplierReturnId} [get]
+func (h *SupplierReturn) GetSupplierReturnByID(c *gin.Context) {
+	// Get and validate UUID parameter
+	returnID, err := h.GetUUIDParam(c, "supplierReturnId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Fetch supplier return
+	data, err := h.supplierReturnRepository.GetSupplierReturnById(returnID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch supplier return")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, fmt.Sprintf("Supplier return %s retrieved successfully", returnID), data)
+}
+
+// CreateSupplierReturn godoc
+// @Summary Return rejected fish to the supplier
+// @Description Reduce a stock item or stock sort and debit the purchase's remaining amount; amounts already paid become supplier credit
+// @Tags supplier-returns
+// @Accept json
+// @Produce json
+// @Param supplierReturn body models.SupplierReturnRequest true "Supplier return data"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.SupplierReturnResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 409 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /supplier-returns [post]
+func (h *SupplierReturn) CreateSupplierReturn(c *gin.Context) {
+	var req models.SupplierReturnRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Create supplier return
+	data, err := h.supplierReturnRepository.CreateSupplierReturn(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to create supplier return")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Supplier return created successfully", data)
+}
+
+// DeleteSupplierReturn godoc
+// @Summary Delete a supplier return
+// @Description Reverse a supplier return, restoring stock, the purchase amount and any supplier credit
+// @Tags supplier-returns
+// @Accept json
+// @Produce json
+// @Param supplierReturnId path string true "Supplier return ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /supplier-returns/{supplierReturnId} [delete]
+func (h *SupplierReturn) DeleteSupplierReturn(c *gin.Context) {
+	// Get and validate UUID parameter
+	returnID, err := h.GetUUIDParam(c, "supplierReturnId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete supplier return
+	if err = h.supplierReturnRepository.DeleteSupplierReturn(returnID); err != nil {
+		h.HandleError(c, err, "Failed to delete supplier return")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Supplier return deleted successfully", nil)
+}
+
+// RegisterRoutes registers all supplier return routes
+func (h *SupplierReturn) RegisterRoutes(router *gin.RouterGroup) {
+	returns := router.Group("/supplier-returns")
+	{
+		returns.GET("", h.GetAllSupplierReturns)
+		returns.POST("", h.CreateSupplierReturn)
+		returns.GET("/:supplierReturnId", h.GetSupplierReturnByID)
+		returns.DELETE("/:supplierReturnId", h.DeleteSupplierReturn)
+	}
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type Temperature struct {
+	temperatureRepository repository.TemperatureRepository
+	*baseHandler.BaseHandler
+}
+
+func NewTemperatureHandler(temperatureRepository repository.TemperatureRepository, validate *validator.Validate) *Temperature {
+	return &Temperature{
+		temperatureRepository: temperatureRepository,
+		BaseHandler:           baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// GetAllTemperatureReadings godoc
+// @Summary Get temperature readings
+// @Description Retrieve the paginated cold-chain log, newest first
+// @Tags temperature
+// @Accept json
+// @Produce json
+// @Param page_no query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param location_id query string false "Filter by location ID"
+// @Param fiber_id query string false "Filter by fiber ID"
+// @Param stock_sort_id query string false "Readings linked to this stock sort"
+// @Param sale_id query string false "Fiber readings recorded against this sale"
+// @Param excursions_only query bool false "Only readings above the limit"
+// @Param start_date query string false "Recorded from (YYYY-MM-DD)"
+// @Param end_date query string false "Recorded to (YYYY-MM-DD)"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.TemperatureReadingPaginationResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /temperature-readings [get]
+func (h *Temperature) GetAllTemperatureReadings(c *gin.Context) {
+	var filter models.TemperatureReadingFilter
+
+	// Bind query parameters
+	if err := h.BindQuery(c, &filter); err != nil {
+		return // Error already sent
+	}
+
+	// Normalize pagination
+	if filter.PageNo < 1 {
+		filter.PageNo = 1
+	}
+	if filter.Size < 1 {
+		filter.Size = 10
+	}
+	if filter.Size > 100 {
+		filter.Size = 100
+	}
+
+	// Fetch temperature readings
+	data, err := h.temperatureRepository.GetAllTemperatureReadings(filter)
+	if err != nil {
+		h.HandleError(c, err, "Failed to fetch temperature readings")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Temperature readings retrieved successfully", data)
+}
+
+// CreateTemperatureReading godoc
+// @Summary Record a temperature reading
+// @Description Manually record a reading for a location or fiber; readings above the limit are flagged as excursions
+// @Tags temperature
+// @Accept json
+// @Produce json
+// @Param reading body models.TemperatureReadingRequest true "Temperature reading"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.TemperatureReadingResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /temperature-readings [post]
+func (h *Temperature) CreateTemperatureReading(c *gin.Context) {
+	var req models.TemperatureReadingRequest
+
+	// Bind and validate request
+	if err := h.BindAndValidate(c, &req); err != nil {
+		return // Error already sent
+	}
+
+	// Record reading
+	data, err := h.temperatureRepository.CreateTemperatureReading(req)
+	if err != nil {
+		h.HandleError(c, err, "Failed to record temperature reading")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated, "Temperature reading recorded successfully", data)
+}
+
+// ImportTemperatureReadings godoc
+// @Summary Import temperature readings from a logger
+// @Description Import a CSV or XLSX logger export with columns recorded_at, temperature and optionally location, fiber, notes. Rows without a location or fiber column use the form values. Nothing is imported if any row is invalid.
+// @Tags temperature
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param location_id formData string false "Location the logger was placed in"
+// @Param fiber_id formData string false "Fiber the logger was placed in"
+// @Success 201 {object} models.HTTPResponseSuccess{data=models.TemperatureImportResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 422 {object} models.HTTPResponseSuccess{data=models.TemperatureImportResponse}
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /temperature-readings/import [post]
+func (h *Temperature) ImportTemperatureReadings(c *gin.Context) {
+	locationID := c.PostForm("location_id")
+	fiberID := c.PostForm("fiber_id")
+	if locationID != "" && fiberID != "" {
+		h.SendError(c, http.StatusBadRequest, "Provide either location_id or fiber_id, not both", nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.SendError(c, http.StatusBadRequest, "Failed to open file", err)
+		return
+	}
+	defer file.Close()
+
+	// Import readings
+	data, err := h.temperatureRepository.ImportTemperatureReadings(fileHeader.Filename, file, locationID, fiberID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to import temperature readings")
+		return
+	}
+
+	if !data.Success {
+		h.SendSuccess(c, http.StatusUnprocessableEntity,
+			fmt.Sprintf("%d of %d row(s) are invalid, no readings were imported", data.InvalidRows, data.TotalRows), data)
+		return
+	}
+
+	h.SendSuccess(c, http.StatusCreated,
+		fmt.Sprintf("Imported %d reading(s), %d excursion(s)", data.CreatedCount, data.Excursions), data)
+}
+
+// DeleteTemperatureReading godoc
+// @Summary Delete a temperature reading
+// @Tags temperature
+// @Accept json
+// @Produce json
+// @Param readingId path string true "Temperature reading ID"
+// @Success 200 {object} models.HTTPResponseSuccess
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /temperature-readings/{readingId} [delete]
+func (h *Temperature) DeleteTemperatureReading(c *gin.Context) {
+	// Get and validate UUID parameter
+	readingID, err := h.GetUUIDParam(c, "readingId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Delete reading
+	if err = h.temperatureRepository.DeleteTemperatureReading(readingID); err != nil {
+		h.HandleError(c, err, "Failed to delete temperature reading")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Temperature reading deleted successfully", nil)
+}
+
+// RegisterRoutes registers all temperature routes
+func (h *Temperature) RegisterRoutes(router *gin.RouterGroup) {
+	readings := router.Group("/temperature-readings")
+	{
+		readings.GET("", h.GetAllTemperatureReadings)
+		readings.POST("", h.CreateTemperatureReading)
+		readings.POST("/import", h.ImportTemperatureReadings)
+		readings.DELETE("/:readingId", h.DeleteTemperatureReading)
+	}
+}
@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+	"dashboard-app/pkg/baseHandler"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/xuri/excelize/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Traceability struct {
+	traceabilityRepository repository.TraceabilityRepository
+	*baseHandler.BaseHandler
+}
+
+func NewTraceabilityHandler(traceabilityRepository repository.TraceabilityRepository, validate *validator.Validate) *Traceability {
+	return &Traceability{
+		traceabilityRepository: traceabilityRepository,
+		BaseHandler:            baseHandler.NewBaseHandler(validate),
+	}
+}
+
+// TracePurchase godoc
+// @Summary Trace a purchase forward
+// @Description Follow a supplier delivery through stock items, sorts and sales to the customers who received it
+// @Tags traceability
+// @Accept json
+// @Produce json
+// @Param purchaseId path string true "Purchase ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.TraceabilityResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /traceability/purchases/{purchaseId} [get]
+func (h *Traceability) TracePurchase(c *gin.Context) {
+	// Get and validate UUID parameter
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Trace purchase
+	data, err := h.traceabilityRepository.TracePurchase(purchaseID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to trace purchase")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Purchase traced successfully", data)
+}
+
+// TraceSale godoc
+// @Summary Trace a sale backward
+// @Description Follow a sale back through stock sorts and items to the supplier deliveries and catch data
+// @Tags traceability
+// @Accept json
+// @Produce json
+// @Param saleId path string true "Sale ID"
+// @Success 200 {object} models.HTTPResponseSuccess{data=models.TraceabilityResponse}
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /traceability/sales/{saleId} [get]
+func (h *Traceability) TraceSale(c *gin.Context) {
+	// Get and validate UUID parameter
+	saleID, err := h.GetUUIDParam(c, "saleId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Trace sale
+	data, err := h.traceabilityRepository.TraceSale(saleID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to trace sale")
+		return
+	}
+
+	h.SendSuccess(c, http.StatusOK, "Sale traced successfully", data)
+}
+
+// ExportPurchaseRecall godoc
+// @Summary Export recall report for a purchase
+// @Description Download the forward trace of a supplier delivery as an Excel recall report
+// @Tags traceability
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param purchaseId path string true "Purchase ID"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /traceability/purchases/{purchaseId}/export [get]
+func (h *Traceability) ExportPurchaseRecall(c *gin.Context) {
+	// Get and validate UUID parameter
+	purchaseID, err := h.GetUUIDParam(c, "purchaseId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Trace purchase
+	data, err := h.traceabilityRepository.TracePurchase(purchaseID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to trace purchase")
+		return
+	}
+
+	h.writeRecallReport(c, data)
+}
+
+// ExportSaleRecall godoc
+// @Summary Export trace report for a sale
+// @Description Download the backward trace of a sale as an Excel report
+// @Tags traceability
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param saleId path string true "Sale ID"
+// @Success 200 {file} file "Excel file"
+// @Failure 400 {object} models.HTTPResponseError
+// @Failure 404 {object} models.HTTPResponseError
+// @Failure 500 {object} models.HTTPResponseError
+// @Router /traceability/sales/{saleId}/export [get]
+func (h *Traceability) ExportSaleRecall(c *gin.Context) {
+	// Get and validate UUID parameter
+	saleID, err := h.GetUUIDParam(c, "saleId")
+	if err != nil {
+		return // Error already sent
+	}
+
+	// Trace sale
+	data, err := h.traceabilityRepository.TraceSale(saleID)
+	if err != nil {
+		h.HandleError(c, err, "Failed to trace sale")
+		return
+	}
+
+	h.writeRecallReport(c, data)
+}
+
+func (h *Traceability) writeRecallReport(c *gin.Context, data *models.TraceabilityResponse) {
+	f := excelize.NewFile()
+	sheet := "Recall Report"
+	_ = f.SetSheetName("Sheet1", sheet)
+
+	// Header row
+	headers := []string{
+		"Stock Code",
+		"Supplier",
+		"Supplier Phone",
+		"Purchase Date",
+		"Vessel",
+		"Landing Site",
+		"Catch Date",
+		"Item",
+		"Purchased (kg)",
+		"Sorted Item",
+		"Sorted (kg)",
+		"Sale Code",
+		"Sale Date",
+		"Customer",
+		"Customer Phone",
+		"Sold (kg)",
+		"Fibers",
+	}
+
+	for col, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		_ = f.SetCellValue(sheet, cell, header)
+	}
+
+	formatDate := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+
+	// Data rows
+	for row, line := range data.Lines {
+		values := []any{
+			line.StockCode,
+			line.SupplierName,
+			line.SupplierPhone,
+			line.PurchaseDate.Format("2006-01-02"),
+			line.Vessel,
+			line.LandingSite,
+			formatDate(line.CatchDate),
+			line.ItemName,
+			line.PurchasedWeight,
+			line.SortedItemName,
+			line.SortedWeight,
+			line.SaleCode,
+			formatDate(line.SaleDate),
+			line.CustomerName,
+			line.CustomerPhone,
+			line.SoldWeight,
+			strings.Join(line.Fibers, ", "),
+		}
+
+		for col, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(col+1, row+2)
+			_ = f.SetCellValue(sheet, cell, value)
+		}
+	}
+
+	for i := 1; i <= len(headers); i++ {
+		col, _ := excelize.ColumnNumberToName(i)
+		_ = f.SetColWidth(sheet, col, col, 18)
+	}
+
+	// Cold-chain readings on a second sheet
+	tempSheet := "Temperature Log"
+	_, _ = f.NewSheet(tempSheet)
+
+	tempHeaders := []string{
+		"Recorded At",
+		"Location",
+		"Fiber",
+		"Sale Code",
+		"Temperature (°C)",
+		"Limit (°C)",
+		"Excursion",
+		"Source",
+		"Notes",
+	}
+
+	for col, header := range tempHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		_ = f.SetCellValue(tempSheet, cell, header)
+	}
+
+	for row, reading := range data.Temperatures {
+		excursion := ""
+		if reading.IsExcursion {
+			excursion = "YES"
+		}
+
+		values := []any{
+			reading.RecordedAt.In(constants.JakartaTz).Format("2006-01-02 15:04"),
+			reading.LocationName,
+			reading.FiberName,
+			reading.SaleCode,
+			reading.Temperature,
+			reading.Threshold,
+			excursion,
+			reading.Source,
+			reading.Notes,
+		}
+
+		for col, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(col+1, row+2)
+			_ = f.SetCellValue(tempSheet, cell, value)
+		}
+	}
+
+	for i := 1; i <= len(tempHeaders); i++ {
+		col, _ := excelize.ColumnNumberToName(i)
+		_ = f.SetColWidth(tempSheet, col, col, 18)
+	}
+
+	filename := fmt.Sprintf(
+		"recall_%s_%s.xlsx",
+		strings.ToLower(data.Direction),
+		data.Reference,
+	)
+
+	c.Header(
+		"Content-Type",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	)
+	c.Header(
+		"Content-Disposition",
+		`attachment; filename="`+filename+`"`,
+	)
+
+	_ = f.Write(c.Writer)
+}
+
+// RegisterRoutes registers all traceability routes
+func (h *Traceability) RegisterRoutes(router *gin.RouterGroup) {
+	trace := router.Group("/traceability")
+	{
+		trace.GET("/purchases/:purchaseId", h.TracePurchase)
+		trace.GET("/purchases/:purchaseId/export", h.ExportPurchaseRecall)
+		trace.GET("/sales/:saleId", h.TraceSale)
+		trace.GET("/sales/:saleId/export", h.ExportSaleRecall)
+	}
+}
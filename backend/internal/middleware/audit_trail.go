@@ -239,6 +239,26 @@ func formatAction(method, path string) string {
 	case method == "GET" && strings.Contains(path, "/analytics/customer/performance"):
 		return "View Customer Performance"
 
+	// ===== ACCOUNTING PERIODS =====
+	case method == "POST" && strings.HasPrefix(path, "/v1/api/accounting-periods/") && strings.HasSuffix(path, "/close"):
+		return "Close Accounting Period"
+	case method == "POST" && strings.HasPrefix(path, "/v1/api/accounting-periods/") && strings.HasSuffix(path, "/reopen"):
+		return "Reopen Accounting Period"
+
+	// ===== BANK RECONCILIATION =====
+	case method == "POST" && path == "/v1/api/bank-statements/import":
+		return "Import Bank Statement"
+	case method == "POST" && strings.HasPrefix(path, "/v1/api/bank-statement-lines/") && strings.HasSuffix(path, "/confirm"):
+		return "Confirm Bank Statement Match"
+
+	// ===== EXPENSES =====
+	case method == "POST" && path == "/v1/api/recurring-expenses/run":
+		return "Run Recurring Expenses"
+	case method == "POST" && strings.HasPrefix(path, "/v1/api/payment/") && strings.HasSuffix(path, "/attachments"):
+		return "Upload Payment Receipt"
+	case method == "DELETE" && strings.HasPrefix(path, "/v1/api/payment/") && strings.Contains(path, "/attachments/"):
+		return "Delete Payment Receipt"
+
 	// ===== AUDIT TRAIL =====
 	case method == "GET" && path == "/v1/api/audit-logs/export":
 		return "Download Audit Trail"
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// AccountingPeriod records the close state of a calendar month. Months
+// without a row are open.
+type AccountingPeriod struct {
+	ID           int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid         string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Period       string     `json:"period" gorm:"column:period;type:varchar(7);not null"`
+	Status       string     `json:"status" gorm:"column:status;not null"`
+	Notes        string     `json:"notes" gorm:"column:notes"`
+	ClosedBy     string     `json:"closed_by" gorm:"column:closed_by;type:varchar(36)"`
+	ClosedAt     *time.Time `json:"closed_at" gorm:"column:closed_at"`
+	ReopenedBy   string     `json:"reopened_by" gorm:"column:reopened_by;type:varchar(36)"`
+	ReopenedAt   *time.Time `json:"reopened_at" gorm:"column:reopened_at"`
+	ReopenReason string     `json:"reopen_reason" gorm:"column:reopen_reason"`
+	Deleted      bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*AccountingPeriod) TableName() string {
+	return "accounting_periods"
+}
+
+type ClosePeriodRequest struct {
+	Notes string `json:"notes"`
+}
+
+type ReopenPeriodRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+type AccountingPeriodFilter struct {
+	Year string `form:"year"`
+}
+
+type AccountingPeriodResponse struct {
+	Period         string     `json:"period" gorm:"column:period"`
+	Status         string     `json:"status" gorm:"column:status"`
+	Notes          string     `json:"notes" gorm:"column:notes"`
+	ClosedBy       string     `json:"closed_by" gorm:"column:closed_by"`
+	ClosedByName   string     `json:"closed_by_name" gorm:"column:closed_by_name"`
+	ClosedAt       *time.Time `json:"closed_at" gorm:"column:closed_at"`
+	ReopenedBy     string     `json:"reopened_by" gorm:"column:reopened_by"`
+	ReopenedByName string     `json:"reopened_by_name" gorm:"column:reopened_by_name"`
+	ReopenedAt     *time.Time `json:"reopened_at" gorm:"column:reopened_at"`
+	ReopenReason   string     `json:"reopen_reason" gorm:"column:reopen_reason"`
+}
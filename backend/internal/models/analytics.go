@@ -37,8 +37,10 @@ type StockDistributionData struct {
 }
 
 type UserData struct {
-	Name  string `json:"name" gorm:"column:name"`
-	Total int64  `json:"total" gorm:"column:total"`
+	Name           string `json:"name" gorm:"column:name"`
+	Total          int64  `json:"total" gorm:"column:total"`
+	ReturnedWeight int64  `json:"returned_weight,omitempty" gorm:"column:returned_weight"`
+	ReturnedAmount int64  `json:"returned_amount,omitempty" gorm:"column:returned_amount"`
 }
 
 type SalesResult struct {
@@ -51,6 +53,15 @@ type PurchaseResult struct {
 	TotalPayment int64     `json:"total" gorm:"column:total"`
 }
 
+type ProductDistResult struct {
+	ProductName string `gorm:"column:product_name"`
+	TotalWeight int64  `gorm:"column:total_weight"`
+}
+
+type TopItemsFilter struct {
+	Limit int `form:"limit"`
+}
+
 type StockDistributionDataResult struct {
 	Id    int `json:"id" gorm:"column:id"`
 	Value int `json:"value" gorm:"column:value"`
@@ -67,6 +78,8 @@ type DateRangeStatsResponse struct {
 	EndDate             string `json:"end_date"`
 }
 type ItemPerformance struct {
+	ProductId    string `json:"product_id" gorm:"column:product_id"`
+	ProductCode  string `json:"product_code" gorm:"column:product_code"`
 	ItemName     string `json:"item_name" gorm:"column:item_name"`
 	SalesCount   int64  `json:"sales_count" gorm:"column:sales_count"`
 	TotalWeight  int64  `json:"total_weight" gorm:"column:total_weight"`
@@ -107,12 +120,14 @@ type DailyBookKeepingFilter struct {
 }
 
 type AnalyticStatsFilter struct {
-	StartDate string `form:"start_date"`
-	EndDate   string `form:"end_date"`
+	StartDate  string `form:"start_date"`
+	EndDate    string `form:"end_date"`
+	LocationId string `form:"location_id"`
 }
 
 type SalesSupplierDetailResponse struct {
 	SupplierName string `json:"supplier_name" gorm:"column:supplier_name"`
+	ProductId    string `json:"product_id" gorm:"column:product_id"`
 	ItemName     string `json:"item_name" gorm:"column:item_name"`
 	Quantity     int64  `json:"qty" gorm:"column:qty"`
 	Price        int64  `json:"price" gorm:"column:price"`
@@ -131,8 +146,10 @@ type SalesSupplierDetailWithPurchaseDataResponse struct {
 	SupplierName    string    `json:"supplier_name" gorm:"column:supplier_name"`
 	PurchaseDate    time.Time `json:"purchase_date" gorm:"column:purchase_date"`
 	StockWeight     int64     `json:"stock_weight" gorm:"column:stock_weight"`
+	ProductId       string    `json:"product_id" gorm:"column:product_id"`
 	ItemName        string    `json:"item_name" gorm:"column:item_name"`
 	StockSortWeight int64     `json:"stock_sort_weight" gorm:"column:stock_sort_weight"`
+	SortProductId   string    `json:"sort_product_id" gorm:"column:sort_product_id"`
 	ItemSortName    string    `json:"item_sort_name" gorm:"column:item_sort_name"`
 	Quantity        int64     `json:"qty" gorm:"column:qty"`
 	Price           int64     `json:"price" gorm:"column:price"`
@@ -148,3 +165,34 @@ type SalesSupplierDetailWithPurchaseDataPaginationResponse struct {
 	Total  int64                                         `json:"total"`
 	Data   []SalesSupplierDetailWithPurchaseDataResponse `json:"data"`
 }
+
+type ExpenseBreakdownFilter struct {
+	Year         string `form:"year"`
+	CostCenterId string `form:"cost_center_id"`
+}
+
+type ExpenseBreakdownRow struct {
+	Month        int    `gorm:"column:month"`
+	CategoryId   string `gorm:"column:category_id"`
+	CategoryName string `gorm:"column:category_name"`
+	Total        int64  `gorm:"column:total"`
+}
+
+type ExpenseCategoryTotal struct {
+	CategoryId   string `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Total        int64  `json:"total"`
+}
+
+type MonthlyExpense struct {
+	Month      string                 `json:"month"`
+	Total      int64                  `json:"total"`
+	Categories []ExpenseCategoryTotal `json:"categories"`
+}
+
+type ExpenseBreakdownResponse struct {
+	Year       string                 `json:"year"`
+	Total      int64                  `json:"total"`
+	Categories []ExpenseCategoryTotal `json:"categories"`
+	Months     []MonthlyExpense       `json:"months"`
+}
@@ -0,0 +1,158 @@
+package models
+
+import "time"
+
+// BankStatement is one imported statement file of a bank account.
+type BankStatement struct {
+	ID            int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid          string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	CashAccountId string     `json:"cash_account_id" gorm:"column:cash_account_id;type:varchar(36);not null"`
+	FileName      string     `json:"file_name" gorm:"column:file_name"`
+	Format        string     `json:"format" gorm:"column:format"`
+	StartDate     *time.Time `json:"start_date" gorm:"column:start_date"`
+	EndDate       *time.Time `json:"end_date" gorm:"column:end_date"`
+	LineCount     int        `json:"line_count" gorm:"column:line_count"`
+	ImportedBy    string     `json:"imported_by" gorm:"column:imported_by;type:varchar(36)"`
+	Deleted       bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*BankStatement) TableName() string {
+	return "bank_statements"
+}
+
+// BankStatementLine is one transaction on a statement. Credits are matched
+// against open sales and debits against open purchases; a confirmed match
+// records the payment on that sale or purchase.
+type BankStatementLine struct {
+	ID              int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	StatementId     string     `json:"statement_id" gorm:"column:statement_id;type:varchar(36);not null"`
+	LineNo          int        `json:"line_no" gorm:"column:line_no"`
+	TransactionDate time.Time  `json:"transaction_date" gorm:"column:transaction_date"`
+	Direction       string     `json:"direction" gorm:"column:direction"`
+	Amount          int        `json:"amount" gorm:"column:amount"`
+	Description     string     `json:"description" gorm:"column:description"`
+	Reference       string     `json:"reference" gorm:"column:reference"`
+	Status          string     `json:"status" gorm:"column:status"`
+	MatchType       string     `json:"match_type" gorm:"column:match_type"`
+	MatchId         string     `json:"match_id" gorm:"column:match_id;type:varchar(36)"`
+	MatchScore      int        `json:"match_score" gorm:"column:match_score"`
+	PaymentId       string     `json:"payment_id" gorm:"column:payment_id;type:varchar(36)"`
+	ConfirmedBy     string     `json:"confirmed_by" gorm:"column:confirmed_by;type:varchar(36)"`
+	ConfirmedAt     *time.Time `json:"confirmed_at" gorm:"column:confirmed_at"`
+	Deleted         bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*BankStatementLine) TableName() string {
+	return "bank_statement_lines"
+}
+
+type BankStatementImportRow struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type BankStatementImportResponse struct {
+	Success        bool                     `json:"success"`
+	StatementId    string                   `json:"statement_id"`
+	Format         string                   `json:"format"`
+	TotalRows      int                      `json:"total_rows"`
+	CreatedCount   int                      `json:"created_count"`
+	DuplicateRows  int                      `json:"duplicate_rows"`
+	InvalidRows    int                      `json:"invalid_rows"`
+	SuggestedCount int                      `json:"suggested_count"`
+	Rows           []BankStatementImportRow `json:"rows"`
+}
+
+type BankStatementResponse struct {
+	Uuid            string     `json:"uuid" gorm:"column:uuid"`
+	CashAccountId   string     `json:"cash_account_id" gorm:"column:cash_account_id"`
+	CashAccountName string     `json:"cash_account_name" gorm:"column:cash_account_name"`
+	FileName        string     `json:"file_name" gorm:"column:file_name"`
+	Format          string     `json:"format" gorm:"column:format"`
+	StartDate       *time.Time `json:"start_date" gorm:"column:start_date"`
+	EndDate         *time.Time `json:"end_date" gorm:"column:end_date"`
+	LineCount       int        `json:"line_count" gorm:"column:line_count"`
+	UnmatchedCount  int        `json:"unmatched_count" gorm:"column:unmatched_count"`
+	SuggestedCount  int        `json:"suggested_count" gorm:"column:suggested_count"`
+	MatchedCount    int        `json:"matched_count" gorm:"column:matched_count"`
+	IgnoredCount    int        `json:"ignored_count" gorm:"column:ignored_count"`
+	ImportedBy      string     `json:"imported_by" gorm:"column:imported_by"`
+	ImportedByName  string     `json:"imported_by_name" gorm:"column:imported_by_name"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at"`
+}
+
+type BankStatementFilter struct {
+	Size          int    `form:"size"`
+	PageNo        int    `form:"page_no"`
+	CashAccountId string `form:"cash_account_id"`
+}
+
+type BankStatementPaginationResponse struct {
+	Size   int                     `json:"size"`
+	PageNo int                     `json:"page_no"`
+	Total  int                     `json:"total"`
+	Data   []BankStatementResponse `json:"data"`
+}
+
+type BankStatementLineResponse struct {
+	Uuid            string     `json:"uuid" gorm:"column:uuid"`
+	StatementId     string     `json:"statement_id" gorm:"column:statement_id"`
+	CashAccountName string     `json:"cash_account_name" gorm:"column:cash_account_name"`
+	LineNo          int        `json:"line_no" gorm:"column:line_no"`
+	TransactionDate time.Time  `json:"transaction_date" gorm:"column:transaction_date"`
+	Direction       string     `json:"direction" gorm:"column:direction"`
+	Amount          int        `json:"amount" gorm:"column:amount"`
+	Description     string     `json:"description" gorm:"column:description"`
+	Reference       string     `json:"reference" gorm:"column:reference"`
+	Status          string     `json:"status" gorm:"column:status"`
+	MatchType       string     `json:"match_type" gorm:"column:match_type"`
+	MatchId         string     `json:"match_id" gorm:"column:match_id"`
+	MatchCode       string     `json:"match_code" gorm:"-"`
+	MatchNo         int        `json:"-" gorm:"column:match_no"`
+	MatchPartyName  string     `json:"match_party_name" gorm:"column:match_party_name"`
+	MatchRemaining  int        `json:"match_remaining" gorm:"column:match_remaining"`
+	MatchScore      int        `json:"match_score" gorm:"column:match_score"`
+	PaymentId       string     `json:"payment_id" gorm:"column:payment_id"`
+	ConfirmedByName string     `json:"confirmed_by_name" gorm:"column:confirmed_by_name"`
+	ConfirmedAt     *time.Time `json:"confirmed_at" gorm:"column:confirmed_at"`
+}
+
+type BankStatementLineFilter struct {
+	Size        int    `form:"size"`
+	PageNo      int    `form:"page_no"`
+	StatementId string `form:"statement_id"`
+	Status      string `form:"status"`
+	Direction   string `form:"direction"`
+}
+
+type BankStatementLinePaginationResponse struct {
+	Size   int                         `json:"size"`
+	PageNo int                         `json:"page_no"`
+	Total  int                         `json:"total"`
+	Data   []BankStatementLineResponse `json:"data"`
+}
+
+// MatchCandidate is an open sale or purchase a statement line could pay.
+type MatchCandidate struct {
+	MatchType       string    `json:"match_type"`
+	MatchId         string    `json:"match_id"`
+	Code            string    `json:"code"`
+	PartyName       string    `json:"party_name"`
+	Date            time.Time `json:"date"`
+	TotalAmount     int       `json:"total_amount"`
+	RemainingAmount int       `json:"remaining_amount"`
+	Score           int       `json:"score"`
+	Reasons         []string  `json:"reasons"`
+}
+
+// ConfirmMatchRequest confirms the suggested match of a line when empty, or
+// another sale or purchase picked from its candidates.
+type ConfirmMatchRequest struct {
+	MatchType string `json:"match_type" validate:"omitempty,oneof=SALE PURCHASE"`
+	MatchId   string `json:"match_id"`
+}
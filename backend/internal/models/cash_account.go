@@ -0,0 +1,107 @@
+package models
+
+import "time"
+
+// CashAccount is a cash box or bank account that payments are paid from or
+// into. Each one has its own asset account in the chart of accounts, which
+// holds its balance.
+type CashAccount struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Name            string    `json:"name" gorm:"column:name;not null"`
+	AccountType     string    `json:"account_type" gorm:"column:account_type;not null"`
+	BankName        string    `json:"bank_name" gorm:"column:bank_name"`
+	AccountNumber   string    `json:"account_number" gorm:"column:account_number"`
+	AccountHolder   string    `json:"account_holder" gorm:"column:account_holder"`
+	LedgerAccountId string    `json:"ledger_account_id" gorm:"column:ledger_account_id;type:varchar(36);not null"`
+	IsActive        bool      `json:"is_active" gorm:"column:is_active"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*CashAccount) TableName() string {
+	return "cash_accounts"
+}
+
+type CashTransfer struct {
+	ID            int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid          string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	FromAccountId string    `json:"from_account_id" gorm:"column:from_account_id;type:varchar(36);not null"`
+	ToAccountId   string    `json:"to_account_id" gorm:"column:to_account_id;type:varchar(36);not null"`
+	Amount        int       `json:"amount" gorm:"column:amount"`
+	TransferDate  time.Time `json:"transfer_date" gorm:"column:transfer_date"`
+	Notes         string    `json:"notes" gorm:"column:notes"`
+	Deleted       bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt     time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*CashTransfer) TableName() string {
+	return "cash_transfers"
+}
+
+type CashAccountRequest struct {
+	Name          string `json:"name" validate:"required"`
+	AccountType   string `json:"account_type" validate:"required,oneof=CASH BANK"`
+	BankName      string `json:"bank_name" validate:"required_if=AccountType BANK"`
+	AccountNumber string `json:"account_number" validate:"required_if=AccountType BANK"`
+	AccountHolder string `json:"account_holder"`
+	IsActive      *bool  `json:"is_active"`
+}
+
+type CashAccountResponse struct {
+	Uuid              string    `json:"uuid"`
+	Name              string    `json:"name"`
+	AccountType       string    `json:"account_type"`
+	BankName          string    `json:"bank_name"`
+	AccountNumber     string    `json:"account_number"`
+	AccountHolder     string    `json:"account_holder"`
+	LedgerAccountId   string    `json:"ledger_account_id"`
+	LedgerAccountCode string    `json:"ledger_account_code"`
+	IsActive          bool      `json:"is_active"`
+	Balance           int       `json:"balance"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type CashAccountFilter struct {
+	AccountType string `form:"account_type"`
+	ActiveOnly  bool   `form:"active_only"`
+}
+
+type CashTransferRequest struct {
+	FromAccountId string    `json:"from_account_id" validate:"required"`
+	ToAccountId   string    `json:"to_account_id" validate:"required,nefield=FromAccountId"`
+	Amount        int       `json:"amount" validate:"required,min=1"`
+	TransferDate  time.Time `json:"transfer_date" validate:"required"`
+	Notes         string    `json:"notes"`
+}
+
+type CashTransferResponse struct {
+	Uuid            string    `json:"uuid" gorm:"column:uuid"`
+	TransferCode    string    `json:"transfer_code" gorm:"-"`
+	TransferNo      int       `json:"-" gorm:"column:transfer_no"`
+	FromAccountId   string    `json:"from_account_id" gorm:"column:from_account_id"`
+	FromAccountName string    `json:"from_account_name" gorm:"column:from_account_name"`
+	ToAccountId     string    `json:"to_account_id" gorm:"column:to_account_id"`
+	ToAccountName   string    `json:"to_account_name" gorm:"column:to_account_name"`
+	Amount          int       `json:"amount" gorm:"column:amount"`
+	TransferDate    time.Time `json:"transfer_date" gorm:"column:transfer_date"`
+	Notes           string    `json:"notes" gorm:"column:notes"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+type CashTransferFilter struct {
+	Size      int    `form:"size"`
+	PageNo    int    `form:"page_no"`
+	AccountId string `form:"account_id"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+}
+
+type CashTransferPaginationResponse struct {
+	Size   int                    `json:"size"`
+	PageNo int                    `json:"page_no"`
+	Total  int                    `json:"total"`
+	Data   []CashTransferResponse `json:"data"`
+}
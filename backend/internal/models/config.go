@@ -18,6 +18,35 @@ type Config struct {
 	Database        struct {
 		Mysql interfaces.SQLConfig `yaml:"mysql"`
 	} `yaml:"database"`
+	Pricing struct {
+		BandPercent    int  `yaml:"band_percent" default:"20"`
+		BlockOutOfBand bool `yaml:"block_out_of_band" default:"false"`
+		BlockBelowCost bool `yaml:"block_below_cost" default:"false"`
+	} `yaml:"pricing"`
+	SalesOrder struct {
+		QuotationValidDays int `yaml:"quotation_valid_days" default:"3"`
+		OrderValidDays     int `yaml:"order_valid_days" default:"7"`
+		ExpiryCheckMinutes int `yaml:"expiry_check_minutes" default:"5"`
+	} `yaml:"sales_order"`
+	StockAging struct {
+		DefaultMaxAgeDays int `yaml:"default_max_age_days" default:"5"`
+		WarningDays       int `yaml:"warning_days" default:"1"`
+		CheckMinutes      int `yaml:"check_minutes" default:"60"`
+	} `yaml:"stock_aging"`
+	ColdChain struct {
+		MaxTemperature float64 `yaml:"max_temperature" default:"4"`
+		FiberLinkHours int     `yaml:"fiber_link_hours" default:"48"`
+	} `yaml:"cold_chain"`
+	Sorting struct {
+		WeightTolerancePercent int `yaml:"weight_tolerance_percent" default:"0"`
+	} `yaml:"sorting"`
+	Expense struct {
+		RecurringCheckMinutes int `yaml:"recurring_check_minutes" default:"60"`
+	} `yaml:"expense"`
+	Storage struct {
+		UploadDir     string `yaml:"upload_dir" default:"uploads"`
+		MaxUploadSize int64  `yaml:"max_upload_size" default:"10485760"`
+	} `yaml:"storage"`
 }
 
 func init() {
@@ -0,0 +1,167 @@
+package models
+
+import "time"
+
+type DeliveryNote struct {
+	ID              int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	SaleId          string     `json:"sale_id" gorm:"column:sale_id;type:varchar(36);not null"`
+	DeliveryDate    time.Time  `json:"delivery_date" gorm:"column:delivery_date"`
+	ShippingAddress string     `json:"shipping_address" gorm:"column:shipping_address"`
+	RecipientName   string     `json:"recipient_name" gorm:"column:recipient_name"`
+	DriverName      string     `json:"driver_name" gorm:"column:driver_name"`
+	DriverPhone     string     `json:"driver_phone" gorm:"column:driver_phone"`
+	VehiclePlate    string     `json:"vehicle_plate" gorm:"column:vehicle_plate"`
+	VehicleType     string     `json:"vehicle_type" gorm:"column:vehicle_type"`
+	Status          string     `json:"status" gorm:"column:status"`
+	Notes           string     `json:"notes" gorm:"column:notes"`
+	DispatchedAt    *time.Time `json:"dispatched_at" gorm:"column:dispatched_at"`
+	DeliveredAt     *time.Time `json:"delivered_at" gorm:"column:delivered_at"`
+	ProofPhotoPath  string     `json:"-" gorm:"column:proof_photo_path"`
+	ProofUploadedAt *time.Time `json:"proof_uploaded_at" gorm:"column:proof_uploaded_at"`
+	Deleted         bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*DeliveryNote) TableName() string {
+	return "delivery_notes"
+}
+
+type DeliveryNoteItem struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	DeliveryNoteId string    `json:"delivery_note_id" gorm:"column:delivery_note_id;type:varchar(36);not null"`
+	StockSortId    string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36)"`
+	StockCode      string    `json:"stock_code" gorm:"column:stock_code"`
+	ItemName       string    `json:"item_name" gorm:"column:item_name"`
+	Weight         int       `json:"weight" gorm:"column:weight"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*DeliveryNoteItem) TableName() string {
+	return "delivery_note_items"
+}
+
+type DeliveryNoteFiber struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	DeliveryNoteId string    `json:"delivery_note_id" gorm:"column:delivery_note_id;type:varchar(36);not null"`
+	FiberId        string    `json:"fiber_id" gorm:"column:fiber_id;type:varchar(36)"`
+	FiberName      string    `json:"fiber_name" gorm:"column:fiber_name"`
+	StockSortId    string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36)"`
+	Weight         int       `json:"weight" gorm:"column:weight"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*DeliveryNoteFiber) TableName() string {
+	return "delivery_note_fibers"
+}
+
+type DeliveryStatusHistory struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	DeliveryNoteId string    `json:"delivery_note_id" gorm:"column:delivery_note_id;type:varchar(36);not null"`
+	Status         string    `json:"status" gorm:"column:status"`
+	Notes          string    `json:"notes" gorm:"column:notes"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (*DeliveryStatusHistory) TableName() string {
+	return "delivery_status_histories"
+}
+
+type DeliveryNoteRequest struct {
+	SaleId          string    `json:"sale_id" validate:"required"`
+	DeliveryDate    time.Time `json:"delivery_date" validate:"required"`
+	ShippingAddress string    `json:"shipping_address"`
+	RecipientName   string    `json:"recipient_name"`
+	DriverName      string    `json:"driver_name"`
+	DriverPhone     string    `json:"driver_phone"`
+	VehiclePlate    string    `json:"vehicle_plate"`
+	VehicleType     string    `json:"vehicle_type"`
+	Notes           string    `json:"notes"`
+}
+
+type UpdateDeliveryNoteRequest struct {
+	DeliveryDate    time.Time `json:"delivery_date" validate:"required"`
+	ShippingAddress string    `json:"shipping_address" validate:"required"`
+	RecipientName   string    `json:"recipient_name"`
+	DriverName      string    `json:"driver_name"`
+	DriverPhone     string    `json:"driver_phone"`
+	VehiclePlate    string    `json:"vehicle_plate"`
+	VehicleType     string    `json:"vehicle_type"`
+	Notes           string    `json:"notes"`
+}
+
+type DeliveryStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=PENDING IN_TRANSIT DELIVERED FAILED"`
+	Notes  string `json:"notes"`
+}
+
+type DeliveryNoteItemResponse struct {
+	StockSortId string `json:"stock_sort_id"`
+	StockCode   string `json:"stock_code"`
+	ItemName    string `json:"item_name"`
+	Weight      int    `json:"weight"`
+}
+
+type DeliveryNoteFiberResponse struct {
+	FiberId     string `json:"fiber_id"`
+	FiberName   string `json:"fiber_name"`
+	StockSortId string `json:"stock_sort_id"`
+	Weight      int    `json:"weight"`
+}
+
+type DeliveryStatusHistoryResponse struct {
+	Status    string    `json:"status"`
+	Notes     string    `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DeliveryNoteResponse struct {
+	Uuid                  string                          `json:"uuid"`
+	DeliveryCode          string                          `json:"delivery_code"`
+	SaleId                string                          `json:"sale_id"`
+	SaleCode              string                          `json:"sale_code"`
+	Customer              GetUserDetail                   `json:"customer"`
+	DeliveryDate          time.Time                       `json:"delivery_date"`
+	ShippingAddress       string                          `json:"shipping_address"`
+	RecipientName         string                          `json:"recipient_name"`
+	DriverName            string                          `json:"driver_name"`
+	DriverPhone           string                          `json:"driver_phone"`
+	VehiclePlate          string                          `json:"vehicle_plate"`
+	VehicleType           string                          `json:"vehicle_type"`
+	Status                string                          `json:"status"`
+	Notes                 string                          `json:"notes"`
+	DispatchedAt          *time.Time                      `json:"dispatched_at"`
+	DeliveredAt           *time.Time                      `json:"delivered_at"`
+	HasProof              bool                            `json:"has_proof"`
+	ProofUploadedAt       *time.Time                      `json:"proof_uploaded_at"`
+	TotalWeight           int                             `json:"total_weight"`
+	Items                 []DeliveryNoteItemResponse      `json:"items"`
+	Fibers                []DeliveryNoteFiberResponse     `json:"fibers"`
+	History               []DeliveryStatusHistoryResponse `json:"history"`
+	Temperatures          []TemperatureReadingResponse    `json:"temperatures,omitempty"`
+	TemperatureExcursions int                             `json:"temperature_excursions"`
+}
+
+type DeliveryNoteFilter struct {
+	Size         int    `form:"size"`
+	PageNo       int    `form:"page_no"`
+	SaleId       string `form:"sale_id"`
+	Status       string `form:"status"`
+	DeliveryDate string `form:"delivery_date"`
+	Keyword      string `form:"keyword"`
+}
+
+type DeliveryNotePaginationResponse struct {
+	Size   int                    `json:"size"`
+	PageNo int                    `json:"page_no"`
+	Total  int                    `json:"total"`
+	Data   []DeliveryNoteResponse `json:"data"`
+}
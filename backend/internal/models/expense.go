@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+// ExpenseCategory groups operational expenses such as ice, fuel or wages.
+// Each category has its own expense account in the chart of accounts, so the
+// profit and loss statement shows the same breakdown.
+type ExpenseCategory struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Name            string    `json:"name" gorm:"column:name;not null"`
+	Description     string    `json:"description" gorm:"column:description"`
+	LedgerAccountId string    `json:"ledger_account_id" gorm:"column:ledger_account_id;type:varchar(36);not null"`
+	IsActive        bool      `json:"is_active" gorm:"column:is_active"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*ExpenseCategory) TableName() string {
+	return "expense_categories"
+}
+
+// CostCenter is the part of the business that carries an expense, e.g. a
+// warehouse, a truck or the processing line.
+type CostCenter struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Code        string    `json:"code" gorm:"column:code;not null"`
+	Name        string    `json:"name" gorm:"column:name;not null"`
+	Description string    `json:"description" gorm:"column:description"`
+	IsActive    bool      `json:"is_active" gorm:"column:is_active"`
+	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*CostCenter) TableName() string {
+	return "cost_centers"
+}
+
+// RecurringExpense is a template for an expense paid on a fixed schedule,
+// such as rent or wages. Due templates are booked as manual payments.
+type RecurringExpense struct {
+	ID                int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid              string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Name              string     `json:"name" gorm:"column:name;not null"`
+	UserId            string     `json:"user_id" gorm:"column:user_id;type:varchar(36);not null"`
+	Total             int        `json:"total" gorm:"column:total"`
+	Description       string     `json:"description" gorm:"column:description"`
+	ExpenseCategoryId string     `json:"expense_category_id" gorm:"column:expense_category_id;type:varchar(36)"`
+	CostCenterId      string     `json:"cost_center_id" gorm:"column:cost_center_id;type:varchar(36)"`
+	CashAccountId     string     `json:"cash_account_id" gorm:"column:cash_account_id;type:varchar(36)"`
+	PaymentMethod     string     `json:"payment_method" gorm:"column:payment_method"`
+	Frequency         string     `json:"frequency" gorm:"column:frequency;not null"`
+	DayOfMonth        int        `json:"day_of_month" gorm:"column:day_of_month"`
+	NextRunDate       time.Time  `json:"next_run_date" gorm:"column:next_run_date"`
+	LastRunAt         *time.Time `json:"last_run_at" gorm:"column:last_run_at"`
+	IsActive          bool       `json:"is_active" gorm:"column:is_active"`
+	Deleted           bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*RecurringExpense) TableName() string {
+	return "recurring_expenses"
+}
+
+type ExpenseCategoryRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type ExpenseCategoryResponse struct {
+	Uuid              string    `json:"uuid"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	LedgerAccountId   string    `json:"ledger_account_id"`
+	LedgerAccountCode string    `json:"ledger_account_code"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type CostCenterRequest struct {
+	Code        string `json:"code" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type CostCenterResponse struct {
+	Uuid        string    `json:"uuid"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ExpenseFilter struct {
+	ActiveOnly bool `form:"active_only"`
+}
+
+type RecurringExpenseRequest struct {
+	Name              string    `json:"name" validate:"required"`
+	UserId            string    `json:"user_id" validate:"required"`
+	Total             int       `json:"total" validate:"required,min=1"`
+	Description       string    `json:"description"`
+	ExpenseCategoryId string    `json:"expense_category_id"`
+	CostCenterId      string    `json:"cost_center_id"`
+	CashAccountId     string    `json:"cash_account_id" validate:"required"`
+	PaymentMethod     string    `json:"payment_method" validate:"required,oneof=CASH TRANSFER GIRO"`
+	Frequency         string    `json:"frequency" validate:"required,oneof=WEEKLY MONTHLY"`
+	NextRunDate       time.Time `json:"next_run_date" validate:"required"`
+	IsActive          *bool     `json:"is_active"`
+}
+
+type RecurringExpenseResponse struct {
+	Uuid                string     `json:"uuid"`
+	Name                string     `json:"name"`
+	UserId              string     `json:"user_id"`
+	UserName            string     `json:"user_name"`
+	Total               int        `json:"total"`
+	Description         string     `json:"description"`
+	ExpenseCategoryId   string     `json:"expense_category_id"`
+	ExpenseCategoryName string     `json:"expense_category_name"`
+	CostCenterId        string     `json:"cost_center_id"`
+	CostCenterName      string     `json:"cost_center_name"`
+	CashAccountId       string     `json:"cash_account_id"`
+	CashAccountName     string     `json:"cash_account_name"`
+	PaymentMethod       string     `json:"payment_method"`
+	Frequency           string     `json:"frequency"`
+	NextRunDate         time.Time  `json:"next_run_date"`
+	LastRunAt           *time.Time `json:"last_run_at"`
+	IsActive            bool       `json:"is_active"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// RecurringExpenseRunResponse reports one run over the due templates.
+// Templates that fail keep their due date and are retried on the next run.
+type RecurringExpenseRunResponse struct {
+	TemplatesRun    int      `json:"templates_run"`
+	PaymentsCreated int      `json:"payments_created"`
+	Failed          []string `json:"failed"`
+}
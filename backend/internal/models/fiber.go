@@ -6,9 +6,12 @@ type Fiber struct {
 	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
 	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
 	Name        string    `json:"name" gorm:"column:name;not null"`
+	FiberType   string    `json:"fiber_type" gorm:"column:fiber_type"`
+	Capacity    int       `json:"capacity" gorm:"column:capacity"`
 	Status      string    `json:"status" gorm:"column:status"`
 	StockSortId string    `json:"stock_sort_id" gorm:"column:stock_sort_id"`
 	SaleId      string    `json:"sale_id" gorm:"column:sale_id"`
+	LocationId  string    `json:"location_id" gorm:"column:location_id;type:varchar(36)"`
 	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
@@ -36,19 +39,25 @@ func (*FiberAllocation) TableName() string {
 
 type FiberRequest struct {
 	Name        string `json:"name" validate:"required"`
+	FiberType   string `json:"fiber_type"`
+	Capacity    int    `json:"capacity" validate:"min=0"`
 	Status      string `json:"status" validate:"required"`
 	StockSortId string `json:"stock_sort_id"`
+	LocationId  string `json:"location_id"`
 }
 
 type FiberResponse struct {
 	Uuid        string    `json:"uuid" gorm:"column:uuid"`
 	Name        string    `json:"name" gorm:"column:name"`
+	FiberType   string    `json:"fiber_type" gorm:"column:fiber_type"`
+	Capacity    int       `json:"capacity" gorm:"column:capacity"`
 	Status      string    `json:"status" gorm:"column:status"`
 	StockSortId string    `json:"stock_sort_id" gorm:"column:stock_sort_id"`
 	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
 	SaleCode    *string   `json:"sale_code" gorm:"column:sale_code"`
 	SaleId      string    `json:"sale_id" gorm:"column:sale_id"`
+	LocationId  string    `json:"location_id" gorm:"column:location_id"`
 }
 
 type FiberPaginationResponse struct {
@@ -59,14 +68,42 @@ type FiberPaginationResponse struct {
 }
 
 type BulkFiberRequest struct {
-	Data []FiberRequest `json:"data"`
+	Data    []FiberRequest    `json:"data"`
+	Pattern *FiberNamePattern `json:"pattern"`
+}
+
+// FiberNamePattern expands to Prefix + zero-padded number for every number
+// from Start to End, e.g. F-001..F-200.
+type FiberNamePattern struct {
+	Prefix    string `json:"prefix" validate:"required"`
+	Start     int    `json:"start" validate:"min=0"`
+	End       int    `json:"end" validate:"gtefield=Start"`
+	Padding   int    `json:"padding" validate:"min=0,max=10"`
+	FiberType string `json:"fiber_type"`
+	Capacity  int    `json:"capacity" validate:"min=0"`
+}
+
+type FiberImportRow struct {
+	Row    int      `json:"row"`
+	Name   string   `json:"name"`
+	Errors []string `json:"errors"`
+}
+
+type BulkFiberResponse struct {
+	Success      bool             `json:"success"`
+	TotalRows    int              `json:"total_rows"`
+	CreatedCount int              `json:"created_count"`
+	InvalidRows  int              `json:"invalid_rows"`
+	Rows         []FiberImportRow `json:"rows"`
+	Fibers       []FiberResponse  `json:"fibers"`
 }
 
 type FiberFilter struct {
-	Size   int    `form:"size"`
-	PageNo int    `form:"page_no"`
-	Name   string `form:"name"`
-	Status string `form:"status"`
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	Name       string `form:"name"`
+	Status     string `form:"status"`
+	LocationId string `form:"location_id"`
 }
 
 type FiberAllocationRequest struct {
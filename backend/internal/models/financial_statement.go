@@ -0,0 +1,78 @@
+package models
+
+// StatementPeriodFilter selects the reporting period: a calendar month, or a
+// custom start and end date. Without either, the current month is used.
+type StatementPeriodFilter struct {
+	Month     string `form:"month"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+}
+
+type BalanceSheetFilter struct {
+	Date        string `form:"date"`
+	CompareDate string `form:"compare_date"`
+}
+
+// StatementLine is one account in a statement with the same figure for the
+// comparison period. Lines without an account (e.g. current year earnings)
+// leave AccountId empty.
+type StatementLine struct {
+	AccountId      string  `json:"account_id"`
+	AccountCode    string  `json:"account_code"`
+	AccountName    string  `json:"account_name"`
+	Amount         int     `json:"amount"`
+	PreviousAmount int     `json:"previous_amount"`
+	Change         int     `json:"change"`
+	ChangePercent  float64 `json:"change_percent"`
+}
+
+type StatementSection struct {
+	Name          string          `json:"name"`
+	Lines         []StatementLine `json:"lines"`
+	Total         int             `json:"total"`
+	PreviousTotal int             `json:"previous_total"`
+	Change        int             `json:"change"`
+	ChangePercent float64         `json:"change_percent"`
+}
+
+type StatementTotal struct {
+	Amount         int     `json:"amount"`
+	PreviousAmount int     `json:"previous_amount"`
+	Change         int     `json:"change"`
+	ChangePercent  float64 `json:"change_percent"`
+}
+
+type ProfitLossResponse struct {
+	StartDate         string           `json:"start_date"`
+	EndDate           string           `json:"end_date"`
+	PreviousStartDate string           `json:"previous_start_date"`
+	PreviousEndDate   string           `json:"previous_end_date"`
+	Revenue           StatementSection `json:"revenue"`
+	CostOfGoodsSold   StatementSection `json:"cost_of_goods_sold"`
+	GrossProfit       StatementTotal   `json:"gross_profit"`
+	Expenses          StatementSection `json:"expenses"`
+	NetProfit         StatementTotal   `json:"net_profit"`
+}
+
+type BalanceSheetResponse struct {
+	Date                      string           `json:"date"`
+	CompareDate               string           `json:"compare_date"`
+	Assets                    StatementSection `json:"assets"`
+	Liabilities               StatementSection `json:"liabilities"`
+	Equity                    StatementSection `json:"equity"`
+	TotalLiabilitiesAndEquity StatementTotal   `json:"total_liabilities_and_equity"`
+	Balanced                  bool             `json:"balanced"`
+}
+
+// CashFlowStatementResponse follows the direct method: every cash movement is
+// classified by the account on the other side of its journal entry.
+type CashFlowStatementResponse struct {
+	StartDate         string             `json:"start_date"`
+	EndDate           string             `json:"end_date"`
+	PreviousStartDate string             `json:"previous_start_date"`
+	PreviousEndDate   string             `json:"previous_end_date"`
+	OpeningCash       StatementTotal     `json:"opening_cash"`
+	Sections          []StatementSection `json:"sections"`
+	NetChange         StatementTotal     `json:"net_change"`
+	ClosingCash       StatementTotal     `json:"closing_cash"`
+}
@@ -0,0 +1,24 @@
+package models
+
+type Label struct {
+	Code     string `json:"code"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle"`
+}
+
+type FiberLabelFilter struct {
+	Ids    string `form:"ids"`
+	Status string `form:"status"`
+}
+
+type ScanLookupFilter struct {
+	Code string `form:"code"`
+}
+
+type ScanLookupResponse struct {
+	Type       string              `json:"type"`
+	Code       string              `json:"code"`
+	Fiber      *FiberResponse      `json:"fiber,omitempty"`
+	StockSorts []StockSortResponse `json:"stock_sorts,omitempty"`
+	Sale       *SaleResponseById   `json:"sale,omitempty"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+type PurchaseCost struct {
+	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PurchaseId       string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36);not null"`
+	CostType         string    `json:"cost_type" gorm:"column:cost_type"`
+	Description      string    `json:"description" gorm:"column:description"`
+	Amount           int       `json:"amount" gorm:"column:amount"`
+	AllocationMethod string    `json:"allocation_method" gorm:"column:allocation_method"`
+	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PurchaseCost) TableName() string {
+	return "purchase_costs"
+}
+
+type PurchaseCostAllocation struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PurchaseCostId string    `json:"purchase_cost_id" gorm:"column:purchase_cost_id;type:varchar(36);not null"`
+	PurchaseId     string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36);not null"`
+	StockItemId    string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36);not null"`
+	Amount         int       `json:"amount" gorm:"column:amount"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PurchaseCostAllocation) TableName() string {
+	return "purchase_cost_allocations"
+}
+
+type PurchaseCostRequest struct {
+	CostType         string `json:"cost_type" validate:"required,oneof=ICE TRANSPORT LABOUR OTHER"`
+	Description      string `json:"description"`
+	Amount           int    `json:"amount" validate:"required,min=1"`
+	AllocationMethod string `json:"allocation_method" validate:"required,oneof=WEIGHT VALUE"`
+}
+
+type PurchaseCostAllocationResponse struct {
+	StockItemId string `json:"stock_item_id"`
+	ItemName    string `json:"item_name"`
+	Amount      int    `json:"amount"`
+}
+
+type PurchaseCostResponse struct {
+	Uuid             string                           `json:"uuid"`
+	CostType         string                           `json:"cost_type"`
+	Description      string                           `json:"description"`
+	Amount           int                              `json:"amount"`
+	AllocationMethod string                           `json:"allocation_method"`
+	Allocations      []PurchaseCostAllocationResponse `json:"allocations"`
+}
+
+type LandedCostItemResponse struct {
+	StockItemId           string `json:"stock_item_id"`
+	ItemName              string `json:"item_name"`
+	Weight                int    `json:"weight"`
+	PricePerKilogram      int    `json:"price_per_kilogram"`
+	TotalPayment          int    `json:"total_payment"`
+	LandedCost            int    `json:"landed_cost"`
+	LandedCostPerKilogram int    `json:"landed_cost_per_kilogram"`
+	CostPerKilogram       int    `json:"cost_per_kilogram"`
+}
+
+type LandedCostResponse struct {
+	PurchaseId      string                   `json:"purchase_id"`
+	StockCode       string                   `json:"stock_code"`
+	PurchaseAmount  int                      `json:"purchase_amount"`
+	TotalLandedCost int                      `json:"total_landed_cost"`
+	TotalCost       int                      `json:"total_cost"`
+	Costs           []PurchaseCostResponse   `json:"costs"`
+	Items           []LandedCostItemResponse `json:"items"`
+}
@@ -0,0 +1,205 @@
+package models
+
+import "time"
+
+type Account struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Code        string    `json:"code" gorm:"column:code;not null"`
+	Name        string    `json:"name" gorm:"column:name;not null"`
+	AccountType string    `json:"account_type" gorm:"column:account_type;not null"`
+	Description string    `json:"description" gorm:"column:description"`
+	IsSystem    bool      `json:"is_system" gorm:"column:is_system"`
+	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*Account) TableName() string {
+	return "accounts"
+}
+
+// JournalEntry is one balanced posting. Entries generated from business
+// documents carry the document in SourceType/SourceId and the purchase or
+// sale they belong to in ReferenceId. Entries are never edited; they are
+// cancelled by a reversing entry.
+type JournalEntry struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	EntryDate   time.Time `json:"entry_date" gorm:"column:entry_date;not null"`
+	Description string    `json:"description" gorm:"column:description"`
+	SourceType  string    `json:"source_type" gorm:"column:source_type;not null"`
+	SourceId    string    `json:"source_id" gorm:"column:source_id;type:varchar(36)"`
+	ReferenceId string    `json:"reference_id" gorm:"column:reference_id;type:varchar(36)"`
+	ReversalOf  string    `json:"reversal_of" gorm:"column:reversal_of;type:varchar(36)"`
+	ReversedBy  string    `json:"reversed_by" gorm:"column:reversed_by;type:varchar(36)"`
+	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+type JournalLine struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	JournalEntryId string    `json:"journal_entry_id" gorm:"column:journal_entry_id;type:varchar(36);not null"`
+	AccountId      string    `json:"account_id" gorm:"column:account_id;type:varchar(36);not null"`
+	UserId         string    `json:"user_id" gorm:"column:user_id;type:varchar(36)"`
+	Description    string    `json:"description" gorm:"column:description"`
+	Debit          int       `json:"debit" gorm:"column:debit"`
+	Credit         int       `json:"credit" gorm:"column:credit"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*JournalLine) TableName() string {
+	return "journal_lines"
+}
+
+type AccountRequest struct {
+	Code        string `json:"code" validate:"required,max=20"`
+	Name        string `json:"name" validate:"required"`
+	AccountType string `json:"account_type" validate:"required,oneof=ASSET LIABILITY EQUITY REVENUE EXPENSE"`
+	Description string `json:"description"`
+}
+
+type AccountResponse struct {
+	Uuid          string    `json:"uuid"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	AccountType   string    `json:"account_type"`
+	NormalBalance string    `json:"normal_balance"`
+	Description   string    `json:"description"`
+	IsSystem      bool      `json:"is_system"`
+	Balance       int       `json:"balance"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AccountFilter struct {
+	AccountType string `form:"account_type"`
+	Keyword     string `form:"keyword"`
+}
+
+type JournalLineRequest struct {
+	AccountId   string `json:"account_id" validate:"required"`
+	UserId      string `json:"user_id"`
+	Description string `json:"description"`
+	Debit       int    `json:"debit" validate:"min=0"`
+	Credit      int    `json:"credit" validate:"min=0"`
+}
+
+// JournalEntryRequest is a manual adjustment keyed in by the accountant.
+// Debits and credits must balance.
+type JournalEntryRequest struct {
+	EntryDate   time.Time            `json:"entry_date" validate:"required"`
+	Description string               `json:"description" validate:"required"`
+	Lines       []JournalLineRequest `json:"lines" validate:"required,min=2,dive"`
+}
+
+type JournalLineResponse struct {
+	AccountId   string `json:"account_id" gorm:"column:account_id"`
+	AccountCode string `json:"account_code" gorm:"column:account_code"`
+	AccountName string `json:"account_name" gorm:"column:account_name"`
+	UserId      string `json:"user_id" gorm:"column:user_id"`
+	UserName    string `json:"user_name" gorm:"column:user_name"`
+	Description string `json:"description" gorm:"column:description"`
+	Debit       int    `json:"debit" gorm:"column:debit"`
+	Credit      int    `json:"credit" gorm:"column:credit"`
+}
+
+type JournalEntryResponse struct {
+	Uuid        string                `json:"uuid"`
+	EntryCode   string                `json:"entry_code"`
+	EntryDate   time.Time             `json:"entry_date"`
+	Description string                `json:"description"`
+	SourceType  string                `json:"source_type"`
+	SourceId    string                `json:"source_id"`
+	ReferenceId string                `json:"reference_id"`
+	ReversalOf  string                `json:"reversal_of"`
+	ReversedBy  string                `json:"reversed_by"`
+	TotalDebit  int                   `json:"total_debit"`
+	TotalCredit int                   `json:"total_credit"`
+	Lines       []JournalLineResponse `json:"lines"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+type JournalEntryFilter struct {
+	Size        int    `form:"size"`
+	PageNo      int    `form:"page_no"`
+	SourceType  string `form:"source_type"`
+	ReferenceId string `form:"reference_id"`
+	AccountId   string `form:"account_id"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+}
+
+type JournalEntryPaginationResponse struct {
+	Size   int                    `json:"size"`
+	PageNo int                    `json:"page_no"`
+	Total  int                    `json:"total"`
+	Data   []JournalEntryResponse `json:"data"`
+}
+
+type TrialBalanceFilter struct {
+	EndDate string `form:"end_date"`
+}
+
+type TrialBalanceRow struct {
+	AccountId   string `json:"account_id" gorm:"column:account_id"`
+	AccountCode string `json:"account_code" gorm:"column:account_code"`
+	AccountName string `json:"account_name" gorm:"column:account_name"`
+	AccountType string `json:"account_type" gorm:"column:account_type"`
+	Debit       int    `json:"debit" gorm:"column:debit"`
+	Credit      int    `json:"credit" gorm:"column:credit"`
+}
+
+type TrialBalanceResponse struct {
+	EndDate     string            `json:"end_date"`
+	Rows        []TrialBalanceRow `json:"rows"`
+	TotalDebit  int               `json:"total_debit"`
+	TotalCredit int               `json:"total_credit"`
+	Balanced    bool              `json:"balanced"`
+}
+
+type LedgerFilter struct {
+	AccountId string `form:"account_id"`
+	UserId    string `form:"user_id"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+}
+
+type LedgerLine struct {
+	JournalEntryId string    `json:"journal_entry_id" gorm:"column:journal_entry_id"`
+	EntryCode      string    `json:"entry_code" gorm:"-"`
+	EntryNo        int       `json:"-" gorm:"column:entry_no"`
+	EntryDate      time.Time `json:"entry_date" gorm:"column:entry_date"`
+	SourceType     string    `json:"source_type" gorm:"column:source_type"`
+	AccountId      string    `json:"-" gorm:"column:account_id"`
+	UserId         string    `json:"user_id" gorm:"column:user_id"`
+	UserName       string    `json:"user_name" gorm:"column:user_name"`
+	Description    string    `json:"description" gorm:"column:description"`
+	Debit          int       `json:"debit" gorm:"column:debit"`
+	Credit         int       `json:"credit" gorm:"column:credit"`
+	Balance        int       `json:"balance" gorm:"-"`
+}
+
+// AccountLedger is one account's movements in a period. Balances follow the
+// account's normal side, so a positive balance is the usual state.
+type AccountLedger struct {
+	Account        AccountResponse `json:"account"`
+	OpeningBalance int             `json:"opening_balance"`
+	TotalDebit     int             `json:"total_debit"`
+	TotalCredit    int             `json:"total_credit"`
+	ClosingBalance int             `json:"closing_balance"`
+	Lines          []LedgerLine    `json:"lines"`
+}
+
+type GeneralLedgerResponse struct {
+	StartDate string          `json:"start_date"`
+	EndDate   string          `json:"end_date"`
+	Accounts  []AccountLedger `json:"accounts"`
+}
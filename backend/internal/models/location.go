@@ -0,0 +1,143 @@
+package models
+
+import "time"
+
+type Location struct {
+	ID           int    `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid         string `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Name         string `json:"name" gorm:"column:name;not null"`
+	LocationType string `json:"location_type" gorm:"column:location_type"`
+	Description  string `json:"description" gorm:"column:description"`
+	// MaxTemperature overrides cold_chain.max_temperature for this location
+	MaxTemperature *float64  `json:"max_temperature" gorm:"column:max_temperature"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*Location) TableName() string {
+	return "locations"
+}
+
+type StockTransfer struct {
+	ID             int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid           string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	FromLocationId string    `json:"from_location_id" gorm:"column:from_location_id;type:varchar(36)"`
+	ToLocationId   string    `json:"to_location_id" gorm:"column:to_location_id;type:varchar(36);not null"`
+	TransferDate   time.Time `json:"transfer_date" gorm:"column:transfer_date"`
+	Notes          string    `json:"notes" gorm:"column:notes"`
+	Deleted        bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*StockTransfer) TableName() string {
+	return "stock_transfers"
+}
+
+// StockTransferItem moves weight of one sort. When only part of the sort is
+// moved the weight is split off into TargetSortId; otherwise both IDs are
+// the same sort.
+type StockTransferItem struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	StockTransferId string    `json:"stock_transfer_id" gorm:"column:stock_transfer_id;type:varchar(36);not null"`
+	StockSortId     string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36);not null"`
+	TargetSortId    string    `json:"target_sort_id" gorm:"column:target_sort_id;type:varchar(36);not null"`
+	ItemName        string    `json:"item_name" gorm:"column:item_name"`
+	Weight          int       `json:"weight" gorm:"column:weight"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*StockTransferItem) TableName() string {
+	return "stock_transfer_items"
+}
+
+// StockTransferFiber records fibers moved along with a transfer so it can be
+// reverted.
+type StockTransferFiber struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	StockTransferId string    `json:"stock_transfer_id" gorm:"column:stock_transfer_id;type:varchar(36);not null"`
+	FiberId         string    `json:"fiber_id" gorm:"column:fiber_id;type:varchar(36);not null"`
+	FromLocationId  string    `json:"from_location_id" gorm:"column:from_location_id;type:varchar(36)"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*StockTransferFiber) TableName() string {
+	return "stock_transfer_fibers"
+}
+
+type LocationRequest struct {
+	Name           string   `json:"name" validate:"required"`
+	LocationType   string   `json:"location_type" validate:"required,oneof=COLD_ROOM MARKET_STALL WAREHOUSE"`
+	Description    string   `json:"description"`
+	MaxTemperature *float64 `json:"max_temperature"`
+}
+
+type LocationResponse struct {
+	Uuid           string    `json:"uuid"`
+	Name           string    `json:"name"`
+	LocationType   string    `json:"location_type"`
+	Description    string    `json:"description"`
+	MaxTemperature *float64  `json:"max_temperature"`
+	SortCount      int       `json:"sort_count"`
+	FiberCount     int       `json:"fiber_count"`
+	TotalWeight    int       `json:"total_weight"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type StockTransferItemRequest struct {
+	StockSortId string `json:"stock_sort_id" validate:"required"`
+	Weight      int    `json:"weight" validate:"required,min=1"`
+}
+
+type StockTransferRequest struct {
+	FromLocationId string                     `json:"from_location_id"`
+	ToLocationId   string                     `json:"to_location_id" validate:"required,nefield=FromLocationId"`
+	TransferDate   time.Time                  `json:"transfer_date" validate:"required"`
+	Notes          string                     `json:"notes"`
+	Items          []StockTransferItemRequest `json:"items" validate:"required_without=FiberIds,dive"`
+	FiberIds       []string                   `json:"fiber_ids"`
+}
+
+type StockTransferItemResponse struct {
+	Uuid         string `json:"uuid"`
+	StockSortId  string `json:"stock_sort_id"`
+	TargetSortId string `json:"target_sort_id"`
+	StockCode    string `json:"stock_code"`
+	ItemName     string `json:"item_name"`
+	Weight       int    `json:"weight"`
+}
+
+type StockTransferResponse struct {
+	Uuid         string                      `json:"uuid"`
+	TransferCode string                      `json:"transfer_code"`
+	FromLocation *LocationResponse           `json:"from_location"`
+	ToLocation   LocationResponse            `json:"to_location"`
+	TransferDate time.Time                   `json:"transfer_date"`
+	Notes        string                      `json:"notes"`
+	TotalWeight  int                         `json:"total_weight"`
+	Items        []StockTransferItemResponse `json:"items"`
+	Fibers       []FiberResponse             `json:"fibers"`
+	CreatedAt    time.Time                   `json:"created_at"`
+}
+
+type StockTransferFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	LocationId string `form:"location_id"`
+	StartDate  string `form:"start_date"`
+	EndDate    string `form:"end_date"`
+}
+
+type StockTransferPaginationResponse struct {
+	Size   int                     `json:"size"`
+	PageNo int                     `json:"page_no"`
+	Total  int                     `json:"total"`
+	Data   []StockTransferResponse `json:"data"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type Notification struct {
+	ID            int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid          string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Type          string     `json:"type" gorm:"column:type"`
+	Title         string     `json:"title" gorm:"column:title"`
+	Message       string     `json:"message" gorm:"column:message"`
+	ReferenceType string     `json:"reference_type" gorm:"column:reference_type"`
+	ReferenceId   string     `json:"reference_id" gorm:"column:reference_id;type:varchar(36)"`
+	IsRead        bool       `json:"is_read" gorm:"column:is_read"`
+	ReadAt        *time.Time `json:"read_at" gorm:"column:read_at"`
+	Deleted       bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*Notification) TableName() string {
+	return "notifications"
+}
+
+type NotificationResponse struct {
+	Uuid          string     `json:"uuid"`
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Message       string     `json:"message"`
+	ReferenceType string     `json:"reference_type"`
+	ReferenceId   string     `json:"reference_id"`
+	IsRead        bool       `json:"is_read"`
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type NotificationFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	Type       string `form:"type"`
+	UnreadOnly bool   `form:"unread_only"`
+}
+
+type NotificationPaginationResponse struct {
+	Size   int                    `json:"size"`
+	PageNo int                    `json:"page_no"`
+	Total  int                    `json:"total"`
+	Unread int                    `json:"unread"`
+	Data   []NotificationResponse `json:"data"`
+}
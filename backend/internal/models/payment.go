@@ -3,34 +3,63 @@ package models
 import "time"
 
 type Payment struct {
+	ID                 int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid               string    `json:"uuid" gorm:"column:uuid;type:varchar(36)"`
+	UserId             string    `json:"user_id" gorm:"column:user_id;type:varchar(36)"`
+	Total              int       `json:"total" gorm:"column:total"`
+	Type               string    `json:"type" gorm:"column:type"`
+	Description        string    `json:"description" gorm:"column:description"`
+	SalesId            string    `json:"sales_id" gorm:"column:sales_id;type:varchar(36)"`
+	PurchaseId         string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36)"`
+	CashAccountId      string    `json:"cash_account_id" gorm:"column:cash_account_id;type:varchar(36)"`
+	PaymentMethod      string    `json:"payment_method" gorm:"column:payment_method"`
+	ExpenseCategoryId  string    `json:"expense_category_id" gorm:"column:expense_category_id;type:varchar(36)"`
+	CostCenterId       string    `json:"cost_center_id" gorm:"column:cost_center_id;type:varchar(36)"`
+	RecurringExpenseId string    `json:"recurring_expense_id" gorm:"column:recurring_expense_id;type:varchar(36)"`
+	Deleted            bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt          time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt          time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*Payment) TableName() string {
+	return "payment"
+}
+
+// PaymentAttachment is a receipt or invoice scan stored on local disk for a
+// payment.
+type PaymentAttachment struct {
 	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
-	Uuid        string    `json:"uuid" gorm:"column:uuid;type:varchar(36)"`
-	UserId      string    `json:"user_id" gorm:"column:user_id;type:varchar(36)"`
-	Total       int       `json:"total" gorm:"column:total"`
-	Type        string    `json:"type" gorm:"column:type"`
-	Description string    `json:"description" gorm:"column:description"`
-	SalesId     string    `json:"sales_id" gorm:"column:sales_id;type:varchar(36)"`
-	PurchaseId  string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36)"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PaymentId   string    `json:"payment_id" gorm:"column:payment_id;type:varchar(36);not null"`
+	FileName    string    `json:"file_name" gorm:"column:file_name"`
+	ContentType string    `json:"content_type" gorm:"column:content_type"`
+	FilePath    string    `json:"file_path" gorm:"column:file_path"`
+	FileSize    int64     `json:"file_size" gorm:"column:file_size"`
 	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
 
-func (*Payment) TableName() string {
-	return "payment"
+func (*PaymentAttachment) TableName() string {
+	return "payment_attachments"
 }
 
 type PaymentResponse struct {
-	Uuid        string    `json:"uuid"`
-	UserId      string    `json:"user_id"`
-	Total       int       `json:"total"`
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	SalesId     string    `json:"sales_id"`
-	PurchaseId  string    `json:"purchase_id"`
-	IsDeleted   bool      `json:"is_deleted"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	Uuid               string    `json:"uuid"`
+	UserId             string    `json:"user_id"`
+	Total              int       `json:"total"`
+	Type               string    `json:"type"`
+	Description        string    `json:"description"`
+	SalesId            string    `json:"sales_id"`
+	PurchaseId         string    `json:"purchase_id"`
+	CashAccountId      string    `json:"cash_account_id"`
+	PaymentMethod      string    `json:"payment_method"`
+	ExpenseCategoryId  string    `json:"expense_category_id"`
+	CostCenterId       string    `json:"cost_center_id"`
+	RecurringExpenseId string    `json:"recurring_expense_id"`
+	IsDeleted          bool      `json:"is_deleted"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 type CashFlowResponse struct {
@@ -39,23 +68,46 @@ type CashFlowResponse struct {
 }
 
 type CreateManualPaymentRequest struct {
-	Total       int    `json:"total"`
-	Type        string `json:"type"`
-	Description string `json:"description"`
+	Total         int    `json:"total"`
+	Type          string `json:"type"`
+	Description   string `json:"description"`
+	CashAccountId string `json:"cash_account_id" validate:"required"`
+	PaymentMethod string `json:"payment_method" validate:"required,oneof=CASH TRANSFER GIRO"`
+	// Optional, and only for EXPENSE payments of staff and other accounts
+	ExpenseCategoryId string `json:"expense_category_id"`
+	CostCenterId      string `json:"cost_center_id"`
 }
 
+// CreatePaymentPurchaseRequest is shared by cash and deposit payments. The
+// cash account and payment method are required only when money moves;
+// payments settled from a deposit leave them empty.
 type CreatePaymentPurchaseRequest struct {
-	PurchaseId   string    `json:"purchase_id" validate:"required"`
-	PurchaseDate time.Time `json:"purchase_date" validate:"required"`
-	StockCode    string    `json:"stock_code" validate:"required"`
-	Total        int       `json:"total" validate:"required"`
+	PurchaseId    string    `json:"purchase_id" validate:"required"`
+	PurchaseDate  time.Time `json:"purchase_date" validate:"required"`
+	StockCode     string    `json:"stock_code" validate:"required"`
+	Total         int       `json:"total" validate:"required"`
+	CashAccountId string    `json:"cash_account_id"`
+	PaymentMethod string    `json:"payment_method" validate:"omitempty,oneof=CASH TRANSFER GIRO"`
 }
 
+// CreatePaymentSaleRequest follows the same rules as
+// CreatePaymentPurchaseRequest.
 type CreatePaymentSaleRequest struct {
-	SalesId   string    `json:"sales_id" validate:"required"`
-	SalesDate time.Time `json:"sales_date" validate:"required"`
-	SalesCode string    `json:"sales_code" validate:"required"`
-	Total     int       `json:"total" validate:"required"`
+	SalesId       string    `json:"sales_id" validate:"required"`
+	SalesDate     time.Time `json:"sales_date" validate:"required"`
+	SalesCode     string    `json:"sales_code" validate:"required"`
+	Total         int       `json:"total" validate:"required"`
+	CashAccountId string    `json:"cash_account_id"`
+	PaymentMethod string    `json:"payment_method" validate:"omitempty,oneof=CASH TRANSFER GIRO"`
+}
+
+type PaymentAttachmentResponse struct {
+	Uuid        string    `json:"uuid"`
+	PaymentId   string    `json:"payment_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 type UserBalanceDepositResponse struct {
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+type PriceBoard struct {
+	ID        int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid      string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	ProductId string    `json:"product_id" gorm:"column:product_id;type:varchar(36);not null"`
+	Grade     string    `json:"grade" gorm:"column:grade"`
+	PriceDate time.Time `json:"price_date" gorm:"column:price_date;type:date"`
+	BuyPrice  int       `json:"buy_price" gorm:"column:buy_price"`
+	SellPrice int       `json:"sell_price" gorm:"column:sell_price"`
+	Deleted   bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PriceBoard) TableName() string {
+	return "price_boards"
+}
+
+type PriceBoardLineRequest struct {
+	ProductId string `json:"product_id" validate:"required"`
+	Grade     string `json:"grade"`
+	BuyPrice  int    `json:"buy_price" validate:"min=0"`
+	SellPrice int    `json:"sell_price" validate:"min=0"`
+}
+
+type PriceBoardRequest struct {
+	PriceDate time.Time               `json:"price_date" validate:"required"`
+	Prices    []PriceBoardLineRequest `json:"prices" validate:"required,min=1,dive"`
+}
+
+type PriceBoardResponse struct {
+	Uuid        string    `json:"uuid" gorm:"column:uuid"`
+	ProductId   string    `json:"product_id" gorm:"column:product_id"`
+	ProductCode string    `json:"product_code" gorm:"column:product_code"`
+	ProductName string    `json:"product_name" gorm:"column:product_name"`
+	Grade       string    `json:"grade" gorm:"column:grade"`
+	PriceDate   time.Time `json:"price_date" gorm:"column:price_date"`
+	BuyPrice    int       `json:"buy_price" gorm:"column:buy_price"`
+	SellPrice   int       `json:"sell_price" gorm:"column:sell_price"`
+}
+
+type PriceBoardFilter struct {
+	Date string `form:"date"`
+}
+
+type PriceHistoryFilter struct {
+	ProductId string `form:"product_id"`
+	Grade     string `form:"grade"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+}
+
+type PriceSuggestFilter struct {
+	ProductId   string `form:"product_id"`
+	StockSortId string `form:"stock_sort_id"`
+	Grade       string `form:"grade"`
+	Date        string `form:"date"`
+}
+
+type PriceSuggestion struct {
+	ProductId     string     `json:"product_id"`
+	Grade         string     `json:"grade"`
+	PriceDate     *time.Time `json:"price_date"`
+	BuyPrice      int        `json:"buy_price"`
+	SellPrice     int        `json:"sell_price"`
+	CostPerKg     int        `json:"cost_per_kg"`
+	BandPercent   int        `json:"band_percent"`
+	MinSellPrice  int        `json:"min_sell_price"`
+	MaxSellPrice  int        `json:"max_sell_price"`
+	HasBoardPrice bool       `json:"has_board_price"`
+}
+
+type PriceCheckRequest struct {
+	Date       time.Time          `json:"date"`
+	SaleItems  []ItemSalesRequest `json:"sale_items"`
+	StockItems []StockItemRequest `json:"stock_items"`
+}
+
+type PriceWarning struct {
+	Line           int    `json:"line"`
+	Kind           string `json:"kind"`
+	Code           string `json:"code"`
+	Message        string `json:"message"`
+	Price          int    `json:"price"`
+	ReferencePrice int    `json:"reference_price"`
+	Blocking       bool   `json:"blocking"`
+}
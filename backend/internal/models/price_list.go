@@ -0,0 +1,112 @@
+package models
+
+import "time"
+
+type PriceList struct {
+	ID         int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid       string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	CustomerId string     `json:"customer_id" gorm:"column:customer_id;type:varchar(36);not null"`
+	Name       string     `json:"name" gorm:"column:name"`
+	ValidFrom  time.Time  `json:"valid_from" gorm:"column:valid_from;type:date"`
+	ValidTo    *time.Time `json:"valid_to" gorm:"column:valid_to;type:date"`
+	Deleted    bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PriceList) TableName() string {
+	return "price_lists"
+}
+
+type PriceListItem struct {
+	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PriceListId      string    `json:"price_list_id" gorm:"column:price_list_id;type:varchar(36);not null"`
+	ProductId        string    `json:"product_id" gorm:"column:product_id;type:varchar(36);not null"`
+	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PriceListItem) TableName() string {
+	return "price_list_items"
+}
+
+type PriceListTier struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PriceListItemId string    `json:"price_list_item_id" gorm:"column:price_list_item_id;type:varchar(36);not null"`
+	MinWeight       int       `json:"min_weight" gorm:"column:min_weight"`
+	DiscountPercent int       `json:"discount_percent" gorm:"column:discount_percent"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PriceListTier) TableName() string {
+	return "price_list_tiers"
+}
+
+type PriceListTierRequest struct {
+	MinWeight       int `json:"min_weight" validate:"required,min=1"`
+	DiscountPercent int `json:"discount_percent" validate:"required,min=1,max=100"`
+}
+
+type PriceListItemRequest struct {
+	ProductId        string                 `json:"product_id" validate:"required"`
+	PricePerKilogram int                    `json:"price_per_kilogram" validate:"required,min=1"`
+	Tiers            []PriceListTierRequest `json:"tiers" validate:"dive"`
+}
+
+type PriceListRequest struct {
+	CustomerId string                 `json:"customer_id" validate:"required"`
+	Name       string                 `json:"name" validate:"required"`
+	ValidFrom  time.Time              `json:"valid_from" validate:"required"`
+	ValidTo    *time.Time             `json:"valid_to"`
+	Items      []PriceListItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PriceListTierResponse struct {
+	MinWeight       int `json:"min_weight"`
+	DiscountPercent int `json:"discount_percent"`
+}
+
+type PriceListItemResponse struct {
+	Uuid             string                  `json:"uuid"`
+	ProductId        string                  `json:"product_id"`
+	ProductCode      string                  `json:"product_code"`
+	ProductName      string                  `json:"product_name"`
+	PricePerKilogram int                     `json:"price_per_kilogram"`
+	Tiers            []PriceListTierResponse `json:"tiers"`
+}
+
+type PriceListResponse struct {
+	Uuid      string                  `json:"uuid"`
+	Name      string                  `json:"name"`
+	Customer  GetUserDetail           `json:"customer"`
+	ValidFrom time.Time               `json:"valid_from"`
+	ValidTo   *time.Time              `json:"valid_to"`
+	CreatedAt time.Time               `json:"created_at"`
+	Items     []PriceListItemResponse `json:"items"`
+}
+
+type PriceListFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	CustomerId string `form:"customer_id"`
+	ActiveOn   string `form:"active_on"`
+}
+
+type PriceListPaginationResponse struct {
+	Size   int                 `json:"size"`
+	PageNo int                 `json:"page_no"`
+	Total  int                 `json:"total"`
+	Data   []PriceListResponse `json:"data"`
+}
+
+type PriceListApplyRequest struct {
+	CustomerId string             `json:"customer_id" validate:"required"`
+	SalesDate  time.Time          `json:"sales_date" validate:"required"`
+	ItemSales  []ItemSalesRequest `json:"sale_items"`
+}
@@ -0,0 +1,160 @@
+package models
+
+import "time"
+
+type ProcessingOrder struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	ProcessType     string    `json:"process_type" gorm:"column:process_type;not null"`
+	Operator        string    `json:"operator" gorm:"column:operator"`
+	ProcessDate     time.Time `json:"process_date" gorm:"column:process_date"`
+	Notes           string    `json:"notes" gorm:"column:notes"`
+	InputWeight     int       `json:"input_weight" gorm:"column:input_weight"`
+	PrimaryWeight   int       `json:"primary_weight" gorm:"column:primary_weight"`
+	ByProductWeight int       `json:"by_product_weight" gorm:"column:by_product_weight"`
+	WasteWeight     int       `json:"waste_weight" gorm:"column:waste_weight"`
+	InputCost       int       `json:"input_cost" gorm:"column:input_cost"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*ProcessingOrder) TableName() string {
+	return "processing_orders"
+}
+
+type ProcessingInput struct {
+	ID                int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid              string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	ProcessingOrderId string    `json:"processing_order_id" gorm:"column:processing_order_id;type:varchar(36);not null"`
+	StockSortId       string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36);not null"`
+	StockItemId       string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
+	ItemName          string    `json:"item_name" gorm:"column:item_name"`
+	Weight            int       `json:"weight" gorm:"column:weight"`
+	CostPerKilogram   int       `json:"cost_per_kilogram" gorm:"column:cost_per_kilogram"`
+	TotalCost         int       `json:"total_cost" gorm:"column:total_cost"`
+	Deleted           bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt         time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt         time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*ProcessingInput) TableName() string {
+	return "processing_inputs"
+}
+
+// ProcessingOutput is one sort produced by a processing order. Outputs are
+// split per source stock item so produced fish stays traceable to its
+// purchase; waste is kept as a shrinkage sort.
+type ProcessingOutput struct {
+	ID                int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid              string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	ProcessingOrderId string    `json:"processing_order_id" gorm:"column:processing_order_id;type:varchar(36);not null"`
+	StockSortId       string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36);not null"`
+	StockItemId       string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
+	ProductId         string    `json:"product_id" gorm:"column:product_id;type:varchar(36)"`
+	ItemName          string    `json:"item_name" gorm:"column:item_name"`
+	OutputType        string    `json:"output_type" gorm:"column:output_type"`
+	Weight            int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram  int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	TotalCost         int       `json:"total_cost" gorm:"column:total_cost"`
+	Deleted           bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt         time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt         time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*ProcessingOutput) TableName() string {
+	return "processing_outputs"
+}
+
+type ProcessingInputRequest struct {
+	StockSortId string `json:"stock_sort_id" validate:"required"`
+	Weight      int    `json:"weight" validate:"required,min=1"`
+}
+
+type ProcessingOutputRequest struct {
+	ProductId  string `json:"product_id"`
+	ItemName   string `json:"item_name" validate:"required_without=ProductId"`
+	OutputType string `json:"output_type" validate:"required,oneof=PRIMARY BY_PRODUCT"`
+	Weight     int    `json:"weight" validate:"required,min=1"`
+}
+
+// ProcessingOrderRequest consumes weight from sorts and produces new ones.
+// Whatever input weight the outputs do not account for is waste.
+type ProcessingOrderRequest struct {
+	ProcessType string                    `json:"process_type" validate:"required,oneof=FILLET GUT FREEZE OTHER"`
+	Operator    string                    `json:"operator" validate:"required"`
+	ProcessDate time.Time                 `json:"process_date" validate:"required"`
+	Notes       string                    `json:"notes"`
+	LocationId  string                    `json:"location_id"`
+	Inputs      []ProcessingInputRequest  `json:"inputs" validate:"required,min=1,dive"`
+	Outputs     []ProcessingOutputRequest `json:"outputs" validate:"required,min=1,dive"`
+}
+
+type ProcessingLineResponse struct {
+	StockSortId      string `json:"stock_sort_id"`
+	StockCode        string `json:"stock_code"`
+	ProductId        string `json:"product_id,omitempty"`
+	ItemName         string `json:"item_name"`
+	OutputType       string `json:"output_type,omitempty"`
+	Weight           int    `json:"weight"`
+	PricePerKilogram int    `json:"price_per_kilogram"`
+	TotalCost        int    `json:"total_cost"`
+}
+
+type ProcessingOrderResponse struct {
+	Uuid            string                   `json:"uuid"`
+	ProcessingCode  string                   `json:"processing_code"`
+	ProcessType     string                   `json:"process_type"`
+	Operator        string                   `json:"operator"`
+	ProcessDate     time.Time                `json:"process_date"`
+	Notes           string                   `json:"notes"`
+	InputWeight     int                      `json:"input_weight"`
+	PrimaryWeight   int                      `json:"primary_weight"`
+	ByProductWeight int                      `json:"by_product_weight"`
+	WasteWeight     int                      `json:"waste_weight"`
+	YieldPercent    float64                  `json:"yield_percent"`
+	InputCost       int                      `json:"input_cost"`
+	Inputs          []ProcessingLineResponse `json:"inputs"`
+	Outputs         []ProcessingLineResponse `json:"outputs"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+type ProcessingOrderFilter struct {
+	Size        int    `form:"size"`
+	PageNo      int    `form:"page_no"`
+	ProcessType string `form:"process_type"`
+	Operator    string `form:"operator"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+}
+
+type ProcessingOrderPaginationResponse struct {
+	Size   int                       `json:"size"`
+	PageNo int                       `json:"page_no"`
+	Total  int                       `json:"total"`
+	Data   []ProcessingOrderResponse `json:"data"`
+}
+
+type ProcessingYieldFilter struct {
+	ProcessType string `form:"process_type"`
+	Operator    string `form:"operator"`
+	StartDate   string `form:"start_date"`
+	EndDate     string `form:"end_date"`
+}
+
+type ProcessingYieldRow struct {
+	ProcessType     string  `json:"process_type" gorm:"column:process_type"`
+	Operator        string  `json:"operator" gorm:"column:operator"`
+	OrderCount      int     `json:"order_count" gorm:"column:order_count"`
+	InputWeight     int     `json:"input_weight" gorm:"column:input_weight"`
+	PrimaryWeight   int     `json:"primary_weight" gorm:"column:primary_weight"`
+	ByProductWeight int     `json:"by_product_weight" gorm:"column:by_product_weight"`
+	WasteWeight     int     `json:"waste_weight" gorm:"column:waste_weight"`
+	YieldPercent    float64 `json:"yield_percent"`
+	RecoveryPercent float64 `json:"recovery_percent"`
+}
+
+type ProcessingYieldResponse struct {
+	Rows  []ProcessingYieldRow `json:"rows"`
+	Total ProcessingYieldRow   `json:"total"`
+}
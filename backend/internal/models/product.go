@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+type Product struct {
+	ID         int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid       string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Code       string    `json:"code" gorm:"column:code;not null"`
+	Name       string    `json:"name" gorm:"column:name;not null"`
+	Species    string    `json:"species" gorm:"column:species"`
+	Grade      string    `json:"grade" gorm:"column:grade"`
+	SizeClass  string    `json:"size_class" gorm:"column:size_class"`
+	Unit       string    `json:"unit" gorm:"column:unit"`
+	MaxAgeDays int       `json:"max_age_days" gorm:"column:max_age_days"`
+	Deleted    bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*Product) TableName() string {
+	return "products"
+}
+
+type ProductRequest struct {
+	Code       string `json:"code" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	Species    string `json:"species"`
+	Grade      string `json:"grade"`
+	SizeClass  string `json:"size_class"`
+	Unit       string `json:"unit"`
+	MaxAgeDays int    `json:"max_age_days" validate:"min=0"`
+}
+
+type ProductResponse struct {
+	Uuid       string    `json:"uuid"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Species    string    `json:"species"`
+	Grade      string    `json:"grade"`
+	SizeClass  string    `json:"size_class"`
+	Unit       string    `json:"unit"`
+	MaxAgeDays int       `json:"max_age_days"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ProductFilter struct {
+	Size    int    `form:"size"`
+	PageNo  int    `form:"page_no"`
+	Keyword string `form:"keyword"`
+	Species string `form:"species"`
+	Grade   string `form:"grade"`
+}
+
+type ProductPaginationResponse struct {
+	Size   int               `json:"size"`
+	PageNo int               `json:"page_no"`
+	Total  int               `json:"total"`
+	Data   []ProductResponse `json:"data"`
+}
@@ -3,18 +3,21 @@ package models
 import "time"
 
 type Purchase struct {
-	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
-	Uuid            string    `json:"uuid" gorm:"column:uuid"`
-	SupplierID      string    `json:"supplier_id" gorm:"column:supplier_id;type:varchar(36)"`
-	PurchaseDate    time.Time `json:"purchase_date" gorm:"column:purchase_date"`
-	TotalAmount     int       `json:"total_amount" gorm:"column:total_amount"`
-	PaidAmount      int       `json:"paid_amount" gorm:"column:paid_amount"`
-	RemainingAmount int       `json:"remaining_amount" gorm:"column:remaining_amount"`
-	PaymentStatus   string    `json:"payment_status" gorm:"column:payment_status"`
-	StockId         string    `json:"stock_id" gorm:"column:stock_id;type:varchar(36)"`
-	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
-	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+	ID              int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string     `json:"uuid" gorm:"column:uuid"`
+	SupplierID      string     `json:"supplier_id" gorm:"column:supplier_id;type:varchar(36)"`
+	PurchaseDate    time.Time  `json:"purchase_date" gorm:"column:purchase_date"`
+	TotalAmount     int        `json:"total_amount" gorm:"column:total_amount"`
+	PaidAmount      int        `json:"paid_amount" gorm:"column:paid_amount"`
+	RemainingAmount int        `json:"remaining_amount" gorm:"column:remaining_amount"`
+	PaymentStatus   string     `json:"payment_status" gorm:"column:payment_status"`
+	StockId         string     `json:"stock_id" gorm:"column:stock_id;type:varchar(36)"`
+	Vessel          string     `json:"vessel" gorm:"column:vessel"`
+	LandingSite     string     `json:"landing_site" gorm:"column:landing_site"`
+	CatchDate       *time.Time `json:"catch_date" gorm:"column:catch_date"`
+	Deleted         bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"column:updated_at"`
 }
 
 func (*Purchase) TableName() string {
@@ -25,10 +28,16 @@ type CreatePurchaseRequest struct {
 	SupplierID   string             `json:"supplier_id" validate:"required"`
 	PurchaseDate time.Time          `json:"purchase_date" validate:"required"`
 	StockItems   []StockItemRequest `json:"stock_items" validate:"required,dive,required"`
+	Vessel       string             `json:"vessel"`
+	LandingSite  string             `json:"landing_site"`
+	CatchDate    *time.Time         `json:"catch_date"`
 }
 
 type UpdatePurchaseRequest struct {
-	PurchaseDate time.Time `json:"purchase_date"`
+	PurchaseDate time.Time  `json:"purchase_date"`
+	Vessel       *string    `json:"vessel"`
+	LandingSite  *string    `json:"landing_site"`
+	CatchDate    *time.Time `json:"catch_date"`
 }
 
 type PurchaseDataResponse struct {
@@ -43,6 +52,9 @@ type PurchaseDataResponse struct {
 	PaymentStatus   string                `json:"payment_status"`
 	StockEntry      *StockEntriesResponse `json:"stock_entry,omitempty"`
 	LastPayment     string                `json:"last_payment"`
+	Vessel          string                `json:"vessel"`
+	LandingSite     string                `json:"landing_site"`
+	CatchDate       *time.Time            `json:"catch_date"`
 }
 
 type PurchaseData struct {
@@ -59,6 +71,9 @@ type PurchaseData struct {
 	SupplierPhone   string     `gorm:"column:supplier_phone"`
 	StockEntryID    int        `gorm:"column:stock_entry_id"`
 	LastPaymentDate *time.Time `gorm:"column:last_payment_date"`
+	Vessel          string     `gorm:"column:vessel"`
+	LandingSite     string     `gorm:"column:landing_site"`
+	CatchDate       *time.Time `gorm:"column:catch_date"`
 }
 
 type PurchaseResponse struct {
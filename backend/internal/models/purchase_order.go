@@ -0,0 +1,182 @@
+package models
+
+import "time"
+
+type PurchaseOrder struct {
+	ID           int        `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid         string     `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	SupplierId   string     `json:"supplier_id" gorm:"column:supplier_id;type:varchar(36);not null"`
+	OrderDate    time.Time  `json:"order_date" gorm:"column:order_date"`
+	ExpectedDate *time.Time `json:"expected_date" gorm:"column:expected_date"`
+	Status       string     `json:"status" gorm:"column:status"`
+	Notes        string     `json:"notes" gorm:"column:notes"`
+	Deleted      bool       `json:"deleted" gorm:"column:deleted"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+type PurchaseOrderItem struct {
+	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PurchaseOrderId  string    `json:"purchase_order_id" gorm:"column:purchase_order_id;type:varchar(36);not null"`
+	ProductId        string    `json:"product_id" gorm:"column:product_id;type:varchar(36)"`
+	ItemName         string    `json:"item_name" gorm:"column:item_name"`
+	Weight           int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	ReceivedWeight   int       `json:"received_weight" gorm:"column:received_weight"`
+	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*PurchaseOrderItem) TableName() string {
+	return "purchase_order_items"
+}
+
+type GoodsReceipt struct {
+	ID              int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid            string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PurchaseOrderId string    `json:"purchase_order_id" gorm:"column:purchase_order_id;type:varchar(36);not null"`
+	PurchaseId      string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36)"`
+	StockEntryId    string    `json:"stock_entry_id" gorm:"column:stock_entry_id;type:varchar(36)"`
+	ReceiptDate     time.Time `json:"receipt_date" gorm:"column:receipt_date"`
+	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*GoodsReceipt) TableName() string {
+	return "goods_receipts"
+}
+
+type GoodsReceiptItem struct {
+	ID                  int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid                string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	GoodsReceiptId      string    `json:"goods_receipt_id" gorm:"column:goods_receipt_id;type:varchar(36);not null"`
+	PurchaseOrderItemId string    `json:"purchase_order_item_id" gorm:"column:purchase_order_item_id;type:varchar(36);not null"`
+	StockItemId         string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
+	Weight              int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram    int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	Deleted             bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt           time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt           time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*GoodsReceiptItem) TableName() string {
+	return "goods_receipt_items"
+}
+
+type PurchaseOrderItemRequest struct {
+	ProductId        string `json:"product_id"`
+	ItemName         string `json:"item_name" validate:"required_without=ProductId"`
+	Weight           int    `json:"weight" validate:"required,min=1"`
+	PricePerKilogram int    `json:"price_per_kilogram" validate:"required,min=1"`
+}
+
+type PurchaseOrderRequest struct {
+	SupplierId   string                     `json:"supplier_id" validate:"required"`
+	OrderDate    time.Time                  `json:"order_date" validate:"required"`
+	ExpectedDate *time.Time                 `json:"expected_date"`
+	Notes        string                     `json:"notes"`
+	Items        []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemId string `json:"purchase_order_item_id" validate:"required"`
+	Weight              int    `json:"weight" validate:"required,min=1"`
+	PricePerKilogram    int    `json:"price_per_kilogram" validate:"min=0"`
+}
+
+type GoodsReceiptRequest struct {
+	ReceiptDate time.Time                 `json:"receipt_date" validate:"required"`
+	CloseOrder  bool                      `json:"close_order"`
+	Vessel      string                    `json:"vessel"`
+	LandingSite string                    `json:"landing_site"`
+	CatchDate   *time.Time                `json:"catch_date"`
+	Items       []GoodsReceiptItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type PurchaseOrderItemResponse struct {
+	Uuid             string `json:"uuid"`
+	ProductId        string `json:"product_id"`
+	ItemName         string `json:"item_name"`
+	Weight           int    `json:"weight"`
+	PricePerKilogram int    `json:"price_per_kilogram"`
+	ReceivedWeight   int    `json:"received_weight"`
+	RemainingWeight  int    `json:"remaining_weight"`
+}
+
+type GoodsReceiptResponse struct {
+	Uuid        string    `json:"uuid"`
+	PurchaseId  string    `json:"purchase_id"`
+	StockId     string    `json:"stock_id"`
+	StockCode   string    `json:"stock_code"`
+	ReceiptDate time.Time `json:"receipt_date"`
+	TotalWeight int       `json:"total_weight"`
+	TotalAmount int       `json:"total_amount"`
+}
+
+type PurchaseOrderResponse struct {
+	Uuid         string                      `json:"uuid"`
+	OrderCode    string                      `json:"order_code"`
+	Supplier     GetUserDetail               `json:"supplier"`
+	OrderDate    time.Time                   `json:"order_date"`
+	ExpectedDate *time.Time                  `json:"expected_date"`
+	Status       string                      `json:"status"`
+	Notes        string                      `json:"notes"`
+	TotalAmount  int                         `json:"total_amount"`
+	Items        []PurchaseOrderItemResponse `json:"items"`
+	Receipts     []GoodsReceiptResponse      `json:"receipts"`
+}
+
+type PurchaseOrderFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	SupplierId string `form:"supplier_id"`
+	Status     string `form:"status"`
+	StartDate  string `form:"start_date"`
+	EndDate    string `form:"end_date"`
+}
+
+type PurchaseOrderPaginationResponse struct {
+	Size   int                     `json:"size"`
+	PageNo int                     `json:"page_no"`
+	Total  int                     `json:"total"`
+	Data   []PurchaseOrderResponse `json:"data"`
+}
+
+type PurchaseVarianceFilter struct {
+	SupplierId string `form:"supplier_id"`
+	StartDate  string `form:"start_date"`
+	EndDate    string `form:"end_date"`
+}
+
+type PurchaseVarianceItem struct {
+	ProductId        string `json:"product_id" gorm:"column:product_id"`
+	ItemName         string `json:"item_name" gorm:"column:item_name"`
+	OrderedWeight    int    `json:"ordered_weight" gorm:"column:ordered_weight"`
+	ReceivedWeight   int    `json:"received_weight" gorm:"column:received_weight"`
+	WeightVariance   int    `json:"weight_variance" gorm:"-"`
+	OrderedAmount    int    `json:"ordered_amount" gorm:"column:ordered_amount"`
+	ReceivedAmount   int    `json:"received_amount" gorm:"column:received_amount"`
+	OrderedAvgPrice  int    `json:"ordered_avg_price" gorm:"-"`
+	ReceivedAvgPrice int    `json:"received_avg_price" gorm:"-"`
+	PriceVariance    int    `json:"price_variance" gorm:"-"`
+}
+
+type PurchaseVarianceResponse struct {
+	Supplier        GetUserDetail          `json:"supplier"`
+	OrderCount      int                    `json:"order_count"`
+	OrderedWeight   int                    `json:"ordered_weight"`
+	ReceivedWeight  int                    `json:"received_weight"`
+	WeightVariance  int                    `json:"weight_variance"`
+	OrderedAmount   int                    `json:"ordered_amount"`
+	ReceivedAmount  int                    `json:"received_amount"`
+	AmountVariance  int                    `json:"amount_variance"`
+	FillRatePercent float64                `json:"fill_rate_percent"`
+	Items           []PurchaseVarianceItem `json:"items"`
+}
@@ -11,7 +11,6 @@ type Sale struct {
 	RemainingAmount int       `json:"remaining_amount" gorm:"column:remaining_amount"`
 	TotalAmount     int       `json:"total_amount" gorm:"column:total_amount"`
 	PaymentStatus   string    `json:"payment_status" gorm:"column:payment_status"`
-	FiberList       string    `json:"fiber_list" gorm:"column:fiber_list"`
 	ExportSale      bool      `json:"export_sale" gorm:"column:export_sale"`
 	Deleted         bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt       time.Time `json:"created_at" gorm:"column:created_at"`
@@ -46,6 +45,9 @@ type ItemSales struct {
 	Weight           int       `json:"weight" gorm:"column:weight"`
 	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
 	TotalAmount      int       `json:"total_amount" gorm:"column:total_amount"`
+	PriceListId      string    `json:"price_list_id" gorm:"column:price_list_id;type:varchar(36)"`
+	ListPrice        int       `json:"list_price" gorm:"column:list_price"`
+	DiscountPercent  int       `json:"discount_percent" gorm:"column:discount_percent"`
 	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
@@ -61,6 +63,10 @@ type ItemSalesRequest struct {
 	PricePerKilogram int    `json:"price_per_kilogram"`
 	TotalAmount      int    `json:"total_amount"`
 	StockCode        string `json:"stock_code"`
+	ManualPrice      bool   `json:"manual_price"`
+	PriceListId      string `json:"price_list_id"`
+	ListPrice        int    `json:"list_price"`
+	DiscountPercent  int    `json:"discount_percent"`
 }
 
 type AddOnnRequest struct {
@@ -76,6 +82,9 @@ type ItemSaleList struct {
 	PricePerKilogram int    `json:"price_per_kilogram"`
 	Weight           int    `json:"weight"`
 	TotalAmount      int    `json:"total_amount"`
+	PriceListId      string `json:"price_list_id"`
+	ListPrice        int    `json:"list_price"`
+	DiscountPercent  int    `json:"discount_percent"`
 }
 
 type ItemAddOnnList struct {
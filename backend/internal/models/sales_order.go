@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+type SalesOrder struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	Type        string    `json:"type" gorm:"column:type"`
+	CustomerId  string    `json:"customer_id" gorm:"column:customer_id;type:varchar(36);not null"`
+	OrderDate   time.Time `json:"order_date" gorm:"column:order_date"`
+	ExpiresAt   time.Time `json:"expires_at" gorm:"column:expires_at"`
+	Status      string    `json:"status" gorm:"column:status"`
+	SaleId      string    `json:"sale_id" gorm:"column:sale_id;type:varchar(36)"`
+	Notes       string    `json:"notes" gorm:"column:notes"`
+	TotalAmount int       `json:"total_amount" gorm:"column:total_amount"`
+	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*SalesOrder) TableName() string {
+	return "sales_orders"
+}
+
+type SalesOrderItem struct {
+	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	SalesOrderId     string    `json:"sales_order_id" gorm:"column:sales_order_id;type:varchar(36);not null"`
+	StockSortId      string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36);not null"`
+	StockCode        string    `json:"stock_code" gorm:"column:stock_code"`
+	Weight           int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	TotalAmount      int       `json:"total_amount" gorm:"column:total_amount"`
+	PriceListId      string    `json:"price_list_id" gorm:"column:price_list_id;type:varchar(36)"`
+	ListPrice        int       `json:"list_price" gorm:"column:list_price"`
+	DiscountPercent  int       `json:"discount_percent" gorm:"column:discount_percent"`
+	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*SalesOrderItem) TableName() string {
+	return "sales_order_items"
+}
+
+type SalesOrderRequest struct {
+	Type       string             `json:"type" validate:"required,oneof=QUOTATION ORDER"`
+	CustomerId string             `json:"customer_id" validate:"required"`
+	OrderDate  time.Time          `json:"order_date" validate:"required"`
+	ExpiresAt  *time.Time         `json:"expires_at"`
+	Notes      string             `json:"notes"`
+	ItemSales  []ItemSalesRequest `json:"sale_items" validate:"required,min=1"`
+}
+
+type SalesOrderConvertRequest struct {
+	SalesDate  time.Time                `json:"sales_date" validate:"required"`
+	ExportSale bool                     `json:"export_sale"`
+	FiberList  []FiberAllocationRequest `json:"fiber_allocations"`
+	ItemAddOnn []AddOnnRequest          `json:"add_ons"`
+}
+
+type SalesOrderItemResponse struct {
+	Uuid             string `json:"uuid"`
+	StockSortId      string `json:"stock_sort_id"`
+	StockCode        string `json:"stock_code"`
+	StockSortName    string `json:"stock_sort_name"`
+	Weight           int    `json:"weight"`
+	PricePerKilogram int    `json:"price_per_kilogram"`
+	TotalAmount      int    `json:"total_amount"`
+	PriceListId      string `json:"price_list_id"`
+	ListPrice        int    `json:"list_price"`
+	DiscountPercent  int    `json:"discount_percent"`
+}
+
+type SalesOrderResponse struct {
+	Uuid        string                   `json:"uuid"`
+	OrderCode   string                   `json:"order_code"`
+	Type        string                   `json:"type"`
+	Customer    GetUserDetail            `json:"customer"`
+	OrderDate   time.Time                `json:"order_date"`
+	ExpiresAt   time.Time                `json:"expires_at"`
+	Status      string                   `json:"status"`
+	SaleId      string                   `json:"sale_id"`
+	SaleCode    string                   `json:"sale_code"`
+	Notes       string                   `json:"notes"`
+	TotalAmount int                      `json:"total_amount"`
+	Items       []SalesOrderItemResponse `json:"items"`
+}
+
+type SalesOrderFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	Type       string `form:"type"`
+	Status     string `form:"status"`
+	CustomerId string `form:"customer_id"`
+}
+
+type SalesOrderPaginationResponse struct {
+	Size   int                  `json:"size"`
+	PageNo int                  `json:"page_no"`
+	Total  int                  `json:"total"`
+	Data   []SalesOrderResponse `json:"data"`
+}
@@ -18,10 +18,12 @@ type StockItem struct {
 	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
 	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
 	StockEntryID     string    `json:"stock_entry_id" gorm:"column:stock_entry_id;type:varchar(36)"`
+	ProductId        string    `json:"product_id" gorm:"column:product_id;type:varchar(36)"`
 	ItemName         string    `json:"item_name" gorm:"column:item_name"`
 	Weight           int       `json:"weight" gorm:"column:weight"`
 	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
 	TotalPayment     int       `json:"total_payment" gorm:"column:total_payment"`
+	LandedCost       int       `json:"landed_cost" gorm:"column:landed_cost"`
 	IsSorted         bool      `json:"is_sorted" gorm:"column:is_sorted"`
 	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
 	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
@@ -33,18 +35,21 @@ func (*StockItem) TableName() string {
 }
 
 type StockSort struct {
-	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
-	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
-	StockItemID      string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
-	ItemName         string    `json:"sorted_item_name" gorm:"column:sorted_item_name"`
-	Weight           int       `json:"weight" gorm:"column:weight"`
-	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
-	CurrentWeight    int       `json:"current_weight" gorm:"column:current_weight"`
-	TotalCost        int       `json:"total_cost" gorm:"column:total_cost"`
-	IsShrinkage      bool      `json:"is_shrinkage" gorm:"column:is_shrinkage"`
-	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
-	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+	ID                    int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid                  string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	StockItemID           string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
+	ProductId             string    `json:"product_id" gorm:"column:product_id;type:varchar(36)"`
+	ItemName              string    `json:"sorted_item_name" gorm:"column:sorted_item_name"`
+	Weight                int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram      int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	CurrentWeight         int       `json:"current_weight" gorm:"column:current_weight"`
+	TotalCost             int       `json:"total_cost" gorm:"column:total_cost"`
+	LandedCostPerKilogram int       `json:"landed_cost_per_kilogram" gorm:"column:landed_cost_per_kilogram"`
+	IsShrinkage           bool      `json:"is_shrinkage" gorm:"column:is_shrinkage"`
+	LocationId            string    `json:"location_id" gorm:"column:location_id;type:varchar(36)"`
+	Deleted               bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt             time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt             time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
 
 func (*StockSort) TableName() string {
@@ -52,7 +57,8 @@ func (*StockSort) TableName() string {
 }
 
 type StockItemRequest struct {
-	ItemName         string `json:"item_name" validate:"required"`
+	ProductId        string `json:"product_id"`
+	ItemName         string `json:"item_name" validate:"required_without=ProductId"`
 	Weight           int    `json:"weight" validate:"required"`
 	PricePerKilogram int    `json:"price_per_kilogram" validate:"required"`
 }
@@ -76,10 +82,12 @@ type StockEntryResponse struct {
 type StockItemResponse struct {
 	Uuid               string              `json:"uuid"`
 	StockEntryID       string              `json:"stock_entry_id"`
+	ProductId          string              `json:"product_id"`
 	ItemName           string              `json:"item_name"`
 	Weight             int                 `json:"weight"`
 	PricePerKilogram   int                 `json:"price_per_kilogram"`
 	TotalPayment       int                 `json:"total_payment"`
+	LandedCost         int                 `json:"landed_cost"`
 	IsSorted           bool                `json:"is_sorted"`
 	RemainingWeight    int                 `json:"remaining_weight"`
 	AlreadySorted      int                 `json:"already_sortir"`
@@ -87,18 +95,24 @@ type StockItemResponse struct {
 }
 
 type StockSortResponse struct {
-	ID               int    `json:"id" gorm:"column:sort_id"`
-	Uuid             string `json:"uuid" gorm:"column:sort_uuid"`
-	StockItemID      string `json:"stock_item_id" gorm:"column:stock_item_id"`
-	ItemName         string `json:"sorted_item_name" gorm:"column:item_name"`
-	StockEntryID     string `json:"stock_entry_id" gorm:"column:entry_uuid"`
-	StockCode        string `json:"stock_code"`
-	Weight           int    `json:"weight" gorm:"column:weight"`
-	PricePerKilogram int    `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
-	CurrentWeight    int    `json:"current_weight" gorm:"column:current_weight"`
-	TotalCost        int    `json:"total_cost" gorm:"column:total_cost"`
-	IsShrinkage      bool   `json:"is_shrinkage" gorm:"column:is_shrinkage"`
-	EntryId          int    `json:"entry_id" gorm:"column:entry_id"`
+	ID                    int    `json:"id" gorm:"column:sort_id"`
+	Uuid                  string `json:"uuid" gorm:"column:sort_uuid"`
+	StockItemID           string `json:"stock_item_id" gorm:"column:stock_item_id"`
+	ProductId             string `json:"product_id" gorm:"column:product_id"`
+	ItemName              string `json:"sorted_item_name" gorm:"column:item_name"`
+	StockEntryID          string `json:"stock_entry_id" gorm:"column:entry_uuid"`
+	StockCode             string `json:"stock_code"`
+	Weight                int    `json:"weight" gorm:"column:weight"`
+	PricePerKilogram      int    `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	CurrentWeight         int    `json:"current_weight" gorm:"column:current_weight"`
+	TotalCost             int    `json:"total_cost" gorm:"column:total_cost"`
+	LandedCostPerKilogram int    `json:"landed_cost_per_kilogram" gorm:"column:landed_cost_per_kilogram"`
+	IsShrinkage           bool   `json:"is_shrinkage" gorm:"column:is_shrinkage"`
+	LocationId            string `json:"location_id" gorm:"column:location_id"`
+	LocationName          string `json:"location_name" gorm:"column:location_name"`
+	ReservedWeight        int    `json:"reserved_weight" gorm:"column:reserved_weight"`
+	FreeWeight            int    `json:"free_weight" gorm:"column:free_weight"`
+	EntryId               int    `json:"entry_id" gorm:"column:entry_id"`
 }
 
 type StockResponse struct {
@@ -115,14 +129,24 @@ type StockEntryFilter struct {
 	PurchaseDate string `form:"purchase_date"`
 	AgeInDay     string `form:"age_in_day"`
 	Keyword      string `form:"keyword"`
+	LocationId   string `form:"location_id"`
+}
+
+type StockSortFilter struct {
+	LocationId string `form:"location_id"`
 }
 
+// StockSortRequest is one sort line. On update, lines carrying the UUID of an
+// existing sort edit it in place; lines without one become new sorts.
+// Current weight is derived from what has already left the sort.
 type StockSortRequest struct {
-	SortedItemName   string `json:"sorted_item_name" validate:"required,min=3"`
+	Uuid             string `json:"uuid"`
+	ProductId        string `json:"product_id"`
+	SortedItemName   string `json:"sorted_item_name" validate:"required_without=ProductId,omitempty,min=3"`
 	Weight           int    `json:"weight" validate:"required"`
 	PricePerKilogram int    `json:"price_per_kilogram" validate:"required"`
-	CurrentWeight    int    `json:"current_weight"`
 	IsShrinkage      bool   `json:"is_shrinkage"`
+	LocationId       string `json:"location_id"`
 }
 
 type SubmitSortRequest struct {
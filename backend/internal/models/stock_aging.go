@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type StockAgingFilter struct {
+	Status    string `form:"status"`
+	ProductId string `form:"product_id"`
+}
+
+type StockAgingItem struct {
+	StockSortId      string    `json:"stock_sort_id" gorm:"column:stock_sort_id"`
+	StockItemId      string    `json:"stock_item_id" gorm:"column:stock_item_id"`
+	StockEntryNo     int       `json:"-" gorm:"column:stock_entry_no"`
+	StockCode        string    `json:"stock_code" gorm:"-"`
+	ProductId        string    `json:"product_id" gorm:"column:product_id"`
+	ItemName         string    `json:"item_name" gorm:"column:item_name"`
+	PurchaseDate     time.Time `json:"purchase_date" gorm:"column:purchase_date"`
+	AgeInDay         int       `json:"age_in_day" gorm:"-"`
+	MaxAgeDays       int       `json:"max_age_days" gorm:"column:max_age_days"`
+	DaysLeft         int       `json:"days_left" gorm:"-"`
+	Status           string    `json:"status" gorm:"-"`
+	CurrentWeight    int       `json:"current_weight" gorm:"column:current_weight"`
+	ReservedWeight   int       `json:"reserved_weight" gorm:"-"`
+	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	CostValue        int       `json:"cost_value" gorm:"-"`
+}
+
+type StockAgingResponse struct {
+	ExpiredWeight    int              `json:"expired_weight"`
+	ExpiredValue     int              `json:"expired_value"`
+	NearExpiryWeight int              `json:"near_expiry_weight"`
+	NearExpiryValue  int              `json:"near_expiry_value"`
+	Data             []StockAgingItem `json:"data"`
+}
+
+type WriteOffExpiredRequest struct {
+	StockSortIds []string `json:"stock_sort_ids"`
+}
+
+type WriteOffExpiredResponse struct {
+	Count       int              `json:"count"`
+	TotalWeight int              `json:"total_weight"`
+	TotalValue  int              `json:"total_value"`
+	Sorts       []StockAgingItem `json:"sorts"`
+}
+
+type StockAgingCheckResponse struct {
+	NearExpiry int `json:"near_expiry"`
+	Expired    int `json:"expired"`
+	Created    int `json:"created"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+type SupplierReturn struct {
+	ID               int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid             string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	PurchaseId       string    `json:"purchase_id" gorm:"column:purchase_id;type:varchar(36);not null"`
+	SupplierId       string    `json:"supplier_id" gorm:"column:supplier_id;type:varchar(36);not null"`
+	StockEntryId     string    `json:"stock_entry_id" gorm:"column:stock_entry_id;type:varchar(36)"`
+	StockItemId      string    `json:"stock_item_id" gorm:"column:stock_item_id;type:varchar(36)"`
+	StockSortId      string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36)"`
+	ItemName         string    `json:"item_name" gorm:"column:item_name"`
+	ReturnDate       time.Time `json:"return_date" gorm:"column:return_date"`
+	Weight           int       `json:"weight" gorm:"column:weight"`
+	PricePerKilogram int       `json:"price_per_kilogram" gorm:"column:price_per_kilogram"`
+	TotalAmount      int       `json:"total_amount" gorm:"column:total_amount"`
+	DebitAmount      int       `json:"debit_amount" gorm:"column:debit_amount"`
+	CreditAmount     int       `json:"credit_amount" gorm:"column:credit_amount"`
+	PaymentId        string    `json:"payment_id" gorm:"column:payment_id;type:varchar(36)"`
+	Reason           string    `json:"reason" gorm:"column:reason"`
+	Deleted          bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*SupplierReturn) TableName() string {
+	return "supplier_returns"
+}
+
+type SupplierReturnRequest struct {
+	StockItemId      string    `json:"stock_item_id" validate:"required_without=StockSortId"`
+	StockSortId      string    `json:"stock_sort_id"`
+	ReturnDate       time.Time `json:"return_date" validate:"required"`
+	Weight           int       `json:"weight" validate:"required,min=1"`
+	PricePerKilogram int       `json:"price_per_kilogram" validate:"min=0"`
+	Reason           string    `json:"reason" validate:"required"`
+}
+
+type SupplierReturnResponse struct {
+	Uuid             string        `json:"uuid"`
+	ReturnCode       string        `json:"return_code"`
+	PurchaseId       string        `json:"purchase_id"`
+	Supplier         GetUserDetail `json:"supplier"`
+	StockId          string        `json:"stock_id"`
+	StockCode        string        `json:"stock_code"`
+	StockItemId      string        `json:"stock_item_id"`
+	StockSortId      string        `json:"stock_sort_id"`
+	ItemName         string        `json:"item_name"`
+	ReturnDate       time.Time     `json:"return_date"`
+	Weight           int           `json:"weight"`
+	PricePerKilogram int           `json:"price_per_kilogram"`
+	TotalAmount      int           `json:"total_amount"`
+	DebitAmount      int           `json:"debit_amount"`
+	CreditAmount     int           `json:"credit_amount"`
+	Reason           string        `json:"reason"`
+}
+
+type SupplierReturnFilter struct {
+	Size       int    `form:"size"`
+	PageNo     int    `form:"page_no"`
+	SupplierId string `form:"supplier_id"`
+	PurchaseId string `form:"purchase_id"`
+	StartDate  string `form:"start_date"`
+	EndDate    string `form:"end_date"`
+}
+
+type SupplierReturnPaginationResponse struct {
+	Size   int                      `json:"size"`
+	PageNo int                      `json:"page_no"`
+	Total  int                      `json:"total"`
+	Data   []SupplierReturnResponse `json:"data"`
+}
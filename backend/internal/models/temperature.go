@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+type TemperatureReading struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	Uuid        string    `json:"uuid" gorm:"column:uuid;unique;not null;type:varchar(36)"`
+	LocationId  string    `json:"location_id" gorm:"column:location_id;type:varchar(36)"`
+	FiberId     string    `json:"fiber_id" gorm:"column:fiber_id;type:varchar(36)"`
+	SaleId      string    `json:"sale_id" gorm:"column:sale_id;type:varchar(36)"`
+	RecordedAt  time.Time `json:"recorded_at" gorm:"column:recorded_at;not null"`
+	Temperature float64   `json:"temperature" gorm:"column:temperature"`
+	Threshold   float64   `json:"threshold" gorm:"column:threshold"`
+	IsExcursion bool      `json:"is_excursion" gorm:"column:is_excursion"`
+	Source      string    `json:"source" gorm:"column:source"`
+	Notes       string    `json:"notes" gorm:"column:notes"`
+	Deleted     bool      `json:"deleted" gorm:"column:deleted"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (*TemperatureReading) TableName() string {
+	return "temperature_readings"
+}
+
+// TemperatureReadingSort links a reading to every stock sort that was kept
+// where the reading was taken at that moment.
+type TemperatureReadingSort struct {
+	ID          int       `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
+	ReadingId   string    `json:"reading_id" gorm:"column:reading_id;type:varchar(36);not null"`
+	StockSortId string    `json:"stock_sort_id" gorm:"column:stock_sort_id;type:varchar(36);not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (*TemperatureReadingSort) TableName() string {
+	return "temperature_reading_sorts"
+}
+
+type TemperatureReadingRequest struct {
+	LocationId  string    `json:"location_id" validate:"required_without=FiberId,excluded_with=FiberId"`
+	FiberId     string    `json:"fiber_id"`
+	RecordedAt  time.Time `json:"recorded_at" validate:"required"`
+	Temperature *float64  `json:"temperature" validate:"required"`
+	Notes       string    `json:"notes"`
+}
+
+type TemperatureReadingResponse struct {
+	Uuid         string    `json:"uuid" gorm:"column:uuid"`
+	LocationId   string    `json:"location_id" gorm:"column:location_id"`
+	LocationName string    `json:"location_name" gorm:"column:location_name"`
+	FiberId      string    `json:"fiber_id" gorm:"column:fiber_id"`
+	FiberName    string    `json:"fiber_name" gorm:"column:fiber_name"`
+	SaleId       string    `json:"sale_id" gorm:"column:sale_id"`
+	SaleCode     string    `json:"sale_code" gorm:"column:sale_code"`
+	RecordedAt   time.Time `json:"recorded_at" gorm:"column:recorded_at"`
+	Temperature  float64   `json:"temperature" gorm:"column:temperature"`
+	Threshold    float64   `json:"threshold" gorm:"column:threshold"`
+	IsExcursion  bool      `json:"is_excursion" gorm:"column:is_excursion"`
+	Source       string    `json:"source" gorm:"column:source"`
+	Notes        string    `json:"notes" gorm:"column:notes"`
+	LinkedSorts  int       `json:"linked_sorts" gorm:"column:linked_sorts"`
+}
+
+type TemperatureReadingFilter struct {
+	Size           int    `form:"size"`
+	PageNo         int    `form:"page_no"`
+	LocationId     string `form:"location_id"`
+	FiberId        string `form:"fiber_id"`
+	StockSortId    string `form:"stock_sort_id"`
+	SaleId         string `form:"sale_id"`
+	ExcursionsOnly bool   `form:"excursions_only"`
+	StartDate      string `form:"start_date"`
+	EndDate        string `form:"end_date"`
+}
+
+type TemperatureReadingPaginationResponse struct {
+	Size       int                          `json:"size"`
+	PageNo     int                          `json:"page_no"`
+	Total      int                          `json:"total"`
+	Excursions int                          `json:"excursions"`
+	Data       []TemperatureReadingResponse `json:"data"`
+}
+
+type TemperatureImportRow struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type TemperatureImportResponse struct {
+	Success      bool                   `json:"success"`
+	TotalRows    int                    `json:"total_rows"`
+	CreatedCount int                    `json:"created_count"`
+	InvalidRows  int                    `json:"invalid_rows"`
+	Excursions   int                    `json:"excursions"`
+	Rows         []TemperatureImportRow `json:"rows"`
+}
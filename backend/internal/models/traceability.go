@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type TraceabilityLine struct {
+	PurchaseId      string     `json:"purchase_id" gorm:"column:purchase_id"`
+	StockId         string     `json:"stock_id" gorm:"column:stock_id"`
+	StockEntryNo    int        `json:"-" gorm:"column:stock_entry_no"`
+	StockCode       string     `json:"stock_code" gorm:"-"`
+	SupplierId      string     `json:"supplier_id" gorm:"column:supplier_id"`
+	SupplierName    string     `json:"supplier_name" gorm:"column:supplier_name"`
+	SupplierPhone   string     `json:"supplier_phone" gorm:"column:supplier_phone"`
+	PurchaseDate    time.Time  `json:"purchase_date" gorm:"column:purchase_date"`
+	Vessel          string     `json:"vessel" gorm:"column:vessel"`
+	LandingSite     string     `json:"landing_site" gorm:"column:landing_site"`
+	CatchDate       *time.Time `json:"catch_date" gorm:"column:catch_date"`
+	StockItemId     string     `json:"stock_item_id" gorm:"column:stock_item_id"`
+	ItemName        string     `json:"item_name" gorm:"column:item_name"`
+	PurchasedWeight int        `json:"purchased_weight" gorm:"column:purchased_weight"`
+	StockSortId     string     `json:"stock_sort_id" gorm:"column:stock_sort_id"`
+	SortedItemName  string     `json:"sorted_item_name" gorm:"column:sorted_item_name"`
+	SortedWeight    int        `json:"sorted_weight" gorm:"column:sorted_weight"`
+	SaleId          string     `json:"sale_id" gorm:"column:sale_id"`
+	SaleNo          int        `json:"-" gorm:"column:sale_no"`
+	SaleCode        string     `json:"sale_code" gorm:"-"`
+	SaleDate        *time.Time `json:"sale_date" gorm:"column:sale_date"`
+	CustomerId      string     `json:"customer_id" gorm:"column:customer_id"`
+	CustomerName    string     `json:"customer_name" gorm:"column:customer_name"`
+	CustomerPhone   string     `json:"customer_phone" gorm:"column:customer_phone"`
+	SoldWeight      int        `json:"sold_weight" gorm:"column:sold_weight"`
+	Fibers          []string   `json:"fibers" gorm:"-"`
+}
+
+type TraceabilityResponse struct {
+	Direction             string                       `json:"direction"`
+	Reference             string                       `json:"reference"`
+	Suppliers             []GetUserDetail              `json:"suppliers"`
+	Customers             []GetUserDetail              `json:"customers"`
+	TotalSoldWeight       int                          `json:"total_sold_weight"`
+	Lines                 []TraceabilityLine           `json:"lines"`
+	Temperatures          []TemperatureReadingResponse `json:"temperatures"`
+	TemperatureExcursions int                          `json:"temperature_excursions"`
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type AccountingPeriodRepository interface {
+	GetAllPeriods(models.AccountingPeriodFilter) ([]models.AccountingPeriodResponse, error)
+	ClosePeriod(string, string, models.ClosePeriodRequest) (*models.AccountingPeriodResponse, error)
+	ReopenPeriod(string, string, models.ReopenPeriodRequest) (*models.AccountingPeriodResponse, error)
+}
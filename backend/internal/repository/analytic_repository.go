@@ -7,8 +7,11 @@ type AnalyticRepository interface {
 	GetDailyGetAnalyticStats(string) (*models.DailyAnalyticStatsResponse, error)
 	GetSalesTrendData(string) ([]models.SalesTrendData, error)
 	GetStockDistributionData(models.AnalyticStatsFilter) ([]models.StockDistributionData, error)
+	GetProductDistributionData(models.AnalyticStatsFilter) ([]models.StockDistributionData, error)
+	GetTopPerformingItems(int) ([]models.ItemPerformance, error)
 	GetSupplierPerformance(models.AnalyticStatsFilter) ([]models.UserData, error)
 	GetCustomerPerformance(models.AnalyticStatsFilter) ([]models.UserData, error)
 	GetSalesSupplierDetail(models.DailyBookKeepingFilter) (*models.SalesSupplierDetailPaginationResponse, error)
 	SalesSupplierDetailWithPurchaseData(models.DailyBookKeepingFilter) (*models.SalesSupplierDetailWithPurchaseDataPaginationResponse, error)
+	GetMonthlyExpenseBreakdown(models.ExpenseBreakdownFilter) (*models.ExpenseBreakdownResponse, error)
 }
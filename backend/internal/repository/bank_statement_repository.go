@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"dashboard-app/internal/models"
+	"io"
+)
+
+type BankStatementRepository interface {
+	ImportBankStatement(string, string, string, io.Reader) (*models.BankStatementImportResponse, error)
+	GetAllBankStatements(models.BankStatementFilter) (*models.BankStatementPaginationResponse, error)
+	GetBankStatementById(string) (*models.BankStatementResponse, error)
+	DeleteBankStatement(string) error
+	MatchBankStatement(string) (*models.BankStatementResponse, error)
+	GetAllStatementLines(models.BankStatementLineFilter) (*models.BankStatementLinePaginationResponse, error)
+	GetLineCandidates(string) ([]models.MatchCandidate, error)
+	ConfirmLineMatch(string, string, models.ConfirmMatchRequest) (*models.BankStatementLineResponse, error)
+	IgnoreLine(string) (*models.BankStatementLineResponse, error)
+	ResetLine(string) (*models.BankStatementLineResponse, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type CashAccountRepository interface {
+	GetAllCashAccounts(models.CashAccountFilter) ([]models.CashAccountResponse, error)
+	CreateCashAccount(models.CashAccountRequest) (*models.CashAccountResponse, error)
+	UpdateCashAccount(string, models.CashAccountRequest) (*models.CashAccountResponse, error)
+	DeleteCashAccount(string) error
+	GetCashAccountStatement(string, models.LedgerFilter) (*models.AccountLedger, error)
+	GetAllCashTransfers(models.CashTransferFilter) (*models.CashTransferPaginationResponse, error)
+	CreateCashTransfer(models.CashTransferRequest) (*models.CashTransferResponse, error)
+	DeleteCashTransfer(string) error
+}
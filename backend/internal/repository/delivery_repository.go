@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"dashboard-app/internal/models"
+	"io"
+)
+
+type DeliveryRepository interface {
+	CreateDeliveryNote(context.Context, models.DeliveryNoteRequest) (*models.DeliveryNoteResponse, error)
+	GetAllDeliveryNotes(context.Context, models.DeliveryNoteFilter) (*models.DeliveryNotePaginationResponse, error)
+	GetDeliveryNoteById(context.Context, string) (*models.DeliveryNoteResponse, error)
+	UpdateDeliveryNote(context.Context, string, models.UpdateDeliveryNoteRequest) error
+	UpdateDeliveryStatus(context.Context, string, models.DeliveryStatusRequest) error
+	DeleteDeliveryNote(context.Context, string) error
+	UploadDeliveryProof(context.Context, string, io.Reader) (*models.DeliveryNoteResponse, error)
+	GetDeliveryProofPath(context.Context, string) (string, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type ExpenseRepository interface {
+	GetAllExpenseCategories(models.ExpenseFilter) ([]models.ExpenseCategoryResponse, error)
+	CreateExpenseCategory(models.ExpenseCategoryRequest) (*models.ExpenseCategoryResponse, error)
+	UpdateExpenseCategory(string, models.ExpenseCategoryRequest) (*models.ExpenseCategoryResponse, error)
+	DeleteExpenseCategory(string) error
+	GetAllCostCenters(models.ExpenseFilter) ([]models.CostCenterResponse, error)
+	CreateCostCenter(models.CostCenterRequest) (*models.CostCenterResponse, error)
+	UpdateCostCenter(string, models.CostCenterRequest) (*models.CostCenterResponse, error)
+	DeleteCostCenter(string) error
+	GetAllRecurringExpenses(models.ExpenseFilter) ([]models.RecurringExpenseResponse, error)
+	CreateRecurringExpense(models.RecurringExpenseRequest) (*models.RecurringExpenseResponse, error)
+	UpdateRecurringExpense(string, models.RecurringExpenseRequest) (*models.RecurringExpenseResponse, error)
+	DeleteRecurringExpense(string) error
+	RunRecurringExpenses() (*models.RecurringExpenseRunResponse, error)
+}
@@ -1,6 +1,9 @@
 package repository
 
-import "dashboard-app/internal/models"
+import (
+	"dashboard-app/internal/models"
+	"io"
+)
 
 type FiberRepository interface {
 	GetAllFibers(models.FiberFilter) (*models.FiberPaginationResponse, error)
@@ -10,4 +13,6 @@ type FiberRepository interface {
 	DeleteFiber(string) error
 	UpdateFiber(string, models.FiberRequest) error
 	GetAllUsedFibers() ([]models.FiberResponse, error)
+	BulkCreateFibers(models.BulkFiberRequest) (*models.BulkFiberResponse, error)
+	ImportFibers(string, io.Reader) (*models.BulkFiberResponse, error)
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type FinancialStatementRepository interface {
+	GetProfitLoss(models.StatementPeriodFilter) (*models.ProfitLossResponse, error)
+	GetBalanceSheet(models.BalanceSheetFilter) (*models.BalanceSheetResponse, error)
+	GetCashFlowStatement(models.StatementPeriodFilter) (*models.CashFlowStatementResponse, error)
+}
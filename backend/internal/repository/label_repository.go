@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"dashboard-app/internal/models"
+)
+
+type LabelRepository interface {
+	GetFiberLabels(models.FiberLabelFilter) ([]models.Label, error)
+	GetStockLabels(string) ([]models.Label, error)
+	LookupCode(context.Context, string) (*models.ScanLookupResponse, error)
+}
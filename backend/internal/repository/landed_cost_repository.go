@@ -0,0 +1,10 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type LandedCostRepository interface {
+	GetLandedCosts(string) (*models.LandedCostResponse, error)
+	CreatePurchaseCost(string, models.PurchaseCostRequest) (*models.LandedCostResponse, error)
+	UpdatePurchaseCost(string, string, models.PurchaseCostRequest) (*models.LandedCostResponse, error)
+	DeletePurchaseCost(string, string) error
+}
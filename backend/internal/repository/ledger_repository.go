@@ -0,0 +1,17 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type LedgerRepository interface {
+	GetAllAccounts(models.AccountFilter) ([]models.AccountResponse, error)
+	CreateAccount(models.AccountRequest) (*models.AccountResponse, error)
+	UpdateAccount(string, models.AccountRequest) error
+	DeleteAccount(string) error
+	GetAccountStatement(string, models.LedgerFilter) (*models.AccountLedger, error)
+	GetAllJournalEntries(models.JournalEntryFilter) (*models.JournalEntryPaginationResponse, error)
+	GetJournalEntryById(string) (*models.JournalEntryResponse, error)
+	CreateJournalEntry(models.JournalEntryRequest) (*models.JournalEntryResponse, error)
+	ReverseJournalEntry(string) (*models.JournalEntryResponse, error)
+	GetTrialBalance(models.TrialBalanceFilter) (*models.TrialBalanceResponse, error)
+	GetGeneralLedger(models.LedgerFilter) (*models.GeneralLedgerResponse, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type LocationRepository interface {
+	GetAllLocations() ([]models.LocationResponse, error)
+	GetLocationById(string) (*models.LocationResponse, error)
+	CreateLocation(models.LocationRequest) (*models.LocationResponse, error)
+	UpdateLocation(string, models.LocationRequest) (*models.LocationResponse, error)
+	DeleteLocation(string) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type NotificationRepository interface {
+	GetAllNotifications(models.NotificationFilter) (*models.NotificationPaginationResponse, error)
+	MarkNotificationRead(string) error
+	MarkAllNotificationsRead() (int, error)
+	DeleteNotification(string) error
+}
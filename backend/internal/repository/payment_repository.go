@@ -1,6 +1,11 @@
 package repository
 
-import "dashboard-app/internal/models"
+import (
+	"dashboard-app/internal/models"
+	"io"
+
+	"gorm.io/gorm"
+)
 
 type PaymentRepository interface {
 	GetAllPaymentFromUserId(string) (*models.CashFlowResponse, error)
@@ -10,7 +15,13 @@ type PaymentRepository interface {
 	GetAllPaymentByFieldId(string, string) (*models.CashFlowResponse, error)
 	CreatePaymentByPurchaseId(models.CreatePaymentPurchaseRequest) error
 	CreatePaymentBySalesId(models.CreatePaymentSaleRequest) error
+	CreatePurchasePaymentTx(*gorm.DB, models.CreatePaymentPurchaseRequest) (*models.Payment, error)
+	CreateSalePaymentTx(*gorm.DB, models.CreatePaymentSaleRequest) (*models.Payment, error)
 	CreatePaymentFromDepositByPurchaseId(models.CreatePaymentPurchaseRequest) error
 	GetUserBalanceDeposit(string) (*models.UserBalanceDepositResponse, error)
 	CreatePaymentFromDepositBySalesId(models.CreatePaymentSaleRequest) error
+	UploadPaymentAttachment(string, string, io.Reader) (*models.PaymentAttachmentResponse, error)
+	GetPaymentAttachments(string) ([]models.PaymentAttachmentResponse, error)
+	GetPaymentAttachment(string, string) (*models.PaymentAttachment, error)
+	DeletePaymentAttachment(string, string) error
 }
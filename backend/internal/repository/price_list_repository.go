@@ -0,0 +1,12 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type PriceListRepository interface {
+	GetAllPriceLists(models.PriceListFilter) (*models.PriceListPaginationResponse, error)
+	GetPriceListById(string) (*models.PriceListResponse, error)
+	CreatePriceList(models.PriceListRequest) (*models.PriceListResponse, error)
+	UpdatePriceList(string, models.PriceListRequest) error
+	DeletePriceList(string) error
+	ApplyPriceList(models.PriceListApplyRequest) ([]models.ItemSalesRequest, error)
+}
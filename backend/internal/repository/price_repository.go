@@ -0,0 +1,11 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type PriceRepository interface {
+	SetPriceBoard(models.PriceBoardRequest) ([]models.PriceBoardResponse, error)
+	GetPriceBoard(models.PriceBoardFilter) ([]models.PriceBoardResponse, error)
+	GetPriceHistory(models.PriceHistoryFilter) ([]models.PriceBoardResponse, error)
+	SuggestPrice(models.PriceSuggestFilter) (*models.PriceSuggestion, error)
+	CheckPrices(models.PriceCheckRequest) ([]models.PriceWarning, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type ProcessingRepository interface {
+	CreateProcessingOrder(models.ProcessingOrderRequest) (*models.ProcessingOrderResponse, error)
+	GetAllProcessingOrders(models.ProcessingOrderFilter) (*models.ProcessingOrderPaginationResponse, error)
+	GetProcessingOrderById(string) (*models.ProcessingOrderResponse, error)
+	DeleteProcessingOrder(string) error
+	GetProcessingYield(models.ProcessingYieldFilter) (*models.ProcessingYieldResponse, error)
+}
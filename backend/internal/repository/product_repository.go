@@ -0,0 +1,11 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type ProductRepository interface {
+	GetAllProducts(models.ProductFilter) (*models.ProductPaginationResponse, error)
+	GetProductById(string) (*models.ProductResponse, error)
+	CreateProduct(models.ProductRequest) (*models.ProductResponse, error)
+	UpdateProduct(string, models.ProductRequest) error
+	DeleteProduct(string) error
+}
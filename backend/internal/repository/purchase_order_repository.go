@@ -0,0 +1,14 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type PurchaseOrderRepository interface {
+	CreatePurchaseOrder(models.PurchaseOrderRequest) (*models.PurchaseOrderResponse, error)
+	GetAllPurchaseOrders(models.PurchaseOrderFilter) (*models.PurchaseOrderPaginationResponse, error)
+	GetPurchaseOrderById(string) (*models.PurchaseOrderResponse, error)
+	UpdatePurchaseOrder(string, models.PurchaseOrderRequest) error
+	CancelPurchaseOrder(string) error
+	DeletePurchaseOrder(string) error
+	ReceiveGoods(string, models.GoodsReceiptRequest) (*models.GoodsReceiptResponse, error)
+	GetPurchaseVariance(models.PurchaseVarianceFilter) ([]models.PurchaseVarianceResponse, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"dashboard-app/internal/models"
+)
+
+type SalesOrderRepository interface {
+	CreateSalesOrder(context.Context, models.SalesOrderRequest) (*models.SalesOrderResponse, error)
+	GetAllSalesOrders(context.Context, models.SalesOrderFilter) (*models.SalesOrderPaginationResponse, error)
+	GetSalesOrderById(context.Context, string) (*models.SalesOrderResponse, error)
+	UpdateSalesOrder(context.Context, string, models.SalesOrderRequest) error
+	ConfirmSalesOrder(context.Context, string) error
+	CancelSalesOrder(context.Context, string) error
+	ConvertSalesOrder(context.Context, string, models.SalesOrderConvertRequest) (*models.SalesOrderResponse, error)
+	ExpireSalesOrders(context.Context) (int64, error)
+}
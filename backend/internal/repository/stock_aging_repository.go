@@ -0,0 +1,9 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type StockAgingRepository interface {
+	GetStockAging(models.StockAgingFilter) (*models.StockAgingResponse, error)
+	CheckStockAging() (*models.StockAgingCheckResponse, error)
+	WriteOffExpiredStock(models.WriteOffExpiredRequest) (*models.WriteOffExpiredResponse, error)
+}
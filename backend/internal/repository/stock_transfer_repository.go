@@ -0,0 +1,10 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type StockTransferRepository interface {
+	CreateStockTransfer(models.StockTransferRequest) (*models.StockTransferResponse, error)
+	GetAllStockTransfers(models.StockTransferFilter) (*models.StockTransferPaginationResponse, error)
+	GetStockTransferById(string) (*models.StockTransferResponse, error)
+	DeleteStockTransfer(string) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type SupplierReturnRepository interface {
+	CreateSupplierReturn(models.SupplierReturnRequest) (*models.SupplierReturnResponse, error)
+	GetAllSupplierReturns(models.SupplierReturnFilter) (*models.SupplierReturnPaginationResponse, error)
+	GetSupplierReturnById(string) (*models.SupplierReturnResponse, error)
+	DeleteSupplierReturn(string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"dashboard-app/internal/models"
+	"io"
+)
+
+type TemperatureRepository interface {
+	CreateTemperatureReading(models.TemperatureReadingRequest) (*models.TemperatureReadingResponse, error)
+	ImportTemperatureReadings(string, io.Reader, string, string) (*models.TemperatureImportResponse, error)
+	GetAllTemperatureReadings(models.TemperatureReadingFilter) (*models.TemperatureReadingPaginationResponse, error)
+	DeleteTemperatureReading(string) error
+}
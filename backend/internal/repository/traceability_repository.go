@@ -0,0 +1,8 @@
+package repository
+
+import "dashboard-app/internal/models"
+
+type TraceabilityRepository interface {
+	TracePurchase(string) (*models.TraceabilityResponse, error)
+	TraceSale(string) (*models.TraceabilityResponse, error)
+}
@@ -1,11 +1,14 @@
 package server
 
 import (
+	"context"
 	"dashboard-app/internal/config"
 	"dashboard-app/internal/handler"
 	"dashboard-app/internal/middleware"
 	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
 	"dashboard-app/internal/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -30,6 +33,28 @@ func Run() error {
 	salesService := service.NewSalesService()
 	analyticService := service.NewAnalyticService()
 	auditLogService := service.NewAuditLogService()
+	labelService := service.NewLabelService(fiberService, salesService)
+	productService := service.NewProductService()
+	priceService := service.NewPriceService()
+	priceListService := service.NewPriceListService()
+	purchaseOrderService := service.NewPurchaseOrderService(userService)
+	salesOrderService := service.NewSalesOrderService()
+	deliveryService := service.NewDeliveryService()
+	supplierReturnService := service.NewSupplierReturnService()
+	landedCostService := service.NewLandedCostService()
+	traceabilityService := service.NewTraceabilityService()
+	notificationService := service.NewNotificationService()
+	stockAgingService := service.NewStockAgingService()
+	locationService := service.NewLocationService()
+	stockTransferService := service.NewStockTransferService()
+	temperatureService := service.NewTemperatureService()
+	processingService := service.NewProcessingService()
+	ledgerService := service.NewLedgerService()
+	financialStatementService := service.NewFinancialStatementService()
+	accountingPeriodService := service.NewAccountingPeriodService()
+	cashAccountService := service.NewCashAccountService()
+	bankStatementService := service.NewBankStatementService(paymentService)
+	expenseService := service.NewExpenseService()
 
 	userHandler := handler.NewUserHandler(userService, validate)
 	purchaseHandler := handler.NewPurchaseHandler(purchaseService, validate)
@@ -39,6 +64,28 @@ func Run() error {
 	salesHandler := handler.NewSalesHandler(salesService, validate)
 	analyticsHandler := handler.NewAnalyticsHandler(analyticService, validate)
 	auditLogHandler := handler.NewAuditLogHandler(auditLogService, validate)
+	labelHandler := handler.NewLabelHandler(labelService, validate)
+	productHandler := handler.NewProductHandler(productService, validate)
+	priceHandler := handler.NewPriceHandler(priceService, validate)
+	priceListHandler := handler.NewPriceListHandler(priceListService, validate)
+	purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderService, validate)
+	salesOrderHandler := handler.NewSalesOrderHandler(salesOrderService, validate)
+	deliveryHandler := handler.NewDeliveryHandler(deliveryService, validate)
+	supplierReturnHandler := handler.NewSupplierReturnHandler(supplierReturnService, validate)
+	landedCostHandler := handler.NewLandedCostHandler(landedCostService, validate)
+	traceabilityHandler := handler.NewTraceabilityHandler(traceabilityService, validate)
+	notificationHandler := handler.NewNotificationHandler(notificationService, validate)
+	stockAgingHandler := handler.NewStockAgingHandler(stockAgingService, validate)
+	locationHandler := handler.NewLocationHandler(locationService, validate)
+	stockTransferHandler := handler.NewStockTransferHandler(stockTransferService, validate)
+	temperatureHandler := handler.NewTemperatureHandler(temperatureService, validate)
+	processingHandler := handler.NewProcessingHandler(processingService, validate)
+	ledgerHandler := handler.NewLedgerHandler(ledgerService, validate)
+	financialStatementHandler := handler.NewFinancialStatementHandler(financialStatementService, validate)
+	accountingPeriodHandler := handler.NewAccountingPeriodHandler(accountingPeriodService, validate)
+	cashAccountHandler := handler.NewCashAccountHandler(cashAccountService, validate)
+	bankStatementHandler := handler.NewBankStatementHandler(bankStatementService, validate)
+	expenseHandler := handler.NewExpenseHandler(expenseService, validate)
 
 	api := app.Group("/v1/api")
 	api.Use(middleware.RequestResponseLogger())
@@ -56,7 +103,92 @@ func Run() error {
 		userHandler.RegisterRoutes(api)
 		purchaseHandler.RegisterRoutes(api)
 		auditLogHandler.RegisterRoutes(api)
+		labelHandler.RegisterRoutes(api)
+		productHandler.RegisterRoutes(api)
+		priceHandler.RegisterRoutes(api)
+		priceListHandler.RegisterRoutes(api)
+		purchaseOrderHandler.RegisterRoutes(api)
+		salesOrderHandler.RegisterRoutes(api)
+		deliveryHandler.RegisterRoutes(api)
+		supplierReturnHandler.RegisterRoutes(api)
+		landedCostHandler.RegisterRoutes(api)
+		traceabilityHandler.RegisterRoutes(api)
+		notificationHandler.RegisterRoutes(api)
+		stockAgingHandler.RegisterRoutes(api)
+		locationHandler.RegisterRoutes(api)
+		stockTransferHandler.RegisterRoutes(api)
+		temperatureHandler.RegisterRoutes(api)
+		processingHandler.RegisterRoutes(api)
+		ledgerHandler.RegisterRoutes(api)
+		financialStatementHandler.RegisterRoutes(api)
+		accountingPeriodHandler.RegisterRoutes(api)
+		cashAccountHandler.RegisterRoutes(api)
+		bankStatementHandler.RegisterRoutes(api)
+		expenseHandler.RegisterRoutes(api)
 	}
 
+	go expireSalesOrders(salesOrderService)
+	go checkStockAging(stockAgingService)
+	go runRecurringExpenses(expenseService)
+
 	return app.Run(":" + models.GetConfig().Port)
 }
+
+// expireSalesOrders periodically moves open quotations and sales orders past
+// their expiry to EXPIRED so their reserved weight is released.
+func expireSalesOrders(salesOrderRepository repository.SalesOrderRepository) {
+	minutes := models.GetConfig().SalesOrder.ExpiryCheckMinutes
+	if minutes <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := salesOrderRepository.ExpireSalesOrders(context.Background()); err != nil {
+			config.GetLogger().Error("Failed to expire sales orders: %v", err)
+		}
+	}
+}
+
+// checkStockAging periodically raises alerts for stock nearing or past the
+// shelf life of its product.
+func checkStockAging(stockAgingRepository repository.StockAgingRepository) {
+	minutes := models.GetConfig().StockAging.CheckMinutes
+	if minutes <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := stockAgingRepository.CheckStockAging(); err != nil {
+			config.GetLogger().Error("Failed to check stock aging: %v", err)
+		}
+	}
+}
+
+// runRecurringExpenses periodically books recurring expense templates that
+// have fallen due.
+func runRecurringExpenses(expenseRepository repository.ExpenseRepository) {
+	minutes := models.GetConfig().Expense.RecurringCheckMinutes
+	if minutes <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		result, err := expenseRepository.RunRecurringExpenses()
+		if err != nil {
+			config.GetLogger().Error("Failed to run recurring expenses: %v", err)
+			continue
+		}
+		for _, failure := range result.Failed {
+			config.GetLogger().Error("Failed to run recurring expense %s", failure)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type AccountingPeriodService struct{}
+
+func NewAccountingPeriodService() repository.AccountingPeriodRepository {
+	return &AccountingPeriodService{}
+}
+
+// GetAllPeriods - Close State of Every Month in a Year
+// =====================================================
+func (s *AccountingPeriodService) GetAllPeriods(filter models.AccountingPeriodFilter) ([]models.AccountingPeriodResponse, error) {
+	db := config.GetDBConn()
+
+	year := time.Now().In(constants.JakartaTz).Year()
+	if filter.Year != "" {
+		parsed, err := strconv.Atoi(filter.Year)
+		if err != nil || parsed < 2000 || parsed > 9999 {
+			return nil, apperror.NewBadRequest("year must be a four digit year")
+		}
+		year = parsed
+	}
+
+	var rows []models.AccountingPeriodResponse
+	if err := s.periodQuery(db).
+		Where("ap.period LIKE ?", fmt.Sprintf("%d-%%", year)).
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch accounting periods: ", err)
+	}
+	periodMap := make(map[string]models.AccountingPeriodResponse, len(rows))
+	for _, row := range rows {
+		periodMap[row.Period] = row
+	}
+
+	// Months that were never closed have no row and are open
+	periods := make([]models.AccountingPeriodResponse, 0, 12)
+	for month := 1; month <= 12; month++ {
+		period := fmt.Sprintf("%d-%02d", year, month)
+		row, ok := periodMap[period]
+		if !ok {
+			row = models.AccountingPeriodResponse{Period: period, Status: constants.PeriodOpen}
+		}
+		periods = append(periods, row)
+	}
+
+	return periods, nil
+}
+
+// ClosePeriod - Lock a Month Against Changes
+// =====================================================
+func (s *AccountingPeriodService) ClosePeriod(userId, period string, request models.ClosePeriodRequest) (*models.AccountingPeriodResponse, error) {
+	db := config.GetDBConn()
+
+	month, err := time.Parse("2006-01", period)
+	if err != nil {
+		return nil, apperror.NewBadRequest("period must be in YYYY-MM format")
+	}
+
+	user, err := periodUser(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != constants.SuperAdminRole && user.Role != constants.AdminRole {
+		return nil, apperror.NewForbidden("only admins can close an accounting period")
+	}
+
+	now := time.Now()
+	if !month.AddDate(0, 1, 0).Before(now.In(constants.JakartaTz)) {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("period %s has not ended yet", period))
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var existing models.AccountingPeriod
+		err := tx.Where("period = ? AND deleted = false", period).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewUnprocessableEntity("failed to fetch accounting period: ", err)
+		}
+
+		if err == nil {
+			if existing.Status == constants.PeriodClosed {
+				return apperror.NewConflict(fmt.Sprintf("period %s is already closed", period))
+			}
+			if err = tx.Model(&models.AccountingPeriod{}).
+				Where("uuid = ?", existing.Uuid).
+				Updates(map[string]interface{}{
+					"status":     constants.PeriodClosed,
+					"notes":      strings.TrimSpace(request.Notes),
+					"closed_by":  user.Uuid,
+					"closed_at":  now,
+					"updated_at": now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to close accounting period: ", err)
+			}
+			return nil
+		}
+
+		record := models.AccountingPeriod{
+			Uuid:      uuid.New().String(),
+			Period:    period,
+			Status:    constants.PeriodClosed,
+			Notes:     strings.TrimSpace(request.Notes),
+			ClosedBy:  user.Uuid,
+			ClosedAt:  &now,
+			Deleted:   false,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err = tx.Create(&record).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to close accounting period: ", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPeriod(db, period)
+}
+
+// ReopenPeriod - Unlock a Closed Month
+// =====================================================
+func (s *AccountingPeriodService) ReopenPeriod(userId, period string, request models.ReopenPeriodRequest) (*models.AccountingPeriodResponse, error) {
+	db := config.GetDBConn()
+
+	if _, err := time.Parse("2006-01", period); err != nil {
+		return nil, apperror.NewBadRequest("period must be in YYYY-MM format")
+	}
+
+	user, err := periodUser(db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != constants.SuperAdminRole {
+		return nil, apperror.NewForbidden("only a super admin can reopen an accounting period")
+	}
+
+	now := time.Now()
+	result := db.Model(&models.AccountingPeriod{}).
+		Where("period = ? AND status = ? AND deleted = false", period, constants.PeriodClosed).
+		Updates(map[string]interface{}{
+			"status":        constants.PeriodOpen,
+			"reopened_by":   user.Uuid,
+			"reopened_at":   now,
+			"reopen_reason": strings.TrimSpace(request.Reason),
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to reopen accounting period: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.NewConflict(fmt.Sprintf("period %s is not closed", period))
+	}
+
+	return s.getPeriod(db, period)
+}
+
+func (s *AccountingPeriodService) periodQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("accounting_periods AS ap").
+		Select(`
+			ap.period,
+			ap.status,
+			ap.notes,
+			COALESCE(ap.closed_by, '') AS closed_by,
+			COALESCE(cu.name, '') AS closed_by_name,
+			ap.closed_at,
+			COALESCE(ap.reopened_by, '') AS reopened_by,
+			COALESCE(ru.name, '') AS reopened_by_name,
+			ap.reopened_at,
+			ap.reopen_reason
+		`).
+		Joins(`LEFT JOIN "user" cu ON cu.uuid = ap.closed_by`).
+		Joins(`LEFT JOIN "user" ru ON ru.uuid = ap.reopened_by`).
+		Where("ap.deleted = false")
+}
+
+func (s *AccountingPeriodService) getPeriod(db *gorm.DB, period string) (*models.AccountingPeriodResponse, error) {
+	var response models.AccountingPeriodResponse
+	if err := s.periodQuery(db).Where("ap.period = ?", period).Scan(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch accounting period: ", err)
+	}
+
+	return &response, nil
+}
+
+func periodUser(db *gorm.DB, userId string) (*models.User, error) {
+	var user models.User
+	if err := db.Where("uuid = ? AND status = true", userId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("user not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch user: ", err)
+	}
+
+	return &user, nil
+}
+
+// checkPeriodsOpen rejects changes to documents dated in a closed month.
+// Pass every date the change touches, e.g. both the old and the new date of
+// an edited sale.
+func checkPeriodsOpen(db *gorm.DB, dates ...time.Time) error {
+	periods := make([]string, 0, len(dates))
+	for _, date := range dates {
+		if date.IsZero() {
+			continue
+		}
+		periods = append(periods, date.In(constants.JakartaTz).Format("2006-01"))
+	}
+	if len(periods) == 0 {
+		return nil
+	}
+
+	var closed []string
+	if err := db.Model(&models.AccountingPeriod{}).
+		Where("period IN ? AND status = ? AND deleted = false", distinct(periods), constants.PeriodClosed).
+		Pluck("period", &closed).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check accounting periods: ", err)
+	}
+	if len(closed) > 0 {
+		sort.Strings(closed)
+		return apperror.NewConflict(fmt.Sprintf(
+			"accounting period %s is closed; a super admin must reopen it first", strings.Join(closed, ", ")))
+	}
+
+	return nil
+}
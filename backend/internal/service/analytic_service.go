@@ -3,9 +3,11 @@ package service
 import (
 	"dashboard-app/pkg/apperror"
 	"fmt"
+	"sort"
 	"time"
 
 	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
 	"dashboard-app/internal/models"
 	"dashboard-app/internal/repository"
 	"dashboard-app/util"
@@ -214,23 +216,29 @@ func (s *AnalyticService) GetSalesTrendData(year string) ([]models.SalesTrendDat
 func (s *AnalyticService) GetStockDistributionData(filter models.AnalyticStatsFilter) ([]models.StockDistributionData, error) {
 	db := config.GetDBConn()
 
-	// Optimized query with proper grouping
-
+	// Optimized query with proper grouping. Only weight still in stock
+	// counts, so a location filter narrows the same figure down.
 	var distributions []models.StockDistResult
 	if err := db.Raw(`
 		SELECT
 			se.id AS stock_entry_id,
-			COALESCE(SUM(si.weight), 0) AS total_weight
-		FROM stock_items si
+			COALESCE(SUM(ss.current_weight), 0) AS total_weight
+		FROM stock_sorts ss
+		INNER JOIN stock_items si ON si.uuid = ss.stock_item_id
 		INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id
-		WHERE si.deleted = false
+		WHERE ss.deleted = false
+		AND si.deleted = false
 		AND se.deleted = false
+		AND ss.is_shrinkage = false
+		AND (? = '' OR ss.location_id = ?)
 		AND si.created_at >= CAST(? AS DATE)
-        AND si.created_at <  CAST(? AS DATE) + INTERVAL '1 day'
+		AND si.created_at <  CAST(? AS DATE) + INTERVAL '1 day'
 		GROUP BY se.id
-		HAVING SUM(si.weight) > 0
+		HAVING SUM(ss.current_weight) > 0
 		ORDER BY se.id
 	`,
+		filter.LocationId,
+		filter.LocationId,
 		filter.StartDate,
 		filter.EndDate,
 	).Scan(&distributions).Error; err != nil {
@@ -258,9 +266,19 @@ func (s *AnalyticService) GetSupplierPerformance(filter models.AnalyticStatsFilt
 	if err := db.Raw(`
 		SELECT
 			u.name,
-			COALESCE(SUM(p.total_amount), 0) AS total
+			COALESCE(SUM(p.total_amount), 0) AS total,
+			COALESCE(MAX(r.weight), 0) AS returned_weight,
+			COALESCE(MAX(r.amount), 0) AS returned_amount
 		FROM purchase p
 		INNER JOIN "user" u ON u.uuid = p.supplier_id
+		LEFT JOIN (
+			SELECT supplier_id, SUM(weight) AS weight, SUM(total_amount) AS amount
+			FROM supplier_returns
+			WHERE deleted = false
+			AND return_date >= CAST(? AS DATE)
+			AND return_date <  CAST(? AS DATE) + INTERVAL '1 day'
+			GROUP BY supplier_id
+		) r ON r.supplier_id = u.uuid
 		WHERE p.deleted = false
 		AND u.status = true
 		AND p.purchase_date >= CAST(? AS DATE)
@@ -271,6 +289,8 @@ func (s *AnalyticService) GetSupplierPerformance(filter models.AnalyticStatsFilt
 	`,
 		filter.StartDate,
 		filter.EndDate,
+		filter.StartDate,
+		filter.EndDate,
 	).Scan(&userData).Error; err != nil {
 		return nil, apperror.NewUnprocessableEntity("failed to fetch supplier performance: ", err)
 	}
@@ -364,22 +384,25 @@ func (s *AnalyticService) GetDateRangeStats(startDate, endDate string) (*models.
 	}, nil
 }
 
-// GetTopPerformingItems - Get top-selling items
+// GetTopPerformingItems - Get top-selling catalog products
 func (s *AnalyticService) GetTopPerformingItems(limit int) ([]models.ItemPerformance, error) {
 	db := config.GetDBConn()
 
 	var items []models.ItemPerformance
 	if err := db.Raw(`
 		SELECT
-			ss.sorted_item_name AS item_name,
+			COALESCE(p.uuid, '') AS product_id,
+			COALESCE(p.code, '') AS product_code,
+			COALESCE(p.name, ss.sorted_item_name) AS item_name,
 			COUNT(DISTINCT i.uuid) AS sales_count,
 			COALESCE(SUM(i.weight), 0) AS total_weight,
 			COALESCE(SUM(i.total_amount), 0) AS total_revenue
 		FROM item_sales i
 		INNER JOIN stock_sorts ss ON ss.uuid = i.stock_sort_id
+		LEFT JOIN products p ON p.uuid = ss.product_id
 		WHERE i.deleted = false
 		AND ss.deleted = false
-		GROUP BY ss.sorted_item_name
+		GROUP BY 1, 2, 3
 		ORDER BY total_revenue DESC
 		LIMIT ?
 	`, limit).Scan(&items).Error; err != nil {
@@ -389,6 +412,48 @@ func (s *AnalyticService) GetTopPerformingItems(limit int) ([]models.ItemPerform
 	return items, nil
 }
 
+// GetProductDistributionData - Remaining stock weight per catalog product
+// =====================================================
+func (s *AnalyticService) GetProductDistributionData(filter models.AnalyticStatsFilter) ([]models.StockDistributionData, error) {
+	db := config.GetDBConn()
+
+	var distributions []models.ProductDistResult
+	if err := db.Raw(`
+		SELECT
+			COALESCE(p.name, ss.sorted_item_name) AS product_name,
+			COALESCE(SUM(ss.current_weight), 0) AS total_weight
+		FROM stock_sorts ss
+		INNER JOIN stock_items si ON si.uuid = ss.stock_item_id
+		INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id
+		LEFT JOIN products p ON p.uuid = ss.product_id
+		WHERE ss.deleted = false
+		AND si.deleted = false
+		AND se.deleted = false
+		AND ss.is_shrinkage = false
+		AND si.created_at >= CAST(? AS DATE)
+        AND si.created_at <  CAST(? AS DATE) + INTERVAL '1 day'
+		GROUP BY 1
+		HAVING SUM(ss.current_weight) > 0
+		ORDER BY total_weight DESC
+	`,
+		filter.StartDate,
+		filter.EndDate,
+	).Scan(&distributions).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch product distribution: ", err)
+	}
+
+	results := make([]models.StockDistributionData, 0, len(distributions))
+	for _, dist := range distributions {
+		results = append(results, models.StockDistributionData{
+			Name:  dist.ProductName,
+			Value: dist.TotalWeight,
+			Color: util.RandomHexColor(),
+		})
+	}
+
+	return results, nil
+}
+
 // GetProfitAnalysis - Calculate profit margins
 func (s *AnalyticService) GetProfitAnalysis() (*models.ProfitAnalysis, error) {
 	db := config.GetDBConn()
@@ -396,7 +461,7 @@ func (s *AnalyticService) GetProfitAnalysis() (*models.ProfitAnalysis, error) {
 	var result models.ProfitAnalysis
 	if err := db.Raw(`
 		WITH costs AS (
-			SELECT COALESCE(SUM(total_payment), 0) AS total_cost
+			SELECT COALESCE(SUM(total_payment + landed_cost), 0) AS total_cost
 			FROM stock_items
 			WHERE deleted = false
 		),
@@ -494,17 +559,18 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 	WITH base_data AS (
 		SELECT
 			sup.name              AS supplier_name,
-			ss.sorted_item_name   AS item_name,
+			COALESCE(prod.uuid, '') AS product_id,
+			COALESCE(prod.name, ss.sorted_item_name) AS item_name,
 			fa.weight             AS qty,
 			it.price_per_kilogram AS price,
 			cust.name             AS customer_name,
 			f.name                AS fiber_name
 		FROM sales s
 				 JOIN "user" cust ON cust.uuid = s.customer_id
-				 JOIN fibers f ON f.uuid = ANY(string_to_array(s.fiber_list, ','))
 				 JOIN fiber_allocations fa
-					  ON fa.fiber_id = f.uuid
+					  ON fa.sale_id = s.uuid
 					 AND fa.deleted = false
+				 JOIN fibers f ON f.uuid = fa.fiber_id
 				 LEFT JOIN item_sales it
 						ON it.sale_id = s.uuid
 					   AND it.stock_sort_id = fa.stock_sort_id
@@ -514,9 +580,8 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 				 JOIN stock_entries se ON se.uuid = si.stock_entry_id
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
+				 LEFT JOIN products prod ON prod.uuid = ss.product_id
 		WHERE s.deleted = false
-		  AND s.fiber_list IS NOT NULL
-		  AND s.fiber_list <> ''
 		  AND s.purchase_date >= CAST(? AS DATE)
           AND s.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 
@@ -524,7 +589,8 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 
 		SELECT
 			sup.name              AS supplier_name,
-			ss.sorted_item_name   AS item_name,
+			COALESCE(prod.uuid, '') AS product_id,
+			COALESCE(prod.name, ss.sorted_item_name) AS item_name,
 			it.weight             AS qty,
 			it.price_per_kilogram AS price,
 			cust.name             AS customer_name,
@@ -539,21 +605,26 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 				 JOIN stock_entries se ON se.uuid = si.stock_entry_id
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
+				 LEFT JOIN products prod ON prod.uuid = ss.product_id
 		WHERE s.deleted = false
-		  AND (s.fiber_list IS NULL OR s.fiber_list = '')
+		  AND NOT EXISTS (
+			  SELECT 1 FROM fiber_allocations fa
+			  WHERE fa.sale_id = s.uuid AND fa.deleted = false
+		  )
 		  AND s.purchase_date >= CAST(? AS DATE)
           AND s.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	)
 
 	SELECT
 		supplier_name,
+		product_id,
 		item_name,
 		qty,
 		price,
 		customer_name,
 		fiber_name
 	FROM base_data
-	ORDER BY fiber_name, supplier_name, item_name
+	ORDER BY fiber_name, supplier_name, item_name, product_id
 	LIMIT ? OFFSET ?;
 	`
 
@@ -573,11 +644,10 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 		SELECT 1
 		FROM sales s
 				 JOIN "user" cust ON cust.uuid = s.customer_id
-				 JOIN fibers f
-					  ON f.uuid = ANY(string_to_array(s.fiber_list, ','))
 				 JOIN fiber_allocations fa
-					  ON fa.fiber_id = f.uuid
+					  ON fa.sale_id = s.uuid
 						  AND fa.deleted = false
+				 JOIN fibers f ON f.uuid = fa.fiber_id
 				 LEFT JOIN item_sales it
 						   ON it.sale_id = s.uuid
 							   AND it.stock_sort_id = fa.stock_sort_id
@@ -588,8 +658,6 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
 		WHERE s.deleted = false
-		  AND s.fiber_list IS NOT NULL
-		  AND s.fiber_list <> ''
 		  AND s.created_at >= CAST(? AS DATE)
           AND s.created_at <  CAST(? AS DATE) + INTERVAL '1 day'
 	
@@ -607,7 +675,10 @@ func (s *AnalyticService) GetSalesSupplierDetail(
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
 		WHERE s.deleted = false
-		  AND (s.fiber_list IS NULL OR s.fiber_list = '')
+		  AND NOT EXISTS (
+			  SELECT 1 FROM fiber_allocations fa
+			  WHERE fa.sale_id = s.uuid AND fa.deleted = false
+		  )
 		  AND s.created_at >= CAST(? AS DATE)
           AND s.created_at <  CAST(? AS DATE) + INTERVAL '1 day'
 	)
@@ -661,26 +732,28 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 			sup.name              AS supplier_name,
 			p.purchase_date       AS purchase_date,
 			si.weight             AS stock_weight,
-       		si.item_name          AS item_name,
+			COALESCE(ip.uuid, '') AS product_id,
+			COALESCE(ip.name, si.item_name) AS item_name,
         	ss.weight             AS stock_sort_weight,
         	ss.current_weight     AS current_weight,
-        	ss.sorted_item_name   AS item_sort_name,
+			COALESCE(sp.uuid, '') AS sort_product_id,
+			COALESCE(sp.name, ss.sorted_item_name) AS item_sort_name,
 			fa.weight             AS qty,
 			si.price_per_kilogram AS price,
 			cust.name             AS customer_name,
 			f.name                AS fiber_name
 		FROM sales s
 				 JOIN "user" cust ON cust.uuid = s.customer_id
-				 JOIN fibers f ON f.uuid = ANY(string_to_array(s.fiber_list, ','))
-				 JOIN fiber_allocations fa ON fa.fiber_id = f.uuid AND fa.deleted = false
+				 JOIN fiber_allocations fa ON fa.sale_id = s.uuid AND fa.deleted = false
+				 JOIN fibers f ON f.uuid = fa.fiber_id
 				 JOIN stock_sorts ss ON ss.uuid = fa.stock_sort_id
 				 JOIN stock_items si ON si.uuid = ss.stock_item_id
 				 JOIN stock_entries se ON se.uuid = si.stock_entry_id
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
+				 LEFT JOIN products ip ON ip.uuid = si.product_id
+				 LEFT JOIN products sp ON sp.uuid = ss.product_id
 		WHERE s.deleted = false
-		  AND s.fiber_list IS NOT NULL
-		  AND s.fiber_list <> ''
 		  AND p.purchase_date >= CAST(? AS DATE)
           AND p.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	
@@ -690,10 +763,12 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 			sup.name              AS supplier_name,
 			p.purchase_date       AS purchase_date,
 			si.weight             AS stock_weight,
-        	si.item_name          AS item_name,
+			COALESCE(ip.uuid, '') AS product_id,
+			COALESCE(ip.name, si.item_name) AS item_name,
         	ss.weight             AS stock_sort_weight,
         	ss.current_weight     AS current_weight,
-        	ss.sorted_item_name   AS item_sort_name,
+			COALESCE(sp.uuid, '') AS sort_product_id,
+			COALESCE(sp.name, ss.sorted_item_name) AS item_sort_name,
 			it.weight             AS qty,
 			si.price_per_kilogram AS price,
 			cust.name             AS customer_name,
@@ -706,15 +781,20 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 				 JOIN stock_entries se ON se.uuid = si.stock_entry_id
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
+				 LEFT JOIN products ip ON ip.uuid = si.product_id
+				 LEFT JOIN products sp ON sp.uuid = ss.product_id
 		WHERE s.deleted = false
-		  AND (s.fiber_list IS NULL OR s.fiber_list = '')
+		  AND NOT EXISTS (
+			  SELECT 1 FROM fiber_allocations fa
+			  WHERE fa.sale_id = s.uuid AND fa.deleted = false
+		  )
 		   AND p.purchase_date >= CAST(? AS DATE)
       	   AND p.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	),
 	
 		 numbered AS (
 			 SELECT *,
-					ROW_NUMBER() OVER (PARTITION BY supplier_name ORDER BY item_name) AS rn
+					ROW_NUMBER() OVER (PARTITION BY supplier_name ORDER BY item_name, product_id) AS rn
 			 FROM base_data
 		 )
 	
@@ -722,8 +802,10 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		supplier_name,
 		purchase_date,
 		stock_weight,
+		product_id,
 		item_name,
 		stock_sort_weight,
+		sort_product_id,
     	item_sort_name,
 		qty,
 		price,
@@ -731,7 +813,7 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		fiber_name,
 		current_weight
 	FROM numbered
-	ORDER BY supplier_name, item_name
+	ORDER BY supplier_name, item_name, product_id
 	LIMIT ? OFFSET ?;
 	`
 
@@ -754,11 +836,10 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		SELECT 1
 		FROM sales s
 				 JOIN "user" cust ON cust.uuid = s.customer_id
-				 JOIN fibers f
-					  ON f.uuid = ANY(string_to_array(s.fiber_list, ','))
 				 JOIN fiber_allocations fa
-					  ON fa.fiber_id = f.uuid
+					  ON fa.sale_id = s.uuid
 						  AND fa.deleted = false
+				 JOIN fibers f ON f.uuid = fa.fiber_id
 				 LEFT JOIN item_sales it
 						   ON it.sale_id = s.uuid
 							   AND it.stock_sort_id = fa.stock_sort_id
@@ -769,8 +850,6 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
 		WHERE s.deleted = false
-		  AND s.fiber_list IS NOT NULL
-		  AND s.fiber_list <> ''
 		  AND p.purchase_date >= CAST(? AS DATE)
 		  AND p.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	
@@ -788,7 +867,10 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 				 JOIN purchase p ON p.stock_id = se.uuid
 				 JOIN "user" sup ON sup.uuid = p.supplier_id
 		WHERE s.deleted = false
-		  AND (s.fiber_list IS NULL OR s.fiber_list = '')
+		  AND NOT EXISTS (
+			  SELECT 1 FROM fiber_allocations fa
+			  WHERE fa.sale_id = s.uuid AND fa.deleted = false
+		  )
 		  AND p.purchase_date >= CAST(? AS DATE)
       	  AND p.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	)
@@ -814,10 +896,12 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		sup.name              AS supplier_name,
 		p.purchase_date       AS purchase_date,
 		si.weight             AS stock_weight,
-		si.item_name          AS item_name,
+		COALESCE(ip.uuid, '') AS product_id,
+		COALESCE(ip.name, si.item_name) AS item_name,
 		ss.weight             AS stock_sort_weight,
 		ss.current_weight     AS current_weight,
-		ss.sorted_item_name   AS item_sort_name,
+		COALESCE(sp.uuid, '') AS sort_product_id,
+		COALESCE(sp.name, ss.sorted_item_name) AS item_sort_name,
 		0                     AS qty,
 		si.price_per_kilogram AS price,
 		'-'                   AS customer_name,
@@ -827,11 +911,13 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		JOIN stock_entries se ON se.uuid = si.stock_entry_id
 		JOIN purchase p ON p.stock_id = se.uuid
 		JOIN "user" sup ON sup.uuid = p.supplier_id
+		LEFT JOIN products ip ON ip.uuid = si.product_id
+		LEFT JOIN products sp ON sp.uuid = ss.product_id
 	WHERE ss.weight = ss.current_weight AND ss.is_shrinkage = false
 	  AND p.purchase_date >= CAST(? AS DATE)
 	  AND p.purchase_date <  CAST(? AS DATE) + INTERVAL '1 day'
 	  AND ss.deleted = false
-	ORDER BY sup.name, si.item_name;
+	ORDER BY sup.name, item_name, product_id;
 	`
 
 	var unsoldResult []models.SalesSupplierDetailWithPurchaseDataResponse
@@ -851,8 +937,10 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 			SupplierName:    v.SupplierName,
 			PurchaseDate:    v.PurchaseDate,
 			StockWeight:     v.StockWeight,
+			ProductId:       v.ProductId,
 			ItemName:        v.ItemName,
 			StockSortWeight: v.StockSortWeight,
+			SortProductId:   v.SortProductId,
 			ItemSortName:    v.ItemSortName,
 			Quantity:        v.Quantity,
 			Price:           v.Price,
@@ -870,8 +958,10 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 			SupplierName:    v.SupplierName,
 			PurchaseDate:    v.PurchaseDate,
 			StockWeight:     v.StockWeight,
+			ProductId:       v.ProductId,
 			ItemName:        v.ItemName,
 			StockSortWeight: v.StockSortWeight,
+			SortProductId:   v.SortProductId,
 			ItemSortName:    v.ItemSortName,
 			Quantity:        v.Quantity,
 			Price:           v.Price,
@@ -890,3 +980,86 @@ func (s *AnalyticService) SalesSupplierDetailWithPurchaseData(
 		Data:   results,
 	}, nil
 }
+
+// GetMonthlyExpenseBreakdown - Operational Expenses per Month and Category
+// =====================================================
+func (s *AnalyticService) GetMonthlyExpenseBreakdown(filter models.ExpenseBreakdownFilter) (*models.ExpenseBreakdownResponse, error) {
+	db := config.GetDBConn()
+
+	// Operational expenses are the EXPENSE payments booked on staff and other
+	// accounts; payments to buyers and suppliers settle trade balances
+	costCenterClause := ""
+	args := []interface{}{constants.Expense, constants.BuyerRole, constants.SupplierRole, filter.Year}
+	if filter.CostCenterId != "" {
+		costCenterClause = "AND p.cost_center_id = ?"
+		args = append(args, filter.CostCenterId)
+	}
+
+	var rows []models.ExpenseBreakdownRow
+	if err := db.Raw(`
+		SELECT
+			EXTRACT(MONTH FROM p.created_at)::int AS month,
+			COALESCE(ec.uuid, '') AS category_id,
+			COALESCE(ec.name, '') AS category_name,
+			COALESCE(SUM(p.total), 0) AS total
+		FROM payment p
+		INNER JOIN "user" u ON u.uuid = p.user_id
+		LEFT JOIN expense_categories ec ON ec.uuid = p.expense_category_id
+		WHERE p.deleted = false
+		  AND p.type = ?
+		  AND COALESCE(p.purchase_id, '') = ''
+		  AND COALESCE(p.sales_id, '') = ''
+		  AND u.role NOT IN (?, ?)
+		  AND EXTRACT(YEAR FROM p.created_at) = ?
+		  `+costCenterClause+`
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 4 DESC
+	`, args...).Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch expense breakdown: ", err)
+	}
+
+	monthNames := []string{
+		"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+		"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+	}
+	result := models.ExpenseBreakdownResponse{
+		Year:       filter.Year,
+		Categories: make([]models.ExpenseCategoryTotal, 0),
+		Months:     make([]models.MonthlyExpense, 12),
+	}
+	for i := range result.Months {
+		result.Months[i] = models.MonthlyExpense{
+			Month:      monthNames[i],
+			Categories: make([]models.ExpenseCategoryTotal, 0),
+		}
+	}
+
+	yearTotals := make(map[string]int)
+	for _, r := range rows {
+		if r.Month < 1 || r.Month > 12 {
+			continue
+		}
+		if r.CategoryId == "" {
+			r.CategoryName = "Tanpa Kategori"
+		}
+		line := models.ExpenseCategoryTotal{CategoryId: r.CategoryId, CategoryName: r.CategoryName, Total: r.Total}
+
+		month := &result.Months[r.Month-1]
+		month.Total += r.Total
+		month.Categories = append(month.Categories, line)
+
+		if i, ok := yearTotals[r.CategoryId]; ok {
+			result.Categories[i].Total += r.Total
+		} else {
+			yearTotals[r.CategoryId] = len(result.Categories)
+			result.Categories = append(result.Categories, line)
+		}
+		result.Total += r.Total
+	}
+
+	sort.SliceStable(result.Categories, func(i, j int) bool {
+		return result.Categories[i].Total > result.Categories[j].Total
+	})
+
+	return &result, nil
+}
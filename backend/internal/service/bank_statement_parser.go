@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"dashboard-app/internal/constants"
+)
+
+// statementRow is one transaction read from a statement file, before it is
+// stored. Rows with errors block the whole import.
+type statementRow struct {
+	row         int
+	date        time.Time
+	direction   string
+	amount      int
+	description string
+	reference   string
+	errors      []string
+}
+
+var statementDateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"02-01-2006",
+	"02/01/06",
+	"2006/01/02",
+	"02 Jan 2006",
+}
+
+// statementColumns maps the header names banks use onto our columns.
+var statementColumns = map[string][]string{
+	"date":        {"date", "tanggal", "tgl", "transaction_date", "tanggal transaksi"},
+	"description": {"description", "keterangan", "uraian", "remark", "remarks"},
+	"reference":   {"reference", "referensi", "ref", "no_ref", "no. ref"},
+	"amount":      {"amount", "jumlah", "nominal", "mutasi"},
+	"debit":       {"debit", "debet", "db"},
+	"credit":      {"credit", "kredit", "cr"},
+}
+
+// parseCSVStatement reads a CSV export with a header row. Amounts come either
+// as one signed amount column (positive is money in) or as separate debit
+// and credit columns.
+func parseCSVStatement(records [][]string) ([]statementRow, error) {
+	if len(records) < 2 {
+		return nil, fmt.Errorf("file must contain a header row and at least one transaction")
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+		for column, aliases := range statementColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, fmt.Errorf("header row must contain a date column")
+	}
+	_, hasAmount := columns["amount"]
+	_, hasDebit := columns["debit"]
+	_, hasCredit := columns["credit"]
+	if !hasAmount && !(hasDebit && hasCredit) {
+		return nil, fmt.Errorf("header row must contain an amount column or debit and credit columns")
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]statementRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := statementRow{
+			row:         i + 2,
+			description: cell(record, "description"),
+			reference:   cell(record, "reference"),
+		}
+
+		date, ok := parseStatementDate(cell(record, "date"))
+		if !ok {
+			row.errors = append(row.errors, fmt.Sprintf("date %q is not a valid date", cell(record, "date")))
+		}
+		row.date = date
+
+		var amount int
+		if hasAmount {
+			value := cell(record, "amount")
+			amount, ok = parseStatementAmount(value)
+			if !ok {
+				row.errors = append(row.errors, fmt.Sprintf("amount %q is not a number", value))
+			}
+		} else {
+			debit, credit := cell(record, "debit"), cell(record, "credit")
+			debitAmount, debitOk := parseStatementAmount(debit)
+			creditAmount, creditOk := parseStatementAmount(credit)
+			switch {
+			case debit != "" && !debitOk:
+				row.errors = append(row.errors, fmt.Sprintf("debit %q is not a number", debit))
+			case credit != "" && !creditOk:
+				row.errors = append(row.errors, fmt.Sprintf("credit %q is not a number", credit))
+			default:
+				amount = creditAmount - debitAmount
+			}
+		}
+
+		row.direction = constants.StatementCredit
+		if amount < 0 {
+			row.direction, amount = constants.StatementDebit, -amount
+		}
+		row.amount = amount
+		if amount == 0 && len(row.errors) == 0 {
+			row.errors = append(row.errors, "amount must not be zero")
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// mt940Transaction matches the :61: statement line, e.g.
+// 2401150115C1500000,00NTRFSELL12//BANKREF
+var mt940Transaction = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)[A-Z]?(\d+,\d*)([NSF][A-Z0-9]{3})([^/]*)(?://(.*))?$`)
+
+// parseMT940Statement reads the :61: transaction lines of a SWIFT MT940
+// file. The :86: information that follows a transaction becomes its
+// description. Reversals (RC, RD) flip the direction.
+func parseMT940Statement(content []byte) ([]statementRow, error) {
+	var (
+		rows    []statementRow
+		current *statementRow
+		tag     string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || line == "-" || strings.HasPrefix(line, "-}") {
+			continue
+		}
+
+		value := line
+		if strings.HasPrefix(line, ":") {
+			if end := strings.Index(line[1:], ":"); end > 0 {
+				tag, value = line[1:end+1], line[end+2:]
+			}
+		} else if tag == "86" && current != nil {
+			// Continuation of the information field
+			current.description = strings.TrimSpace(current.description + " " + strings.TrimSpace(line))
+			continue
+		} else {
+			continue
+		}
+
+		switch tag {
+		case "61":
+			if current != nil {
+				rows = append(rows, *current)
+			}
+			current = parseMT940Line(lineNo, value)
+		case "86":
+			if current != nil {
+				current.description = strings.TrimSpace(value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mt940: %v", err)
+	}
+	if current != nil {
+		rows = append(rows, *current)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("file contains no :61: transaction lines")
+	}
+
+	return rows, nil
+}
+
+func parseMT940Line(lineNo int, value string) *statementRow {
+	row := &statementRow{row: lineNo}
+
+	match := mt940Transaction.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		row.errors = append(row.errors, fmt.Sprintf("transaction line %q is not valid MT940", value))
+		return row
+	}
+
+	date, err := time.ParseInLocation("060102", match[1], constants.JakartaTz)
+	if err != nil {
+		row.errors = append(row.errors, fmt.Sprintf("value date %q is not a valid date", match[1]))
+	}
+	row.date = date
+
+	switch match[3] {
+	case "C", "RD":
+		row.direction = constants.StatementCredit
+	default:
+		row.direction = constants.StatementDebit
+	}
+
+	// MT940 always uses a comma for decimals and no thousands separator
+	amount, err := strconv.ParseFloat(strings.Replace(match[4], ",", ".", 1), 64)
+	if err != nil || amount == 0 {
+		row.errors = append(row.errors, fmt.Sprintf("amount %q is not valid", match[4]))
+	}
+	row.amount = int(math.Round(amount))
+
+	row.reference = strings.TrimSpace(match[6])
+	if row.reference == "NONREF" {
+		row.reference = ""
+	}
+	if bankRef := strings.TrimSpace(match[7]); bankRef != "" {
+		row.reference = strings.TrimSpace(row.reference + " " + bankRef)
+	}
+
+	return row
+}
+
+func parseStatementDate(value string) (time.Time, bool) {
+	for _, layout := range statementDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, constants.JakartaTz); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseStatementAmount reads amounts in both Indonesian (1.500.000,00) and
+// English (1,500,000.00) notation and rounds to whole rupiah. An empty value
+// is zero; parentheses or a leading minus make it negative.
+func parseStatementAmount(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "Rp"), "IDR")
+	value = strings.ReplaceAll(value, " ", "")
+	if value == "" {
+		return 0, true
+	}
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative, value = true, value[1:len(value)-1]
+	}
+	if strings.HasPrefix(value, "-") {
+		negative, value = true, value[1:]
+	}
+
+	lastDot, lastComma := strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		// Whichever separator comes last marks the decimals
+		if lastComma > lastDot {
+			value = strings.ReplaceAll(value, ".", "")
+			value = strings.Replace(value, ",", ".", 1)
+		} else {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+	case lastComma >= 0:
+		if strings.Count(value, ",") == 1 && len(value)-lastComma-1 != 3 {
+			value = strings.Replace(value, ",", ".", 1)
+		} else {
+			value = strings.ReplaceAll(value, ",", "")
+		}
+	case lastDot >= 0:
+		if strings.Count(value, ".") > 1 || len(value)-lastDot-1 == 3 {
+			value = strings.ReplaceAll(value, ".", "")
+		}
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return int(math.Round(amount)), true
+}
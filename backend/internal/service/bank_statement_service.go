@@ -0,0 +1,853 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+// maxStatementLines caps how many transactions one statement file may contain.
+const maxStatementLines = 5000
+
+// A line is suggested only when its best candidate scores at least this
+// much and beats the runner-up: a reference hit, or the exact open balance
+// paid within a week.
+const suggestMinScore = 40
+
+var (
+	saleReferencePattern  = regexp.MustCompile(`(?i)SELL\s*-?(\d+)`)
+	stockReferencePattern = regexp.MustCompile(`(?i)STOCK\s*-?(\d+)`)
+)
+
+type BankStatementService struct {
+	paymentRepository repository.PaymentRepository
+}
+
+func NewBankStatementService(paymentRepository repository.PaymentRepository) repository.BankStatementRepository {
+	return &BankStatementService{paymentRepository: paymentRepository}
+}
+
+// ImportBankStatement - Read a CSV or MT940 Statement and Suggest Matches
+// =====================================================
+func (s *BankStatementService) ImportBankStatement(userId, cashAccountId, filename string, file io.Reader) (*models.BankStatementImportResponse, error) {
+	db := config.GetDBConn()
+
+	account, err := activeCashAccount(db, cashAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType != constants.CashAccountBank {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("%s is not a bank account", account.Name))
+	}
+
+	var (
+		format string
+		rows   []statementRow
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		format = constants.StatementFormatCSV
+		var records [][]string
+		if records, err = readSpreadsheetRows(filename, file); err != nil {
+			return nil, err
+		}
+		rows, err = parseCSVStatement(records)
+	case ".sta", ".mt940", ".940", ".txt":
+		format = constants.StatementFormatMT940
+		var content []byte
+		if content, err = io.ReadAll(file); err != nil {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("failed to read file: %v", err))
+		}
+		rows, err = parseMT940Statement(content)
+	default:
+		return nil, apperror.NewBadRequest("unsupported file type: must be .csv or an MT940 file (.sta, .mt940, .940, .txt)")
+	}
+	if err != nil {
+		return nil, apperror.NewBadRequest(err.Error())
+	}
+	if len(rows) > maxStatementLines {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("file contains more than %d transactions", maxStatementLines))
+	}
+
+	resp := &models.BankStatementImportResponse{
+		Format:    format,
+		TotalRows: len(rows),
+		Rows:      make([]models.BankStatementImportRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			resp.InvalidRows++
+		}
+		resp.Rows = append(resp.Rows, models.BankStatementImportRow{Row: row.row, Errors: row.errors})
+	}
+	if resp.InvalidRows > 0 {
+		return resp, nil
+	}
+
+	// Statements overlap; transactions already imported for this account are
+	// skipped rather than doubled
+	start, end := rows[0].date, rows[0].date
+	for _, row := range rows {
+		start = minTime(start, row.date)
+		end = maxTime(end, row.date)
+	}
+	existing, err := s.existingLineKeys(db, account.Uuid, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	newRows := make([]statementRow, 0, len(rows))
+	for _, row := range rows {
+		if existing[statementLineKey(row.date, row.direction, row.amount, row.reference, row.description)] {
+			resp.DuplicateRows++
+			continue
+		}
+		newRows = append(newRows, row)
+	}
+	if len(newRows) == 0 {
+		return nil, apperror.NewConflict("every transaction in the file has already been imported")
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		statement := models.BankStatement{
+			Uuid:          uuid.New().String(),
+			CashAccountId: account.Uuid,
+			FileName:      filepath.Base(filename),
+			Format:        format,
+			StartDate:     &start,
+			EndDate:       &end,
+			LineCount:     len(newRows),
+			ImportedBy:    userId,
+			Deleted:       false,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := tx.Create(&statement).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create bank statement: ", err)
+		}
+
+		lines := make([]models.BankStatementLine, 0, len(newRows))
+		for i, row := range newRows {
+			lines = append(lines, models.BankStatementLine{
+				Uuid:            uuid.New().String(),
+				StatementId:     statement.Uuid,
+				LineNo:          i + 1,
+				TransactionDate: row.date,
+				Direction:       row.direction,
+				Amount:          row.amount,
+				Description:     row.description,
+				Reference:       row.reference,
+				Status:          constants.StatementUnmatched,
+				Deleted:         false,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			})
+		}
+		if err := tx.CreateInBatches(&lines, 500).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create bank statement lines: ", err)
+		}
+
+		suggested, err := s.suggestMatches(tx, lines)
+		if err != nil {
+			return err
+		}
+
+		resp.StatementId = statement.Uuid
+		resp.CreatedCount = len(lines)
+		resp.SuggestedCount = suggested
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Success = true
+	return resp, nil
+}
+
+// GetAllBankStatements - Imported Statements with Reconciliation Progress
+// =====================================================
+func (s *BankStatementService) GetAllBankStatements(filter models.BankStatementFilter) (*models.BankStatementPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Table("bank_statements AS bs").Where("bs.deleted = false")
+	if filter.CashAccountId != "" {
+		query = query.Where("bs.cash_account_id = ?", filter.CashAccountId)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count bank statements: ", err)
+	}
+
+	var rows []models.BankStatementResponse
+	if err := s.statementQuery(query).
+		Order("bs.created_at DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statements: ", err)
+	}
+
+	return &models.BankStatementPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   rows,
+	}, nil
+}
+
+// GetBankStatementById - One Statement
+// =====================================================
+func (s *BankStatementService) GetBankStatementById(statementId string) (*models.BankStatementResponse, error) {
+	db := config.GetDBConn()
+
+	if _, err := findBankStatement(db, statementId); err != nil {
+		return nil, err
+	}
+
+	return s.getStatement(db, statementId)
+}
+
+// DeleteBankStatement - Remove an Import Without Confirmed Matches
+// =====================================================
+func (s *BankStatementService) DeleteBankStatement(statementId string) error {
+	db := config.GetDBConn()
+
+	statement, err := findBankStatement(db, statementId)
+	if err != nil {
+		return err
+	}
+
+	var matched int64
+	if err = db.Model(&models.BankStatementLine{}).
+		Where("statement_id = ? AND status = ? AND deleted = false", statement.Uuid, constants.StatementMatched).
+		Count(&matched).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check bank statement lines: ", err)
+	}
+	if matched > 0 {
+		return apperror.NewConflict(fmt.Sprintf(
+			"statement %s has %d confirmed match(es); delete those payments first", statement.FileName, matched))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"deleted": true, "updated_at": time.Now()}
+		if err := tx.Model(&models.BankStatementLine{}).
+			Where("statement_id = ?", statement.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete bank statement lines: ", err)
+		}
+		if err := tx.Model(&models.BankStatement{}).
+			Where("uuid = ?", statement.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete bank statement: ", err)
+		}
+		return nil
+	})
+}
+
+// MatchBankStatement - Look Again for Matches of Unmatched Lines
+// =====================================================
+func (s *BankStatementService) MatchBankStatement(statementId string) (*models.BankStatementResponse, error) {
+	db := config.GetDBConn()
+
+	statement, err := findBankStatement(db, statementId)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []models.BankStatementLine
+	if err = db.Where("statement_id = ? AND status = ? AND deleted = false", statement.Uuid, constants.StatementUnmatched).
+		Order("line_no ASC").
+		Find(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement lines: ", err)
+	}
+
+	if err = db.Transaction(func(tx *gorm.DB) error {
+		_, err := s.suggestMatches(tx, lines)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.getStatement(db, statement.Uuid)
+}
+
+// GetAllStatementLines - Review Queue
+// =====================================================
+func (s *BankStatementService) GetAllStatementLines(filter models.BankStatementLineFilter) (*models.BankStatementLinePaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Table("bank_statement_lines AS bl").
+		Joins("INNER JOIN bank_statements bs ON bs.uuid = bl.statement_id AND bs.deleted = false").
+		Where("bl.deleted = false")
+	if filter.StatementId != "" {
+		query = query.Where("bl.statement_id = ?", filter.StatementId)
+	}
+	if filter.Status != "" {
+		query = query.Where("bl.status = ?", filter.Status)
+	}
+	if filter.Direction != "" {
+		query = query.Where("bl.direction = ?", filter.Direction)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count bank statement lines: ", err)
+	}
+
+	var rows []models.BankStatementLineResponse
+	if err := s.lineQuery(query).
+		Order("bl.transaction_date ASC, bl.line_no ASC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement lines: ", err)
+	}
+	for i := range rows {
+		rows[i].MatchCode = matchCode(rows[i].MatchType, rows[i].MatchNo)
+	}
+
+	return &models.BankStatementLinePaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   rows,
+	}, nil
+}
+
+// GetLineCandidates - Sales or Purchases a Line Could Pay
+// =====================================================
+func (s *BankStatementService) GetLineCandidates(lineId string) ([]models.MatchCandidate, error) {
+	db := config.GetDBConn()
+
+	line, err := findStatementLine(db, lineId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.matchCandidates(db, *line)
+}
+
+// ConfirmLineMatch - Record the Payment of a Matched Line
+// =====================================================
+func (s *BankStatementService) ConfirmLineMatch(userId, lineId string, request models.ConfirmMatchRequest) (*models.BankStatementLineResponse, error) {
+	db := config.GetDBConn()
+
+	line, err := findStatementLine(db, lineId)
+	if err != nil {
+		return nil, err
+	}
+	if line.Status != constants.StatementUnmatched && line.Status != constants.StatementSuggested {
+		return nil, apperror.NewConflict(fmt.Sprintf("line is already %s", strings.ToLower(line.Status)))
+	}
+
+	matchType, matchId := line.MatchType, line.MatchId
+	if request.MatchId != "" || request.MatchType != "" {
+		if request.MatchId == "" || request.MatchType == "" {
+			return nil, apperror.NewBadRequest("match_type and match_id must be given together")
+		}
+		matchType, matchId = request.MatchType, request.MatchId
+	}
+	if matchId == "" {
+		return nil, apperror.NewBadRequest("line has no suggested match; pick a sale or purchase")
+	}
+	if (line.Direction == constants.StatementCredit) != (matchType == constants.MatchSale) {
+		return nil, apperror.NewBadRequest("money in can only pay a sale and money out only a purchase")
+	}
+
+	statement, err := findBankStatement(db, line.StatementId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the target before claiming the line so bad picks fail cleanly
+	var pay func(tx *gorm.DB) (*models.Payment, error)
+	switch matchType {
+	case constants.MatchSale:
+		var sale models.Sale
+		if err = db.Where("uuid = ? AND deleted = false", matchId).First(&sale).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound("sale not found")
+			}
+			return nil, apperror.NewUnprocessableEntity("failed to fetch sale: ", err)
+		}
+		if line.Amount > sale.RemainingAmount {
+			return nil, apperror.NewBadRequest(fmt.Sprintf(
+				"line amount %d exceeds the open balance %d of SELL%d", line.Amount, sale.RemainingAmount, sale.ID))
+		}
+		pay = func(tx *gorm.DB) (*models.Payment, error) {
+			return s.paymentRepository.CreateSalePaymentTx(tx, models.CreatePaymentSaleRequest{
+				SalesId:       sale.Uuid,
+				SalesDate:     line.TransactionDate,
+				SalesCode:     fmt.Sprintf("SELL%d", sale.ID),
+				Total:         line.Amount,
+				CashAccountId: statement.CashAccountId,
+				PaymentMethod: constants.PaymentMethodTransfer,
+			})
+		}
+	default:
+		var purchase struct {
+			Uuid            string
+			RemainingAmount int
+			StockEntryId    int
+		}
+		if err = db.Table("purchase AS p").
+			Select("p.uuid, p.remaining_amount, COALESCE(se.id, 0) AS stock_entry_id").
+			Joins("LEFT JOIN stock_entries se ON se.uuid = p.stock_id AND se.deleted = false").
+			Where("p.uuid = ? AND p.deleted = false", matchId).
+			Limit(1).
+			Scan(&purchase).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+		}
+		if purchase.Uuid == "" {
+			return nil, apperror.NewNotFound("purchase not found")
+		}
+		stockCode := fmt.Sprintf("STOCK%d", purchase.StockEntryId)
+		if line.Amount > purchase.RemainingAmount {
+			return nil, apperror.NewBadRequest(fmt.Sprintf(
+				"line amount %d exceeds the open balance %d of %s", line.Amount, purchase.RemainingAmount, stockCode))
+		}
+		pay = func(tx *gorm.DB) (*models.Payment, error) {
+			return s.paymentRepository.CreatePurchasePaymentTx(tx, models.CreatePaymentPurchaseRequest{
+				PurchaseId:    purchase.Uuid,
+				PurchaseDate:  line.TransactionDate,
+				StockCode:     stockCode,
+				Total:         line.Amount,
+				CashAccountId: statement.CashAccountId,
+				PaymentMethod: constants.PaymentMethodTransfer,
+			})
+		}
+	}
+
+	// Claim the line first so a double click cannot pay twice; a failed
+	// payment releases the claim with the rest of the transaction
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.BankStatementLine{}).
+			Where("uuid = ? AND status IN ?", line.Uuid, []string{constants.StatementUnmatched, constants.StatementSuggested}).
+			Updates(map[string]interface{}{
+				"status":       constants.StatementMatched,
+				"match_type":   matchType,
+				"match_id":     matchId,
+				"confirmed_by": userId,
+				"confirmed_at": now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to confirm match: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewConflict("line was confirmed or ignored in the meantime")
+		}
+
+		payment, err := pay(tx)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Model(&models.BankStatementLine{}).
+			Where("uuid = ?", line.Uuid).
+			Update("payment_id", payment.Uuid).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to link payment: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getLine(db, line.Uuid)
+}
+
+// IgnoreLine - Leave a Line Out of Reconciliation
+// =====================================================
+func (s *BankStatementService) IgnoreLine(lineId string) (*models.BankStatementLineResponse, error) {
+	return s.setLineStatus(lineId, constants.StatementIgnored,
+		[]string{constants.StatementUnmatched, constants.StatementSuggested})
+}
+
+// ResetLine - Reject a Suggestion or Take Back an Ignore
+// =====================================================
+func (s *BankStatementService) ResetLine(lineId string) (*models.BankStatementLineResponse, error) {
+	return s.setLineStatus(lineId, constants.StatementUnmatched,
+		[]string{constants.StatementSuggested, constants.StatementIgnored})
+}
+
+func (s *BankStatementService) setLineStatus(lineId, status string, from []string) (*models.BankStatementLineResponse, error) {
+	db := config.GetDBConn()
+
+	line, err := findStatementLine(db, lineId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := db.Model(&models.BankStatementLine{}).
+		Where("uuid = ? AND status IN ?", line.Uuid, from).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"match_type":  "",
+			"match_id":    "",
+			"match_score": 0,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to update bank statement line: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.NewConflict(fmt.Sprintf("a %s line cannot be set to %s",
+			strings.ToLower(line.Status), strings.ToLower(status)))
+	}
+
+	return s.getLine(db, line.Uuid)
+}
+
+// suggestMatches marks lines whose best candidate is clear enough as
+// SUGGESTED and returns how many were.
+func (s *BankStatementService) suggestMatches(tx *gorm.DB, lines []models.BankStatementLine) (int, error) {
+	suggested := 0
+	for _, line := range lines {
+		candidates, err := s.matchCandidates(tx, line)
+		if err != nil {
+			return 0, err
+		}
+		if len(candidates) == 0 || candidates[0].Score < suggestMinScore {
+			continue
+		}
+		if len(candidates) > 1 && candidates[1].Score == candidates[0].Score {
+			continue
+		}
+
+		best := candidates[0]
+		if err = tx.Model(&models.BankStatementLine{}).
+			Where("uuid = ?", line.Uuid).
+			Updates(map[string]interface{}{
+				"status":      constants.StatementSuggested,
+				"match_type":  best.MatchType,
+				"match_id":    best.MatchId,
+				"match_score": best.Score,
+				"updated_at":  time.Now(),
+			}).Error; err != nil {
+			return 0, apperror.NewUnprocessableEntity("failed to suggest match: ", err)
+		}
+		suggested++
+	}
+
+	return suggested, nil
+}
+
+// matchCandidates scores the open sales (for money in) or purchases (for
+// money out) a line could pay, best first. A candidate is considered when
+// the line mentions its code or pays its open balance exactly; the line may
+// never exceed the open balance.
+func (s *BankStatementService) matchCandidates(db *gorm.DB, line models.BankStatementLine) ([]models.MatchCandidate, error) {
+	text := line.Reference + " " + line.Description
+
+	type candidateRow struct {
+		Uuid            string
+		CodeNo          int
+		Date            time.Time
+		TotalAmount     int
+		RemainingAmount int
+		PartyName       string
+	}
+
+	var (
+		rows      []candidateRow
+		matchType string
+		prefix    string
+		codes     []int
+		query     *gorm.DB
+	)
+	if line.Direction == constants.StatementCredit {
+		matchType, prefix = constants.MatchSale, "SELL"
+		codes = referencedCodes(saleReferencePattern, text)
+		query = db.Table("sales AS s").
+			Select(`s.uuid, s.id AS code_no, s.purchase_date AS date, s.total_amount, s.remaining_amount,
+				COALESCE(u.name, '') AS party_name`).
+			Joins(`LEFT JOIN "user" u ON u.uuid = s.customer_id`).
+			Where("s.deleted = false AND s.remaining_amount >= ?", line.Amount)
+		if len(codes) > 0 {
+			query = query.Where("(s.id IN ? OR s.remaining_amount = ?)", codes, line.Amount)
+		} else {
+			query = query.Where("s.remaining_amount = ?", line.Amount)
+		}
+		query = query.Order("s.purchase_date DESC")
+	} else {
+		matchType, prefix = constants.MatchPurchase, "STOCK"
+		codes = referencedCodes(stockReferencePattern, text)
+		query = db.Table("purchase AS p").
+			Select(`p.uuid, COALESCE(se.id, 0) AS code_no, p.purchase_date AS date, p.total_amount, p.remaining_amount,
+				COALESCE(u.name, '') AS party_name`).
+			Joins("LEFT JOIN stock_entries se ON se.uuid = p.stock_id AND se.deleted = false").
+			Joins(`LEFT JOIN "user" u ON u.uuid = p.supplier_id`).
+			Where("p.deleted = false AND p.remaining_amount >= ?", line.Amount)
+		if len(codes) > 0 {
+			query = query.Where("(se.id IN ? OR p.remaining_amount = ?)", codes, line.Amount)
+		} else {
+			query = query.Where("p.remaining_amount = ?", line.Amount)
+		}
+		query = query.Order("p.purchase_date DESC")
+	}
+
+	if err := query.Limit(50).Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch match candidates: ", err)
+	}
+
+	referenced := make(map[int]bool, len(codes))
+	for _, code := range codes {
+		referenced[code] = true
+	}
+
+	candidates := make([]models.MatchCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidate := models.MatchCandidate{
+			MatchType:       matchType,
+			MatchId:         row.Uuid,
+			Code:            fmt.Sprintf("%s%d", prefix, row.CodeNo),
+			PartyName:       row.PartyName,
+			Date:            row.Date,
+			TotalAmount:     row.TotalAmount,
+			RemainingAmount: row.RemainingAmount,
+			Reasons:         make([]string, 0, 3),
+		}
+
+		if referenced[row.CodeNo] {
+			candidate.Score += 60
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("reference mentions %s", candidate.Code))
+		}
+		if row.RemainingAmount == line.Amount {
+			candidate.Score += 30
+			candidate.Reasons = append(candidate.Reasons, "amount equals the open balance")
+		} else {
+			candidate.Score += 10
+			candidate.Reasons = append(candidate.Reasons, "part payment of the open balance")
+		}
+		days := line.TransactionDate.Sub(row.Date).Hours() / 24
+		if days > -1 && days <= 7 {
+			candidate.Score += 10
+			candidate.Reasons = append(candidate.Reasons, "dated within a week")
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Date.After(candidates[j].Date)
+	})
+	if len(candidates) > 10 {
+		candidates = candidates[:10]
+	}
+
+	return candidates, nil
+}
+
+func (s *BankStatementService) existingLineKeys(db *gorm.DB, cashAccountId string, start, end time.Time) (map[string]bool, error) {
+	var lines []models.BankStatementLine
+	if err := db.Table("bank_statement_lines AS bl").
+		Select("bl.transaction_date, bl.direction, bl.amount, bl.reference, bl.description").
+		Joins("INNER JOIN bank_statements bs ON bs.uuid = bl.statement_id AND bs.deleted = false").
+		Where("bs.cash_account_id = ? AND bl.deleted = false", cashAccountId).
+		Where("DATE(bl.transaction_date) >= CAST(? AS DATE)", start.Format("2006-01-02")).
+		Where("DATE(bl.transaction_date) <= CAST(? AS DATE)", end.Format("2006-01-02")).
+		Scan(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch imported transactions: ", err)
+	}
+
+	keys := make(map[string]bool, len(lines))
+	for _, l := range lines {
+		keys[statementLineKey(l.TransactionDate, l.Direction, l.Amount, l.Reference, l.Description)] = true
+	}
+	return keys, nil
+}
+
+func (s *BankStatementService) statementQuery(query *gorm.DB) *gorm.DB {
+	return query.
+		Select(`
+			bs.uuid,
+			bs.cash_account_id,
+			COALESCE(ca.name, '') AS cash_account_name,
+			bs.file_name,
+			bs.format,
+			bs.start_date,
+			bs.end_date,
+			bs.line_count,
+			COUNT(bl.id) FILTER (WHERE bl.status = ?) AS unmatched_count,
+			COUNT(bl.id) FILTER (WHERE bl.status = ?) AS suggested_count,
+			COUNT(bl.id) FILTER (WHERE bl.status = ?) AS matched_count,
+			COUNT(bl.id) FILTER (WHERE bl.status = ?) AS ignored_count,
+			bs.imported_by,
+			COALESCE(u.name, '') AS imported_by_name,
+			bs.created_at
+		`, constants.StatementUnmatched, constants.StatementSuggested, constants.StatementMatched, constants.StatementIgnored).
+		Joins("LEFT JOIN bank_statement_lines bl ON bl.statement_id = bs.uuid AND bl.deleted = false").
+		Joins("LEFT JOIN cash_accounts ca ON ca.uuid = bs.cash_account_id").
+		Joins(`LEFT JOIN "user" u ON u.uuid = bs.imported_by`).
+		Group("bs.id, ca.name, u.name")
+}
+
+func (s *BankStatementService) getStatement(db *gorm.DB, statementId string) (*models.BankStatementResponse, error) {
+	var response models.BankStatementResponse
+	if err := s.statementQuery(db.Table("bank_statements AS bs")).
+		Where("bs.uuid = ?", statementId).
+		Scan(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement: ", err)
+	}
+
+	return &response, nil
+}
+
+func (s *BankStatementService) lineQuery(query *gorm.DB) *gorm.DB {
+	return query.
+		Select(`
+			bl.uuid,
+			bl.statement_id,
+			COALESCE(ca.name, '') AS cash_account_name,
+			bl.line_no,
+			bl.transaction_date,
+			bl.direction,
+			bl.amount,
+			bl.description,
+			bl.reference,
+			bl.status,
+			bl.match_type,
+			bl.match_id,
+			COALESCE(s.id, se.id, 0) AS match_no,
+			COALESCE(cu.name, su.name, '') AS match_party_name,
+			COALESCE(s.remaining_amount, p.remaining_amount, 0) AS match_remaining,
+			bl.match_score,
+			COALESCE(bl.payment_id, '') AS payment_id,
+			COALESCE(cb.name, '') AS confirmed_by_name,
+			bl.confirmed_at
+		`).
+		Joins("LEFT JOIN cash_accounts ca ON ca.uuid = bs.cash_account_id").
+		Joins("LEFT JOIN sales s ON bl.match_type = ? AND s.uuid = bl.match_id", constants.MatchSale).
+		Joins(`LEFT JOIN "user" cu ON cu.uuid = s.customer_id`).
+		Joins("LEFT JOIN purchase p ON bl.match_type = ? AND p.uuid = bl.match_id", constants.MatchPurchase).
+		Joins("LEFT JOIN stock_entries se ON se.uuid = p.stock_id").
+		Joins(`LEFT JOIN "user" su ON su.uuid = p.supplier_id`).
+		Joins(`LEFT JOIN "user" cb ON cb.uuid = bl.confirmed_by`)
+}
+
+func (s *BankStatementService) getLine(db *gorm.DB, lineId string) (*models.BankStatementLineResponse, error) {
+	var response models.BankStatementLineResponse
+	if err := s.lineQuery(db.Table("bank_statement_lines AS bl").
+		Joins("INNER JOIN bank_statements bs ON bs.uuid = bl.statement_id")).
+		Where("bl.uuid = ?", lineId).
+		Scan(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement line: ", err)
+	}
+	response.MatchCode = matchCode(response.MatchType, response.MatchNo)
+
+	return &response, nil
+}
+
+func findBankStatement(db *gorm.DB, statementId string) (*models.BankStatement, error) {
+	var statement models.BankStatement
+	if err := db.Where("uuid = ? AND deleted = false", statementId).First(&statement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("bank statement not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement: ", err)
+	}
+
+	return &statement, nil
+}
+
+func findStatementLine(db *gorm.DB, lineId string) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	if err := db.Where("uuid = ? AND deleted = false", lineId).First(&line).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("bank statement line not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch bank statement line: ", err)
+	}
+
+	return &line, nil
+}
+
+// referencedCodes returns the document numbers a statement text mentions,
+// e.g. 12 for "TRF SELL12 PT ABC".
+func referencedCodes(pattern *regexp.Regexp, text string) []int {
+	codes := make([]int, 0)
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			codes = append(codes, n)
+		}
+	}
+	return codes
+}
+
+func matchCode(matchType string, no int) string {
+	switch {
+	case no == 0:
+		return ""
+	case matchType == constants.MatchSale:
+		return fmt.Sprintf("SELL%d", no)
+	case matchType == constants.MatchPurchase:
+		return fmt.Sprintf("STOCK%d", no)
+	}
+	return ""
+}
+
+func statementLineKey(date time.Time, direction string, amount int, reference, description string) string {
+	return strings.Join([]string{
+		date.In(constants.JakartaTz).Format("2006-01-02"),
+		direction,
+		strconv.Itoa(amount),
+		strings.TrimSpace(reference),
+		strings.TrimSpace(description),
+	}, "|")
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,540 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type CashAccountService struct{}
+
+func NewCashAccountService() repository.CashAccountRepository {
+	return &CashAccountService{}
+}
+
+// GetAllCashAccounts - Cash and Bank Accounts with Balances
+// =====================================================
+func (s *CashAccountService) GetAllCashAccounts(filter models.CashAccountFilter) ([]models.CashAccountResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Where("deleted = false")
+	if filter.AccountType != "" {
+		query = query.Where("account_type = ?", filter.AccountType)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = true")
+	}
+
+	var accounts []models.CashAccount
+	if err := query.Order("account_type ASC, name ASC").Find(&accounts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cash accounts: ", err)
+	}
+
+	return s.buildResponses(db, accounts)
+}
+
+// CreateCashAccount - Open a Cash Box or Bank Account
+// =====================================================
+func (s *CashAccountService) CreateCashAccount(request models.CashAccountRequest) (*models.CashAccountResponse, error) {
+	db := config.GetDBConn()
+
+	var account models.CashAccount
+	err := db.Transaction(func(tx *gorm.DB) error {
+		code, err := nextCashLedgerCode(tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ledger := models.Account{
+			Uuid:        uuid.New().String(),
+			Code:        code,
+			Name:        strings.TrimSpace(request.Name),
+			AccountType: constants.AccountAsset,
+			Description: cashLedgerDescription(request),
+			IsSystem:    true,
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err = tx.Create(&ledger).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create ledger account: ", err)
+		}
+
+		account = models.CashAccount{
+			Uuid:            uuid.New().String(),
+			Name:            strings.TrimSpace(request.Name),
+			AccountType:     request.AccountType,
+			BankName:        strings.TrimSpace(request.BankName),
+			AccountNumber:   strings.TrimSpace(request.AccountNumber),
+			AccountHolder:   strings.TrimSpace(request.AccountHolder),
+			LedgerAccountId: ledger.Uuid,
+			IsActive:        request.IsActive == nil || *request.IsActive,
+			Deleted:         false,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err = tx.Create(&account).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create cash account: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.CashAccount{account})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// UpdateCashAccount - Edit Details or Deactivate
+// =====================================================
+func (s *CashAccountService) UpdateCashAccount(cashAccountId string, request models.CashAccountRequest) (*models.CashAccountResponse, error) {
+	db := config.GetDBConn()
+
+	account, err := findCashAccount(db, cashAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"name":           strings.TrimSpace(request.Name),
+			"account_type":   request.AccountType,
+			"bank_name":      strings.TrimSpace(request.BankName),
+			"account_number": strings.TrimSpace(request.AccountNumber),
+			"account_holder": strings.TrimSpace(request.AccountHolder),
+			"updated_at":     now,
+		}
+		if request.IsActive != nil {
+			updates["is_active"] = *request.IsActive
+		}
+
+		if err := tx.Model(&models.CashAccount{}).
+			Where("uuid = ?", account.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update cash account: ", err)
+		}
+
+		if err := tx.Model(&models.Account{}).
+			Where("uuid = ?", account.LedgerAccountId).
+			Updates(map[string]interface{}{
+				"name":        strings.TrimSpace(request.Name),
+				"description": cashLedgerDescription(request),
+				"updated_at":  now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update ledger account: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account, err = findCashAccount(db, cashAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.CashAccount{*account})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// DeleteCashAccount - Remove an Unused Account
+// =====================================================
+func (s *CashAccountService) DeleteCashAccount(cashAccountId string) error {
+	db := config.GetDBConn()
+
+	account, err := findCashAccount(db, cashAccountId)
+	if err != nil {
+		return err
+	}
+
+	ledger, err := findAccount(db, account.LedgerAccountId)
+	if err != nil {
+		return err
+	}
+	if ledger.Code == constants.AccountCodeCash {
+		return apperror.NewConflict("the main cash account cannot be deleted; deactivate it instead")
+	}
+
+	var payments, lines int64
+	if err = db.Model(&models.Payment{}).
+		Where("cash_account_id = ? AND deleted = false", account.Uuid).
+		Count(&payments).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check cash account usage: ", err)
+	}
+	if err = db.Model(&models.JournalLine{}).
+		Where("account_id = ? AND deleted = false", account.LedgerAccountId).
+		Count(&lines).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check cash account usage: ", err)
+	}
+	if payments > 0 || lines > 0 {
+		return apperror.NewConflict(fmt.Sprintf("cash account %s has transactions; deactivate it instead", account.Name))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"deleted": true, "updated_at": time.Now()}
+		if err := tx.Model(&models.CashAccount{}).
+			Where("uuid = ?", account.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete cash account: ", err)
+		}
+		if err := tx.Model(&models.Account{}).
+			Where("uuid = ?", account.LedgerAccountId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete ledger account: ", err)
+		}
+		return nil
+	})
+}
+
+// GetCashAccountStatement - Running Balance of One Account
+// =====================================================
+func (s *CashAccountService) GetCashAccountStatement(cashAccountId string, filter models.LedgerFilter) (*models.AccountLedger, error) {
+	db := config.GetDBConn()
+
+	account, err := findCashAccount(db, cashAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&LedgerService{}).GetAccountStatement(account.LedgerAccountId, filter)
+}
+
+// GetAllCashTransfers - Paginated Transfers
+// =====================================================
+func (s *CashAccountService) GetAllCashTransfers(filter models.CashTransferFilter) (*models.CashTransferPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Table("cash_transfers AS ct").Where("ct.deleted = false")
+	if filter.AccountId != "" {
+		query = query.Where("(ct.from_account_id = ? OR ct.to_account_id = ?)", filter.AccountId, filter.AccountId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(ct.transfer_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(ct.transfer_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count cash transfers: ", err)
+	}
+
+	var rows []models.CashTransferResponse
+	if err := s.transferQuery(query).
+		Order("ct.transfer_date DESC, ct.id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cash transfers: ", err)
+	}
+	for i := range rows {
+		rows[i].TransferCode = fmt.Sprintf("TRF%d", rows[i].TransferNo)
+	}
+
+	return &models.CashTransferPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   rows,
+	}, nil
+}
+
+// CreateCashTransfer - Move Money Between Accounts
+// =====================================================
+func (s *CashAccountService) CreateCashTransfer(request models.CashTransferRequest) (*models.CashTransferResponse, error) {
+	db := config.GetDBConn()
+
+	if err := checkPeriodsOpen(db, request.TransferDate); err != nil {
+		return nil, err
+	}
+
+	from, err := activeCashAccount(db, request.FromAccountId)
+	if err != nil {
+		return nil, err
+	}
+	to, err := activeCashAccount(db, request.ToAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer models.CashTransfer
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		transfer = models.CashTransfer{
+			Uuid:          uuid.New().String(),
+			FromAccountId: from.Uuid,
+			ToAccountId:   to.Uuid,
+			Amount:        request.Amount,
+			TransferDate:  request.TransferDate,
+			Notes:         strings.TrimSpace(request.Notes),
+			Deleted:       false,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create cash transfer: ", err)
+		}
+
+		fromCode, err := cashLedgerCode(tx, from.Uuid)
+		if err != nil {
+			return err
+		}
+		toCode, err := cashLedgerCode(tx, to.Uuid)
+		if err != nil {
+			return err
+		}
+
+		return postJournal(tx, models.JournalEntry{
+			EntryDate:   transfer.TransferDate,
+			Description: fmt.Sprintf("Transfer TRF%d %s ke %s", transfer.ID, from.Name, to.Name),
+			SourceType:  constants.JournalCashTransfer,
+			SourceId:    transfer.Uuid,
+		}, []journalLine{
+			{accountCode: toCode, debit: transfer.Amount},
+			{accountCode: fromCode, credit: transfer.Amount},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.CashTransferResponse
+	if err = s.transferQuery(db.Table("cash_transfers AS ct")).
+		Where("ct.uuid = ?", transfer.Uuid).
+		Scan(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cash transfer: ", err)
+	}
+	response.TransferCode = fmt.Sprintf("TRF%d", response.TransferNo)
+
+	return &response, nil
+}
+
+// DeleteCashTransfer - Cancel a Transfer
+// =====================================================
+func (s *CashAccountService) DeleteCashTransfer(transferId string) error {
+	db := config.GetDBConn()
+
+	var transfer models.CashTransfer
+	if err := db.Where("uuid = ? AND deleted = false", transferId).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("cash transfer not found")
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch cash transfer: ", err)
+	}
+
+	if err := checkPeriodsOpen(db, transfer.TransferDate); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CashTransfer{}).
+			Where("uuid = ?", transfer.Uuid).
+			Updates(map[string]interface{}{"deleted": true, "updated_at": time.Now()}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete cash transfer: ", err)
+		}
+
+		return reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalCashTransfer, transfer.Uuid)
+	})
+}
+
+func (s *CashAccountService) transferQuery(query *gorm.DB) *gorm.DB {
+	return query.
+		Select(`
+			ct.uuid,
+			ct.id AS transfer_no,
+			ct.from_account_id,
+			COALESCE(fa.name, '') AS from_account_name,
+			ct.to_account_id,
+			COALESCE(ta.name, '') AS to_account_name,
+			ct.amount,
+			ct.transfer_date,
+			ct.notes,
+			ct.created_at
+		`).
+		Joins("LEFT JOIN cash_accounts fa ON fa.uuid = ct.from_account_id").
+		Joins("LEFT JOIN cash_accounts ta ON ta.uuid = ct.to_account_id")
+}
+
+func (s *CashAccountService) buildResponses(db *gorm.DB, accounts []models.CashAccount) ([]models.CashAccountResponse, error) {
+	responses := make([]models.CashAccountResponse, 0, len(accounts))
+	if len(accounts) == 0 {
+		return responses, nil
+	}
+
+	ledgerIDs := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		ledgerIDs = append(ledgerIDs, a.LedgerAccountId)
+	}
+
+	var ledgers []models.Account
+	if err := db.Where("uuid IN ?", ledgerIDs).Find(&ledgers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch ledger accounts: ", err)
+	}
+	codeMap := make(map[string]string, len(ledgers))
+	for _, l := range ledgers {
+		codeMap[l.Uuid] = l.Code
+	}
+
+	totals, err := accountTotals(db, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range accounts {
+		t := totals[a.LedgerAccountId]
+		responses = append(responses, models.CashAccountResponse{
+			Uuid:              a.Uuid,
+			Name:              a.Name,
+			AccountType:       a.AccountType,
+			BankName:          a.BankName,
+			AccountNumber:     a.AccountNumber,
+			AccountHolder:     a.AccountHolder,
+			LedgerAccountId:   a.LedgerAccountId,
+			LedgerAccountCode: codeMap[a.LedgerAccountId],
+			IsActive:          a.IsActive,
+			Balance:           t.Debit - t.Credit,
+			CreatedAt:         a.CreatedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+func findCashAccount(db *gorm.DB, cashAccountId string) (*models.CashAccount, error) {
+	var account models.CashAccount
+	if err := db.Where("uuid = ? AND deleted = false", cashAccountId).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("cash account not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cash account: ", err)
+	}
+
+	return &account, nil
+}
+
+func activeCashAccount(db *gorm.DB, cashAccountId string) (*models.CashAccount, error) {
+	account, err := findCashAccount(db, cashAccountId)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("cash account %s is inactive", account.Name))
+	}
+
+	return account, nil
+}
+
+// checkPaymentAccount makes sure a payment that moves money says where it
+// went: an active cash or bank account and a payment method.
+func checkPaymentAccount(db *gorm.DB, cashAccountId, method string) error {
+	if cashAccountId == "" || method == "" {
+		return apperror.NewBadRequest("cash_account_id and payment_method are required")
+	}
+	switch method {
+	case constants.PaymentMethodCash, constants.PaymentMethodTransfer, constants.PaymentMethodGiro:
+	default:
+		return apperror.NewBadRequest("payment_method must be one of CASH, TRANSFER, GIRO")
+	}
+
+	_, err := activeCashAccount(db, cashAccountId)
+	return err
+}
+
+// cashLedgerCode returns the ledger account that holds a cash account's
+// balance. Payments recorded before cash accounts existed have none and
+// post to the main cash account.
+func cashLedgerCode(tx *gorm.DB, cashAccountId string) (string, error) {
+	if cashAccountId == "" {
+		return constants.AccountCodeCash, nil
+	}
+
+	var code string
+	if err := tx.Table("cash_accounts AS ca").
+		Select("a.code").
+		Joins("INNER JOIN accounts a ON a.uuid = ca.ledger_account_id").
+		Where("ca.uuid = ?", cashAccountId).
+		Limit(1).
+		Scan(&code).Error; err != nil {
+		return "", apperror.NewUnprocessableEntity("failed to fetch cash ledger account: ", err)
+	}
+	if code == "" {
+		return "", apperror.NewNotFound("cash account not found")
+	}
+
+	return code, nil
+}
+
+// nextCashLedgerCode picks the next free code in the cash range after the
+// main cash account (1101-1199).
+func nextCashLedgerCode(tx *gorm.DB) (string, error) {
+	return nextLedgerCode(tx, constants.AccountCodeCash, "cash accounts")
+}
+
+// nextLedgerCode picks the next free code in the hundred codes after a
+// system account, e.g. 6101-6199 after 6100.
+func nextLedgerCode(tx *gorm.DB, baseCode, purpose string) (string, error) {
+	var codes []string
+	if err := tx.Model(&models.Account{}).
+		Where("code LIKE ?", baseCode[:2]+"__").
+		Pluck("code", &codes).Error; err != nil {
+		return "", apperror.NewUnprocessableEntity("failed to fetch ledger codes: ", err)
+	}
+
+	base, _ := strconv.Atoi(baseCode)
+	next := base + 1
+	for _, code := range codes {
+		if n, err := strconv.Atoi(code); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	if next > base+99 {
+		return "", apperror.NewConflict(fmt.Sprintf("no free ledger codes left for %s", purpose))
+	}
+
+	return strconv.Itoa(next), nil
+}
+
+func cashLedgerDescription(request models.CashAccountRequest) string {
+	if request.AccountType == constants.CashAccountBank {
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s",
+			strings.TrimSpace(request.BankName),
+			strings.TrimSpace(request.AccountNumber),
+			strings.TrimSpace(request.AccountHolder)))
+	}
+	return "Kas tunai"
+}
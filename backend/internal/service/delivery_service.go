@@ -0,0 +1,650 @@
+package service
+
+import (
+	"context"
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+// deliveryProofDir is the sub-directory of the upload dir holding proof photos
+const deliveryProofDir = "delivery-proofs"
+
+// deliveryTransitions lists the statuses a delivery note may move to
+var deliveryTransitions = map[string][]string{
+	constants.DeliveryPending:   {constants.DeliveryInTransit, constants.DeliveryDelivered, constants.DeliveryFailed},
+	constants.DeliveryInTransit: {constants.DeliveryDelivered, constants.DeliveryFailed},
+	constants.DeliveryFailed:    {constants.DeliveryPending},
+	constants.DeliveryDelivered: {},
+}
+
+// proofImageTypes maps the accepted proof photo content types to file extensions
+var proofImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type DeliveryService struct{}
+
+func NewDeliveryService() repository.DeliveryRepository {
+	return &DeliveryService{}
+}
+
+// CreateDeliveryNote - Generated from a Sale
+// =====================================================
+func (s *DeliveryService) CreateDeliveryNote(ctx context.Context, request models.DeliveryNoteRequest) (*models.DeliveryNoteResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	var sale models.Sale
+	if err := db.Where("uuid = ? AND deleted = false", request.SaleId).First(&sale).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("sale not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sale: ", err)
+	}
+
+	// A sale has one delivery at a time; a failed one can be replaced
+	var active int64
+	if err := db.Model(&models.DeliveryNote{}).
+		Where("sale_id = ? AND deleted = false AND status <> ?", sale.Uuid, constants.DeliveryFailed).
+		Count(&active).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to check delivery notes: ", err)
+	}
+	if active > 0 {
+		return nil, apperror.NewConflict(fmt.Sprintf("SELL%d already has a delivery note", sale.ID))
+	}
+
+	var customer models.User
+	if sale.CustomerId != "" {
+		if err := db.Where("uuid = ?", sale.CustomerId).First(&customer).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch customer: ", err)
+		}
+	}
+
+	shippingAddress := strings.TrimSpace(request.ShippingAddress)
+	if shippingAddress == "" {
+		shippingAddress = customer.ShippingAddress
+	}
+	if shippingAddress == "" {
+		shippingAddress = customer.Address
+	}
+	if shippingAddress == "" {
+		return nil, apperror.NewBadRequest("shipping address is required, the customer has none on file")
+	}
+
+	recipientName := strings.TrimSpace(request.RecipientName)
+	if recipientName == "" {
+		recipientName = customer.Name
+	}
+
+	var items []struct {
+		models.ItemSales
+		ItemName string `gorm:"column:item_name"`
+	}
+	if err := db.Table("item_sales AS its").
+		Select("its.*, ss.sorted_item_name AS item_name").
+		Joins("LEFT JOIN stock_sorts ss ON ss.uuid = its.stock_sort_id").
+		Where("its.sale_id = ? AND its.deleted = false", sale.Uuid).
+		Order("its.id ASC").
+		Scan(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sale items: ", err)
+	}
+	if len(items) == 0 {
+		return nil, apperror.NewBadRequest("sale has no items to deliver")
+	}
+
+	var fibers []struct {
+		models.FiberAllocation
+		FiberName string `gorm:"column:fiber_name"`
+	}
+	if err := db.Table("fiber_allocations AS fa").
+		Select("fa.*, f.name AS fiber_name").
+		Joins("LEFT JOIN fibers f ON f.uuid = fa.fiber_id").
+		Where("fa.sale_id = ? AND fa.deleted = false", sale.Uuid).
+		Order("f.name ASC").
+		Scan(&fibers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch fiber allocations: ", err)
+	}
+
+	now := time.Now()
+	note := models.DeliveryNote{
+		Uuid:            uuid.New().String(),
+		SaleId:          sale.Uuid,
+		DeliveryDate:    request.DeliveryDate,
+		ShippingAddress: shippingAddress,
+		RecipientName:   recipientName,
+		DriverName:      strings.TrimSpace(request.DriverName),
+		DriverPhone:     strings.TrimSpace(request.DriverPhone),
+		VehiclePlate:    strings.ToUpper(strings.TrimSpace(request.VehiclePlate)),
+		VehicleType:     strings.TrimSpace(request.VehicleType),
+		Status:          constants.DeliveryPending,
+		Notes:           strings.TrimSpace(request.Notes),
+		Deleted:         false,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&note).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create delivery note: ", err)
+		}
+
+		noteItems := make([]models.DeliveryNoteItem, 0, len(items))
+		for _, item := range items {
+			noteItems = append(noteItems, models.DeliveryNoteItem{
+				Uuid:           uuid.New().String(),
+				DeliveryNoteId: note.Uuid,
+				StockSortId:    item.StockSortId,
+				StockCode:      item.StockCode,
+				ItemName:       item.ItemName,
+				Weight:         item.Weight,
+				Deleted:        false,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+		if err := tx.Create(&noteItems).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create delivery note items: ", err)
+		}
+
+		if len(fibers) > 0 {
+			noteFibers := make([]models.DeliveryNoteFiber, 0, len(fibers))
+			for _, fiber := range fibers {
+				noteFibers = append(noteFibers, models.DeliveryNoteFiber{
+					Uuid:           uuid.New().String(),
+					DeliveryNoteId: note.Uuid,
+					FiberId:        fiber.FiberId,
+					FiberName:      fiber.FiberName,
+					StockSortId:    fiber.StockSortId,
+					Weight:         fiber.Weight,
+					Deleted:        false,
+					CreatedAt:      now,
+					UpdatedAt:      now,
+				})
+			}
+			if err := tx.Create(&noteFibers).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create delivery note fibers: ", err)
+			}
+		}
+
+		return s.recordStatus(tx, note.Uuid, constants.DeliveryPending, "delivery note created", now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDeliveryNoteById(ctx, note.Uuid)
+}
+
+// UpdateDeliveryNote - Driver, Vehicle and Address
+// =====================================================
+func (s *DeliveryService) UpdateDeliveryNote(ctx context.Context, noteId string, request models.UpdateDeliveryNoteRequest) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return err
+	}
+	if note.Status == constants.DeliveryDelivered {
+		return apperror.NewConflict("delivered notes can no longer be edited")
+	}
+
+	if err = db.Model(&models.DeliveryNote{}).
+		Where("uuid = ? AND deleted = false", noteId).
+		Updates(map[string]interface{}{
+			"delivery_date":    request.DeliveryDate,
+			"shipping_address": strings.TrimSpace(request.ShippingAddress),
+			"recipient_name":   strings.TrimSpace(request.RecipientName),
+			"driver_name":      strings.TrimSpace(request.DriverName),
+			"driver_phone":     strings.TrimSpace(request.DriverPhone),
+			"vehicle_plate":    strings.ToUpper(strings.TrimSpace(request.VehiclePlate)),
+			"vehicle_type":     strings.TrimSpace(request.VehicleType),
+			"notes":            strings.TrimSpace(request.Notes),
+			"updated_at":       time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update delivery note: ", err)
+	}
+
+	return nil
+}
+
+// UpdateDeliveryStatus - Tracked Status Transition
+// =====================================================
+func (s *DeliveryService) UpdateDeliveryStatus(ctx context.Context, noteId string, request models.DeliveryStatusRequest) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return s.transition(tx, note, request.Status, strings.TrimSpace(request.Notes), time.Now())
+	})
+}
+
+// DeleteDeliveryNote - Soft Delete Undispatched Notes
+// =====================================================
+func (s *DeliveryService) DeleteDeliveryNote(ctx context.Context, noteId string) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return err
+	}
+	if note.Status != constants.DeliveryPending && note.Status != constants.DeliveryFailed {
+		return apperror.NewConflict(fmt.Sprintf("delivery note is %s and cannot be deleted", note.Status))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}
+
+		if err := tx.Model(&models.DeliveryNoteItem{}).
+			Where("delivery_note_id = ? AND deleted = false", noteId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete delivery note items: ", err)
+		}
+
+		if err := tx.Model(&models.DeliveryNoteFiber{}).
+			Where("delivery_note_id = ? AND deleted = false", noteId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete delivery note fibers: ", err)
+		}
+
+		if err := tx.Model(&models.DeliveryNote{}).
+			Where("uuid = ? AND deleted = false", noteId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete delivery note: ", err)
+		}
+
+		return nil
+	})
+}
+
+// UploadDeliveryProof - Signed Photo Stored on Local Disk
+// =====================================================
+func (s *DeliveryService) UploadDeliveryProof(ctx context.Context, noteId string, file io.Reader) (*models.DeliveryNoteResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return nil, err
+	}
+	if note.Status == constants.DeliveryFailed {
+		return nil, apperror.NewConflict("failed deliveries cannot receive a proof of delivery")
+	}
+
+	now := time.Now()
+	upload, err := storeUpload(file, deliveryProofDir, fmt.Sprintf("%s-%d", note.Uuid, now.Unix()),
+		proofImageTypes, "proof of delivery must be a JPEG, PNG or WEBP image")
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.DeliveryNote{}).
+			Where("uuid = ?", note.Uuid).
+			Updates(map[string]interface{}{
+				"proof_photo_path":  upload.Path,
+				"proof_uploaded_at": now,
+				"updated_at":        now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to save proof of delivery: ", err)
+		}
+
+		// A signed proof closes the delivery
+		if note.Status != constants.DeliveryDelivered {
+			return s.transition(tx, note, constants.DeliveryDelivered, "proof of delivery uploaded", now)
+		}
+
+		return s.recordStatus(tx, note.Uuid, note.Status, "proof of delivery replaced", now)
+	})
+	if err != nil {
+		os.Remove(upload.Path)
+		return nil, err
+	}
+
+	// Keep only the latest photo on disk
+	if note.ProofPhotoPath != "" && note.ProofPhotoPath != upload.Path {
+		os.Remove(note.ProofPhotoPath)
+	}
+
+	return s.GetDeliveryNoteById(ctx, noteId)
+}
+
+// GetDeliveryProofPath - Location of the Stored Proof Photo
+// =====================================================
+func (s *DeliveryService) GetDeliveryProofPath(ctx context.Context, noteId string) (string, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return "", err
+	}
+	if note.ProofPhotoPath == "" {
+		return "", apperror.NewNotFound("delivery note has no proof of delivery")
+	}
+	if _, err = os.Stat(note.ProofPhotoPath); err != nil {
+		return "", apperror.NewNotFound("proof of delivery file is missing")
+	}
+
+	return note.ProofPhotoPath, nil
+}
+
+// GetDeliveryNoteById - Note with Items, Fibers and History
+// =====================================================
+func (s *DeliveryService) GetDeliveryNoteById(ctx context.Context, noteId string) (*models.DeliveryNoteResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	note, err := s.getNote(db, noteId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.DeliveryNote{*note}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllDeliveryNotes - Paginated with Filters
+// =====================================================
+func (s *DeliveryService) GetAllDeliveryNotes(ctx context.Context, filter models.DeliveryNoteFilter) (*models.DeliveryNotePaginationResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.DeliveryNote{}).Where("deleted = false")
+
+	if filter.SaleId != "" {
+		query = query.Where("sale_id = ?", filter.SaleId)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.DeliveryDate != "" {
+		query = query.Where("DATE(delivery_date) = CAST(? AS DATE)", filter.DeliveryDate)
+	}
+	if filter.Keyword != "" {
+		keyword := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(driver_name) LIKE ? OR LOWER(vehicle_plate) LIKE ? OR LOWER(recipient_name) LIKE ?",
+			keyword, keyword, keyword)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count delivery notes: ", err)
+	}
+
+	var notes []models.DeliveryNote
+	if err := query.
+		Order("delivery_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&notes).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch delivery notes: ", err)
+	}
+
+	responses, err := s.buildResponses(db, notes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DeliveryNotePaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+func (s *DeliveryService) getNote(db *gorm.DB, noteId string) (*models.DeliveryNote, error) {
+	var note models.DeliveryNote
+	if err := db.Where("uuid = ? AND deleted = false", noteId).First(&note).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("delivery note not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch delivery note: ", err)
+	}
+
+	return &note, nil
+}
+
+// transition moves the note to status, stamping dispatch and delivery times
+// and recording the change in the status history.
+func (s *DeliveryService) transition(tx *gorm.DB, note *models.DeliveryNote, status, notes string, now time.Time) error {
+	if note.Status == status {
+		return apperror.NewConflict(fmt.Sprintf("delivery note is already %s", status))
+	}
+
+	allowed := false
+	for _, next := range deliveryTransitions[note.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return apperror.NewConflict(fmt.Sprintf("cannot change delivery status from %s to %s", note.Status, status))
+	}
+
+	updates := map[string]interface{}{
+		"status":     status,
+		"updated_at": now,
+	}
+	switch status {
+	case constants.DeliveryInTransit:
+		updates["dispatched_at"] = now
+	case constants.DeliveryDelivered:
+		updates["delivered_at"] = now
+		if note.DispatchedAt == nil {
+			updates["dispatched_at"] = now
+		}
+	case constants.DeliveryPending:
+		updates["dispatched_at"] = nil
+	}
+
+	result := tx.Model(&models.DeliveryNote{}).
+		Where("uuid = ? AND deleted = false AND status = ?", note.Uuid, note.Status).
+		Updates(updates)
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to update delivery status: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewConflict("delivery status was changed in the meantime")
+	}
+
+	return s.recordStatus(tx, note.Uuid, status, notes, now)
+}
+
+func (s *DeliveryService) recordStatus(tx *gorm.DB, noteId, status, notes string, now time.Time) error {
+	history := models.DeliveryStatusHistory{
+		Uuid:           uuid.New().String(),
+		DeliveryNoteId: noteId,
+		Status:         status,
+		Notes:          notes,
+		CreatedAt:      now,
+	}
+
+	if err := tx.Create(&history).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to record delivery status: ", err)
+	}
+
+	return nil
+}
+
+func (s *DeliveryService) buildResponses(db *gorm.DB, notes []models.DeliveryNote, withHistory bool) ([]models.DeliveryNoteResponse, error) {
+	responses := make([]models.DeliveryNoteResponse, 0, len(notes))
+	if len(notes) == 0 {
+		return responses, nil
+	}
+
+	noteIDs := make([]string, 0, len(notes))
+	saleIDs := make([]string, 0, len(notes))
+	for _, n := range notes {
+		noteIDs = append(noteIDs, n.Uuid)
+		saleIDs = append(saleIDs, n.SaleId)
+	}
+
+	var sales []models.Sale
+	if err := db.Where("uuid IN ?", distinct(saleIDs)).Find(&sales).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sales: ", err)
+	}
+	saleMap := make(map[string]models.Sale, len(sales))
+	customerIDs := make([]string, 0, len(sales))
+	for _, sale := range sales {
+		saleMap[sale.Uuid] = sale
+		customerIDs = append(customerIDs, sale.CustomerId)
+	}
+
+	var customers []models.User
+	if err := db.Where("uuid IN ?", distinct(customerIDs)).Find(&customers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch customers: ", err)
+	}
+	customerMap := make(map[string]models.User, len(customers))
+	for _, c := range customers {
+		customerMap[c.Uuid] = c
+	}
+
+	var items []models.DeliveryNoteItem
+	if err := db.Where("delivery_note_id IN ? AND deleted = false", noteIDs).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch delivery note items: ", err)
+	}
+	itemMap := make(map[string][]models.DeliveryNoteItemResponse)
+	weightMap := make(map[string]int)
+	sortMap := make(map[string][]string)
+	for _, item := range items {
+		sortMap[item.DeliveryNoteId] = append(sortMap[item.DeliveryNoteId], item.StockSortId)
+		itemMap[item.DeliveryNoteId] = append(itemMap[item.DeliveryNoteId], models.DeliveryNoteItemResponse{
+			StockSortId: item.StockSortId,
+			StockCode:   item.StockCode,
+			ItemName:    item.ItemName,
+			Weight:      item.Weight,
+		})
+		weightMap[item.DeliveryNoteId] += item.Weight
+	}
+
+	var fibers []models.DeliveryNoteFiber
+	if err := db.Where("delivery_note_id IN ? AND deleted = false", noteIDs).
+		Order("fiber_name ASC").
+		Find(&fibers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch delivery note fibers: ", err)
+	}
+	fiberMap := make(map[string][]models.DeliveryNoteFiberResponse)
+	for _, fiber := range fibers {
+		fiberMap[fiber.DeliveryNoteId] = append(fiberMap[fiber.DeliveryNoteId], models.DeliveryNoteFiberResponse{
+			FiberId:     fiber.FiberId,
+			FiberName:   fiber.FiberName,
+			StockSortId: fiber.StockSortId,
+			Weight:      fiber.Weight,
+		})
+	}
+
+	historyMap := make(map[string][]models.DeliveryStatusHistoryResponse)
+	if withHistory {
+		var history []models.DeliveryStatusHistory
+		if err := db.Where("delivery_note_id IN ?", noteIDs).
+			Order("created_at ASC, id ASC").
+			Find(&history).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch delivery status history: ", err)
+		}
+		for _, h := range history {
+			historyMap[h.DeliveryNoteId] = append(historyMap[h.DeliveryNoteId], models.DeliveryStatusHistoryResponse{
+				Status:    h.Status,
+				Notes:     h.Notes,
+				CreatedAt: h.CreatedAt,
+			})
+		}
+	}
+
+	for _, n := range notes {
+		sale := saleMap[n.SaleId]
+		customer := customerMap[sale.CustomerId]
+
+		noteItems := itemMap[n.Uuid]
+		if noteItems == nil {
+			noteItems = make([]models.DeliveryNoteItemResponse, 0)
+		}
+		noteFibers := fiberMap[n.Uuid]
+		if noteFibers == nil {
+			noteFibers = make([]models.DeliveryNoteFiberResponse, 0)
+		}
+		noteHistory := historyMap[n.Uuid]
+		if noteHistory == nil {
+			noteHistory = make([]models.DeliveryStatusHistoryResponse, 0)
+		}
+
+		// The detail view carries the cold-chain record of the delivered fish
+		var temperatures []models.TemperatureReadingResponse
+		excursions := 0
+		if withHistory {
+			until := truncateDate(sale.PurchaseDate).Add(24 * time.Hour)
+			var err error
+			temperatures, excursions, err = temperatureHistory(db, sortMap[n.Uuid], []string{n.SaleId}, &until)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		responses = append(responses, models.DeliveryNoteResponse{
+			Uuid:         n.Uuid,
+			DeliveryCode: fmt.Sprintf("DO%d", n.ID),
+			SaleId:       n.SaleId,
+			SaleCode:     fmt.Sprintf("SELL%d", sale.ID),
+			Customer: models.GetUserDetail{
+				Uuid:  customer.Uuid,
+				Name:  customer.Name,
+				Phone: customer.Phone,
+			},
+			DeliveryDate:          n.DeliveryDate,
+			ShippingAddress:       n.ShippingAddress,
+			RecipientName:         n.RecipientName,
+			DriverName:            n.DriverName,
+			DriverPhone:           n.DriverPhone,
+			VehiclePlate:          n.VehiclePlate,
+			VehicleType:           n.VehicleType,
+			Status:                n.Status,
+			Notes:                 n.Notes,
+			DispatchedAt:          n.DispatchedAt,
+			DeliveredAt:           n.DeliveredAt,
+			HasProof:              n.ProofPhotoPath != "",
+			ProofUploadedAt:       n.ProofUploadedAt,
+			TotalWeight:           weightMap[n.Uuid],
+			Items:                 noteItems,
+			Fibers:                noteFibers,
+			History:               noteHistory,
+			Temperatures:          temperatures,
+			TemperatureExcursions: excursions,
+		})
+	}
+
+	return responses, nil
+}
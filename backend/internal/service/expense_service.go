@@ -0,0 +1,783 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+// maxRecurringCatchUp bounds how many missed occurrences of one template a
+// single run books, so a long outage cannot flood the cash book.
+const maxRecurringCatchUp = 12
+
+type ExpenseService struct{}
+
+func NewExpenseService() repository.ExpenseRepository {
+	return &ExpenseService{}
+}
+
+// GetAllExpenseCategories - Categories with Their Ledger Codes
+// =====================================================
+func (s *ExpenseService) GetAllExpenseCategories(filter models.ExpenseFilter) ([]models.ExpenseCategoryResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Table("expense_categories AS ec").
+		Select(`ec.uuid, ec.name, ec.description, ec.ledger_account_id,
+			COALESCE(a.code, '') AS ledger_account_code, ec.is_active, ec.created_at`).
+		Joins("LEFT JOIN accounts a ON a.uuid = ec.ledger_account_id").
+		Where("ec.deleted = false")
+	if filter.ActiveOnly {
+		query = query.Where("ec.is_active = true")
+	}
+
+	responses := make([]models.ExpenseCategoryResponse, 0)
+	if err := query.Order("ec.name ASC").Scan(&responses).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch expense categories: ", err)
+	}
+
+	return responses, nil
+}
+
+// CreateExpenseCategory - Category with Its Own Expense Account
+// =====================================================
+func (s *ExpenseService) CreateExpenseCategory(request models.ExpenseCategoryRequest) (*models.ExpenseCategoryResponse, error) {
+	db := config.GetDBConn()
+
+	name := strings.TrimSpace(request.Name)
+	if err := s.checkCategoryName(db, name, ""); err != nil {
+		return nil, err
+	}
+
+	var category models.ExpenseCategory
+	err := db.Transaction(func(tx *gorm.DB) error {
+		code, err := nextLedgerCode(tx, constants.AccountCodeOperatingExpense, "expense categories")
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ledger := models.Account{
+			Uuid:        uuid.New().String(),
+			Code:        code,
+			Name:        "Beban " + name,
+			AccountType: constants.AccountExpense,
+			Description: strings.TrimSpace(request.Description),
+			IsSystem:    true,
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err = tx.Create(&ledger).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create ledger account: ", err)
+		}
+
+		category = models.ExpenseCategory{
+			Uuid:            uuid.New().String(),
+			Name:            name,
+			Description:     strings.TrimSpace(request.Description),
+			LedgerAccountId: ledger.Uuid,
+			IsActive:        request.IsActive == nil || *request.IsActive,
+			Deleted:         false,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err = tx.Create(&category).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create expense category: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCategoryResponse(db, category.Uuid)
+}
+
+// UpdateExpenseCategory - Rename or Deactivate
+// =====================================================
+func (s *ExpenseService) UpdateExpenseCategory(categoryId string, request models.ExpenseCategoryRequest) (*models.ExpenseCategoryResponse, error) {
+	db := config.GetDBConn()
+
+	category, err := findExpenseCategory(db, categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(request.Name)
+	if err = s.checkCategoryName(db, name, category.Uuid); err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"name":        name,
+			"description": strings.TrimSpace(request.Description),
+			"updated_at":  now,
+		}
+		if request.IsActive != nil {
+			updates["is_active"] = *request.IsActive
+		}
+
+		if err := tx.Model(&models.ExpenseCategory{}).
+			Where("uuid = ?", category.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update expense category: ", err)
+		}
+
+		if err := tx.Model(&models.Account{}).
+			Where("uuid = ?", category.LedgerAccountId).
+			Updates(map[string]interface{}{
+				"name":        "Beban " + name,
+				"description": strings.TrimSpace(request.Description),
+				"updated_at":  now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update ledger account: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getCategoryResponse(db, category.Uuid)
+}
+
+// DeleteExpenseCategory - Remove an Unused Category
+// =====================================================
+func (s *ExpenseService) DeleteExpenseCategory(categoryId string) error {
+	db := config.GetDBConn()
+
+	category, err := findExpenseCategory(db, categoryId)
+	if err != nil {
+		return err
+	}
+
+	var payments, templates, lines int64
+	if err = db.Model(&models.Payment{}).
+		Where("expense_category_id = ? AND deleted = false", category.Uuid).
+		Count(&payments).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check expense category usage: ", err)
+	}
+	if err = db.Model(&models.RecurringExpense{}).
+		Where("expense_category_id = ? AND deleted = false", category.Uuid).
+		Count(&templates).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check expense category usage: ", err)
+	}
+	if err = db.Model(&models.JournalLine{}).
+		Where("account_id = ? AND deleted = false", category.LedgerAccountId).
+		Count(&lines).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check expense category usage: ", err)
+	}
+	if payments > 0 || templates > 0 || lines > 0 {
+		return apperror.NewConflict(fmt.Sprintf("expense category %s is in use; deactivate it instead", category.Name))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"deleted": true, "updated_at": time.Now()}
+		if err := tx.Model(&models.ExpenseCategory{}).
+			Where("uuid = ?", category.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete expense category: ", err)
+		}
+		if err := tx.Model(&models.Account{}).
+			Where("uuid = ?", category.LedgerAccountId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete ledger account: ", err)
+		}
+		return nil
+	})
+}
+
+// GetAllCostCenters - Cost Centers by Code
+// =====================================================
+func (s *ExpenseService) GetAllCostCenters(filter models.ExpenseFilter) ([]models.CostCenterResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Where("deleted = false")
+	if filter.ActiveOnly {
+		query = query.Where("is_active = true")
+	}
+
+	var centers []models.CostCenter
+	if err := query.Order("code ASC").Find(&centers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cost centers: ", err)
+	}
+
+	responses := make([]models.CostCenterResponse, 0, len(centers))
+	for _, c := range centers {
+		responses = append(responses, costCenterResponse(c))
+	}
+
+	return responses, nil
+}
+
+// CreateCostCenter - New Cost Center
+// =====================================================
+func (s *ExpenseService) CreateCostCenter(request models.CostCenterRequest) (*models.CostCenterResponse, error) {
+	db := config.GetDBConn()
+
+	code := strings.ToUpper(strings.TrimSpace(request.Code))
+	if err := s.checkCostCenterCode(db, code, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	center := models.CostCenter{
+		Uuid:        uuid.New().String(),
+		Code:        code,
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+		IsActive:    request.IsActive == nil || *request.IsActive,
+		Deleted:     false,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := db.Create(&center).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create cost center: ", err)
+	}
+
+	response := costCenterResponse(center)
+	return &response, nil
+}
+
+// UpdateCostCenter - Edit Details or Deactivate
+// =====================================================
+func (s *ExpenseService) UpdateCostCenter(costCenterId string, request models.CostCenterRequest) (*models.CostCenterResponse, error) {
+	db := config.GetDBConn()
+
+	center, err := findCostCenter(db, costCenterId)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(request.Code))
+	if err = s.checkCostCenterCode(db, code, center.Uuid); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{
+		"code":        code,
+		"name":        strings.TrimSpace(request.Name),
+		"description": strings.TrimSpace(request.Description),
+		"updated_at":  time.Now(),
+	}
+	if request.IsActive != nil {
+		updates["is_active"] = *request.IsActive
+	}
+
+	if err = db.Model(&models.CostCenter{}).
+		Where("uuid = ?", center.Uuid).
+		Updates(updates).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to update cost center: ", err)
+	}
+
+	center, err = findCostCenter(db, costCenterId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := costCenterResponse(*center)
+	return &response, nil
+}
+
+// DeleteCostCenter - Remove an Unused Cost Center
+// =====================================================
+func (s *ExpenseService) DeleteCostCenter(costCenterId string) error {
+	db := config.GetDBConn()
+
+	center, err := findCostCenter(db, costCenterId)
+	if err != nil {
+		return err
+	}
+
+	var payments, templates int64
+	if err = db.Model(&models.Payment{}).
+		Where("cost_center_id = ? AND deleted = false", center.Uuid).
+		Count(&payments).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check cost center usage: ", err)
+	}
+	if err = db.Model(&models.RecurringExpense{}).
+		Where("cost_center_id = ? AND deleted = false", center.Uuid).
+		Count(&templates).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check cost center usage: ", err)
+	}
+	if payments > 0 || templates > 0 {
+		return apperror.NewConflict(fmt.Sprintf("cost center %s is in use; deactivate it instead", center.Code))
+	}
+
+	if err = db.Model(&models.CostCenter{}).
+		Where("uuid = ?", center.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": time.Now()}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete cost center: ", err)
+	}
+
+	return nil
+}
+
+// GetAllRecurringExpenses - Templates by Next Due Date
+// =====================================================
+func (s *ExpenseService) GetAllRecurringExpenses(filter models.ExpenseFilter) ([]models.RecurringExpenseResponse, error) {
+	db := config.GetDBConn()
+
+	query := s.recurringQuery(db)
+	if filter.ActiveOnly {
+		query = query.Where("re.is_active = true")
+	}
+
+	responses := make([]models.RecurringExpenseResponse, 0)
+	if err := query.Order("re.next_run_date ASC, re.name ASC").Scan(&responses).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch recurring expenses: ", err)
+	}
+
+	return responses, nil
+}
+
+// CreateRecurringExpense - New Expense Template
+// =====================================================
+func (s *ExpenseService) CreateRecurringExpense(request models.RecurringExpenseRequest) (*models.RecurringExpenseResponse, error) {
+	db := config.GetDBConn()
+
+	if err := s.checkRecurringRequest(db, request); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	next := truncateDate(request.NextRunDate)
+	template := models.RecurringExpense{
+		Uuid:              uuid.New().String(),
+		Name:              strings.TrimSpace(request.Name),
+		UserId:            request.UserId,
+		Total:             request.Total,
+		Description:       strings.TrimSpace(request.Description),
+		ExpenseCategoryId: request.ExpenseCategoryId,
+		CostCenterId:      request.CostCenterId,
+		CashAccountId:     request.CashAccountId,
+		PaymentMethod:     request.PaymentMethod,
+		Frequency:         request.Frequency,
+		DayOfMonth:        next.Day(),
+		NextRunDate:       next,
+		IsActive:          request.IsActive == nil || *request.IsActive,
+		Deleted:           false,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := db.Create(&template).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create recurring expense: ", err)
+	}
+
+	return s.getRecurringResponse(db, template.Uuid)
+}
+
+// UpdateRecurringExpense - Edit Template or Reschedule
+// =====================================================
+func (s *ExpenseService) UpdateRecurringExpense(templateId string, request models.RecurringExpenseRequest) (*models.RecurringExpenseResponse, error) {
+	db := config.GetDBConn()
+
+	template, err := findRecurringExpense(db, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.checkRecurringRequest(db, request); err != nil {
+		return nil, err
+	}
+
+	next := truncateDate(request.NextRunDate)
+	updates := map[string]interface{}{
+		"name":                strings.TrimSpace(request.Name),
+		"user_id":             request.UserId,
+		"total":               request.Total,
+		"description":         strings.TrimSpace(request.Description),
+		"expense_category_id": request.ExpenseCategoryId,
+		"cost_center_id":      request.CostCenterId,
+		"cash_account_id":     request.CashAccountId,
+		"payment_method":      request.PaymentMethod,
+		"frequency":           request.Frequency,
+		"day_of_month":        next.Day(),
+		"next_run_date":       next,
+		"updated_at":          time.Now(),
+	}
+	if request.IsActive != nil {
+		updates["is_active"] = *request.IsActive
+	}
+
+	if err = db.Model(&models.RecurringExpense{}).
+		Where("uuid = ?", template.Uuid).
+		Updates(updates).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to update recurring expense: ", err)
+	}
+
+	return s.getRecurringResponse(db, template.Uuid)
+}
+
+// DeleteRecurringExpense - Stop and Remove a Template
+// =====================================================
+func (s *ExpenseService) DeleteRecurringExpense(templateId string) error {
+	db := config.GetDBConn()
+
+	template, err := findRecurringExpense(db, templateId)
+	if err != nil {
+		return err
+	}
+
+	// Payments already booked from the template stay in the cash book
+	if err = db.Model(&models.RecurringExpense{}).
+		Where("uuid = ?", template.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": time.Now()}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete recurring expense: ", err)
+	}
+
+	return nil
+}
+
+// RunRecurringExpenses - Book Every Due Template
+// =====================================================
+func (s *ExpenseService) RunRecurringExpenses() (*models.RecurringExpenseRunResponse, error) {
+	db := config.GetDBConn()
+
+	now := time.Now()
+	if err := checkPeriodsOpen(db, now); err != nil {
+		return nil, err
+	}
+
+	today := truncateDate(now)
+	var templates []models.RecurringExpense
+	if err := db.Where("deleted = false AND is_active = true AND next_run_date <= ?", today).
+		Order("next_run_date ASC, id ASC").
+		Find(&templates).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch due recurring expenses: ", err)
+	}
+
+	result := &models.RecurringExpenseRunResponse{Failed: make([]string, 0)}
+	for _, template := range templates {
+		created, err := s.runTemplate(db, template, today, now)
+		if err != nil {
+			// One broken template must not hold back the others
+			config.GetLogger().Error("Failed to run recurring expense %s: %v", template.Uuid, err)
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", template.Name, err))
+			continue
+		}
+		if created == 0 {
+			// Another run booked it first, or it was edited in the meantime
+			continue
+		}
+		result.TemplatesRun++
+		result.PaymentsCreated += created
+	}
+
+	return result, nil
+}
+
+// runTemplate books every occurrence of a template due up to today as an
+// expense payment and moves the template to its next due date. The template
+// is locked and re-read first; when it is no longer due as listed, nothing is
+// booked and zero is returned.
+func (s *ExpenseService) runTemplate(db *gorm.DB, listed models.RecurringExpense, today, now time.Time) (int, error) {
+	created := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var template models.RecurringExpense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ?", listed.Uuid).
+			First(&template).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch recurring expense: ", err)
+		}
+		if template.Deleted || !template.IsActive || !template.NextRunDate.Equal(listed.NextRunDate) {
+			return nil
+		}
+
+		if err := checkPaymentAccount(tx, template.CashAccountId, template.PaymentMethod); err != nil {
+			return err
+		}
+		if _, err := expenseUser(tx, template.UserId); err != nil {
+			return err
+		}
+		if err := checkExpenseAllocation(tx, template.UserId, constants.Expense, template.ExpenseCategoryId, template.CostCenterId); err != nil {
+			return err
+		}
+
+		description := template.Description
+		if description == "" {
+			description = template.Name
+		}
+
+		next := template.NextRunDate
+		for i := 0; !next.After(today) && i < maxRecurringCatchUp; i++ {
+			payment := models.Payment{
+				Uuid:               uuid.NewString(),
+				UserId:             template.UserId,
+				Total:              template.Total,
+				Type:               constants.Expense,
+				Description:        fmt.Sprintf("%s (%s)", description, next.Format("2006-01-02")),
+				CashAccountId:      template.CashAccountId,
+				PaymentMethod:      template.PaymentMethod,
+				ExpenseCategoryId:  template.ExpenseCategoryId,
+				CostCenterId:       template.CostCenterId,
+				RecurringExpenseId: template.Uuid,
+			}
+			if err := tx.Create(&payment).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+			}
+			if err := postPaymentJournal(tx, payment, false); err != nil {
+				return err
+			}
+
+			next = nextRecurringDate(next, template.Frequency, template.DayOfMonth)
+			created++
+		}
+
+		if err := tx.Model(&models.RecurringExpense{}).
+			Where("uuid = ?", template.Uuid).
+			Updates(map[string]interface{}{
+				"next_run_date": next,
+				"last_run_at":   now,
+				"updated_at":    now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update recurring expense: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+// nextRecurringDate moves a due date one period on. Monthly templates keep
+// their day of month, falling back to the last day in shorter months.
+func nextRecurringDate(date time.Time, frequency string, dayOfMonth int) time.Time {
+	if frequency == constants.RecurringWeekly {
+		return date.AddDate(0, 0, 7)
+	}
+
+	firstOfNext := time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, constants.JakartaTz)
+	lastDay := firstOfNext.AddDate(0, 1, -1).Day()
+	day := dayOfMonth
+	if day < 1 || day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfNext.Year(), firstOfNext.Month(), day, 0, 0, 0, 0, constants.JakartaTz)
+}
+
+func (s *ExpenseService) checkRecurringRequest(db *gorm.DB, request models.RecurringExpenseRequest) error {
+	if _, err := expenseUser(db, request.UserId); err != nil {
+		return err
+	}
+	if err := checkPaymentAccount(db, request.CashAccountId, request.PaymentMethod); err != nil {
+		return err
+	}
+	return checkExpenseAllocation(db, request.UserId, constants.Expense, request.ExpenseCategoryId, request.CostCenterId)
+}
+
+func (s *ExpenseService) checkCategoryName(db *gorm.DB, name, excludeId string) error {
+	query := db.Model(&models.ExpenseCategory{}).Where("LOWER(name) = LOWER(?) AND deleted = false", name)
+	if excludeId != "" {
+		query = query.Where("uuid <> ?", excludeId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check expense category name: ", err)
+	}
+	if count > 0 {
+		return apperror.NewConflict(fmt.Sprintf("expense category %s already exists", name))
+	}
+
+	return nil
+}
+
+func (s *ExpenseService) checkCostCenterCode(db *gorm.DB, code, excludeId string) error {
+	query := db.Model(&models.CostCenter{}).Where("code = ? AND deleted = false", code)
+	if excludeId != "" {
+		query = query.Where("uuid <> ?", excludeId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check cost center code: ", err)
+	}
+	if count > 0 {
+		return apperror.NewConflict(fmt.Sprintf("cost center %s already exists", code))
+	}
+
+	return nil
+}
+
+func (s *ExpenseService) getCategoryResponse(db *gorm.DB, categoryId string) (*models.ExpenseCategoryResponse, error) {
+	var response models.ExpenseCategoryResponse
+	if err := db.Table("expense_categories AS ec").
+		Select(`ec.uuid, ec.name, ec.description, ec.ledger_account_id,
+			COALESCE(a.code, '') AS ledger_account_code, ec.is_active, ec.created_at`).
+		Joins("LEFT JOIN accounts a ON a.uuid = ec.ledger_account_id").
+		Where("ec.uuid = ?", categoryId).
+		Take(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch expense category: ", err)
+	}
+
+	return &response, nil
+}
+
+func (s *ExpenseService) recurringQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("recurring_expenses AS re").
+		Select(`re.uuid, re.name, re.user_id, COALESCE(u.name, '') AS user_name, re.total, re.description,
+			re.expense_category_id, COALESCE(ec.name, '') AS expense_category_name,
+			re.cost_center_id, COALESCE(cc.name, '') AS cost_center_name,
+			re.cash_account_id, COALESCE(ca.name, '') AS cash_account_name,
+			re.payment_method, re.frequency, re.next_run_date, re.last_run_at, re.is_active, re.created_at`).
+		Joins(`LEFT JOIN "user" u ON u.uuid = re.user_id`).
+		Joins("LEFT JOIN expense_categories ec ON ec.uuid = re.expense_category_id").
+		Joins("LEFT JOIN cost_centers cc ON cc.uuid = re.cost_center_id").
+		Joins("LEFT JOIN cash_accounts ca ON ca.uuid = re.cash_account_id").
+		Where("re.deleted = false")
+}
+
+func (s *ExpenseService) getRecurringResponse(db *gorm.DB, templateId string) (*models.RecurringExpenseResponse, error) {
+	var response models.RecurringExpenseResponse
+	if err := s.recurringQuery(db).Where("re.uuid = ?", templateId).Take(&response).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch recurring expense: ", err)
+	}
+
+	return &response, nil
+}
+
+func costCenterResponse(c models.CostCenter) models.CostCenterResponse {
+	return models.CostCenterResponse{
+		Uuid:        c.Uuid,
+		Code:        c.Code,
+		Name:        c.Name,
+		Description: c.Description,
+		IsActive:    c.IsActive,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func findExpenseCategory(db *gorm.DB, categoryId string) (*models.ExpenseCategory, error) {
+	var category models.ExpenseCategory
+	if err := db.Where("uuid = ? AND deleted = false", categoryId).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("expense category not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch expense category: ", err)
+	}
+
+	return &category, nil
+}
+
+func findCostCenter(db *gorm.DB, costCenterId string) (*models.CostCenter, error) {
+	var center models.CostCenter
+	if err := db.Where("uuid = ? AND deleted = false", costCenterId).First(&center).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("cost center not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cost center: ", err)
+	}
+
+	return &center, nil
+}
+
+func findRecurringExpense(db *gorm.DB, templateId string) (*models.RecurringExpense, error) {
+	var template models.RecurringExpense
+	if err := db.Where("uuid = ? AND deleted = false", templateId).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("recurring expense not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch recurring expense: ", err)
+	}
+
+	return &template, nil
+}
+
+// expenseUser returns the account an operational expense is booked on.
+// Buyers and suppliers are trading parties; money paid to them settles a
+// balance rather than being an expense.
+func expenseUser(db *gorm.DB, userId string) (*models.User, error) {
+	var user models.User
+	if err := db.Where("uuid = ?", userId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("user not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch user: ", err)
+	}
+	if user.Role == constants.BuyerRole || user.Role == constants.SupplierRole {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("%s is a %s; expenses are booked on staff accounts only",
+			user.Name, strings.ToLower(user.Role)))
+	}
+
+	return &user, nil
+}
+
+// checkExpenseAllocation validates the optional category and cost center of
+// a payment. Both only make sense on an operational expense.
+func checkExpenseAllocation(db *gorm.DB, userId, paymentType, categoryId, costCenterId string) error {
+	if categoryId == "" && costCenterId == "" {
+		return nil
+	}
+	if paymentType != constants.Expense {
+		return apperror.NewBadRequest("expense_category_id and cost_center_id apply to EXPENSE payments only")
+	}
+	if _, err := expenseUser(db, userId); err != nil {
+		return err
+	}
+
+	if categoryId != "" {
+		category, err := findExpenseCategory(db, categoryId)
+		if err != nil {
+			return err
+		}
+		if !category.IsActive {
+			return apperror.NewBadRequest(fmt.Sprintf("expense category %s is inactive", category.Name))
+		}
+	}
+
+	if costCenterId != "" {
+		center, err := findCostCenter(db, costCenterId)
+		if err != nil {
+			return err
+		}
+		if !center.IsActive {
+			return apperror.NewBadRequest(fmt.Sprintf("cost center %s is inactive", center.Code))
+		}
+	}
+
+	return nil
+}
+
+// expenseLedgerCode returns the expense account an operational expense
+// posts to: its category's account, or the general operating expense
+// account when it has none.
+func expenseLedgerCode(tx *gorm.DB, categoryId string) (string, error) {
+	if categoryId == "" {
+		return constants.AccountCodeOperatingExpense, nil
+	}
+
+	var code string
+	if err := tx.Table("expense_categories AS ec").
+		Select("a.code").
+		Joins("INNER JOIN accounts a ON a.uuid = ec.ledger_account_id").
+		Where("ec.uuid = ?", categoryId).
+		Limit(1).
+		Scan(&code).Error; err != nil {
+		return "", apperror.NewUnprocessableEntity("failed to fetch expense ledger account: ", err)
+	}
+	if code == "" {
+		return "", apperror.NewNotFound("expense category not found")
+	}
+
+	return code, nil
+}
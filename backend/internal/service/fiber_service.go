@@ -3,16 +3,22 @@ package service
 import (
 	"dashboard-app/pkg/apperror"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"dashboard-app/internal/config"
 	"dashboard-app/internal/models"
 	"dashboard-app/internal/repository"
 )
 
+// maxBulkFibers caps how many fibers one bulk request or import may create.
+const maxBulkFibers = 1000
+
 type FiberService struct{}
 
 func NewFiberService() repository.FiberRepository {
@@ -38,8 +44,11 @@ func (s *FiberService) GetAllFibers(filter models.FiberFilter) (*models.FiberPag
 		Select(`
 			f.uuid,
 			f.name,
+			f.fiber_type,
+			f.capacity,
 			f.status,
 			f.stock_sort_id,
+			COALESCE(f.location_id, '') AS location_id,
 			f.deleted,
 			f.created_at,
 			f.sale_id,
@@ -60,6 +69,10 @@ func (s *FiberService) GetAllFibers(filter models.FiberFilter) (*models.FiberPag
 		query = query.Where("f.status = ?", filter.Status)
 	}
 
+	if filter.LocationId != "" {
+		query = query.Where("f.location_id = ?", filter.LocationId)
+	}
+
 	// Get total count
 	var total int64
 	countQuery := *query
@@ -83,9 +96,12 @@ func (s *FiberService) GetAllFibers(filter models.FiberFilter) (*models.FiberPag
 		response := models.FiberResponse{
 			Uuid:        result.Uuid,
 			Name:        result.Name,
+			FiberType:   result.FiberType,
+			Capacity:    result.Capacity,
 			Status:      result.Status,
 			StockSortId: result.StockSortId,
 			SaleId:      result.SaleId,
+			LocationId:  result.LocationId,
 			Deleted:     result.Deleted,
 			CreatedAt:   result.CreatedAt,
 		}
@@ -116,8 +132,11 @@ func (s *FiberService) GetFiberById(id string) (*models.FiberResponse, error) {
 		Select(`
 			f.uuid,
 			f.name,
+			f.fiber_type,
+			f.capacity,
 			f.status,
 			f.stock_sort_id,
+			COALESCE(f.location_id, '') AS location_id,
 			f.deleted,
 			f.created_at,
 			f.sale_id,
@@ -143,12 +162,19 @@ func (s *FiberService) CreateFiber(request models.FiberRequest) (*models.FiberRe
 		return nil, fmt.Errorf("invalid status: must be FREE or USED")
 	}
 
+	if err := checkLocations(config.GetDBConn(), []string{request.LocationId}); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	newFiber := models.Fiber{
 		Uuid:        uuid.New().String(),
 		Name:        strings.TrimSpace(request.Name),
+		FiberType:   strings.TrimSpace(request.FiberType),
+		Capacity:    request.Capacity,
 		Status:      request.Status,
 		StockSortId: request.StockSortId,
+		LocationId:  request.LocationId,
 		Deleted:     false,
 		CreatedAt:   now,
 		UpdatedAt:   now,
@@ -161,8 +187,11 @@ func (s *FiberService) CreateFiber(request models.FiberRequest) (*models.FiberRe
 	return &models.FiberResponse{
 		Uuid:        newFiber.Uuid,
 		Name:        newFiber.Name,
+		FiberType:   newFiber.FiberType,
+		Capacity:    newFiber.Capacity,
 		Status:      newFiber.Status,
 		StockSortId: newFiber.StockSortId,
+		LocationId:  newFiber.LocationId,
 		Deleted:     newFiber.Deleted,
 		CreatedAt:   newFiber.CreatedAt,
 	}, nil
@@ -176,8 +205,14 @@ func (s *FiberService) UpdateFiber(fiberId string, request models.FiberRequest)
 		return apperror.NewBadRequest("invalid status: must be FREE or USED")
 	}
 
+	if err := checkLocations(config.GetDBConn(), []string{request.LocationId}); err != nil {
+		return err
+	}
+
 	updates := map[string]interface{}{
 		"name":       strings.TrimSpace(request.Name),
+		"fiber_type": strings.TrimSpace(request.FiberType),
+		"capacity":   request.Capacity,
 		"status":     request.Status,
 		"updated_at": time.Now(),
 	}
@@ -187,6 +222,11 @@ func (s *FiberService) UpdateFiber(fiberId string, request models.FiberRequest)
 		updates["stock_sort_id"] = request.StockSortId
 	}
 
+	// Only update location_id if provided; transfers move fibers otherwise
+	if request.LocationId != "" {
+		updates["location_id"] = request.LocationId
+	}
+
 	result := config.GetDBConn().
 		Model(&models.Fiber{}).
 		Where("uuid = ? AND deleted = false", fiberId).
@@ -310,8 +350,11 @@ func (s *FiberService) GetAllUsedFibers() ([]models.FiberResponse, error) {
 		Select(`
 			f.uuid,
 			f.name,
+			f.fiber_type,
+			f.capacity,
 			f.status,
 			f.stock_sort_id,
+			COALESCE(f.location_id, '') AS location_id,
 			f.deleted,
 			f.created_at,
 			CASE 
@@ -332,6 +375,8 @@ func (s *FiberService) GetAllUsedFibers() ([]models.FiberResponse, error) {
 		response := models.FiberResponse{
 			Uuid:        result.Uuid,
 			Name:        result.Name,
+			FiberType:   result.FiberType,
+			Capacity:    result.Capacity,
 			Status:      result.Status,
 			StockSortId: result.StockSortId,
 			Deleted:     result.Deleted,
@@ -365,8 +410,11 @@ func (s *FiberService) GetAvailableFibers() ([]models.FiberResponse, error) {
 		responseData = append(responseData, models.FiberResponse{
 			Uuid:        fiber.Uuid,
 			Name:        fiber.Name,
+			FiberType:   fiber.FiberType,
+			Capacity:    fiber.Capacity,
 			Status:      fiber.Status,
 			StockSortId: fiber.StockSortId,
+			LocationId:  fiber.LocationId,
 			Deleted:     fiber.Deleted,
 			CreatedAt:   fiber.CreatedAt,
 		})
@@ -392,6 +440,8 @@ func (s *FiberService) GetFibersByStockSort(stockSortId string) ([]models.FiberR
 		responseData = append(responseData, models.FiberResponse{
 			Uuid:        fiber.Uuid,
 			Name:        fiber.Name,
+			FiberType:   fiber.FiberType,
+			Capacity:    fiber.Capacity,
 			Status:      fiber.Status,
 			StockSortId: fiber.StockSortId,
 			Deleted:     fiber.Deleted,
@@ -427,6 +477,231 @@ func (s *FiberService) GetFiberStatistics() (*models.FiberStatistics, error) {
 	}, nil
 }
 
+// BulkCreateFibers - Pattern or list based, all-or-nothing
+// =====================================================
+func (s *FiberService) BulkCreateFibers(request models.BulkFiberRequest) (*models.BulkFiberResponse, error) {
+	rows := make([]fiberImportRow, 0, len(request.Data))
+
+	for i, v := range request.Data {
+		rows = append(rows, fiberImportRow{row: i + 1, request: v})
+	}
+
+	if p := request.Pattern; p != nil {
+		if p.End-p.Start+1 > maxBulkFibers {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("pattern expands to more than %d fibers", maxBulkFibers))
+		}
+
+		for n := p.Start; n <= p.End; n++ {
+			rows = append(rows, fiberImportRow{
+				row: len(rows) + 1,
+				request: models.FiberRequest{
+					Name:      fmt.Sprintf("%s%0*d", strings.TrimSpace(p.Prefix), p.Padding, n),
+					FiberType: p.FiberType,
+					Capacity:  p.Capacity,
+					Status:    "FREE",
+				},
+			})
+		}
+	}
+
+	return s.createFiberRows(rows)
+}
+
+// ImportFibers - CSV/XLSX upload with per-row validation report
+// =====================================================
+func (s *FiberService) ImportFibers(filename string, file io.Reader) (*models.BulkFiberResponse, error) {
+	records, err := readSpreadsheetRows(filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, apperror.NewBadRequest("file must contain a header row and at least one fiber")
+	}
+	if len(records)-1 > maxBulkFibers {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("file contains more than %d fibers", maxBulkFibers))
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, apperror.NewBadRequest("header row must contain a name column")
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]fiberImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := fiberImportRow{
+			row: i + 2,
+			request: models.FiberRequest{
+				Name:      cell(record, "name"),
+				FiberType: cell(record, "fiber_type"),
+				Status:    strings.ToUpper(cell(record, "status")),
+			},
+		}
+
+		if row.request.Status == "" {
+			row.request.Status = "FREE"
+		}
+
+		if capacity := cell(record, "capacity"); capacity != "" {
+			value, err := strconv.Atoi(capacity)
+			if err != nil {
+				row.errors = append(row.errors, fmt.Sprintf("capacity %q is not a number", capacity))
+			}
+			row.request.Capacity = value
+		}
+
+		rows = append(rows, row)
+	}
+
+	return s.createFiberRows(rows)
+}
+
+// fiberImportRow is a single fiber to create together with the row number it
+// came from and any parse errors found before validation.
+type fiberImportRow struct {
+	row     int
+	request models.FiberRequest
+	errors  []string
+}
+
+// createFiberRows validates every row and only inserts when all rows are
+// valid, so a bulk request either creates every fiber or none of them.
+func (s *FiberService) createFiberRows(rows []fiberImportRow) (*models.BulkFiberResponse, error) {
+	if len(rows) == 0 {
+		return nil, apperror.NewBadRequest("no fibers to create")
+	}
+	if len(rows) > maxBulkFibers {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("cannot create more than %d fibers at once", maxBulkFibers))
+	}
+
+	db := config.GetDBConn()
+
+	names := make([]string, 0, len(rows))
+	for i := range rows {
+		rows[i].request.Name = strings.TrimSpace(rows[i].request.Name)
+		rows[i].request.FiberType = strings.TrimSpace(rows[i].request.FiberType)
+		names = append(names, strings.ToLower(rows[i].request.Name))
+	}
+
+	var existingNames []string
+	if err := db.Model(&models.Fiber{}).
+		Where("LOWER(name) IN ? AND deleted = false", names).
+		Pluck("LOWER(name)", &existingNames).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to check existing fibers: ", err)
+	}
+
+	existing := make(map[string]bool, len(existingNames))
+	for _, name := range existingNames {
+		existing[name] = true
+	}
+
+	resp := &models.BulkFiberResponse{
+		TotalRows: len(rows),
+		Rows:      make([]models.FiberImportRow, 0, len(rows)),
+		Fibers:    make([]models.FiberResponse, 0),
+	}
+
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		errs := row.errors
+		key := strings.ToLower(row.request.Name)
+
+		if row.request.Name == "" {
+			errs = append(errs, "name is required")
+		} else {
+			if existing[key] {
+				errs = append(errs, "fiber name already exists")
+			}
+			if first, dup := seen[key]; dup {
+				errs = append(errs, fmt.Sprintf("duplicate name, first used on row %d", first))
+			} else {
+				seen[key] = row.row
+			}
+		}
+
+		if !s.isValidStatus(row.request.Status) {
+			errs = append(errs, "invalid status: must be FREE or USED")
+		}
+		if row.request.Capacity < 0 {
+			errs = append(errs, "capacity cannot be negative")
+		}
+
+		if len(errs) > 0 {
+			resp.InvalidRows++
+		}
+
+		resp.Rows = append(resp.Rows, models.FiberImportRow{
+			Row:    row.row,
+			Name:   row.request.Name,
+			Errors: errs,
+		})
+	}
+
+	if resp.InvalidRows > 0 {
+		return resp, nil
+	}
+
+	locationIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		locationIDs = append(locationIDs, row.request.LocationId)
+	}
+	if err := checkLocations(db, locationIDs); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fibers := make([]models.Fiber, 0, len(rows))
+	for _, row := range rows {
+		fibers = append(fibers, models.Fiber{
+			Uuid:        uuid.New().String(),
+			Name:        row.request.Name,
+			FiberType:   row.request.FiberType,
+			Capacity:    row.request.Capacity,
+			Status:      row.request.Status,
+			StockSortId: row.request.StockSortId,
+			LocationId:  row.request.LocationId,
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&fibers, 100).Error
+	}); err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create fibers: ", err)
+	}
+
+	for _, fiber := range fibers {
+		resp.Fibers = append(resp.Fibers, models.FiberResponse{
+			Uuid:        fiber.Uuid,
+			Name:        fiber.Name,
+			FiberType:   fiber.FiberType,
+			Capacity:    fiber.Capacity,
+			Status:      fiber.Status,
+			StockSortId: fiber.StockSortId,
+			Deleted:     fiber.Deleted,
+			CreatedAt:   fiber.CreatedAt,
+		})
+	}
+
+	resp.Success = true
+	resp.CreatedCount = len(fibers)
+
+	return resp, nil
+}
+
 // isValidStatus checks if the fiber status is valid
 func (s *FiberService) isValidStatus(status string) bool {
 	validStatuses := map[string]bool{
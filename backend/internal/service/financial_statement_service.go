@@ -0,0 +1,487 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type FinancialStatementService struct{}
+
+func NewFinancialStatementService() repository.FinancialStatementRepository {
+	return &FinancialStatementService{}
+}
+
+// GetProfitLoss - Revenue, Cost of Sales and Expenses for a Period
+// =====================================================
+func (s *FinancialStatementService) GetProfitLoss(filter models.StatementPeriodFilter) (*models.ProfitLossResponse, error) {
+	db := config.GetDBConn()
+
+	current, previous, err := resolveStatementPeriod(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := statementAccounts(db, current, previous, "")
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ProfitLossResponse{
+		StartDate:         current.startDate(),
+		EndDate:           current.endDate(),
+		PreviousStartDate: previous.startDate(),
+		PreviousEndDate:   previous.endDate(),
+		Revenue: buildStatementSection("Revenue", accounts, func(a statementAccount) bool {
+			return a.accountType == constants.AccountRevenue
+		}),
+		CostOfGoodsSold: buildStatementSection("Cost of goods sold", accounts, isCostOfSales),
+		Expenses: buildStatementSection("Operating expenses", accounts, func(a statementAccount) bool {
+			return a.accountType == constants.AccountExpense && !isCostOfSales(a)
+		}),
+	}
+
+	response.GrossProfit = statementTotal(
+		response.Revenue.Total-response.CostOfGoodsSold.Total,
+		response.Revenue.PreviousTotal-response.CostOfGoodsSold.PreviousTotal,
+	)
+	response.NetProfit = statementTotal(
+		response.GrossProfit.Amount-response.Expenses.Total,
+		response.GrossProfit.PreviousAmount-response.Expenses.PreviousTotal,
+	)
+
+	return response, nil
+}
+
+// GetBalanceSheet - Assets, Liabilities and Equity at a Date
+// =====================================================
+func (s *FinancialStatementService) GetBalanceSheet(filter models.BalanceSheetFilter) (*models.BalanceSheetResponse, error) {
+	db := config.GetDBConn()
+
+	date := time.Now().In(constants.JakartaTz)
+	if filter.Date != "" {
+		parsed, err := time.Parse("2006-01-02", filter.Date)
+		if err != nil {
+			return nil, apperror.NewBadRequest("date must be in YYYY-MM-DD format")
+		}
+		date = parsed
+	}
+
+	compareDate := date.AddDate(0, -1, 0)
+	if filter.CompareDate != "" {
+		parsed, err := time.Parse("2006-01-02", filter.CompareDate)
+		if err != nil {
+			return nil, apperror.NewBadRequest("compare_date must be in YYYY-MM-DD format")
+		}
+		compareDate = parsed
+	}
+
+	// Balances are cumulative, so both periods start at the first entry
+	accounts, err := statementAccounts(db,
+		statementPeriod{end: date},
+		statementPeriod{end: compareDate},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.BalanceSheetResponse{
+		Date:        date.Format("2006-01-02"),
+		CompareDate: compareDate.Format("2006-01-02"),
+		Assets: buildStatementSection("Assets", accounts, func(a statementAccount) bool {
+			return a.accountType == constants.AccountAsset
+		}),
+		Liabilities: buildStatementSection("Liabilities", accounts, func(a statementAccount) bool {
+			return a.accountType == constants.AccountLiability
+		}),
+		Equity: buildStatementSection("Equity", accounts, func(a statementAccount) bool {
+			return a.accountType == constants.AccountEquity
+		}),
+	}
+
+	// Revenue and expenses are not closed into equity, so their net to date
+	// is shown as earnings
+	var earnings, previousEarnings int
+	for _, a := range accounts {
+		switch a.accountType {
+		case constants.AccountRevenue:
+			earnings += a.amount
+			previousEarnings += a.previous
+		case constants.AccountExpense:
+			earnings -= a.amount
+			previousEarnings -= a.previous
+		}
+	}
+	if earnings != 0 || previousEarnings != 0 {
+		line := statementLine(statementAccount{name: "Laba Ditahan", amount: earnings, previous: previousEarnings})
+		response.Equity.Lines = append(response.Equity.Lines, line)
+		response.Equity = sumStatementSection(response.Equity)
+	}
+
+	response.TotalLiabilitiesAndEquity = statementTotal(
+		response.Liabilities.Total+response.Equity.Total,
+		response.Liabilities.PreviousTotal+response.Equity.PreviousTotal,
+	)
+	response.Balanced = response.Assets.Total == response.TotalLiabilitiesAndEquity.Amount
+
+	return response, nil
+}
+
+// GetCashFlowStatement - Cash Movements by Activity
+// =====================================================
+func (s *FinancialStatementService) GetCashFlowStatement(filter models.StatementPeriodFilter) (*models.CashFlowStatementResponse, error) {
+	db := config.GetDBConn()
+
+	current, previous, err := resolveStatementPeriod(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cashCodes, err := cashAccountCodes(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every non-cash line of an entry that touches cash explains part of
+	// the cash movement: a credit there is cash coming in
+	accounts, err := statementAccounts(db, current, previous, `
+		a.code NOT IN (?) AND EXISTS (
+			SELECT 1 FROM journal_lines cl
+			INNER JOIN accounts ca ON ca.uuid = cl.account_id
+			WHERE cl.journal_entry_id = jl.journal_entry_id AND cl.deleted = false AND ca.code IN (?)
+		)`, cashCodes, cashCodes)
+	if err != nil {
+		return nil, err
+	}
+	for i := range accounts {
+		accounts[i].amount = accounts[i].credit - accounts[i].debit
+		accounts[i].previous = accounts[i].previousCredit - accounts[i].previousDebit
+	}
+
+	openingCash, err := cashBalanceBefore(db, current.start)
+	if err != nil {
+		return nil, err
+	}
+	previousOpeningCash, err := cashBalanceBefore(db, previous.start)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := []models.StatementSection{
+		buildStatementSection("Operating activities", accounts, func(a statementAccount) bool {
+			return cashFlowActivity(a) == "operating"
+		}),
+		buildStatementSection("Investing activities", accounts, func(a statementAccount) bool {
+			return cashFlowActivity(a) == "investing"
+		}),
+		buildStatementSection("Financing activities", accounts, func(a statementAccount) bool {
+			return cashFlowActivity(a) == "financing"
+		}),
+	}
+
+	var netChange, previousNetChange int
+	for _, section := range sections {
+		netChange += section.Total
+		previousNetChange += section.PreviousTotal
+	}
+
+	return &models.CashFlowStatementResponse{
+		StartDate:         current.startDate(),
+		EndDate:           current.endDate(),
+		PreviousStartDate: previous.startDate(),
+		PreviousEndDate:   previous.endDate(),
+		OpeningCash:       statementTotal(openingCash, previousOpeningCash),
+		Sections:          sections,
+		NetChange:         statementTotal(netChange, previousNetChange),
+		ClosingCash:       statementTotal(openingCash+netChange, previousOpeningCash+previousNetChange),
+	}, nil
+}
+
+// statementPeriod is an inclusive date range; a zero start means from the
+// first entry.
+type statementPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+func (p statementPeriod) startDate() string {
+	if p.start.IsZero() {
+		return ""
+	}
+	return p.start.Format("2006-01-02")
+}
+
+func (p statementPeriod) endDate() string {
+	return p.end.Format("2006-01-02")
+}
+
+// resolveStatementPeriod returns the requested period and the one before it
+// of the same length: the previous month for a month, otherwise the same
+// number of days ending the day before.
+func resolveStatementPeriod(filter models.StatementPeriodFilter) (statementPeriod, statementPeriod, error) {
+	var current statementPeriod
+
+	switch {
+	case filter.Month != "":
+		month, err := time.Parse("2006-01", filter.Month)
+		if err != nil {
+			return current, current, apperror.NewBadRequest("month must be in YYYY-MM format")
+		}
+		current = statementPeriod{start: month, end: month.AddDate(0, 1, -1)}
+		return current, statementPeriod{start: month.AddDate(0, -1, 0), end: month.AddDate(0, 0, -1)}, nil
+
+	case filter.StartDate != "" || filter.EndDate != "":
+		if filter.StartDate == "" || filter.EndDate == "" {
+			return current, current, apperror.NewBadRequest("start_date and end_date must be given together")
+		}
+		start, err := time.Parse("2006-01-02", filter.StartDate)
+		if err != nil {
+			return current, current, apperror.NewBadRequest("start_date must be in YYYY-MM-DD format")
+		}
+		end, err := time.Parse("2006-01-02", filter.EndDate)
+		if err != nil {
+			return current, current, apperror.NewBadRequest("end_date must be in YYYY-MM-DD format")
+		}
+		if end.Before(start) {
+			return current, current, apperror.NewBadRequest("end_date must not be before start_date")
+		}
+
+		days := int(end.Sub(start).Hours()/24) + 1
+		previousEnd := start.AddDate(0, 0, -1)
+		return statementPeriod{start: start, end: end},
+			statementPeriod{start: previousEnd.AddDate(0, 0, 1-days), end: previousEnd}, nil
+	}
+
+	now := time.Now().In(constants.JakartaTz)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	current = statementPeriod{start: month, end: month.AddDate(0, 1, -1)}
+	return current, statementPeriod{start: month.AddDate(0, -1, 0), end: month.AddDate(0, 0, -1)}, nil
+}
+
+// statementAccount holds one account's movement in the current and the
+// comparison period. amount and previous are on the account's normal side.
+type statementAccount struct {
+	id             string
+	code           string
+	name           string
+	accountType    string
+	isSystem       bool
+	debit          int
+	credit         int
+	previousDebit  int
+	previousCredit int
+	amount         int
+	previous       int
+}
+
+type accountMovement struct {
+	AccountId   string `gorm:"column:account_id"`
+	Code        string `gorm:"column:code"`
+	Name        string `gorm:"column:name"`
+	AccountType string `gorm:"column:account_type"`
+	IsSystem    bool   `gorm:"column:is_system"`
+	Debit       int    `gorm:"column:debit"`
+	Credit      int    `gorm:"column:credit"`
+}
+
+// statementAccounts sums journal lines per account for both periods, sorted
+// by account code. An optional condition narrows the lines (alias jl) or
+// accounts (alias a).
+func statementAccounts(db *gorm.DB, current, previous statementPeriod, where string, args ...interface{}) ([]statementAccount, error) {
+	movements := func(period statementPeriod) ([]accountMovement, error) {
+		query := db.Table("journal_lines AS jl").
+			Select(`
+				a.uuid AS account_id,
+				a.code,
+				a.name,
+				a.account_type,
+				a.is_system,
+				COALESCE(SUM(jl.debit), 0) AS debit,
+				COALESCE(SUM(jl.credit), 0) AS credit
+			`).
+			Joins("INNER JOIN journal_entries je ON je.uuid = jl.journal_entry_id AND je.deleted = false").
+			Joins("INNER JOIN accounts a ON a.uuid = jl.account_id").
+			Where("jl.deleted = false").
+			Where("DATE(je.entry_date) <= CAST(? AS DATE)", period.endDate())
+		if !period.start.IsZero() {
+			query = query.Where("DATE(je.entry_date) >= CAST(? AS DATE)", period.startDate())
+		}
+		if where != "" {
+			query = query.Where(where, args...)
+		}
+
+		var rows []accountMovement
+		if err := query.Group("a.uuid, a.code, a.name, a.account_type, a.is_system").Scan(&rows).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to sum account movements: ", err)
+		}
+		return rows, nil
+	}
+
+	currentRows, err := movements(current)
+	if err != nil {
+		return nil, err
+	}
+	previousRows, err := movements(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	accountMap := make(map[string]*statementAccount)
+	get := func(row accountMovement) *statementAccount {
+		a, ok := accountMap[row.AccountId]
+		if !ok {
+			a = &statementAccount{
+				id:          row.AccountId,
+				code:        row.Code,
+				name:        row.Name,
+				accountType: row.AccountType,
+				isSystem:    row.IsSystem,
+			}
+			accountMap[row.AccountId] = a
+		}
+		return a
+	}
+	for _, row := range currentRows {
+		a := get(row)
+		a.debit, a.credit = row.Debit, row.Credit
+		a.amount = signedBalance(row.AccountType, row.Debit, row.Credit)
+	}
+	for _, row := range previousRows {
+		a := get(row)
+		a.previousDebit, a.previousCredit = row.Debit, row.Credit
+		a.previous = signedBalance(row.AccountType, row.Debit, row.Credit)
+	}
+
+	accounts := make([]statementAccount, 0, len(accountMap))
+	for _, a := range accountMap {
+		accounts = append(accounts, *a)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].code < accounts[j].code
+	})
+
+	return accounts, nil
+}
+
+func buildStatementSection(name string, accounts []statementAccount, include func(statementAccount) bool) models.StatementSection {
+	section := models.StatementSection{
+		Name:  name,
+		Lines: make([]models.StatementLine, 0),
+	}
+	for _, a := range accounts {
+		if !include(a) || (a.amount == 0 && a.previous == 0) {
+			continue
+		}
+		section.Lines = append(section.Lines, statementLine(a))
+	}
+
+	return sumStatementSection(section)
+}
+
+func sumStatementSection(section models.StatementSection) models.StatementSection {
+	section.Total, section.PreviousTotal = 0, 0
+	for _, line := range section.Lines {
+		section.Total += line.Amount
+		section.PreviousTotal += line.PreviousAmount
+	}
+	total := statementTotal(section.Total, section.PreviousTotal)
+	section.Change = total.Change
+	section.ChangePercent = total.ChangePercent
+
+	return section
+}
+
+func statementLine(a statementAccount) models.StatementLine {
+	total := statementTotal(a.amount, a.previous)
+	return models.StatementLine{
+		AccountId:      a.id,
+		AccountCode:    a.code,
+		AccountName:    a.name,
+		Amount:         a.amount,
+		PreviousAmount: a.previous,
+		Change:         total.Change,
+		ChangePercent:  total.ChangePercent,
+	}
+}
+
+// statementTotal compares a figure with the previous period. The percentage
+// is relative to the size of the previous figure, so a smaller loss shows
+// as an improvement.
+func statementTotal(amount, previous int) models.StatementTotal {
+	base := previous
+	if base < 0 {
+		base = -base
+	}
+
+	return models.StatementTotal{
+		Amount:         amount,
+		PreviousAmount: previous,
+		Change:         amount - previous,
+		ChangePercent:  yieldPercent(amount-previous, base),
+	}
+}
+
+// isCostOfSales reports whether an expense account belongs to cost of sales,
+// which the chart of accounts numbers in the 5xxx range.
+func isCostOfSales(a statementAccount) bool {
+	return a.accountType == constants.AccountExpense && strings.HasPrefix(a.code, "5")
+}
+
+// cashFlowActivity classifies the counter account of a cash movement.
+// Equity is financing and accounts added for long-lived assets are
+// investing; everything else comes from trading.
+func cashFlowActivity(a statementAccount) string {
+	switch {
+	case a.accountType == constants.AccountEquity:
+		return "financing"
+	case a.accountType == constants.AccountAsset && !a.isSystem:
+		return "investing"
+	default:
+		return "operating"
+	}
+}
+
+// cashAccountCodes lists the accounts that hold money: the main cash account
+// and the ledger account of every cash box and bank account. Transfers
+// between them touch only these accounts and so never show as cash flow.
+func cashAccountCodes(db *gorm.DB) ([]string, error) {
+	var codes []string
+	if err := db.Table("cash_accounts AS ca").
+		Joins("INNER JOIN accounts a ON a.uuid = ca.ledger_account_id").
+		Where("ca.deleted = false").
+		Pluck("a.code", &codes).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cash accounts: ", err)
+	}
+
+	return distinct(append(codes, constants.AccountCodeCash)), nil
+}
+
+func cashBalanceBefore(db *gorm.DB, date time.Time) (int, error) {
+	cashCodes, err := cashAccountCodes(db)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance int
+	if err := db.Table("journal_lines AS jl").
+		Select("COALESCE(SUM(jl.debit - jl.credit), 0)").
+		Joins("INNER JOIN journal_entries je ON je.uuid = jl.journal_entry_id AND je.deleted = false").
+		Joins("INNER JOIN accounts a ON a.uuid = jl.account_id").
+		Where("jl.deleted = false AND a.code IN ?", cashCodes).
+		Where("DATE(je.entry_date) < CAST(? AS DATE)", date.Format("2006-01-02")).
+		Scan(&balance).Error; err != nil {
+		return 0, apperror.NewUnprocessableEntity(fmt.Sprintf("failed to fetch cash balance before %s: ", date.Format("2006-01-02")), err)
+	}
+
+	return balance, nil
+}
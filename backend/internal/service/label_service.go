@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"dashboard-app/internal/constants"
+	"dashboard-app/pkg/apperror"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+var (
+	saleCodePattern  = regexp.MustCompile(`^SELL(\d+)$`)
+	stockCodePattern = regexp.MustCompile(`^STOCK-?(\d+)$`)
+)
+
+type LabelService struct {
+	fiberRepository repository.FiberRepository
+	salesRepository repository.SalesRepository
+}
+
+func NewLabelService(fiberRepository repository.FiberRepository, salesRepository repository.SalesRepository) repository.LabelRepository {
+	return &LabelService{
+		fiberRepository: fiberRepository,
+		salesRepository: salesRepository,
+	}
+}
+
+// GetFiberLabels - One label per fiber, encoding the fiber UUID
+// =====================================================
+func (s *LabelService) GetFiberLabels(filter models.FiberLabelFilter) ([]models.Label, error) {
+	db := config.GetDBConn()
+
+	query := db.Model(&models.Fiber{}).Where("deleted = false")
+
+	if ids := strings.TrimSpace(filter.Ids); ids != "" {
+		fiberIDs := make([]string, 0)
+		for _, id := range strings.Split(ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				fiberIDs = append(fiberIDs, id)
+			}
+		}
+		query = query.Where("uuid IN ?", fiberIDs)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var fibers []models.Fiber
+	if err := query.Order("name ASC").Find(&fibers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch fibers: ", err)
+	}
+
+	if len(fibers) == 0 {
+		return nil, apperror.NewNotFound("no fibers found to print")
+	}
+
+	labels := make([]models.Label, 0, len(fibers))
+	for _, fiber := range fibers {
+		subtitle := fiber.FiberType
+		if fiber.Capacity > 0 {
+			subtitle = strings.TrimSpace(fmt.Sprintf("%s %d kg", fiber.FiberType, fiber.Capacity))
+		}
+
+		labels = append(labels, models.Label{
+			Code:     fiber.Uuid,
+			Title:    fiber.Name,
+			Subtitle: subtitle,
+		})
+	}
+
+	return labels, nil
+}
+
+// GetStockLabels - One label for the stock entry plus one per stock sort
+// =====================================================
+func (s *LabelService) GetStockLabels(stockId string) ([]models.Label, error) {
+	db := config.GetDBConn()
+
+	var entry struct {
+		ID           int    `gorm:"column:id"`
+		SupplierName string `gorm:"column:supplier_name"`
+	}
+
+	if err := db.Table("stock_entries AS se").
+		Select("se.id, u.name AS supplier_name").
+		Joins("LEFT JOIN purchase p ON p.stock_id = se.uuid AND p.deleted = false").
+		Joins("LEFT JOIN \"user\" u ON u.uuid = p.supplier_id").
+		Where("se.uuid = ? AND se.deleted = false", stockId).
+		Scan(&entry).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock entry: ", err)
+	}
+
+	if entry.ID == 0 {
+		return nil, apperror.NewNotFound("stock entry not found")
+	}
+
+	stockCode := fmt.Sprintf("STOCK%d", entry.ID)
+
+	var sorts []models.StockSort
+	if err := db.Table("stock_sorts AS ss").
+		Select("ss.*").
+		Joins("INNER JOIN stock_items si ON si.uuid = ss.stock_item_id AND si.deleted = false").
+		Where("si.stock_entry_id = ? AND ss.deleted = false AND ss.is_shrinkage = false", stockId).
+		Order("ss.id ASC").
+		Scan(&sorts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	labels := make([]models.Label, 0, len(sorts)+1)
+	labels = append(labels, models.Label{
+		Code:     stockCode,
+		Title:    stockCode,
+		Subtitle: entry.SupplierName,
+	})
+
+	for _, srt := range sorts {
+		labels = append(labels, models.Label{
+			Code:     srt.Uuid,
+			Title:    stockCode,
+			Subtitle: fmt.Sprintf("%s %d kg", srt.ItemName, srt.Weight),
+		})
+	}
+
+	return labels, nil
+}
+
+// LookupCode - Resolve a scanned code to a fiber, stock or sale
+// =====================================================
+func (s *LabelService) LookupCode(ctx context.Context, code string) (*models.ScanLookupResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	code = strings.TrimSpace(code)
+	normalized := strings.ToUpper(code)
+
+	if match := saleCodePattern.FindStringSubmatch(normalized); match != nil {
+		id, _ := strconv.Atoi(match[1])
+
+		var sale models.Sale
+		if err := db.Where("id = ? AND deleted = false", id).First(&sale).Error; err != nil {
+			return nil, apperror.NewNotFound(fmt.Sprintf("sale not found: %s", code))
+		}
+
+		return s.lookupSale(ctx, code, sale.Uuid)
+	}
+
+	if match := stockCodePattern.FindStringSubmatch(normalized); match != nil {
+		id, _ := strconv.Atoi(match[1])
+
+		sorts, err := s.findStockSorts(db, "se.id = ?", id)
+		if err != nil {
+			return nil, err
+		}
+		if len(sorts) == 0 {
+			return nil, apperror.NewNotFound(fmt.Sprintf("stock not found: %s", code))
+		}
+
+		return &models.ScanLookupResponse{
+			Type:       constants.ScanTypeStock,
+			Code:       code,
+			StockSorts: sorts,
+		}, nil
+	}
+
+	if _, err := uuid.Parse(code); err != nil {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("unrecognised code: %s", code))
+	}
+
+	var count int64
+	if err := db.Model(&models.Fiber{}).Where("uuid = ? AND deleted = false", code).Count(&count).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to lookup fiber: ", err)
+	}
+	if count > 0 {
+		fiber, err := s.fiberRepository.GetFiberById(code)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.ScanLookupResponse{
+			Type:  constants.ScanTypeFiber,
+			Code:  code,
+			Fiber: fiber,
+		}, nil
+	}
+
+	sorts, err := s.findStockSorts(db, "ss.uuid = ?", code)
+	if err != nil {
+		return nil, err
+	}
+	if len(sorts) > 0 {
+		return &models.ScanLookupResponse{
+			Type:       constants.ScanTypeStockSort,
+			Code:       code,
+			StockSorts: sorts,
+		}, nil
+	}
+
+	if err := db.Model(&models.Sale{}).Where("uuid = ? AND deleted = false", code).Count(&count).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to lookup sale: ", err)
+	}
+	if count > 0 {
+		return s.lookupSale(ctx, code, code)
+	}
+
+	return nil, apperror.NewNotFound(fmt.Sprintf("no fiber, stock sort or sale matches code: %s", code))
+}
+
+func (s *LabelService) lookupSale(ctx context.Context, code, saleId string) (*models.ScanLookupResponse, error) {
+	sale, err := s.salesRepository.GetSaleById(ctx, saleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ScanLookupResponse{
+		Type: constants.ScanTypeSale,
+		Code: code,
+		Sale: sale,
+	}, nil
+}
+
+func (s *LabelService) findStockSorts(db *gorm.DB, condition string, value interface{}) ([]models.StockSortResponse, error) {
+	var results []models.StockSortResponse
+	if err := db.Table("stock_sorts AS ss").
+		Select(`
+			ss.id AS sort_id,
+			ss.uuid AS sort_uuid,
+			ss.stock_item_id,
+			ss.sorted_item_name AS item_name,
+			ss.weight,
+			ss.price_per_kilogram,
+			ss.current_weight,
+			ss.total_cost,
+			ss.is_shrinkage,
+			se.uuid AS entry_uuid,
+			se.id AS entry_id
+		`).
+		Joins("INNER JOIN stock_items si ON si.uuid = ss.stock_item_id AND si.deleted = false").
+		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id AND se.deleted = false").
+		Where("ss.deleted = false").
+		Where(condition, value).
+		Order("ss.id ASC").
+		Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	for i := range results {
+		results[i].StockCode = fmt.Sprintf("STOCK%d", results[i].EntryId)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type LandedCostService struct{}
+
+func NewLandedCostService() repository.LandedCostRepository {
+	return &LandedCostService{}
+}
+
+// GetLandedCosts - Cost Lines and Per-Item Allocation of a Purchase
+// =====================================================
+func (s *LandedCostService) GetLandedCosts(purchaseId string) (*models.LandedCostResponse, error) {
+	db := config.GetDBConn()
+
+	purchase, err := s.getPurchase(db, purchaseId)
+	if err != nil {
+		return nil, err
+	}
+
+	var stockEntry models.StockEntry
+	if err = db.Where("uuid = ?", purchase.StockId).First(&stockEntry).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock entry: ", err)
+	}
+
+	var costs []models.PurchaseCost
+	if err = db.Where("purchase_id = ? AND deleted = false", purchaseId).
+		Order("id ASC").
+		Find(&costs).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase costs: ", err)
+	}
+
+	var items []models.StockItem
+	if err = db.Where("stock_entry_id = ? AND deleted = false", purchase.StockId).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock items: ", err)
+	}
+
+	var allocations []models.PurchaseCostAllocation
+	if err = db.Where("purchase_id = ? AND deleted = false", purchaseId).
+		Order("id ASC").
+		Find(&allocations).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch cost allocations: ", err)
+	}
+
+	itemNames := make(map[string]string, len(items))
+	for _, item := range items {
+		itemNames[item.Uuid] = item.ItemName
+	}
+
+	allocationMap := make(map[string][]models.PurchaseCostAllocationResponse)
+	for _, a := range allocations {
+		allocationMap[a.PurchaseCostId] = append(allocationMap[a.PurchaseCostId], models.PurchaseCostAllocationResponse{
+			StockItemId: a.StockItemId,
+			ItemName:    itemNames[a.StockItemId],
+			Amount:      a.Amount,
+		})
+	}
+
+	response := &models.LandedCostResponse{
+		PurchaseId:     purchase.Uuid,
+		StockCode:      fmt.Sprintf("STOCK%d", stockEntry.ID),
+		PurchaseAmount: purchase.TotalAmount,
+		Costs:          make([]models.PurchaseCostResponse, 0, len(costs)),
+		Items:          make([]models.LandedCostItemResponse, 0, len(items)),
+	}
+
+	for _, c := range costs {
+		costAllocations := allocationMap[c.Uuid]
+		if costAllocations == nil {
+			costAllocations = make([]models.PurchaseCostAllocationResponse, 0)
+		}
+
+		response.Costs = append(response.Costs, models.PurchaseCostResponse{
+			Uuid:             c.Uuid,
+			CostType:         c.CostType,
+			Description:      c.Description,
+			Amount:           c.Amount,
+			AllocationMethod: c.AllocationMethod,
+			Allocations:      costAllocations,
+		})
+		response.TotalLandedCost += c.Amount
+	}
+
+	for _, item := range items {
+		perKilogram := 0
+		if item.Weight > 0 {
+			perKilogram = roundDiv(item.LandedCost, item.Weight)
+		}
+
+		response.Items = append(response.Items, models.LandedCostItemResponse{
+			StockItemId:           item.Uuid,
+			ItemName:              item.ItemName,
+			Weight:                item.Weight,
+			PricePerKilogram:      item.PricePerKilogram,
+			TotalPayment:          item.TotalPayment,
+			LandedCost:            item.LandedCost,
+			LandedCostPerKilogram: perKilogram,
+			CostPerKilogram:       item.PricePerKilogram + perKilogram,
+		})
+	}
+	response.TotalCost = response.PurchaseAmount + response.TotalLandedCost
+
+	return response, nil
+}
+
+// CreatePurchaseCost - Add Ice, Transport or Labour Cost
+// =====================================================
+func (s *LandedCostService) CreatePurchaseCost(purchaseId string, request models.PurchaseCostRequest) (*models.LandedCostResponse, error) {
+	db := config.GetDBConn()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		purchase, err := s.getPurchase(tx, purchaseId)
+		if err != nil {
+			return err
+		}
+		if err = checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		cost := models.PurchaseCost{
+			Uuid:             uuid.New().String(),
+			PurchaseId:       purchase.Uuid,
+			CostType:         request.CostType,
+			Description:      strings.TrimSpace(request.Description),
+			Amount:           request.Amount,
+			AllocationMethod: request.AllocationMethod,
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+
+		if err = tx.Create(&cost).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create purchase cost: ", err)
+		}
+
+		stockCode, err := s.stockCode(tx, *purchase)
+		if err != nil {
+			return err
+		}
+		if err = postPurchaseCostJournal(tx, *purchase, cost, stockCode); err != nil {
+			return err
+		}
+
+		return allocateLandedCosts(tx, *purchase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLandedCosts(purchaseId)
+}
+
+// UpdatePurchaseCost - Change Amount or Allocation Method
+// =====================================================
+func (s *LandedCostService) UpdatePurchaseCost(purchaseId, costId string, request models.PurchaseCostRequest) (*models.LandedCostResponse, error) {
+	db := config.GetDBConn()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		purchase, err := s.getPurchase(tx, purchaseId)
+		if err != nil {
+			return err
+		}
+		if err = checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.PurchaseCost{}).
+			Where("uuid = ? AND purchase_id = ? AND deleted = false", costId, purchaseId).
+			Updates(map[string]interface{}{
+				"cost_type":         request.CostType,
+				"description":       strings.TrimSpace(request.Description),
+				"amount":            request.Amount,
+				"allocation_method": request.AllocationMethod,
+				"updated_at":        time.Now(),
+			})
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase cost: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewNotFound("purchase cost not found")
+		}
+
+		var cost models.PurchaseCost
+		if err = tx.Where("uuid = ?", costId).First(&cost).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch purchase cost: ", err)
+		}
+		stockCode, err := s.stockCode(tx, *purchase)
+		if err != nil {
+			return err
+		}
+		if err = repostPurchaseCostJournal(tx, *purchase, cost, stockCode); err != nil {
+			return err
+		}
+
+		return allocateLandedCosts(tx, *purchase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetLandedCosts(purchaseId)
+}
+
+// DeletePurchaseCost - Remove Cost Line and Reallocate
+// =====================================================
+func (s *LandedCostService) DeletePurchaseCost(purchaseId, costId string) error {
+	db := config.GetDBConn()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		purchase, err := s.getPurchase(tx, purchaseId)
+		if err != nil {
+			return err
+		}
+		if err = checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.PurchaseCost{}).
+			Where("uuid = ? AND purchase_id = ? AND deleted = false", costId, purchaseId).
+			Updates(map[string]interface{}{
+				"deleted":    true,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to delete purchase cost: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewNotFound("purchase cost not found")
+		}
+
+		if err = reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalPurchaseCost, costId); err != nil {
+			return err
+		}
+
+		return allocateLandedCosts(tx, *purchase)
+	})
+}
+
+func (s *LandedCostService) getPurchase(db *gorm.DB, purchaseId string) (*models.Purchase, error) {
+	var purchase models.Purchase
+	if err := db.Where("uuid = ? AND deleted = false", purchaseId).First(&purchase).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("purchase not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+	}
+
+	return &purchase, nil
+}
+
+// stockCode returns the STOCK number the purchase is known by.
+func (s *LandedCostService) stockCode(db *gorm.DB, purchase models.Purchase) (string, error) {
+	var stockEntry models.StockEntry
+	if err := db.Where("uuid = ?", purchase.StockId).First(&stockEntry).Error; err != nil {
+		return "", apperror.NewUnprocessableEntity("failed to fetch stock entry: ", err)
+	}
+
+	return fmt.Sprintf("STOCK%d", stockEntry.ID), nil
+}
+
+// allocateLandedCosts spreads every cost line of a purchase over its current
+// stock items and pushes the result down to their sorts. It is rerun whenever
+// the cost lines or the stock items change, so allocations never go stale.
+func allocateLandedCosts(tx *gorm.DB, purchase models.Purchase) error {
+	var costs []models.PurchaseCost
+	if err := tx.Where("purchase_id = ? AND deleted = false", purchase.Uuid).
+		Order("id ASC").
+		Find(&costs).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch purchase costs: ", err)
+	}
+
+	var items []models.StockItem
+	if err := tx.Where("stock_entry_id = ? AND deleted = false", purchase.StockId).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock items: ", err)
+	}
+
+	now := time.Now()
+	if err := tx.Model(&models.PurchaseCostAllocation{}).
+		Where("purchase_id = ? AND deleted = false", purchase.Uuid).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to reset cost allocations: ", err)
+	}
+
+	landed := make(map[string]int, len(items))
+	allocations := make([]models.PurchaseCostAllocation, 0, len(costs)*len(items))
+
+	for _, cost := range costs {
+		shares := splitLandedCost(cost, items)
+		for i, amount := range shares {
+			if amount == 0 {
+				continue
+			}
+			landed[items[i].Uuid] += amount
+			allocations = append(allocations, models.PurchaseCostAllocation{
+				Uuid:           uuid.New().String(),
+				PurchaseCostId: cost.Uuid,
+				PurchaseId:     purchase.Uuid,
+				StockItemId:    items[i].Uuid,
+				Amount:         amount,
+				Deleted:        false,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+	}
+
+	if len(allocations) > 0 {
+		if err := tx.Create(&allocations).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create cost allocations: ", err)
+		}
+	}
+
+	itemIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.Uuid)
+		if err := tx.Model(&models.StockItem{}).
+			Where("uuid = ?", item.Uuid).
+			Updates(map[string]interface{}{
+				"landed_cost": landed[item.Uuid],
+				"updated_at":  now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update stock item landed cost: ", err)
+		}
+	}
+
+	return refreshSortLandedCosts(tx, itemIDs)
+}
+
+// splitLandedCost divides one cost line by weight or purchase value; the
+// rounding remainder goes to the item with the largest share.
+func splitLandedCost(cost models.PurchaseCost, items []models.StockItem) []int {
+	shares := make([]int, len(items))
+
+	basis := make([]int, len(items))
+	var totalBasis, largest int
+	for i, item := range items {
+		basis[i] = item.Weight
+		if cost.AllocationMethod == constants.AllocateByValue {
+			basis[i] = item.TotalPayment
+		}
+		totalBasis += basis[i]
+		if basis[i] > basis[largest] {
+			largest = i
+		}
+	}
+
+	if totalBasis <= 0 {
+		return shares
+	}
+
+	allocated := 0
+	for i := range items {
+		shares[i] = cost.Amount * basis[i] / totalBasis
+		allocated += shares[i]
+	}
+	shares[largest] += cost.Amount - allocated
+
+	return shares
+}
+
+// refreshSortLandedCosts carries each item's landed cost onto its sellable
+// sorts as a per-kilogram amount. Shrinkage carries no cost of its own, so the
+// lost weight makes the remaining fish more expensive.
+func refreshSortLandedCosts(tx *gorm.DB, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	var items []models.StockItem
+	if err := tx.Where("uuid IN ?", itemIDs).Find(&items).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock items: ", err)
+	}
+
+	var sorts []models.StockSort
+	if err := tx.Where("stock_item_id IN ? AND deleted = false", itemIDs).Find(&sorts).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	sellable := make(map[string]int, len(items))
+	for _, srt := range sorts {
+		if !srt.IsShrinkage {
+			sellable[srt.StockItemID] += srt.Weight
+		}
+	}
+
+	perKilogram := make(map[string]int, len(items))
+	for _, item := range items {
+		if weight := sellable[item.Uuid]; weight > 0 {
+			perKilogram[item.Uuid] = roundDiv(item.LandedCost, weight)
+		}
+	}
+
+	now := time.Now()
+	for _, srt := range sorts {
+		value := 0
+		if !srt.IsShrinkage {
+			value = perKilogram[srt.StockItemID]
+		}
+		if value == srt.LandedCostPerKilogram {
+			continue
+		}
+
+		if err := tx.Model(&models.StockSort{}).
+			Where("uuid = ?", srt.Uuid).
+			Updates(map[string]interface{}{
+				"landed_cost_per_kilogram": value,
+				"updated_at":               now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update stock sort landed cost: ", err)
+		}
+	}
+
+	return nil
+}
+
+func roundDiv(value, divisor int) int {
+	return (value + divisor/2) / divisor
+}
@@ -0,0 +1,1070 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type LedgerService struct{}
+
+func NewLedgerService() repository.LedgerRepository {
+	return &LedgerService{}
+}
+
+// GetAllAccounts - Chart of Accounts with Balances
+// =====================================================
+func (s *LedgerService) GetAllAccounts(filter models.AccountFilter) ([]models.AccountResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Model(&models.Account{}).Where("deleted = false")
+	if filter.AccountType != "" {
+		query = query.Where("account_type = ?", filter.AccountType)
+	}
+	if filter.Keyword != "" {
+		query = query.Where("(code ILIKE ? OR name ILIKE ?)", "%"+filter.Keyword+"%", "%"+filter.Keyword+"%")
+	}
+
+	var accounts []models.Account
+	if err := query.Order("code ASC").Find(&accounts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch accounts: ", err)
+	}
+
+	totals, err := accountTotals(db, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.AccountResponse, 0, len(accounts))
+	for _, account := range accounts {
+		t := totals[account.Uuid]
+		responses = append(responses, toAccountResponse(account, signedBalance(account.AccountType, t.Debit, t.Credit)))
+	}
+
+	return responses, nil
+}
+
+// CreateAccount - Add an Account to the Chart
+// =====================================================
+func (s *LedgerService) CreateAccount(request models.AccountRequest) (*models.AccountResponse, error) {
+	db := config.GetDBConn()
+
+	code := strings.TrimSpace(request.Code)
+	if err := s.ensureCodeAvailable(db, code, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	account := models.Account{
+		Uuid:        uuid.New().String(),
+		Code:        code,
+		Name:        strings.TrimSpace(request.Name),
+		AccountType: request.AccountType,
+		Description: request.Description,
+		IsSystem:    false,
+		Deleted:     false,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := db.Create(&account).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create account: ", err)
+	}
+
+	response := toAccountResponse(account, 0)
+	return &response, nil
+}
+
+// UpdateAccount - Rename or Reclassify an Account
+// =====================================================
+func (s *LedgerService) UpdateAccount(accountId string, request models.AccountRequest) error {
+	db := config.GetDBConn()
+
+	account, err := findAccount(db, accountId)
+	if err != nil {
+		return err
+	}
+
+	code := strings.TrimSpace(request.Code)
+	updates := map[string]interface{}{
+		"name":        strings.TrimSpace(request.Name),
+		"description": request.Description,
+		"updated_at":  time.Now(),
+	}
+
+	// Postings look system accounts up by code, so only the wording can change
+	if account.IsSystem {
+		if code != account.Code || request.AccountType != account.AccountType {
+			return apperror.NewBadRequest("the code and type of a system account cannot be changed")
+		}
+	} else {
+		if err = s.ensureCodeAvailable(db, code, account.Uuid); err != nil {
+			return err
+		}
+		updates["code"] = code
+		updates["account_type"] = request.AccountType
+	}
+
+	if err = db.Model(&models.Account{}).
+		Where("uuid = ?", account.Uuid).
+		Updates(updates).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update account: ", err)
+	}
+
+	return nil
+}
+
+// DeleteAccount - Remove an Unused Account
+// =====================================================
+func (s *LedgerService) DeleteAccount(accountId string) error {
+	db := config.GetDBConn()
+
+	account, err := findAccount(db, accountId)
+	if err != nil {
+		return err
+	}
+	if account.IsSystem {
+		return apperror.NewConflict("system accounts cannot be deleted")
+	}
+
+	var used int64
+	if err = db.Model(&models.JournalLine{}).
+		Where("account_id = ? AND deleted = false", account.Uuid).
+		Count(&used).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check account usage: ", err)
+	}
+	if used > 0 {
+		return apperror.NewConflict(fmt.Sprintf("account %s %s has journal lines and cannot be deleted", account.Code, account.Name))
+	}
+
+	if err = db.Model(&models.Account{}).
+		Where("uuid = ?", account.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": time.Now()}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete account: ", err)
+	}
+
+	return nil
+}
+
+// GetAccountStatement - One Account's Movements in a Period
+// =====================================================
+func (s *LedgerService) GetAccountStatement(accountId string, filter models.LedgerFilter) (*models.AccountLedger, error) {
+	db := config.GetDBConn()
+
+	account, err := findAccount(db, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers, err := s.buildLedgers(db, []models.Account{*account}, filter, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ledgers[0], nil
+}
+
+// GetGeneralLedger - Movements per Account in a Period
+// =====================================================
+func (s *LedgerService) GetGeneralLedger(filter models.LedgerFilter) (*models.GeneralLedgerResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Where("deleted = false")
+	if filter.AccountId != "" {
+		query = query.Where("uuid = ?", filter.AccountId)
+	}
+
+	var accounts []models.Account
+	if err := query.Order("code ASC").Find(&accounts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch accounts: ", err)
+	}
+	if filter.AccountId != "" && len(accounts) == 0 {
+		return nil, apperror.NewNotFound("account not found")
+	}
+
+	ledgers, err := s.buildLedgers(db, accounts, filter, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GeneralLedgerResponse{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Accounts:  ledgers,
+	}, nil
+}
+
+// GetTrialBalance - Account Balances as of a Date
+// =====================================================
+func (s *LedgerService) GetTrialBalance(filter models.TrialBalanceFilter) (*models.TrialBalanceResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Table("journal_lines AS jl").
+		Select(`
+			a.uuid AS account_id,
+			a.code AS account_code,
+			a.name AS account_name,
+			a.account_type,
+			COALESCE(SUM(jl.debit), 0) AS debit,
+			COALESCE(SUM(jl.credit), 0) AS credit
+		`).
+		Joins("INNER JOIN journal_entries je ON je.uuid = jl.journal_entry_id AND je.deleted = false").
+		Joins("INNER JOIN accounts a ON a.uuid = jl.account_id").
+		Where("jl.deleted = false")
+	if filter.EndDate != "" {
+		query = query.Where("DATE(je.entry_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var rows []models.TrialBalanceRow
+	if err := query.
+		Group("a.uuid, a.code, a.name, a.account_type").
+		Order("a.code ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch trial balance: ", err)
+	}
+
+	response := &models.TrialBalanceResponse{
+		EndDate: filter.EndDate,
+		Rows:    make([]models.TrialBalanceRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		// Each account shows its net balance on one side only
+		net := row.Debit - row.Credit
+		if net == 0 {
+			continue
+		}
+		row.Debit, row.Credit = 0, 0
+		if net > 0 {
+			row.Debit = net
+		} else {
+			row.Credit = -net
+		}
+
+		response.Rows = append(response.Rows, row)
+		response.TotalDebit += row.Debit
+		response.TotalCredit += row.Credit
+	}
+	response.Balanced = response.TotalDebit == response.TotalCredit
+
+	return response, nil
+}
+
+// GetAllJournalEntries - Paginated Journal
+// =====================================================
+func (s *LedgerService) GetAllJournalEntries(filter models.JournalEntryFilter) (*models.JournalEntryPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.JournalEntry{}).Where("deleted = false")
+
+	if filter.SourceType != "" {
+		query = query.Where("source_type = ?", filter.SourceType)
+	}
+	if filter.ReferenceId != "" {
+		query = query.Where("reference_id = ?", filter.ReferenceId)
+	}
+	if filter.AccountId != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM journal_lines jl
+			WHERE jl.journal_entry_id = journal_entries.uuid AND jl.account_id = ? AND jl.deleted = false
+		)`, filter.AccountId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(entry_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(entry_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count journal entries: ", err)
+	}
+
+	var entries []models.JournalEntry
+	if err := query.
+		Order("entry_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&entries).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch journal entries: ", err)
+	}
+
+	responses, err := s.buildEntryResponses(db, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.JournalEntryPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// GetJournalEntryById - Single Journal Entry
+// =====================================================
+func (s *LedgerService) GetJournalEntryById(entryId string) (*models.JournalEntryResponse, error) {
+	db := config.GetDBConn()
+
+	entry, err := findJournalEntry(db, entryId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildEntryResponses(db, []models.JournalEntry{*entry})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// CreateJournalEntry - Manual Adjusting Entry
+// =====================================================
+func (s *LedgerService) CreateJournalEntry(request models.JournalEntryRequest) (*models.JournalEntryResponse, error) {
+	db := config.GetDBConn()
+
+	accountIDs := make([]string, 0, len(request.Lines))
+	lines := make([]models.JournalLine, 0, len(request.Lines))
+	for i, line := range request.Lines {
+		if (line.Debit > 0) == (line.Credit > 0) {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("line %d must have either a debit or a credit amount", i+1))
+		}
+		accountIDs = append(accountIDs, line.AccountId)
+		lines = append(lines, models.JournalLine{
+			AccountId:   line.AccountId,
+			UserId:      line.UserId,
+			Description: line.Description,
+			Debit:       line.Debit,
+			Credit:      line.Credit,
+		})
+	}
+
+	if err := checkPeriodsOpen(db, request.EntryDate); err != nil {
+		return nil, err
+	}
+
+	var found int64
+	if err := db.Model(&models.Account{}).
+		Where("uuid IN ? AND deleted = false", distinct(accountIDs)).
+		Count(&found).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to check accounts: ", err)
+	}
+	if int(found) != len(distinct(accountIDs)) {
+		return nil, apperror.NewNotFound("account not found")
+	}
+
+	var entry *models.JournalEntry
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		entry, err = writeJournal(tx, models.JournalEntry{
+			EntryDate:   request.EntryDate,
+			Description: strings.TrimSpace(request.Description),
+			SourceType:  constants.JournalManual,
+		}, lines)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJournalEntryById(entry.Uuid)
+}
+
+// ReverseJournalEntry - Cancel a Manual Entry
+// =====================================================
+func (s *LedgerService) ReverseJournalEntry(entryId string) (*models.JournalEntryResponse, error) {
+	db := config.GetDBConn()
+
+	entry, err := findJournalEntry(db, entryId)
+	if err != nil {
+		return nil, err
+	}
+	if entry.SourceType != constants.JournalManual {
+		return nil, apperror.NewConflict("generated entries follow their document; edit or delete the document instead")
+	}
+	if entry.ReversalOf != "" || entry.ReversedBy != "" {
+		return nil, apperror.NewConflict("journal entry has already been reversed")
+	}
+
+	var reversal *models.JournalEntry
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		reversal, err = reverseJournal(tx, *entry, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetJournalEntryById(reversal.Uuid)
+}
+
+func (s *LedgerService) ensureCodeAvailable(db *gorm.DB, code, excludeId string) error {
+	query := db.Model(&models.Account{}).Where("code = ? AND deleted = false", code)
+	if excludeId != "" {
+		query = query.Where("uuid <> ?", excludeId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check account code: ", err)
+	}
+	if count > 0 {
+		return apperror.NewConflict(fmt.Sprintf("account code %s is already used", code))
+	}
+
+	return nil
+}
+
+// buildLedgers lists each account's lines in the period with a running
+// balance. Without keepEmpty, accounts with no balance and no movement are
+// left out.
+func (s *LedgerService) buildLedgers(db *gorm.DB, accounts []models.Account, filter models.LedgerFilter, keepEmpty bool) ([]models.AccountLedger, error) {
+	ledgers := make([]models.AccountLedger, 0, len(accounts))
+	if len(accounts) == 0 {
+		return ledgers, nil
+	}
+
+	accountIDs := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		accountIDs = append(accountIDs, a.Uuid)
+	}
+
+	opening := make(map[string]accountTotal)
+	if filter.StartDate != "" {
+		var err error
+		if opening, err = accountTotals(db, filter.UserId, filter.StartDate); err != nil {
+			return nil, err
+		}
+	}
+
+	query := db.Table("journal_lines AS jl").
+		Select(`
+			jl.journal_entry_id,
+			je.id AS entry_no,
+			je.entry_date,
+			je.source_type,
+			jl.account_id,
+			COALESCE(jl.user_id, '') AS user_id,
+			COALESCE(u.name, '') AS user_name,
+			COALESCE(NULLIF(jl.description, ''), je.description) AS description,
+			jl.debit,
+			jl.credit
+		`).
+		Joins("INNER JOIN journal_entries je ON je.uuid = jl.journal_entry_id AND je.deleted = false").
+		Joins(`LEFT JOIN "user" u ON u.uuid = jl.user_id`).
+		Where("jl.account_id IN ? AND jl.deleted = false", accountIDs)
+	if filter.UserId != "" {
+		query = query.Where("jl.user_id = ?", filter.UserId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(je.entry_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(je.entry_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var lines []models.LedgerLine
+	if err := query.Order("je.entry_date ASC, je.id ASC, jl.id ASC").Scan(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch ledger lines: ", err)
+	}
+	lineMap := make(map[string][]models.LedgerLine, len(accounts))
+	for _, line := range lines {
+		lineMap[line.AccountId] = append(lineMap[line.AccountId], line)
+	}
+
+	for _, account := range accounts {
+		o := opening[account.Uuid]
+		ledger := models.AccountLedger{
+			Account:        toAccountResponse(account, 0),
+			OpeningBalance: signedBalance(account.AccountType, o.Debit, o.Credit),
+			Lines:          make([]models.LedgerLine, 0, len(lineMap[account.Uuid])),
+		}
+
+		balance := ledger.OpeningBalance
+		for _, line := range lineMap[account.Uuid] {
+			balance += signedBalance(account.AccountType, line.Debit, line.Credit)
+			line.EntryCode = fmt.Sprintf("JRN%d", line.EntryNo)
+			line.Balance = balance
+			ledger.Lines = append(ledger.Lines, line)
+			ledger.TotalDebit += line.Debit
+			ledger.TotalCredit += line.Credit
+		}
+		ledger.ClosingBalance = balance
+		ledger.Account.Balance = balance
+
+		if !keepEmpty && len(ledger.Lines) == 0 && ledger.OpeningBalance == 0 {
+			continue
+		}
+		ledgers = append(ledgers, ledger)
+	}
+
+	return ledgers, nil
+}
+
+func (s *LedgerService) buildEntryResponses(db *gorm.DB, entries []models.JournalEntry) ([]models.JournalEntryResponse, error) {
+	responses := make([]models.JournalEntryResponse, 0, len(entries))
+	if len(entries) == 0 {
+		return responses, nil
+	}
+
+	entryIDs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		entryIDs = append(entryIDs, e.Uuid)
+	}
+
+	var lines []struct {
+		JournalEntryId string `gorm:"column:journal_entry_id"`
+		models.JournalLineResponse
+	}
+	if err := db.Table("journal_lines AS jl").
+		Select(`
+			jl.journal_entry_id,
+			jl.account_id,
+			a.code AS account_code,
+			a.name AS account_name,
+			COALESCE(jl.user_id, '') AS user_id,
+			COALESCE(u.name, '') AS user_name,
+			jl.description,
+			jl.debit,
+			jl.credit
+		`).
+		Joins("INNER JOIN accounts a ON a.uuid = jl.account_id").
+		Joins(`LEFT JOIN "user" u ON u.uuid = jl.user_id`).
+		Where("jl.journal_entry_id IN ? AND jl.deleted = false", entryIDs).
+		Order("jl.id ASC").
+		Scan(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch journal lines: ", err)
+	}
+	lineMap := make(map[string][]models.JournalLineResponse, len(entries))
+	for _, line := range lines {
+		lineMap[line.JournalEntryId] = append(lineMap[line.JournalEntryId], line.JournalLineResponse)
+	}
+
+	for _, e := range entries {
+		response := models.JournalEntryResponse{
+			Uuid:        e.Uuid,
+			EntryCode:   fmt.Sprintf("JRN%d", e.ID),
+			EntryDate:   e.EntryDate,
+			Description: e.Description,
+			SourceType:  e.SourceType,
+			SourceId:    e.SourceId,
+			ReferenceId: e.ReferenceId,
+			ReversalOf:  e.ReversalOf,
+			ReversedBy:  e.ReversedBy,
+			Lines:       make([]models.JournalLineResponse, 0),
+			CreatedAt:   e.CreatedAt,
+		}
+		if entryLines, ok := lineMap[e.Uuid]; ok {
+			response.Lines = entryLines
+		}
+		for _, line := range response.Lines {
+			response.TotalDebit += line.Debit
+			response.TotalCredit += line.Credit
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func findAccount(db *gorm.DB, accountId string) (*models.Account, error) {
+	var account models.Account
+	if err := db.Where("uuid = ? AND deleted = false", accountId).First(&account).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("account not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch account: ", err)
+	}
+
+	return &account, nil
+}
+
+func findJournalEntry(db *gorm.DB, entryId string) (*models.JournalEntry, error) {
+	var entry models.JournalEntry
+	if err := db.Where("uuid = ? AND deleted = false", entryId).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("journal entry not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch journal entry: ", err)
+	}
+
+	return &entry, nil
+}
+
+type accountTotal struct {
+	AccountId string `gorm:"column:account_id"`
+	Debit     int    `gorm:"column:debit"`
+	Credit    int    `gorm:"column:credit"`
+}
+
+// accountTotals sums debits and credits per account, optionally for one
+// counterparty and only for entries dated before a day.
+func accountTotals(db *gorm.DB, userId, before string) (map[string]accountTotal, error) {
+	query := db.Table("journal_lines AS jl").
+		Select("jl.account_id, COALESCE(SUM(jl.debit), 0) AS debit, COALESCE(SUM(jl.credit), 0) AS credit").
+		Joins("INNER JOIN journal_entries je ON je.uuid = jl.journal_entry_id AND je.deleted = false").
+		Where("jl.deleted = false")
+	if userId != "" {
+		query = query.Where("jl.user_id = ?", userId)
+	}
+	if before != "" {
+		query = query.Where("DATE(je.entry_date) < CAST(? AS DATE)", before)
+	}
+
+	var rows []accountTotal
+	if err := query.Group("jl.account_id").Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to sum account balances: ", err)
+	}
+
+	totals := make(map[string]accountTotal, len(rows))
+	for _, row := range rows {
+		totals[row.AccountId] = row
+	}
+
+	return totals, nil
+}
+
+func toAccountResponse(account models.Account, balance int) models.AccountResponse {
+	normal := "CREDIT"
+	if debitNormal(account.AccountType) {
+		normal = "DEBIT"
+	}
+
+	return models.AccountResponse{
+		Uuid:          account.Uuid,
+		Code:          account.Code,
+		Name:          account.Name,
+		AccountType:   account.AccountType,
+		NormalBalance: normal,
+		Description:   account.Description,
+		IsSystem:      account.IsSystem,
+		Balance:       balance,
+		CreatedAt:     account.CreatedAt,
+	}
+}
+
+func debitNormal(accountType string) bool {
+	return accountType == constants.AccountAsset || accountType == constants.AccountExpense
+}
+
+// signedBalance expresses a movement on the account's normal side.
+func signedBalance(accountType string, debit, credit int) int {
+	if debitNormal(accountType) {
+		return debit - credit
+	}
+	return credit - debit
+}
+
+// =====================================================
+// Posting
+// =====================================================
+
+// journalLine is one side of a generated posting, addressed by account code.
+type journalLine struct {
+	accountCode string
+	userId      string
+	debit       int
+	credit      int
+}
+
+// postJournal writes a generated entry against the system accounts. Zero
+// lines are dropped and nothing is written when no amount is left.
+func postJournal(tx *gorm.DB, entry models.JournalEntry, lines []journalLine) error {
+	codes := make([]string, 0, len(lines))
+	for _, line := range lines {
+		codes = append(codes, line.accountCode)
+	}
+
+	var accounts []models.Account
+	if err := tx.Where("code IN ? AND deleted = false", distinct(codes)).Find(&accounts).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch ledger accounts: ", err)
+	}
+	accountMap := make(map[string]string, len(accounts))
+	for _, a := range accounts {
+		accountMap[a.Code] = a.Uuid
+	}
+
+	records := make([]models.JournalLine, 0, len(lines))
+	for _, line := range lines {
+		if line.debit == 0 && line.credit == 0 {
+			continue
+		}
+		accountId, ok := accountMap[line.accountCode]
+		if !ok {
+			return apperror.NewUnprocessableEntity(fmt.Sprintf(
+				"ledger account %s is missing; run with migrate enabled to seed the chart of accounts", line.accountCode), nil)
+		}
+		records = append(records, models.JournalLine{
+			AccountId: accountId,
+			UserId:    line.userId,
+			Debit:     line.debit,
+			Credit:    line.credit,
+		})
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	_, err := writeJournal(tx, entry, records)
+	return err
+}
+
+// writeJournal checks that the lines balance and stores the entry.
+func writeJournal(tx *gorm.DB, entry models.JournalEntry, lines []models.JournalLine) (*models.JournalEntry, error) {
+	var debit, credit int
+	for _, line := range lines {
+		debit += line.Debit
+		credit += line.Credit
+	}
+	if debit != credit {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("journal entry does not balance: debit %d, credit %d", debit, credit))
+	}
+	if debit == 0 {
+		return nil, apperror.NewBadRequest("journal entry has no amount")
+	}
+
+	now := time.Now()
+	entry.Uuid = uuid.New().String()
+	entry.Deleted = false
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	if err := tx.Create(&entry).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create journal entry: ", err)
+	}
+
+	for i := range lines {
+		lines[i].Uuid = uuid.New().String()
+		lines[i].JournalEntryId = entry.Uuid
+		lines[i].Deleted = false
+		lines[i].CreatedAt = now
+		lines[i].UpdatedAt = now
+	}
+	if err := tx.Create(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create journal lines: ", err)
+	}
+
+	return &entry, nil
+}
+
+// reverseJournal posts the mirror image of an entry on entryDate and marks
+// the original as reversed.
+func reverseJournal(tx *gorm.DB, entry models.JournalEntry, entryDate time.Time) (*models.JournalEntry, error) {
+	var lines []models.JournalLine
+	if err := tx.Where("journal_entry_id = ? AND deleted = false", entry.Uuid).Find(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch journal lines: ", err)
+	}
+
+	mirrored := make([]models.JournalLine, 0, len(lines))
+	for _, line := range lines {
+		mirrored = append(mirrored, models.JournalLine{
+			AccountId:   line.AccountId,
+			UserId:      line.UserId,
+			Description: line.Description,
+			Debit:       line.Credit,
+			Credit:      line.Debit,
+		})
+	}
+
+	reversal, err := writeJournal(tx, models.JournalEntry{
+		EntryDate:   entryDate,
+		Description: fmt.Sprintf("Pembatalan JRN%d: %s", entry.ID, entry.Description),
+		SourceType:  entry.SourceType,
+		SourceId:    entry.SourceId,
+		ReferenceId: entry.ReferenceId,
+		ReversalOf:  entry.Uuid,
+	}, mirrored)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Model(&models.JournalEntry{}).
+		Where("uuid = ?", entry.Uuid).
+		Updates(map[string]interface{}{"reversed_by": reversal.Uuid, "updated_at": time.Now()}).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to mark journal entry reversed: ", err)
+	}
+
+	return reversal, nil
+}
+
+// reverseJournals reverses every open generated entry matching the
+// condition, e.g. all entries of a deleted sale. Each reversal is dated on
+// its original entry, so editing or deleting a document from an earlier
+// month only changes that month.
+func reverseJournals(tx *gorm.DB, where string, args ...interface{}) error {
+	var entries []models.JournalEntry
+	if err := tx.Where(where, args...).
+		Where("COALESCE(reversal_of, '') = '' AND COALESCE(reversed_by, '') = '' AND deleted = false").
+		Order("id ASC").
+		Find(&entries).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch journal entries: ", err)
+	}
+
+	for _, entry := range entries {
+		if _, err := reverseJournal(tx, entry, entry.EntryDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postPurchaseJournal books received fish into inventory against the
+// supplier's payable.
+func postPurchaseJournal(tx *gorm.DB, purchase models.Purchase, stockCode string) error {
+	return postJournal(tx, models.JournalEntry{
+		EntryDate:   purchase.PurchaseDate,
+		Description: fmt.Sprintf("Pembelian %s", stockCode),
+		SourceType:  constants.JournalPurchase,
+		SourceId:    purchase.Uuid,
+		ReferenceId: purchase.Uuid,
+	}, []journalLine{
+		{accountCode: constants.AccountCodeInventory, debit: purchase.TotalAmount},
+		{accountCode: constants.AccountCodePayable, userId: purchase.SupplierID, credit: purchase.TotalAmount},
+	})
+}
+
+// repostPurchaseJournal replaces the open posting of an edited purchase.
+func repostPurchaseJournal(tx *gorm.DB, purchase models.Purchase, stockCode string) error {
+	if err := reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalPurchase, purchase.Uuid); err != nil {
+		return err
+	}
+	return postPurchaseJournal(tx, purchase, stockCode)
+}
+
+// postSaleJournal books the customer's receivable against revenue and moves
+// the sold weight out of inventory at the sorts' cost per kilogram, landed
+// costs included.
+func postSaleJournal(tx *gorm.DB, sale models.Sale, items []models.ItemSalesRequest) error {
+	sortIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		sortIDs = append(sortIDs, item.StockSortId)
+	}
+
+	cost := 0
+	if len(sortIDs) > 0 {
+		var sorts []models.StockSort
+		if err := tx.Where("uuid IN ?", distinct(sortIDs)).Find(&sorts).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+		}
+		priceMap := make(map[string]int, len(sorts))
+		for _, srt := range sorts {
+			priceMap[srt.Uuid] = srt.PricePerKilogram + srt.LandedCostPerKilogram
+		}
+		for _, item := range items {
+			cost += item.Weight * priceMap[item.StockSortId]
+		}
+	}
+
+	return postJournal(tx, models.JournalEntry{
+		EntryDate:   sale.PurchaseDate,
+		Description: fmt.Sprintf("Penjualan SELL%d", sale.ID),
+		SourceType:  constants.JournalSale,
+		SourceId:    sale.Uuid,
+		ReferenceId: sale.Uuid,
+	}, []journalLine{
+		{accountCode: constants.AccountCodeReceivable, userId: sale.CustomerId, debit: sale.TotalAmount},
+		{accountCode: constants.AccountCodeSales, credit: sale.TotalAmount},
+		{accountCode: constants.AccountCodeCostOfGoodsSold, debit: cost},
+		{accountCode: constants.AccountCodeInventory, credit: cost},
+	})
+}
+
+// repostSaleJournal replaces the open posting of an edited sale.
+func repostSaleJournal(tx *gorm.DB, sale models.Sale, items []models.ItemSalesRequest) error {
+	if err := reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalSale, sale.Uuid); err != nil {
+		return err
+	}
+	return postSaleJournal(tx, sale, items)
+}
+
+// postPaymentJournal books a payment row. Purchase and sale payments settle
+// the payable or receivable, from cash or from the party's deposit. Manual
+// payments move cash against the party: whatever exceeds their open balance
+// becomes (or uses up) a deposit. Payments by staff accounts are expenses,
+// booked on their category's expense account, or other income. Cash lines
+// post to the ledger account behind the payment's cash or bank account.
+func postPaymentJournal(tx *gorm.DB, payment models.Payment, fromDeposit bool) error {
+	entry := models.JournalEntry{
+		EntryDate:   payment.CreatedAt,
+		Description: payment.Description,
+		SourceType:  constants.JournalPayment,
+		SourceId:    payment.Uuid,
+	}
+	amount := payment.Total
+
+	cashCode, err := cashLedgerCode(tx, payment.CashAccountId)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case payment.PurchaseId != "":
+		entry.ReferenceId = payment.PurchaseId
+		source := cashCode
+		if fromDeposit {
+			source = constants.AccountCodeSupplierAdvance
+		}
+		return postJournal(tx, entry, []journalLine{
+			{accountCode: constants.AccountCodePayable, userId: payment.UserId, debit: amount},
+			{accountCode: source, userId: payment.UserId, credit: amount},
+		})
+
+	case payment.SalesId != "":
+		entry.ReferenceId = payment.SalesId
+		source := cashCode
+		if fromDeposit {
+			source = constants.AccountCodeCustomerDeposit
+		}
+		return postJournal(tx, entry, []journalLine{
+			{accountCode: source, userId: payment.UserId, debit: amount},
+			{accountCode: constants.AccountCodeReceivable, userId: payment.UserId, credit: amount},
+		})
+	}
+
+	var user models.User
+	if err := tx.Where("uuid = ?", payment.UserId).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("user not found")
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch user: ", err)
+	}
+
+	var control, deposit string
+	switch user.Role {
+	case constants.BuyerRole:
+		control, deposit = constants.AccountCodeReceivable, constants.AccountCodeCustomerDeposit
+	case constants.SupplierRole:
+		control, deposit = constants.AccountCodePayable, constants.AccountCodeSupplierAdvance
+	default:
+		if payment.Type == constants.Income {
+			return postJournal(tx, entry, []journalLine{
+				{accountCode: cashCode, debit: amount},
+				{accountCode: constants.AccountCodeOtherIncome, userId: payment.UserId, credit: amount},
+			})
+		}
+		expenseCode, err := expenseLedgerCode(tx, payment.ExpenseCategoryId)
+		if err != nil {
+			return err
+		}
+		return postJournal(tx, entry, []journalLine{
+			{accountCode: expenseCode, userId: payment.UserId, debit: amount},
+			{accountCode: cashCode, credit: amount},
+		})
+	}
+
+	// Open balance before this payment: positive is debt, negative a deposit
+	var balance int
+	if err := tx.Model(&models.Payment{}).
+		Select(`COALESCE(SUM(CASE WHEN type = ? THEN total WHEN type = ? THEN -total ELSE 0 END), 0)`,
+			constants.Income, constants.Expense).
+		Where("user_id = ? AND uuid <> ? AND deleted = false", payment.UserId, payment.Uuid).
+		Scan(&balance).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch user balance: ", err)
+	}
+
+	// EXPENSE lowers the balance: debt first, the rest becomes a deposit.
+	// INCOME raises it: deposit first, the rest becomes debt.
+	var controlPart, depositPart int
+	if payment.Type == constants.Expense {
+		controlPart = min(amount, max(balance, 0))
+		depositPart = amount - controlPart
+	} else {
+		depositPart = min(amount, max(-balance, 0))
+		controlPart = amount - depositPart
+	}
+
+	// Receivables and advances sit on the debit side, payables and deposits
+	// on the credit side, so cash flows in opposite directions per role
+	cashIn := (payment.Type == constants.Expense) == (user.Role == constants.BuyerRole)
+	partyLines := []journalLine{
+		{accountCode: control, userId: payment.UserId},
+		{accountCode: deposit, userId: payment.UserId},
+	}
+	cash := journalLine{accountCode: cashCode}
+	if cashIn {
+		cash.debit = amount
+		partyLines[0].credit, partyLines[1].credit = controlPart, depositPart
+	} else {
+		cash.credit = amount
+		partyLines[0].debit, partyLines[1].debit = controlPart, depositPart
+	}
+
+	return postJournal(tx, entry, append([]journalLine{cash}, partyLines...))
+}
+
+// postSupplierReturnJournal takes returned fish out of inventory, first
+// against what is still owed on the purchase and then as supplier credit.
+func postSupplierReturnJournal(tx *gorm.DB, record models.SupplierReturn, stockCode string) error {
+	return postJournal(tx, models.JournalEntry{
+		EntryDate:   record.ReturnDate,
+		Description: fmt.Sprintf("Retur %s", stockCode),
+		SourceType:  constants.JournalSupplierReturn,
+		SourceId:    record.Uuid,
+		ReferenceId: record.PurchaseId,
+	}, []journalLine{
+		{accountCode: constants.AccountCodePayable, userId: record.SupplierId, debit: record.DebitAmount},
+		{accountCode: constants.AccountCodeSupplierAdvance, userId: record.SupplierId, debit: record.CreditAmount},
+		{accountCode: constants.AccountCodeInventory, credit: record.TotalAmount},
+	})
+}
+
+// postPurchaseCostJournal adds a landed cost line to inventory. The cost is
+// owed to whoever delivered the ice, transport or labour, who is not a
+// supplier in the system, so the payable carries no party.
+func postPurchaseCostJournal(tx *gorm.DB, purchase models.Purchase, cost models.PurchaseCost, stockCode string) error {
+	return postJournal(tx, models.JournalEntry{
+		EntryDate:   purchase.PurchaseDate,
+		Description: fmt.Sprintf("Biaya %s %s", cost.CostType, stockCode),
+		SourceType:  constants.JournalPurchaseCost,
+		SourceId:    cost.Uuid,
+		ReferenceId: purchase.Uuid,
+	}, []journalLine{
+		{accountCode: constants.AccountCodeInventory, debit: cost.Amount},
+		{accountCode: constants.AccountCodePayable, credit: cost.Amount},
+	})
+}
+
+// repostPurchaseCostJournal replaces the open posting of an edited cost line.
+func repostPurchaseCostJournal(tx *gorm.DB, purchase models.Purchase, cost models.PurchaseCost, stockCode string) error {
+	if err := reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalPurchaseCost, cost.Uuid); err != nil {
+		return err
+	}
+	return postPurchaseCostJournal(tx, purchase, cost, stockCode)
+}
+
+// postStockWriteOffJournal books written-off weight as shrinkage expense.
+// The entry hangs off the purchase so deleting the purchase reverses it.
+func postStockWriteOffJournal(tx *gorm.DB, shrinkage models.StockSort, purchaseId, description string, amount int) error {
+	return postJournal(tx, models.JournalEntry{
+		EntryDate:   shrinkage.CreatedAt,
+		Description: description,
+		SourceType:  constants.JournalStockWriteOff,
+		SourceId:    shrinkage.Uuid,
+		ReferenceId: purchaseId,
+	}, []journalLine{
+		{accountCode: constants.AccountCodeShrinkage, debit: amount},
+		{accountCode: constants.AccountCodeInventory, credit: amount},
+	})
+}
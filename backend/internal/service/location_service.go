@@ -0,0 +1,231 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type LocationService struct{}
+
+func NewLocationService() repository.LocationRepository {
+	return &LocationService{}
+}
+
+// locationSummaryQuery lists locations with the stock and fibers currently
+// stored in them.
+const locationSummaryQuery = `
+	SELECT
+		l.uuid,
+		l.name,
+		l.location_type,
+		l.description,
+		l.max_temperature,
+		l.created_at,
+		COALESCE(ss.sort_count, 0) AS sort_count,
+		COALESCE(ss.total_weight, 0) AS total_weight,
+		COALESCE(f.fiber_count, 0) AS fiber_count
+	FROM locations l
+	LEFT JOIN (
+		SELECT location_id, COUNT(*) AS sort_count, SUM(current_weight) AS total_weight
+		FROM stock_sorts
+		WHERE deleted = false AND is_shrinkage = false AND current_weight > 0
+		GROUP BY location_id
+	) ss ON ss.location_id = l.uuid
+	LEFT JOIN (
+		SELECT location_id, COUNT(*) AS fiber_count
+		FROM fibers
+		WHERE deleted = false
+		GROUP BY location_id
+	) f ON f.location_id = l.uuid
+	WHERE l.deleted = false
+`
+
+// GetAllLocations - Locations with Stored Stock
+// =====================================================
+func (s *LocationService) GetAllLocations() ([]models.LocationResponse, error) {
+	db := config.GetDBConn()
+
+	results := make([]models.LocationResponse, 0)
+	if err := db.Raw(locationSummaryQuery + " ORDER BY l.name ASC").
+		Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch locations: ", err)
+	}
+
+	return results, nil
+}
+
+// GetLocationById - Single Location with Stored Stock
+// =====================================================
+func (s *LocationService) GetLocationById(locationId string) (*models.LocationResponse, error) {
+	db := config.GetDBConn()
+
+	var results []models.LocationResponse
+	if err := db.Raw(locationSummaryQuery+" AND l.uuid = ?", locationId).
+		Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch location: ", err)
+	}
+	if len(results) == 0 {
+		return nil, apperror.NewNotFound("location not found")
+	}
+
+	return &results[0], nil
+}
+
+// CreateLocation - Register a Cold Room, Warehouse or Stall
+// =====================================================
+func (s *LocationService) CreateLocation(request models.LocationRequest) (*models.LocationResponse, error) {
+	db := config.GetDBConn()
+
+	name := strings.TrimSpace(request.Name)
+	if err := s.checkDuplicateName(db, name, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	location := models.Location{
+		Uuid:           uuid.New().String(),
+		Name:           name,
+		LocationType:   request.LocationType,
+		Description:    strings.TrimSpace(request.Description),
+		MaxTemperature: request.MaxTemperature,
+		Deleted:        false,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := db.Create(&location).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create location: ", err)
+	}
+
+	return &models.LocationResponse{
+		Uuid:           location.Uuid,
+		Name:           location.Name,
+		LocationType:   location.LocationType,
+		Description:    location.Description,
+		MaxTemperature: location.MaxTemperature,
+		CreatedAt:      location.CreatedAt,
+	}, nil
+}
+
+// UpdateLocation - Rename or Retype a Location
+// =====================================================
+func (s *LocationService) UpdateLocation(locationId string, request models.LocationRequest) (*models.LocationResponse, error) {
+	db := config.GetDBConn()
+
+	name := strings.TrimSpace(request.Name)
+	if err := s.checkDuplicateName(db, name, locationId); err != nil {
+		return nil, err
+	}
+
+	result := db.Model(&models.Location{}).
+		Where("uuid = ? AND deleted = false", locationId).
+		Updates(map[string]interface{}{
+			"name":            name,
+			"location_type":   request.LocationType,
+			"description":     strings.TrimSpace(request.Description),
+			"max_temperature": request.MaxTemperature,
+			"updated_at":      time.Now(),
+		})
+
+	if result.Error != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to update location: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.NewNotFound("location not found")
+	}
+
+	return s.GetLocationById(locationId)
+}
+
+// DeleteLocation - Only Empty Locations
+// =====================================================
+func (s *LocationService) DeleteLocation(locationId string) error {
+	location, err := s.GetLocationById(locationId)
+	if err != nil {
+		return err
+	}
+
+	if location.SortCount > 0 || location.FiberCount > 0 {
+		return apperror.NewConflict(fmt.Sprintf("%s still holds %d kg of stock and %d fibers; transfer them first",
+			location.Name, location.TotalWeight, location.FiberCount))
+	}
+
+	if err = config.GetDBConn().Model(&models.Location{}).
+		Where("uuid = ? AND deleted = false", locationId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete location: ", err)
+	}
+
+	return nil
+}
+
+func (s *LocationService) checkDuplicateName(db *gorm.DB, name, excludeId string) error {
+	query := db.Model(&models.Location{}).
+		Where("LOWER(name) = ? AND deleted = false", strings.ToLower(name))
+	if excludeId != "" {
+		query = query.Where("uuid != ?", excludeId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check location name: ", err)
+	}
+	if count > 0 {
+		return apperror.NewConflict(fmt.Sprintf("location %s already exists", name))
+	}
+
+	return nil
+}
+
+// checkLocations verifies every non-empty location ID refers to an active
+// location. Empty IDs mean the stock has not been assigned a location.
+func checkLocations(db *gorm.DB, locationIDs []string) error {
+	ids := make([]string, 0, len(locationIDs))
+	for _, id := range locationIDs {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	ids = distinct(ids)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var found int64
+	if err := db.Model(&models.Location{}).
+		Where("uuid IN ? AND deleted = false", ids).
+		Count(&found).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check locations: ", err)
+	}
+	if int(found) != len(ids) {
+		return apperror.NewNotFound("location not found")
+	}
+
+	return nil
+}
+
+// findLocation loads an active location by ID.
+func findLocation(db *gorm.DB, locationId string) (*models.Location, error) {
+	var location models.Location
+	if err := db.Where("uuid = ? AND deleted = false", locationId).First(&location).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("location not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch location: ", err)
+	}
+
+	return &location, nil
+}
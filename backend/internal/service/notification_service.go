@@ -0,0 +1,189 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type NotificationService struct{}
+
+func NewNotificationService() repository.NotificationRepository {
+	return &NotificationService{}
+}
+
+// GetAllNotifications - Paginated Alert Feed
+// =====================================================
+func (s *NotificationService) GetAllNotifications(filter models.NotificationFilter) (*models.NotificationPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.Notification{}).Where("deleted = false")
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.UnreadOnly {
+		query = query.Where("is_read = false")
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count notifications: ", err)
+	}
+
+	var unread int64
+	if err := db.Model(&models.Notification{}).
+		Where("deleted = false AND is_read = false").
+		Count(&unread).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count unread notifications: ", err)
+	}
+
+	var notifications []models.Notification
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&notifications).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch notifications: ", err)
+	}
+
+	responses := make([]models.NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		responses = append(responses, models.NotificationResponse{
+			Uuid:          n.Uuid,
+			Type:          n.Type,
+			Title:         n.Title,
+			Message:       n.Message,
+			ReferenceType: n.ReferenceType,
+			ReferenceId:   n.ReferenceId,
+			IsRead:        n.IsRead,
+			ReadAt:        n.ReadAt,
+			CreatedAt:     n.CreatedAt,
+		})
+	}
+
+	return &models.NotificationPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Unread: int(unread),
+		Data:   responses,
+	}, nil
+}
+
+// MarkNotificationRead - Single Notification
+// =====================================================
+func (s *NotificationService) MarkNotificationRead(notificationId string) error {
+	db := config.GetDBConn()
+
+	now := time.Now()
+	result := db.Model(&models.Notification{}).
+		Where("uuid = ? AND deleted = false", notificationId).
+		Updates(map[string]interface{}{
+			"is_read":    true,
+			"read_at":    gorm.Expr("COALESCE(read_at, ?)", now),
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to update notification: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("notification not found")
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead - Clear the Unread Badge
+// =====================================================
+func (s *NotificationService) MarkAllNotificationsRead() (int, error) {
+	db := config.GetDBConn()
+
+	now := time.Now()
+	result := db.Model(&models.Notification{}).
+		Where("deleted = false AND is_read = false").
+		Updates(map[string]interface{}{
+			"is_read":    true,
+			"read_at":    now,
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to update notifications: ", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// DeleteNotification - Soft Delete
+// =====================================================
+func (s *NotificationService) DeleteNotification(notificationId string) error {
+	db := config.GetDBConn()
+
+	result := db.Model(&models.Notification{}).
+		Where("uuid = ? AND deleted = false", notificationId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to delete notification: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("notification not found")
+	}
+
+	return nil
+}
+
+// notify stores an alert unless the same type was already raised for the
+// referenced record, so periodic checks can run without flooding the feed.
+func notify(db *gorm.DB, notificationType, title, message, referenceType, referenceId string) (bool, error) {
+	var exists int64
+	if err := db.Model(&models.Notification{}).
+		Where("type = ? AND reference_type = ? AND reference_id = ? AND deleted = false",
+			notificationType, referenceType, referenceId).
+		Count(&exists).Error; err != nil {
+		return false, apperror.NewUnprocessableEntity("failed to check notifications: ", err)
+	}
+	if exists > 0 {
+		return false, nil
+	}
+
+	now := time.Now()
+	notification := models.Notification{
+		Uuid:          uuid.New().String(),
+		Type:          notificationType,
+		Title:         title,
+		Message:       message,
+		ReferenceType: referenceType,
+		ReferenceId:   referenceId,
+		IsRead:        false,
+		Deleted:       false,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := db.Create(&notification).Error; err != nil {
+		return false, apperror.NewUnprocessableEntity("failed to create notification: ", err)
+	}
+
+	return true, nil
+}
@@ -6,13 +6,28 @@ import (
 	"dashboard-app/internal/models"
 	"dashboard-app/internal/repository"
 	"dashboard-app/pkg/apperror"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// paymentReceiptDir is the sub-directory of the upload dir holding receipts
+const paymentReceiptDir = "payment-receipts"
+
+// receiptFileTypes maps the accepted receipt content types to file extensions
+var receiptFileTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
 type PaymentService struct {
 }
 
@@ -39,16 +54,21 @@ func calculateBalance(payments []models.Payment) (totalIncome, totalOutcome int)
 // Helper to convert payment to response with deletion rules
 func (p *PaymentService) buildPaymentResponse(payment models.Payment, userRole string) models.PaymentResponse {
 	result := models.PaymentResponse{
-		Uuid:        payment.Uuid,
-		UserId:      payment.UserId,
-		Total:       payment.Total,
-		Type:        payment.Type,
-		Description: payment.Description,
-		SalesId:     payment.SalesId,
-		PurchaseId:  payment.PurchaseId,
-		CreatedAt:   payment.CreatedAt,
-		UpdatedAt:   payment.UpdatedAt,
-		IsDeleted:   false,
+		Uuid:               payment.Uuid,
+		UserId:             payment.UserId,
+		Total:              payment.Total,
+		Type:               payment.Type,
+		Description:        payment.Description,
+		SalesId:            payment.SalesId,
+		PurchaseId:         payment.PurchaseId,
+		CashAccountId:      payment.CashAccountId,
+		PaymentMethod:      payment.PaymentMethod,
+		ExpenseCategoryId:  payment.ExpenseCategoryId,
+		CostCenterId:       payment.CostCenterId,
+		RecurringExpenseId: payment.RecurringExpenseId,
+		CreatedAt:          payment.CreatedAt,
+		UpdatedAt:          payment.UpdatedAt,
+		IsDeleted:          false,
 	}
 
 	// Apply deletion rules
@@ -119,20 +139,41 @@ func (p *PaymentService) CreateManualPayment(userId string, requests []models.Cr
 		return nil
 	}
 
+	if err := checkPeriodsOpen(config.GetDBConn(), time.Now()); err != nil {
+		return err
+	}
+
 	payments := make([]models.Payment, 0, len(requests))
 	for _, req := range requests {
+		if err := checkPaymentAccount(config.GetDBConn(), req.CashAccountId, req.PaymentMethod); err != nil {
+			return err
+		}
+		if err := checkExpenseAllocation(config.GetDBConn(), userId, req.Type, req.ExpenseCategoryId, req.CostCenterId); err != nil {
+			return err
+		}
 		payments = append(payments, models.Payment{
-			Uuid:        uuid.NewString(),
-			UserId:      userId,
-			Total:       req.Total,
-			Type:        req.Type,
-			Description: req.Description,
+			Uuid:              uuid.NewString(),
+			UserId:            userId,
+			Total:             req.Total,
+			Type:              req.Type,
+			Description:       req.Description,
+			CashAccountId:     req.CashAccountId,
+			PaymentMethod:     req.PaymentMethod,
+			ExpenseCategoryId: req.ExpenseCategoryId,
+			CostCenterId:      req.CostCenterId,
 		})
 	}
 
+	// Payments are written one at a time so each posting sees the balance
+	// left by the ones before it
 	return config.GetDBConn().Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&payments).Error; err != nil {
-			return apperror.NewUnprocessableEntity("failed to create payments: ", err)
+		for i := range payments {
+			if err := tx.Create(&payments[i]).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create payments: ", err)
+			}
+			if err := postPaymentJournal(tx, payments[i], false); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -141,58 +182,53 @@ func (p *PaymentService) CreateManualPayment(userId string, requests []models.Cr
 func (p *PaymentService) DeleteManualPayment(paymentId string) error {
 	db := config.GetDBConn()
 
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperror.NewInternal("failed to begin transaction: ", tx.Error)
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
+	return db.Transaction(func(tx *gorm.DB) error {
+		var payment models.Payment
+		if err := tx.Model(&models.Payment{}).
+			Where("uuid = ? AND deleted = ?", paymentId, false).
+			First(&payment).Error; err != nil {
+			return apperror.NewNotFound(fmt.Sprintf("payment not found: %v", err))
 		}
-	}()
-
-	var payment models.Payment
-	if err := tx.Model(&models.Payment{}).
-		Where("uuid = ? AND deleted = ?", paymentId, false).
-		First(&payment).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("payment not found: %v", err))
-	}
 
-	// Soft delete payment
-	if err := tx.Model(&models.Payment{}).
-		Where("uuid = ?", paymentId).
-		Update("deleted", true).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("failed to delete payment: %v", err))
-	}
-
-	// Update related purchase if exists
-	if payment.PurchaseId != "" {
-		var purchase models.Purchase
-		if err := tx.Model(&models.Purchase{}).
-			Where("uuid = ? AND deleted = ?", payment.PurchaseId, false).
-			First(&purchase).Error; err != nil {
-			return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
+		if err := checkPeriodsOpen(tx, payment.CreatedAt); err != nil {
+			return err
 		}
 
-		updates := map[string]interface{}{
-			"remaining_amount": purchase.RemainingAmount + payment.Total,
-			"paid_amount":      purchase.PaidAmount - payment.Total,
-			"updated_at":       time.Now(),
+		// Soft delete payment
+		if err := tx.Model(&models.Payment{}).
+			Where("uuid = ?", paymentId).
+			Update("deleted", true).Error; err != nil {
+			return apperror.NewNotFound(fmt.Sprintf("failed to delete payment: %v", err))
 		}
 
-		if err := tx.Model(&models.Purchase{}).
-			Where("uuid = ?", purchase.Uuid).
-			Updates(updates).Error; err != nil {
-			return apperror.NewNotFound(fmt.Sprintf("failed to update purchase: %v", err))
+		// Update related purchase if exists
+		if payment.PurchaseId != "" {
+			var purchase models.Purchase
+			if err := tx.Model(&models.Purchase{}).
+				Where("uuid = ? AND deleted = ?", payment.PurchaseId, false).
+				First(&purchase).Error; err != nil {
+				return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
+			}
+
+			updates := map[string]interface{}{
+				"remaining_amount": purchase.RemainingAmount + payment.Total,
+				"paid_amount":      purchase.PaidAmount - payment.Total,
+				"updated_at":       time.Now(),
+			}
+
+			if err := tx.Model(&models.Purchase{}).
+				Where("uuid = ?", purchase.Uuid).
+				Updates(updates).Error; err != nil {
+				return apperror.NewNotFound(fmt.Sprintf("failed to update purchase: %v", err))
+			}
 		}
-	}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
-	}
+		if err := reverseJournals(tx, "source_id = ?", payment.Uuid); err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (p *PaymentService) GetAllPaymentByFieldId(id string, field string) (*models.CashFlowResponse, error) {
@@ -221,15 +257,20 @@ func (p *PaymentService) GetAllPaymentByFieldId(id string, field string) (*model
 
 	for _, payment := range payments {
 		results.Payment = append(results.Payment, models.PaymentResponse{
-			Uuid:        payment.Uuid,
-			UserId:      payment.UserId,
-			Total:       payment.Total,
-			Type:        payment.Type,
-			Description: payment.Description,
-			SalesId:     payment.SalesId,
-			PurchaseId:  payment.PurchaseId,
-			CreatedAt:   payment.CreatedAt,
-			UpdatedAt:   payment.UpdatedAt,
+			Uuid:               payment.Uuid,
+			UserId:             payment.UserId,
+			Total:              payment.Total,
+			Type:               payment.Type,
+			Description:        payment.Description,
+			SalesId:            payment.SalesId,
+			PurchaseId:         payment.PurchaseId,
+			CashAccountId:      payment.CashAccountId,
+			PaymentMethod:      payment.PaymentMethod,
+			ExpenseCategoryId:  payment.ExpenseCategoryId,
+			CostCenterId:       payment.CostCenterId,
+			RecurringExpenseId: payment.RecurringExpenseId,
+			CreatedAt:          payment.CreatedAt,
+			UpdatedAt:          payment.UpdatedAt,
 		})
 	}
 
@@ -237,24 +278,28 @@ func (p *PaymentService) GetAllPaymentByFieldId(id string, field string) (*model
 }
 
 func (p *PaymentService) CreatePaymentByPurchaseId(request models.CreatePaymentPurchaseRequest) error {
-	db := config.GetDBConn()
+	return config.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		_, err := p.CreatePurchasePaymentTx(tx, request)
+		return err
+	})
+}
 
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperror.NewInternal("failed to begin transaction: ", tx.Error)
+// CreatePurchasePaymentTx pays towards a purchase inside tx and returns the
+// payment, so callers can record it together with their own changes.
+func (p *PaymentService) CreatePurchasePaymentTx(tx *gorm.DB, request models.CreatePaymentPurchaseRequest) (*models.Payment, error) {
+	if err := checkPeriodsOpen(tx, request.PurchaseDate); err != nil {
+		return nil, err
+	}
+
+	if err := checkPaymentAccount(tx, request.CashAccountId, request.PaymentMethod); err != nil {
+		return nil, err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
-		}
-	}()
 
 	var purchase models.Purchase
 	if err := tx.Model(&models.Purchase{}).
 		Where("uuid = ? AND deleted = ?", request.PurchaseId, false).
 		First(&purchase).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
+		return nil, apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
 	}
 
 	paidAmount := purchase.PaidAmount + request.Total
@@ -275,50 +320,57 @@ func (p *PaymentService) CreatePaymentByPurchaseId(request models.CreatePaymentP
 	if err := tx.Model(&models.Purchase{}).
 		Where("uuid = ?", purchase.Uuid).
 		Updates(updates).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to update purchase: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to update purchase: ", err)
 	}
 
 	payment := models.Payment{
-		Uuid:        uuid.New().String(),
-		PurchaseId:  purchase.Uuid,
-		UserId:      purchase.SupplierID,
-		Description: fmt.Sprintf("Pembayaran Buying %s", request.StockCode),
-		Total:       request.Total,
-		Type:        constants.Expense,
-		Deleted:     false,
-		CreatedAt:   request.PurchaseDate,
+		Uuid:          uuid.New().String(),
+		PurchaseId:    purchase.Uuid,
+		UserId:        purchase.SupplierID,
+		Description:   fmt.Sprintf("Pembayaran Buying %s", request.StockCode),
+		Total:         request.Total,
+		Type:          constants.Expense,
+		CashAccountId: request.CashAccountId,
+		PaymentMethod: request.PaymentMethod,
+		Deleted:       false,
+		CreatedAt:     request.PurchaseDate,
 	}
 
 	if err := tx.Create(&payment).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to create payment: ", err)
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
+	if err := postPaymentJournal(tx, payment, false); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &payment, nil
 }
 
 func (p *PaymentService) CreatePaymentBySalesId(request models.CreatePaymentSaleRequest) error {
-	db := config.GetDBConn()
+	return config.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		_, err := p.CreateSalePaymentTx(tx, request)
+		return err
+	})
+}
 
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperror.NewInternal("failed to begin transaction: ", tx.Error)
+// CreateSalePaymentTx records a customer payment on a sale inside tx and
+// returns the payment, so callers can record it together with their own
+// changes.
+func (p *PaymentService) CreateSalePaymentTx(tx *gorm.DB, request models.CreatePaymentSaleRequest) (*models.Payment, error) {
+	if err := checkPeriodsOpen(tx, request.SalesDate); err != nil {
+		return nil, err
+	}
+
+	if err := checkPaymentAccount(tx, request.CashAccountId, request.PaymentMethod); err != nil {
+		return nil, err
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
-		}
-	}()
 
 	var sale models.Sale
 	if err := tx.Model(&models.Sale{}).
 		Where("uuid = ? AND deleted = ?", request.SalesId, false).
 		First(&sale).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
+		return nil, apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
 	}
 
 	paidAmount := sale.PaidAmount + request.Total
@@ -339,185 +391,175 @@ func (p *PaymentService) CreatePaymentBySalesId(request models.CreatePaymentSale
 	if err := tx.Model(&models.Sale{}).
 		Where("uuid = ?", sale.Uuid).
 		Updates(updates).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to update sale: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to update sale: ", err)
 	}
 
 	payment := models.Payment{
-		Uuid:        uuid.New().String(),
-		SalesId:     sale.Uuid,
-		UserId:      sale.CustomerId,
-		Description: fmt.Sprintf("Pembayaran Buying %s", request.SalesCode),
-		Total:       request.Total,
-		Type:        constants.Expense,
-		Deleted:     false,
-		CreatedAt:   request.SalesDate,
+		Uuid:          uuid.New().String(),
+		SalesId:       sale.Uuid,
+		UserId:        sale.CustomerId,
+		Description:   fmt.Sprintf("Pembayaran Buying %s", request.SalesCode),
+		Total:         request.Total,
+		Type:          constants.Expense,
+		CashAccountId: request.CashAccountId,
+		PaymentMethod: request.PaymentMethod,
+		Deleted:       false,
+		CreatedAt:     request.SalesDate,
 	}
 
 	if err := tx.Create(&payment).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to create payment: ", err)
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
+	if err := postPaymentJournal(tx, payment, false); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &payment, nil
 }
 
 func (p *PaymentService) CreatePaymentFromDepositByPurchaseId(request models.CreatePaymentPurchaseRequest) error {
-	db := config.GetDBConn()
-
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperror.NewInternal("failed to begin transaction: ", tx.Error)
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
+	return config.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var purchase models.Purchase
+		if err := tx.Model(&models.Purchase{}).
+			Where("uuid = ? AND deleted = ?", request.PurchaseId, false).
+			First(&purchase).Error; err != nil {
+			return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
 		}
-	}()
 
-	var purchase models.Purchase
-	if err := tx.Model(&models.Purchase{}).
-		Where("uuid = ? AND deleted = ?", request.PurchaseId, false).
-		First(&purchase).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
-	}
+		// The payment is booked today against a document that keeps its own date
+		if err := checkPeriodsOpen(tx, time.Now(), purchase.PurchaseDate, request.PurchaseDate); err != nil {
+			return err
+		}
 
-	paidAmount := purchase.PaidAmount + request.Total
-	remainingAmount := purchase.TotalAmount - paidAmount
+		paidAmount := purchase.PaidAmount + request.Total
+		remainingAmount := purchase.TotalAmount - paidAmount
 
-	paymentStatus := constants.PartialPayment
-	if paidAmount >= purchase.TotalAmount {
-		paymentStatus = constants.PaymentInFull
-	}
+		paymentStatus := constants.PartialPayment
+		if paidAmount >= purchase.TotalAmount {
+			paymentStatus = constants.PaymentInFull
+		}
 
-	now := time.Now()
-	updates := map[string]interface{}{
-		"purchase_date":    request.PurchaseDate,
-		"remaining_amount": remainingAmount,
-		"payment_status":   paymentStatus,
-		"paid_amount":      paidAmount,
-		"updated_at":       now,
-	}
+		now := time.Now()
+		updates := map[string]interface{}{
+			"remaining_amount": remainingAmount,
+			"payment_status":   paymentStatus,
+			"paid_amount":      paidAmount,
+			"updated_at":       now,
+		}
 
-	if err := tx.Model(&models.Purchase{}).
-		Where("uuid = ?", purchase.Uuid).
-		Updates(updates).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to update purchase: ", err)
-	}
+		if err := tx.Model(&models.Purchase{}).
+			Where("uuid = ?", purchase.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase: ", err)
+		}
 
-	payments := []models.Payment{
-		{
-			Uuid:        uuid.New().String(),
-			PurchaseId:  purchase.Uuid,
-			UserId:      purchase.SupplierID,
-			Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.StockCode),
-			Total:       request.Total,
-			Type:        constants.Expense,
-			Deleted:     false,
-			CreatedAt:   now,
-		},
-		{
-			Uuid:        uuid.New().String(),
-			PurchaseId:  purchase.Uuid,
-			UserId:      purchase.SupplierID,
-			Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.StockCode),
-			Total:       request.Total,
-			Type:        constants.Income,
-			Deleted:     false,
-			CreatedAt:   now,
-		},
-	}
+		payments := []models.Payment{
+			{
+				Uuid:        uuid.New().String(),
+				PurchaseId:  purchase.Uuid,
+				UserId:      purchase.SupplierID,
+				Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.StockCode),
+				Total:       request.Total,
+				Type:        constants.Expense,
+				Deleted:     false,
+				CreatedAt:   now,
+			},
+			{
+				Uuid:        uuid.New().String(),
+				PurchaseId:  purchase.Uuid,
+				UserId:      purchase.SupplierID,
+				Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.StockCode),
+				Total:       request.Total,
+				Type:        constants.Income,
+				Deleted:     false,
+				CreatedAt:   now,
+			},
+		}
 
-	if err := tx.Create(&payments).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to create payment: ", err)
-	}
+		if err := tx.Create(&payments).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+		}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
-	}
+		// The pair only moves the balance from the deposit onto the debt
+		if err := postPaymentJournal(tx, payments[0], true); err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (p *PaymentService) CreatePaymentFromDepositBySalesId(request models.CreatePaymentSaleRequest) error {
-	db := config.GetDBConn()
-
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperror.NewInternal("failed to begin transaction: ", tx.Error)
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			panic(r)
+	return config.GetDBConn().Transaction(func(tx *gorm.DB) error {
+		var sale models.Sale
+		if err := tx.Model(&models.Sale{}).
+			Where("uuid = ? AND deleted = ?", request.SalesId, false).
+			First(&sale).Error; err != nil {
+			return apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
 		}
-	}()
 
-	var sale models.Sale
-	if err := tx.Model(&models.Sale{}).
-		Where("uuid = ? AND deleted = ?", request.SalesId, false).
-		First(&sale).Error; err != nil {
-		return apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
-	}
+		// The payment is booked today against a document that keeps its own date
+		if err := checkPeriodsOpen(tx, time.Now(), sale.PurchaseDate, request.SalesDate); err != nil {
+			return err
+		}
 
-	paidAmount := sale.PaidAmount + request.Total
-	remainingAmount := sale.TotalAmount - paidAmount
+		paidAmount := sale.PaidAmount + request.Total
+		remainingAmount := sale.TotalAmount - paidAmount
 
-	paymentStatus := constants.PartialPayment
-	if paidAmount >= sale.TotalAmount {
-		paymentStatus = constants.PaymentInFull
-	}
+		paymentStatus := constants.PartialPayment
+		if paidAmount >= sale.TotalAmount {
+			paymentStatus = constants.PaymentInFull
+		}
 
-	now := time.Now()
-	updates := map[string]interface{}{
-		"purchase_date":    request.SalesDate,
-		"remaining_amount": remainingAmount,
-		"payment_status":   paymentStatus,
-		"paid_amount":      paidAmount,
-		"updated_at":       now,
-	}
+		now := time.Now()
+		updates := map[string]interface{}{
+			"remaining_amount": remainingAmount,
+			"payment_status":   paymentStatus,
+			"paid_amount":      paidAmount,
+			"updated_at":       now,
+		}
 
-	if err := tx.Model(&models.Sale{}).
-		Where("uuid = ?", sale.Uuid).
-		Updates(updates).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to update sale: ", err)
-	}
+		if err := tx.Model(&models.Sale{}).
+			Where("uuid = ?", sale.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update sale: ", err)
+		}
 
-	payments := []models.Payment{
-		{
-			Uuid:        uuid.New().String(),
-			SalesId:     sale.Uuid,
-			UserId:      sale.CustomerId,
-			Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.SalesCode),
-			Total:       request.Total,
-			Type:        constants.Expense,
-			Deleted:     false,
-			CreatedAt:   now,
-		},
-		{
-			Uuid:        uuid.New().String(),
-			SalesId:     sale.Uuid,
-			UserId:      sale.CustomerId,
-			Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.SalesCode),
-			Total:       request.Total,
-			Type:        constants.Income,
-			Deleted:     false,
-			CreatedAt:   now,
-		},
-	}
+		payments := []models.Payment{
+			{
+				Uuid:        uuid.New().String(),
+				SalesId:     sale.Uuid,
+				UserId:      sale.CustomerId,
+				Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.SalesCode),
+				Total:       request.Total,
+				Type:        constants.Expense,
+				Deleted:     false,
+				CreatedAt:   now,
+			},
+			{
+				Uuid:        uuid.New().String(),
+				SalesId:     sale.Uuid,
+				UserId:      sale.CustomerId,
+				Description: fmt.Sprintf("Pembayaran Melalui Deposit %s", request.SalesCode),
+				Total:       request.Total,
+				Type:        constants.Income,
+				Deleted:     false,
+				CreatedAt:   now,
+			},
+		}
 
-	if err := tx.Create(&payments).Error; err != nil {
-		return apperror.NewUnprocessableEntity("failed to create payment: ", err)
-	}
+		if err := tx.Create(&payments).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+		}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
-	}
+		// The pair only moves the balance from the deposit onto the debt
+		if err := postPaymentJournal(tx, payments[0], true); err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (p *PaymentService) GetUserBalanceDeposit(userId string) (*models.UserBalanceDepositResponse, error) {
@@ -551,3 +593,118 @@ func (p *PaymentService) GetUserBalanceDeposit(userId string) (*models.UserBalan
 
 	return &response, nil
 }
+
+// UploadPaymentAttachment - Receipt Stored on Local Disk
+// =====================================================
+func (p *PaymentService) UploadPaymentAttachment(paymentId string, fileName string, file io.Reader) (*models.PaymentAttachmentResponse, error) {
+	db := config.GetDBConn()
+
+	var payment models.Payment
+	if err := db.Where("uuid = ? AND deleted = false", paymentId).First(&payment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("payment not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch payment: ", err)
+	}
+
+	attachmentId := uuid.NewString()
+	upload, err := storeUpload(file, paymentReceiptDir, attachmentId,
+		receiptFileTypes, "receipt must be a JPEG, PNG or WEBP image or a PDF")
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := models.PaymentAttachment{
+		Uuid:        attachmentId,
+		PaymentId:   payment.Uuid,
+		FileName:    filepath.Base(fileName),
+		FilePath:    upload.Path,
+		ContentType: upload.ContentType,
+		FileSize:    upload.Size,
+		Deleted:     false,
+	}
+
+	if err := db.Create(&attachment).Error; err != nil {
+		os.Remove(attachment.FilePath)
+		return nil, apperror.NewUnprocessableEntity("failed to save receipt: ", err)
+	}
+
+	response := paymentAttachmentResponse(attachment)
+	return &response, nil
+}
+
+// GetPaymentAttachments - Receipts of One Payment
+// =====================================================
+func (p *PaymentService) GetPaymentAttachments(paymentId string) ([]models.PaymentAttachmentResponse, error) {
+	var attachments []models.PaymentAttachment
+	if err := config.GetDBConn().
+		Where("payment_id = ? AND deleted = false", paymentId).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch receipts: ", err)
+	}
+
+	responses := make([]models.PaymentAttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		responses = append(responses, paymentAttachmentResponse(a))
+	}
+
+	return responses, nil
+}
+
+// GetPaymentAttachment - Stored Receipt for Download
+// =====================================================
+func (p *PaymentService) GetPaymentAttachment(paymentId string, attachmentId string) (*models.PaymentAttachment, error) {
+	attachment, err := p.findAttachment(paymentId, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(attachment.FilePath); err != nil {
+		return nil, apperror.NewNotFound("receipt file is missing")
+	}
+
+	return attachment, nil
+}
+
+// DeletePaymentAttachment - Remove a Receipt
+// =====================================================
+func (p *PaymentService) DeletePaymentAttachment(paymentId string, attachmentId string) error {
+	attachment, err := p.findAttachment(paymentId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	if err = config.GetDBConn().Model(&models.PaymentAttachment{}).
+		Where("uuid = ?", attachment.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": time.Now()}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete receipt: ", err)
+	}
+
+	os.Remove(attachment.FilePath)
+	return nil
+}
+
+func (p *PaymentService) findAttachment(paymentId string, attachmentId string) (*models.PaymentAttachment, error) {
+	var attachment models.PaymentAttachment
+	if err := config.GetDBConn().
+		Where("uuid = ? AND payment_id = ? AND deleted = false", attachmentId, paymentId).
+		First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("receipt not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch receipt: ", err)
+	}
+
+	return &attachment, nil
+}
+
+func paymentAttachmentResponse(a models.PaymentAttachment) models.PaymentAttachmentResponse {
+	return models.PaymentAttachmentResponse{
+		Uuid:        a.Uuid,
+		PaymentId:   a.PaymentId,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		FileSize:    a.FileSize,
+		CreatedAt:   a.CreatedAt,
+	}
+}
@@ -0,0 +1,566 @@
+package service
+
+import (
+	"dashboard-app/internal/constants"
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type PriceListService struct{}
+
+func NewPriceListService() repository.PriceListRepository {
+	return &PriceListService{}
+}
+
+// CreatePriceList - With Customer and Overlap Validation
+// =====================================================
+func (s *PriceListService) CreatePriceList(request models.PriceListRequest) (*models.PriceListResponse, error) {
+	db := config.GetDBConn()
+
+	if err := s.validatePriceList(db, request, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	priceList := models.PriceList{
+		Uuid:       uuid.New().String(),
+		CustomerId: request.CustomerId,
+		Name:       strings.TrimSpace(request.Name),
+		ValidFrom:  truncateDate(request.ValidFrom),
+		ValidTo:    truncateDatePtr(request.ValidTo),
+		Deleted:    false,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&priceList).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create price list: ", err)
+		}
+
+		return s.createItems(tx, priceList.Uuid, request.Items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPriceListById(priceList.Uuid)
+}
+
+// UpdatePriceList - Replace Header, Items and Tiers
+// =====================================================
+func (s *PriceListService) UpdatePriceList(priceListId string, request models.PriceListRequest) error {
+	db := config.GetDBConn()
+
+	if err := s.validatePriceList(db, request, priceListId); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&models.PriceList{}).
+			Where("uuid = ? AND deleted = false", priceListId).
+			Updates(map[string]interface{}{
+				"customer_id": request.CustomerId,
+				"name":        strings.TrimSpace(request.Name),
+				"valid_from":  truncateDate(request.ValidFrom),
+				"valid_to":    truncateDatePtr(request.ValidTo),
+				"updated_at":  now,
+			})
+
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to update price list: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewNotFound("price list not found or already deleted")
+		}
+
+		if err := s.deleteItems(tx, priceListId, now); err != nil {
+			return err
+		}
+
+		return s.createItems(tx, priceListId, request.Items)
+	})
+}
+
+// DeletePriceList - Soft Delete with Items and Tiers
+// =====================================================
+func (s *PriceListService) DeletePriceList(priceListId string) error {
+	db := config.GetDBConn()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&models.PriceList{}).
+			Where("uuid = ? AND deleted = false", priceListId).
+			Updates(map[string]interface{}{
+				"deleted":    true,
+				"updated_at": now,
+			})
+
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to delete price list: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewNotFound("price list not found or already deleted")
+		}
+
+		return s.deleteItems(tx, priceListId, now)
+	})
+}
+
+// GetPriceListById - Header, Items and Tiers
+// =====================================================
+func (s *PriceListService) GetPriceListById(priceListId string) (*models.PriceListResponse, error) {
+	db := config.GetDBConn()
+
+	var priceList models.PriceList
+	if err := db.Where("uuid = ? AND deleted = false", priceListId).First(&priceList).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("price list not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price list: ", err)
+	}
+
+	responses, err := s.buildResponses(db, []models.PriceList{priceList})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllPriceLists - Paginated with Filters
+// =====================================================
+func (s *PriceListService) GetAllPriceLists(filter models.PriceListFilter) (*models.PriceListPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.PriceList{}).Where("deleted = false")
+
+	if filter.CustomerId != "" {
+		query = query.Where("customer_id = ?", filter.CustomerId)
+	}
+	if filter.ActiveOn != "" {
+		query = query.Where("valid_from <= CAST(? AS DATE) AND (valid_to IS NULL OR valid_to >= CAST(? AS DATE))",
+			filter.ActiveOn, filter.ActiveOn)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count price lists: ", err)
+	}
+
+	var priceLists []models.PriceList
+	if err := query.
+		Order("valid_from DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&priceLists).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price lists: ", err)
+	}
+
+	responses, err := s.buildResponses(db, priceLists)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PriceListPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// ApplyPriceList - Preview sale lines priced from the customer's list
+// =====================================================
+func (s *PriceListService) ApplyPriceList(request models.PriceListApplyRequest) ([]models.ItemSalesRequest, error) {
+	items := append([]models.ItemSalesRequest(nil), request.ItemSales...)
+
+	if _, err := applyCustomerPriceList(config.GetDBConn(), request.CustomerId, request.SalesDate, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s *PriceListService) validatePriceList(db *gorm.DB, request models.PriceListRequest, excludeId string) error {
+	validFrom := truncateDate(request.ValidFrom)
+	validTo := truncateDatePtr(request.ValidTo)
+
+	if validTo != nil && validTo.Before(validFrom) {
+		return apperror.NewBadRequest("valid_to must not be before valid_from")
+	}
+
+	var customer models.User
+	if err := db.Where("uuid = ? AND status = true", request.CustomerId).First(&customer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("customer not found")
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch customer: ", err)
+	}
+
+	if customer.Role != constants.BuyerRole {
+		return apperror.NewBadRequest("price lists can only be attached to BUYER users")
+	}
+
+	productIDs := make([]string, 0, len(request.Items))
+	seen := make(map[string]bool, len(request.Items))
+	for i, item := range request.Items {
+		if seen[item.ProductId] {
+			return apperror.NewBadRequest(fmt.Sprintf("item %d: product %s is listed twice", i+1, item.ProductId))
+		}
+		seen[item.ProductId] = true
+		productIDs = append(productIDs, item.ProductId)
+
+		tierWeights := make(map[int]bool, len(item.Tiers))
+		for _, tier := range item.Tiers {
+			if tierWeights[tier.MinWeight] {
+				return apperror.NewBadRequest(fmt.Sprintf("item %d: duplicate tier for %d kg", i+1, tier.MinWeight))
+			}
+			tierWeights[tier.MinWeight] = true
+		}
+	}
+
+	var count int64
+	if err := db.Model(&models.Product{}).
+		Where("uuid IN ? AND deleted = false", productIDs).
+		Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch products: ", err)
+	}
+	if int(count) != len(productIDs) {
+		return apperror.NewNotFound("one or more products not found")
+	}
+
+	overlap := db.Model(&models.PriceList{}).
+		Where("customer_id = ? AND deleted = false", request.CustomerId).
+		Where("valid_to IS NULL OR valid_to >= ?", validFrom)
+	if validTo != nil {
+		overlap = overlap.Where("valid_from <= ?", *validTo)
+	}
+	if excludeId != "" {
+		overlap = overlap.Where("uuid <> ?", excludeId)
+	}
+
+	var overlapping int64
+	if err := overlap.Count(&overlapping).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check price list periods: ", err)
+	}
+	if overlapping > 0 {
+		return apperror.NewConflict("customer already has a price list valid in this period")
+	}
+
+	return nil
+}
+
+func (s *PriceListService) createItems(tx *gorm.DB, priceListId string, items []models.PriceListItemRequest) error {
+	now := time.Now()
+	listItems := make([]models.PriceListItem, 0, len(items))
+	tiers := make([]models.PriceListTier, 0)
+
+	for _, item := range items {
+		listItem := models.PriceListItem{
+			Uuid:             uuid.New().String(),
+			PriceListId:      priceListId,
+			ProductId:        item.ProductId,
+			PricePerKilogram: item.PricePerKilogram,
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		listItems = append(listItems, listItem)
+
+		for _, tier := range item.Tiers {
+			tiers = append(tiers, models.PriceListTier{
+				Uuid:            uuid.New().String(),
+				PriceListItemId: listItem.Uuid,
+				MinWeight:       tier.MinWeight,
+				DiscountPercent: tier.DiscountPercent,
+				Deleted:         false,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			})
+		}
+	}
+
+	if err := tx.Create(&listItems).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to create price list items: ", err)
+	}
+
+	if len(tiers) > 0 {
+		if err := tx.Create(&tiers).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create price list tiers: ", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *PriceListService) deleteItems(tx *gorm.DB, priceListId string, now time.Time) error {
+	if err := tx.Model(&models.PriceListTier{}).
+		Where("deleted = false AND price_list_item_id IN (?)",
+			tx.Model(&models.PriceListItem{}).Select("uuid").Where("price_list_id = ?", priceListId)).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete price list tiers: ", err)
+	}
+
+	if err := tx.Model(&models.PriceListItem{}).
+		Where("price_list_id = ? AND deleted = false", priceListId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete price list items: ", err)
+	}
+
+	return nil
+}
+
+func (s *PriceListService) buildResponses(db *gorm.DB, priceLists []models.PriceList) ([]models.PriceListResponse, error) {
+	responses := make([]models.PriceListResponse, 0, len(priceLists))
+	if len(priceLists) == 0 {
+		return responses, nil
+	}
+
+	listIDs := make([]string, 0, len(priceLists))
+	customerIDs := make([]string, 0, len(priceLists))
+	for _, pl := range priceLists {
+		listIDs = append(listIDs, pl.Uuid)
+		customerIDs = append(customerIDs, pl.CustomerId)
+	}
+
+	var customers []models.User
+	if err := db.Where("uuid IN ?", distinct(customerIDs)).Find(&customers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch customers: ", err)
+	}
+	customerMap := make(map[string]models.User, len(customers))
+	for _, c := range customers {
+		customerMap[c.Uuid] = c
+	}
+
+	var items []struct {
+		models.PriceListItem
+		ProductCode string `gorm:"column:product_code"`
+		ProductName string `gorm:"column:product_name"`
+	}
+	if err := db.Table("price_list_items AS pli").
+		Select("pli.*, p.code AS product_code, p.name AS product_name").
+		Joins("LEFT JOIN products p ON p.uuid = pli.product_id").
+		Where("pli.price_list_id IN ? AND pli.deleted = false", listIDs).
+		Order("p.name ASC").
+		Scan(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price list items: ", err)
+	}
+
+	itemIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.Uuid)
+	}
+
+	tierMap, err := fetchPriceListTiers(db, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	itemMap := make(map[string][]models.PriceListItemResponse)
+	for _, item := range items {
+		tierResponses := make([]models.PriceListTierResponse, 0, len(tierMap[item.Uuid]))
+		for _, tier := range tierMap[item.Uuid] {
+			tierResponses = append(tierResponses, models.PriceListTierResponse{
+				MinWeight:       tier.MinWeight,
+				DiscountPercent: tier.DiscountPercent,
+			})
+		}
+
+		itemMap[item.PriceListId] = append(itemMap[item.PriceListId], models.PriceListItemResponse{
+			Uuid:             item.Uuid,
+			ProductId:        item.ProductId,
+			ProductCode:      item.ProductCode,
+			ProductName:      item.ProductName,
+			PricePerKilogram: item.PricePerKilogram,
+			Tiers:            tierResponses,
+		})
+	}
+
+	for _, pl := range priceLists {
+		customer := customerMap[pl.CustomerId]
+		listItems := itemMap[pl.Uuid]
+		if listItems == nil {
+			listItems = make([]models.PriceListItemResponse, 0)
+		}
+
+		responses = append(responses, models.PriceListResponse{
+			Uuid: pl.Uuid,
+			Name: pl.Name,
+			Customer: models.GetUserDetail{
+				Uuid:  customer.Uuid,
+				Name:  customer.Name,
+				Phone: customer.Phone,
+			},
+			ValidFrom: pl.ValidFrom,
+			ValidTo:   pl.ValidTo,
+			CreatedAt: pl.CreatedAt,
+			Items:     listItems,
+		})
+	}
+
+	return responses, nil
+}
+
+// fetchPriceListTiers returns the active tiers per price list item, ordered
+// by ascending minimum weight.
+func fetchPriceListTiers(db *gorm.DB, itemIDs []string) (map[string][]models.PriceListTier, error) {
+	tierMap := make(map[string][]models.PriceListTier)
+	if len(itemIDs) == 0 {
+		return tierMap, nil
+	}
+
+	var tiers []models.PriceListTier
+	if err := db.Where("price_list_item_id IN ? AND deleted = false", itemIDs).
+		Order("min_weight ASC").
+		Find(&tiers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price list tiers: ", err)
+	}
+
+	for _, tier := range tiers {
+		tierMap[tier.PriceListItemId] = append(tierMap[tier.PriceListItemId], tier)
+	}
+
+	return tierMap, nil
+}
+
+// applyCustomerPriceList prices sale lines from the customer's price list
+// valid on the sale date and records the applied list on each line. Lines
+// marked manual_price keep the price typed in. It returns the change in line
+// totals so the caller can keep the sale total consistent.
+func applyCustomerPriceList(db *gorm.DB, customerId string, date time.Time, items []models.ItemSalesRequest) (int, error) {
+	for i := range items {
+		items[i].PriceListId = ""
+		items[i].ListPrice = 0
+		items[i].DiscountPercent = 0
+	}
+
+	if customerId == "" || len(items) == 0 {
+		return 0, nil
+	}
+
+	var lists []models.PriceList
+	if err := db.
+		Where("customer_id = ? AND deleted = false AND valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)",
+			customerId, truncateDate(date), truncateDate(date)).
+		Order("valid_from DESC").
+		Limit(1).
+		Find(&lists).Error; err != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to fetch customer price list: ", err)
+	}
+
+	if len(lists) == 0 {
+		return 0, nil
+	}
+	priceList := lists[0]
+
+	sortIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		sortIDs = append(sortIDs, item.StockSortId)
+	}
+
+	var sorts []models.StockSort
+	if err := db.Where("uuid IN ?", sortIDs).Find(&sorts).Error; err != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	sortProduct := make(map[string]string, len(sorts))
+	for _, srt := range sorts {
+		sortProduct[srt.Uuid] = srt.ProductId
+	}
+
+	var listItems []models.PriceListItem
+	if err := db.Where("price_list_id = ? AND deleted = false", priceList.Uuid).Find(&listItems).Error; err != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to fetch price list items: ", err)
+	}
+
+	productItem := make(map[string]models.PriceListItem, len(listItems))
+	itemIDs := make([]string, 0, len(listItems))
+	for _, li := range listItems {
+		productItem[li.ProductId] = li
+		itemIDs = append(itemIDs, li.Uuid)
+	}
+
+	tierMap, err := fetchPriceListTiers(db, itemIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	delta := 0
+	for i := range items {
+		if items[i].ManualPrice {
+			continue
+		}
+
+		listItem, ok := productItem[sortProduct[items[i].StockSortId]]
+		if !ok {
+			continue
+		}
+
+		discount := tierDiscount(tierMap[listItem.Uuid], items[i].Weight)
+		price := listItem.PricePerKilogram * (100 - discount) / 100
+		total := items[i].Weight * price
+
+		delta += total - items[i].TotalAmount
+
+		items[i].PricePerKilogram = price
+		items[i].TotalAmount = total
+		items[i].PriceListId = priceList.Uuid
+		items[i].ListPrice = listItem.PricePerKilogram
+		items[i].DiscountPercent = discount
+	}
+
+	return delta, nil
+}
+
+// tierDiscount returns the discount of the highest tier the weight reaches.
+func tierDiscount(tiers []models.PriceListTier, weight int) int {
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].MinWeight < tiers[j].MinWeight })
+
+	discount := 0
+	for _, tier := range tiers {
+		if weight >= tier.MinWeight {
+			discount = tier.DiscountPercent
+		}
+	}
+
+	return discount
+}
+
+func truncateDatePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	d := truncateDate(*t)
+	return &d
+}
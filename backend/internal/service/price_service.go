@@ -0,0 +1,496 @@
+package service
+
+import (
+	"dashboard-app/internal/constants"
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type PriceService struct{}
+
+func NewPriceService() repository.PriceRepository {
+	return &PriceService{}
+}
+
+// SetPriceBoard - Replace the day's prices, keeping earlier days as history
+// =====================================================
+func (s *PriceService) SetPriceBoard(request models.PriceBoardRequest) ([]models.PriceBoardResponse, error) {
+	db := config.GetDBConn()
+
+	priceDate := truncateDate(request.PriceDate)
+
+	seen := make(map[string]bool, len(request.Prices))
+	productIDs := make([]string, 0, len(request.Prices))
+	for _, v := range request.Prices {
+		key := v.ProductId + "|" + strings.TrimSpace(v.Grade)
+		if seen[key] {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("duplicate price for product %s grade %q", v.ProductId, v.Grade))
+		}
+		seen[key] = true
+		productIDs = append(productIDs, v.ProductId)
+	}
+
+	productIDs = distinct(productIDs)
+
+	var count int64
+	if err := db.Model(&models.Product{}).
+		Where("uuid IN ? AND deleted = false", productIDs).
+		Count(&count).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch products: ", err)
+	}
+
+	if int(count) != len(productIDs) {
+		return nil, apperror.NewNotFound("one or more products not found")
+	}
+
+	now := time.Now()
+	boards := make([]models.PriceBoard, 0, len(request.Prices))
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range request.Prices {
+			grade := strings.TrimSpace(v.Grade)
+
+			if err := tx.Model(&models.PriceBoard{}).
+				Where("product_id = ? AND grade = ? AND price_date = ? AND deleted = false", v.ProductId, grade, priceDate).
+				Updates(map[string]interface{}{
+					"deleted":    true,
+					"updated_at": now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to replace price: ", err)
+			}
+
+			boards = append(boards, models.PriceBoard{
+				Uuid:      uuid.New().String(),
+				ProductId: v.ProductId,
+				Grade:     grade,
+				PriceDate: priceDate,
+				BuyPrice:  v.BuyPrice,
+				SellPrice: v.SellPrice,
+				Deleted:   false,
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+		}
+
+		if err := tx.Create(&boards).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to save price board: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPriceBoard(models.PriceBoardFilter{Date: priceDate.Format("2006-01-02")})
+}
+
+// GetPriceBoard - Latest price per product and grade effective on a date
+// =====================================================
+func (s *PriceService) GetPriceBoard(filter models.PriceBoardFilter) ([]models.PriceBoardResponse, error) {
+	db := config.GetDBConn()
+
+	date, err := parsePriceDate(filter.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.PriceBoardResponse
+	if err := db.Raw(`
+		SELECT DISTINCT ON (pb.product_id, pb.grade)
+			pb.uuid,
+			pb.product_id,
+			p.code AS product_code,
+			p.name AS product_name,
+			pb.grade,
+			pb.price_date,
+			pb.buy_price,
+			pb.sell_price
+		FROM price_boards pb
+		INNER JOIN products p ON p.uuid = pb.product_id AND p.deleted = false
+		WHERE pb.deleted = false
+		  AND pb.price_date <= ?
+		ORDER BY pb.product_id, pb.grade, pb.price_date DESC
+	`, date).Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price board: ", err)
+	}
+
+	return results, nil
+}
+
+// GetPriceHistory - Daily prices for a product over a date range
+// =====================================================
+func (s *PriceService) GetPriceHistory(filter models.PriceHistoryFilter) ([]models.PriceBoardResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Table("price_boards AS pb").
+		Select(`
+			pb.uuid,
+			pb.product_id,
+			p.code AS product_code,
+			p.name AS product_name,
+			pb.grade,
+			pb.price_date,
+			pb.buy_price,
+			pb.sell_price
+		`).
+		Joins("INNER JOIN products p ON p.uuid = pb.product_id").
+		Where("pb.deleted = false")
+
+	if filter.ProductId != "" {
+		query = query.Where("pb.product_id = ?", filter.ProductId)
+	}
+	if filter.Grade != "" {
+		query = query.Where("pb.grade = ?", filter.Grade)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("pb.price_date >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("pb.price_date <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var results []models.PriceBoardResponse
+	if err := query.
+		Order("pb.price_date DESC, p.name ASC, pb.grade ASC").
+		Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch price history: ", err)
+	}
+
+	return results, nil
+}
+
+// SuggestPrice - Default price for a purchase (product) or sale (stock sort) line
+// =====================================================
+func (s *PriceService) SuggestPrice(filter models.PriceSuggestFilter) (*models.PriceSuggestion, error) {
+	db := config.GetDBConn()
+
+	date, err := parsePriceDate(filter.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	productId := filter.ProductId
+	grade := filter.Grade
+	costPerKg := 0
+
+	if filter.StockSortId != "" {
+		var sort models.StockSort
+		if err := db.Where("uuid = ? AND deleted = false", filter.StockSortId).First(&sort).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound("stock sort not found")
+			}
+			return nil, apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+		}
+		productId = sort.ProductId
+		costPerKg = sort.PricePerKilogram
+	}
+
+	if productId == "" {
+		return nil, apperror.NewBadRequest("product_id or stock_sort_id is required")
+	}
+
+	if grade == "" {
+		var product models.Product
+		if err := db.Where("uuid = ? AND deleted = false", productId).First(&product).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound("product not found")
+			}
+			return nil, apperror.NewUnprocessableEntity("failed to fetch product: ", err)
+		}
+		grade = product.Grade
+	}
+
+	board, err := effectivePrice(db, productId, grade, date)
+	if err != nil {
+		return nil, err
+	}
+
+	band := models.GetConfig().Pricing.BandPercent
+	suggestion := &models.PriceSuggestion{
+		ProductId:   productId,
+		Grade:       grade,
+		CostPerKg:   costPerKg,
+		BandPercent: band,
+	}
+
+	if board != nil {
+		minPrice, maxPrice := priceBand(board.SellPrice)
+		suggestion.PriceDate = &board.PriceDate
+		suggestion.BuyPrice = board.BuyPrice
+		suggestion.SellPrice = board.SellPrice
+		suggestion.MinSellPrice = minPrice
+		suggestion.MaxSellPrice = maxPrice
+		suggestion.HasBoardPrice = true
+	}
+
+	return suggestion, nil
+}
+
+// CheckPrices - Warnings for sale and purchase lines before submitting
+// =====================================================
+func (s *PriceService) CheckPrices(request models.PriceCheckRequest) ([]models.PriceWarning, error) {
+	db := config.GetDBConn()
+
+	date := request.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	warnings, err := checkSalePrices(db, date, request.SaleItems)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseWarnings, err := checkPurchasePrices(db, date, request.StockItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, purchaseWarnings...), nil
+}
+
+// checkSalePrices flags sale lines priced below the sort's cost per kg or
+// outside the configured band around the board's sell price.
+func checkSalePrices(db *gorm.DB, date time.Time, items []models.ItemSalesRequest) ([]models.PriceWarning, error) {
+	warnings := make([]models.PriceWarning, 0)
+	if len(items) == 0 {
+		return warnings, nil
+	}
+
+	sortIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		sortIDs = append(sortIDs, item.StockSortId)
+	}
+
+	var sorts []struct {
+		Uuid             string `gorm:"column:uuid"`
+		ItemName         string `gorm:"column:sorted_item_name"`
+		ProductId        string `gorm:"column:product_id"`
+		PricePerKilogram int    `gorm:"column:price_per_kilogram"`
+		Grade            string `gorm:"column:grade"`
+	}
+	if err := db.Table("stock_sorts AS ss").
+		Select("ss.uuid, ss.sorted_item_name, ss.product_id, ss.price_per_kilogram + ss.landed_cost_per_kilogram AS price_per_kilogram, COALESCE(p.grade, '') AS grade").
+		Joins("LEFT JOIN products p ON p.uuid = ss.product_id").
+		Where("ss.uuid IN ?", sortIDs).
+		Scan(&sorts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	sortMap := make(map[string]int, len(sorts))
+	for i := range sorts {
+		sortMap[sorts[i].Uuid] = i
+	}
+
+	pricing := models.GetConfig().Pricing
+
+	for i, item := range items {
+		idx, ok := sortMap[item.StockSortId]
+		if !ok {
+			continue
+		}
+		sort := sorts[idx]
+
+		if sort.PricePerKilogram > 0 && item.PricePerKilogram < sort.PricePerKilogram {
+			warnings = append(warnings, models.PriceWarning{
+				Line:           i + 1,
+				Kind:           constants.PriceKindSale,
+				Code:           constants.PriceBelowCost,
+				Message:        fmt.Sprintf("%s priced at %d/kg, below cost of %d/kg", sort.ItemName, item.PricePerKilogram, sort.PricePerKilogram),
+				Price:          item.PricePerKilogram,
+				ReferencePrice: sort.PricePerKilogram,
+				Blocking:       pricing.BlockBelowCost,
+			})
+		}
+
+		if sort.ProductId == "" {
+			continue
+		}
+
+		board, err := effectivePrice(db, sort.ProductId, sort.Grade, date)
+		if err != nil {
+			return nil, err
+		}
+
+		if warning := bandWarning(i+1, constants.PriceKindSale, sort.ItemName, item.PricePerKilogram, board, true); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+// checkPurchasePrices flags purchase lines outside the configured band
+// around the board's buy price.
+func checkPurchasePrices(db *gorm.DB, date time.Time, items []models.StockItemRequest) ([]models.PriceWarning, error) {
+	warnings := make([]models.PriceWarning, 0)
+	products := newProductResolver(db)
+
+	for i, item := range items {
+		product, err := products.find(item.ProductId, item.ItemName)
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			continue
+		}
+
+		board, err := effectivePrice(db, product.Uuid, product.Grade, date)
+		if err != nil {
+			return nil, err
+		}
+
+		if warning := bandWarning(i+1, constants.PriceKindPurchase, product.Name, item.PricePerKilogram, board, false); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+// applyDefaultBuyPrices fills purchase lines entered without a price with the
+// board's buy price for the purchase date.
+func applyDefaultBuyPrices(db *gorm.DB, date time.Time, items []models.StockItemRequest) error {
+	products := newProductResolver(db)
+
+	for i := range items {
+		if items[i].PricePerKilogram > 0 {
+			continue
+		}
+
+		product, err := products.find(items[i].ProductId, items[i].ItemName)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			continue
+		}
+
+		board, err := effectivePrice(db, product.Uuid, product.Grade, date)
+		if err != nil {
+			return err
+		}
+		if board != nil {
+			items[i].PricePerKilogram = board.BuyPrice
+		}
+	}
+
+	return nil
+}
+
+// blockingPriceError turns blocking warnings into a single bad request.
+func blockingPriceError(warnings []models.PriceWarning) error {
+	messages := make([]string, 0)
+	for _, w := range warnings {
+		if w.Blocking {
+			messages = append(messages, fmt.Sprintf("line %d: %s", w.Line, w.Message))
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return apperror.NewBadRequest(fmt.Sprintf("price check failed: %s", strings.Join(messages, "; ")))
+}
+
+// effectivePrice returns the latest board entry on or before date, preferring
+// a grade-specific price over the product-wide one. It returns nil when the
+// product has no price yet.
+func effectivePrice(db *gorm.DB, productId, grade string, date time.Time) (*models.PriceBoard, error) {
+	var boards []models.PriceBoard
+	if err := db.
+		Where("product_id = ? AND deleted = false AND price_date <= ? AND (grade = ? OR grade = '')", productId, truncateDate(date), grade).
+		Order(gorm.Expr("(grade = ?) DESC, price_date DESC", grade)).
+		Limit(1).
+		Find(&boards).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch board price: ", err)
+	}
+
+	if len(boards) == 0 {
+		return nil, nil
+	}
+
+	return &boards[0], nil
+}
+
+func bandWarning(line int, kind, name string, price int, board *models.PriceBoard, isSale bool) *models.PriceWarning {
+	if board == nil {
+		return nil
+	}
+
+	reference := board.BuyPrice
+	if isSale {
+		reference = board.SellPrice
+	}
+
+	minPrice, maxPrice := priceBand(reference)
+	if minPrice == 0 && maxPrice == 0 {
+		return nil
+	}
+	if price >= minPrice && price <= maxPrice {
+		return nil
+	}
+
+	return &models.PriceWarning{
+		Line:           line,
+		Kind:           kind,
+		Code:           constants.PriceOutOfBand,
+		Message:        fmt.Sprintf("%s priced at %d/kg, outside the board band %d-%d/kg", name, price, minPrice, maxPrice),
+		Price:          price,
+		ReferencePrice: reference,
+		Blocking:       models.GetConfig().Pricing.BlockOutOfBand,
+	}
+}
+
+// priceBand returns the allowed range around a reference price. Both bounds
+// are 0 when the band check is disabled or there is no reference price.
+func priceBand(reference int) (int, int) {
+	band := models.GetConfig().Pricing.BandPercent
+	if band <= 0 || reference <= 0 {
+		return 0, 0
+	}
+
+	return reference * (100 - band) / 100, reference * (100 + band) / 100
+}
+
+func parsePriceDate(value string) (time.Time, error) {
+	if value == "" {
+		return truncateDate(time.Now()), nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, constants.JakartaTz)
+	if err != nil {
+		return time.Time{}, apperror.NewBadRequest("invalid date format, use YYYY-MM-DD")
+	}
+
+	return date, nil
+}
+
+func truncateDate(t time.Time) time.Time {
+	t = t.In(constants.JakartaTz)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, constants.JakartaTz)
+}
+
+func distinct(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,660 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type ProcessingService struct{}
+
+func NewProcessingService() repository.ProcessingRepository {
+	return &ProcessingService{}
+}
+
+// processingItem is the weight one processing order takes from a single
+// stock item, summed over all input sorts of that item.
+type processingItem struct {
+	stockItemId string
+	productId   string
+	itemName    string
+	locationId  string
+	weight      int
+	cost        int
+	assigned    int
+}
+
+// CreateProcessingOrder - Consume Sorts and Produce New Ones
+// =====================================================
+func (s *ProcessingService) CreateProcessingOrder(request models.ProcessingOrderRequest) (*models.ProcessingOrderResponse, error) {
+	db := config.GetDBConn()
+
+	var order models.ProcessingOrder
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if request.LocationId != "" {
+			if _, err := findLocation(tx, request.LocationId); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		order = models.ProcessingOrder{
+			Uuid:        uuid.New().String(),
+			ProcessType: request.ProcessType,
+			Operator:    request.Operator,
+			ProcessDate: request.ProcessDate,
+			Notes:       request.Notes,
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		items, err := s.consumeInputs(tx, &order, request.Inputs, now)
+		if err != nil {
+			return err
+		}
+
+		for _, output := range request.Outputs {
+			if output.OutputType == constants.OutputPrimary {
+				order.PrimaryWeight += output.Weight
+			} else {
+				order.ByProductWeight += output.Weight
+			}
+		}
+		order.WasteWeight = order.InputWeight - order.PrimaryWeight - order.ByProductWeight
+		if order.WasteWeight < 0 {
+			return apperror.NewBadRequest(fmt.Sprintf(
+				"outputs weigh %d kg but only %d kg goes in", order.PrimaryWeight+order.ByProductWeight, order.InputWeight))
+		}
+
+		if err = tx.Create(&order).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create processing order: ", err)
+		}
+
+		if err = s.produceOutputs(tx, order, items, request, now); err != nil {
+			return err
+		}
+
+		itemIDs := make([]string, 0, len(items))
+		for _, item := range items {
+			itemIDs = append(itemIDs, item.stockItemId)
+		}
+
+		return refreshSortLandedCosts(tx, itemIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProcessingOrderById(order.Uuid)
+}
+
+// GetProcessingOrderById - Single Processing Order
+// =====================================================
+func (s *ProcessingService) GetProcessingOrderById(processingOrderId string) (*models.ProcessingOrderResponse, error) {
+	db := config.GetDBConn()
+
+	var order models.ProcessingOrder
+	if err := db.Where("uuid = ? AND deleted = false", processingOrderId).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("processing order not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch processing order: ", err)
+	}
+
+	responses, err := s.buildResponses(db, []models.ProcessingOrder{order})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllProcessingOrders - Paginated Processing Orders
+// =====================================================
+func (s *ProcessingService) GetAllProcessingOrders(filter models.ProcessingOrderFilter) (*models.ProcessingOrderPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := s.filterQuery(db, models.ProcessingYieldFilter{
+		ProcessType: filter.ProcessType,
+		Operator:    filter.Operator,
+		StartDate:   filter.StartDate,
+		EndDate:     filter.EndDate,
+	})
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count processing orders: ", err)
+	}
+
+	var orders []models.ProcessingOrder
+	if err := query.
+		Order("process_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&orders).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch processing orders: ", err)
+	}
+
+	responses, err := s.buildResponses(db, orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProcessingOrderPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// DeleteProcessingOrder - Undo a Processing Order
+// =====================================================
+func (s *ProcessingService) DeleteProcessingOrder(processingOrderId string) error {
+	db := config.GetDBConn()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var order models.ProcessingOrder
+		if err := tx.Where("uuid = ? AND deleted = false", processingOrderId).First(&order).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFound("processing order not found")
+			}
+			return apperror.NewUnprocessableEntity("failed to fetch processing order: ", err)
+		}
+
+		var outputs []models.ProcessingOutput
+		if err := tx.Where("processing_order_id = ? AND deleted = false", order.Uuid).Find(&outputs).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch processing outputs: ", err)
+		}
+
+		sortIDs := make([]string, 0, len(outputs))
+		for _, output := range outputs {
+			sortIDs = append(sortIDs, output.StockSortId)
+		}
+
+		var sorts []models.StockSort
+		if err := tx.Where("uuid IN ? AND deleted = false", sortIDs).Find(&sorts).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+		}
+		sortMap := make(map[string]models.StockSort, len(sorts))
+		for _, srt := range sorts {
+			sortMap[srt.Uuid] = srt
+		}
+
+		reserved, err := reservedSortWeights(tx, sortIDs, "")
+		if err != nil {
+			return err
+		}
+
+		var transferred int64
+		if err = tx.Model(&models.StockTransferItem{}).
+			Where("(stock_sort_id IN ? OR target_sort_id IN ?) AND deleted = false", sortIDs, sortIDs).
+			Count(&transferred).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to check stock transfers: ", err)
+		}
+		if transferred > 0 {
+			return apperror.NewConflict("processed stock has been transferred; delete the transfer first")
+		}
+
+		var reprocessed int64
+		if err = tx.Model(&models.ProcessingInput{}).
+			Where("stock_sort_id IN ? AND deleted = false", sortIDs).
+			Count(&reprocessed).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to check processing orders: ", err)
+		}
+		if reprocessed > 0 {
+			return apperror.NewConflict("processed stock has been processed again; delete the later processing order first")
+		}
+
+		for _, output := range outputs {
+			srt, ok := sortMap[output.StockSortId]
+			if !ok {
+				return apperror.NewConflict(fmt.Sprintf("%s was re-sorted after processing and cannot be reverted", output.ItemName))
+			}
+			if output.OutputType != constants.OutputWaste &&
+				(srt.CurrentWeight != srt.Weight || reserved[srt.Uuid] > 0) {
+				return apperror.NewConflict(fmt.Sprintf("%s produced by this order has already been sold or reserved", output.ItemName))
+			}
+		}
+
+		now := time.Now()
+		if len(sortIDs) > 0 {
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid IN ?", sortIDs).
+				Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to delete produced sorts: ", err)
+			}
+		}
+
+		var inputs []models.ProcessingInput
+		if err = tx.Where("processing_order_id = ? AND deleted = false", order.Uuid).Find(&inputs).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch processing inputs: ", err)
+		}
+
+		itemIDs := make([]string, 0, len(inputs))
+		for _, input := range inputs {
+			var source models.StockSort
+			if err = tx.Where("uuid = ? AND deleted = false", input.StockSortId).First(&source).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return apperror.NewConflict(fmt.Sprintf("%s was re-sorted after processing and cannot be reverted", input.ItemName))
+				}
+				return apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+			}
+
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid = ?", source.Uuid).
+				Updates(map[string]interface{}{
+					"weight":         source.Weight + input.Weight,
+					"current_weight": source.CurrentWeight + input.Weight,
+					"total_cost":     (source.Weight + input.Weight) * source.PricePerKilogram,
+					"updated_at":     now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to restore stock sort: ", err)
+			}
+			itemIDs = append(itemIDs, input.StockItemId)
+		}
+
+		for _, model := range []interface{}{&models.ProcessingInput{}, &models.ProcessingOutput{}} {
+			if err = tx.Model(model).
+				Where("processing_order_id = ? AND deleted = false", order.Uuid).
+				Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to delete processing lines: ", err)
+			}
+		}
+
+		if err = tx.Model(&models.ProcessingOrder{}).
+			Where("uuid = ?", order.Uuid).
+			Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete processing order: ", err)
+		}
+
+		return refreshSortLandedCosts(tx, distinct(itemIDs))
+	})
+}
+
+// GetProcessingYield - Yield per Process and Operator
+// =====================================================
+func (s *ProcessingService) GetProcessingYield(filter models.ProcessingYieldFilter) (*models.ProcessingYieldResponse, error) {
+	db := config.GetDBConn()
+
+	var rows []models.ProcessingYieldRow
+	if err := s.filterQuery(db, filter).
+		Select(`
+			process_type,
+			operator,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(input_weight), 0) AS input_weight,
+			COALESCE(SUM(primary_weight), 0) AS primary_weight,
+			COALESCE(SUM(by_product_weight), 0) AS by_product_weight,
+			COALESCE(SUM(waste_weight), 0) AS waste_weight
+		`).
+		Group("process_type, operator").
+		Order("process_type ASC, operator ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch processing yield: ", err)
+	}
+
+	var total models.ProcessingYieldRow
+	for i := range rows {
+		rows[i].YieldPercent = yieldPercent(rows[i].PrimaryWeight, rows[i].InputWeight)
+		rows[i].RecoveryPercent = yieldPercent(rows[i].PrimaryWeight+rows[i].ByProductWeight, rows[i].InputWeight)
+
+		total.OrderCount += rows[i].OrderCount
+		total.InputWeight += rows[i].InputWeight
+		total.PrimaryWeight += rows[i].PrimaryWeight
+		total.ByProductWeight += rows[i].ByProductWeight
+		total.WasteWeight += rows[i].WasteWeight
+	}
+	total.YieldPercent = yieldPercent(total.PrimaryWeight, total.InputWeight)
+	total.RecoveryPercent = yieldPercent(total.PrimaryWeight+total.ByProductWeight, total.InputWeight)
+
+	if rows == nil {
+		rows = make([]models.ProcessingYieldRow, 0)
+	}
+
+	return &models.ProcessingYieldResponse{
+		Rows:  rows,
+		Total: total,
+	}, nil
+}
+
+func (s *ProcessingService) filterQuery(db *gorm.DB, filter models.ProcessingYieldFilter) *gorm.DB {
+	query := db.Model(&models.ProcessingOrder{}).Where("deleted = false")
+
+	if filter.ProcessType != "" {
+		query = query.Where("process_type = ?", filter.ProcessType)
+	}
+	if filter.Operator != "" {
+		query = query.Where("operator ILIKE ?", "%"+filter.Operator+"%")
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(process_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(process_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	return query
+}
+
+// consumeInputs takes the requested weight off each input sort, records the
+// input lines and returns the consumed weight and cost per stock item.
+func (s *ProcessingService) consumeInputs(tx *gorm.DB, order *models.ProcessingOrder, inputs []models.ProcessingInputRequest, now time.Time) ([]*processingItem, error) {
+	sortIDs := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		sortIDs = append(sortIDs, input.StockSortId)
+	}
+	if len(distinct(sortIDs)) != len(sortIDs) {
+		return nil, apperror.NewBadRequest("each stock sort can only appear once per processing order")
+	}
+
+	var sorts []models.StockSort
+	if err := tx.Where("uuid IN ? AND deleted = false", sortIDs).Find(&sorts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+	sortMap := make(map[string]models.StockSort, len(sorts))
+	for _, srt := range sorts {
+		sortMap[srt.Uuid] = srt
+	}
+
+	reserved, err := reservedSortWeights(tx, sortIDs, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*processingItem, 0, len(inputs))
+	itemMap := make(map[string]*processingItem, len(inputs))
+	lines := make([]models.ProcessingInput, 0, len(inputs))
+	for _, input := range inputs {
+		srt, ok := sortMap[input.StockSortId]
+		if !ok {
+			return nil, apperror.NewNotFound(fmt.Sprintf("stock sort %s not found", input.StockSortId))
+		}
+		if srt.IsShrinkage {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("%s is shrinkage and cannot be processed", srt.ItemName))
+		}
+		if free := srt.CurrentWeight - reserved[srt.Uuid]; input.Weight > free {
+			return nil, apperror.NewBadRequest(fmt.Sprintf(
+				"%s has only %d kg free; %d kg is reserved by sales orders", srt.ItemName, free, reserved[srt.Uuid]))
+		}
+
+		if err = tx.Model(&models.StockSort{}).
+			Where("uuid = ?", srt.Uuid).
+			Updates(map[string]interface{}{
+				"weight":         srt.Weight - input.Weight,
+				"current_weight": srt.CurrentWeight - input.Weight,
+				"total_cost":     (srt.Weight - input.Weight) * srt.PricePerKilogram,
+				"updated_at":     now,
+			}).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to update stock sort: ", err)
+		}
+
+		costPerKilogram := srt.PricePerKilogram + srt.LandedCostPerKilogram
+		lines = append(lines, models.ProcessingInput{
+			Uuid:              uuid.New().String(),
+			ProcessingOrderId: order.Uuid,
+			StockSortId:       srt.Uuid,
+			StockItemId:       srt.StockItemID,
+			ItemName:          srt.ItemName,
+			Weight:            input.Weight,
+			CostPerKilogram:   costPerKilogram,
+			TotalCost:         input.Weight * costPerKilogram,
+			Deleted:           false,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+		order.InputWeight += input.Weight
+		order.InputCost += input.Weight * costPerKilogram
+
+		item, ok := itemMap[srt.StockItemID]
+		if !ok {
+			item = &processingItem{
+				stockItemId: srt.StockItemID,
+				productId:   srt.ProductId,
+				itemName:    srt.ItemName,
+				locationId:  srt.LocationId,
+			}
+			itemMap[srt.StockItemID] = item
+			items = append(items, item)
+		}
+		item.weight += input.Weight
+		item.cost += input.Weight * srt.PricePerKilogram
+	}
+
+	if err = tx.Create(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create processing inputs: ", err)
+	}
+
+	return items, nil
+}
+
+// produceOutputs splits every output across the consumed stock items in
+// proportion to their input weight, so each produced sort stays traceable to
+// one purchase. What an item does not turn into output is its waste.
+func (s *ProcessingService) produceOutputs(tx *gorm.DB, order models.ProcessingOrder, items []*processingItem, request models.ProcessingOrderRequest, now time.Time) error {
+	products := newProductResolver(tx)
+
+	type share struct {
+		item    *processingItem
+		output  models.ProcessingOutputRequest
+		product *models.Product
+		weight  int
+	}
+	shares := make([]share, 0, len(request.Outputs)*len(items))
+
+	for _, output := range request.Outputs {
+		product, err := products.resolve(output.ProductId, output.ItemName)
+		if err != nil {
+			return err
+		}
+
+		weights := make([]int, len(items))
+		remaining := output.Weight
+		for i, item := range items {
+			weights[i] = output.Weight * item.weight / order.InputWeight
+			remaining -= weights[i]
+		}
+		for i, item := range items {
+			if remaining == 0 {
+				break
+			}
+			if free := item.weight - item.assigned - weights[i]; free > 0 {
+				extra := min(free, remaining)
+				weights[i] += extra
+				remaining -= extra
+			}
+		}
+
+		for i, item := range items {
+			if weights[i] == 0 {
+				continue
+			}
+			item.assigned += weights[i]
+			shares = append(shares, share{item: item, output: output, product: product, weight: weights[i]})
+		}
+	}
+
+	sorts := make([]models.StockSort, 0, len(shares)+len(items))
+	lines := make([]models.ProcessingOutput, 0, len(shares)+len(items))
+	addSort := func(item *processingItem, productId, name, outputType string, weight, price int, shrinkage bool) {
+		locationId := request.LocationId
+		if locationId == "" {
+			locationId = item.locationId
+		}
+
+		srt := models.StockSort{
+			Uuid:             uuid.New().String(),
+			StockItemID:      item.stockItemId,
+			ProductId:        productId,
+			ItemName:         name,
+			Weight:           weight,
+			PricePerKilogram: price,
+			CurrentWeight:    weight,
+			TotalCost:        weight * price,
+			IsShrinkage:      shrinkage,
+			LocationId:       locationId,
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		sorts = append(sorts, srt)
+		lines = append(lines, models.ProcessingOutput{
+			Uuid:              uuid.New().String(),
+			ProcessingOrderId: order.Uuid,
+			StockSortId:       srt.Uuid,
+			StockItemId:       item.stockItemId,
+			ProductId:         productId,
+			ItemName:          name,
+			OutputType:        outputType,
+			Weight:            weight,
+			PricePerKilogram:  price,
+			TotalCost:         weight * price,
+			Deleted:           false,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	}
+
+	// The purchase cost of what went in is carried by what came out;
+	// landed cost follows through refreshSortLandedCosts
+	for _, sh := range shares {
+		addSort(sh.item, sh.product.Uuid, sh.product.Name, sh.output.OutputType,
+			sh.weight, roundDiv(sh.item.cost, sh.item.assigned), false)
+	}
+	for _, item := range items {
+		if waste := item.weight - item.assigned; waste > 0 {
+			addSort(item, item.productId, item.itemName, constants.OutputWaste, waste, 0, true)
+		}
+	}
+
+	if err := tx.Create(&sorts).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to create stock sorts: ", err)
+	}
+	if err := tx.Create(&lines).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to create processing outputs: ", err)
+	}
+
+	return nil
+}
+
+func (s *ProcessingService) buildResponses(db *gorm.DB, orders []models.ProcessingOrder) ([]models.ProcessingOrderResponse, error) {
+	responses := make([]models.ProcessingOrderResponse, 0, len(orders))
+	if len(orders) == 0 {
+		return responses, nil
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	for _, o := range orders {
+		orderIDs = append(orderIDs, o.Uuid)
+	}
+
+	var inputs []struct {
+		models.ProcessingInput
+		StockEntryNo int `gorm:"column:stock_entry_no"`
+	}
+	if err := db.Table("processing_inputs AS pi").
+		Select("pi.*, se.id AS stock_entry_no").
+		Joins("INNER JOIN stock_items si ON si.uuid = pi.stock_item_id").
+		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id").
+		Where("pi.processing_order_id IN ? AND pi.deleted = false", orderIDs).
+		Order("pi.id ASC").
+		Scan(&inputs).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch processing inputs: ", err)
+	}
+	inputMap := make(map[string][]models.ProcessingLineResponse, len(orders))
+	for _, input := range inputs {
+		inputMap[input.ProcessingOrderId] = append(inputMap[input.ProcessingOrderId], models.ProcessingLineResponse{
+			StockSortId:      input.StockSortId,
+			StockCode:        fmt.Sprintf("STOCK%d", input.StockEntryNo),
+			ItemName:         input.ItemName,
+			Weight:           input.Weight,
+			PricePerKilogram: input.CostPerKilogram,
+			TotalCost:        input.TotalCost,
+		})
+	}
+
+	var outputs []struct {
+		models.ProcessingOutput
+		StockEntryNo int `gorm:"column:stock_entry_no"`
+	}
+	if err := db.Table("processing_outputs AS po").
+		Select("po.*, se.id AS stock_entry_no").
+		Joins("INNER JOIN stock_items si ON si.uuid = po.stock_item_id").
+		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id").
+		Where("po.processing_order_id IN ? AND po.deleted = false", orderIDs).
+		Order("po.id ASC").
+		Scan(&outputs).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch processing outputs: ", err)
+	}
+	outputMap := make(map[string][]models.ProcessingLineResponse, len(orders))
+	for _, output := range outputs {
+		outputMap[output.ProcessingOrderId] = append(outputMap[output.ProcessingOrderId], models.ProcessingLineResponse{
+			StockSortId:      output.StockSortId,
+			StockCode:        fmt.Sprintf("STOCK%d", output.StockEntryNo),
+			ProductId:        output.ProductId,
+			ItemName:         output.ItemName,
+			OutputType:       output.OutputType,
+			Weight:           output.Weight,
+			PricePerKilogram: output.PricePerKilogram,
+			TotalCost:        output.TotalCost,
+		})
+	}
+
+	for _, o := range orders {
+		response := models.ProcessingOrderResponse{
+			Uuid:            o.Uuid,
+			ProcessingCode:  fmt.Sprintf("PRC%d", o.ID),
+			ProcessType:     o.ProcessType,
+			Operator:        o.Operator,
+			ProcessDate:     o.ProcessDate,
+			Notes:           o.Notes,
+			InputWeight:     o.InputWeight,
+			PrimaryWeight:   o.PrimaryWeight,
+			ByProductWeight: o.ByProductWeight,
+			WasteWeight:     o.WasteWeight,
+			YieldPercent:    yieldPercent(o.PrimaryWeight, o.InputWeight),
+			InputCost:       o.InputCost,
+			Inputs:          make([]models.ProcessingLineResponse, 0),
+			Outputs:         make([]models.ProcessingLineResponse, 0),
+			CreatedAt:       o.CreatedAt,
+		}
+		if lines, ok := inputMap[o.Uuid]; ok {
+			response.Inputs = lines
+		}
+		if lines, ok := outputMap[o.Uuid]; ok {
+			response.Outputs = lines
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// yieldPercent is part over whole as a percentage rounded to two decimals.
+func yieldPercent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(whole)) / 100
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+// defaultProductUnit is used when a product is created without a unit.
+const defaultProductUnit = "KG"
+
+// productCodeStrip removes everything but letters and digits so "Tuna A",
+// "tuna a" and "TUNA-A" share the code TUNAA. It must stay in sync with the
+// SQL normalisation in config.migrateProductCatalog.
+var productCodeStrip = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+type ProductService struct{}
+
+func NewProductService() repository.ProductRepository {
+	return &ProductService{}
+}
+
+// GetAllProducts - Paginated with Filters
+// =====================================================
+func (s *ProductService) GetAllProducts(filter models.ProductFilter) (*models.ProductPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.Product{}).Where("deleted = false")
+
+	if filter.Keyword != "" {
+		keyword := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("(LOWER(name) LIKE ? OR LOWER(code) LIKE ?)", keyword, keyword)
+	}
+	if filter.Species != "" {
+		query = query.Where("LOWER(species) = ?", strings.ToLower(filter.Species))
+	}
+	if filter.Grade != "" {
+		query = query.Where("LOWER(grade) = ?", strings.ToLower(filter.Grade))
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count products: ", err)
+	}
+
+	var products []models.Product
+	if err := query.
+		Order("name ASC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&products).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch products: ", err)
+	}
+
+	responseData := make([]models.ProductResponse, 0, len(products))
+	for _, product := range products {
+		responseData = append(responseData, toProductResponse(product))
+	}
+
+	return &models.ProductPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responseData,
+	}, nil
+}
+
+// GetProductById - Single Query
+// =====================================================
+func (s *ProductService) GetProductById(id string) (*models.ProductResponse, error) {
+	var product models.Product
+	if err := config.GetDBConn().
+		Where("uuid = ? AND deleted = false", id).
+		First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("product not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch product: ", err)
+	}
+
+	resp := toProductResponse(product)
+	return &resp, nil
+}
+
+// CreateProduct - With Unique Code Check
+// =====================================================
+func (s *ProductService) CreateProduct(request models.ProductRequest) (*models.ProductResponse, error) {
+	db := config.GetDBConn()
+
+	code := normalizeProductCode(request.Code)
+	if code == "" {
+		return nil, apperror.NewBadRequest("product code must contain letters or digits")
+	}
+
+	if err := s.ensureCodeAvailable(db, code, ""); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	product := models.Product{
+		Uuid:       uuid.New().String(),
+		Code:       code,
+		Name:       strings.TrimSpace(request.Name),
+		Species:    strings.TrimSpace(request.Species),
+		Grade:      strings.TrimSpace(request.Grade),
+		SizeClass:  strings.TrimSpace(request.SizeClass),
+		Unit:       productUnit(request.Unit),
+		MaxAgeDays: request.MaxAgeDays,
+		Deleted:    false,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := db.Create(&product).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create product: ", err)
+	}
+
+	resp := toProductResponse(product)
+	return &resp, nil
+}
+
+// UpdateProduct - With Unique Code Check
+// =====================================================
+func (s *ProductService) UpdateProduct(productId string, request models.ProductRequest) error {
+	db := config.GetDBConn()
+
+	code := normalizeProductCode(request.Code)
+	if code == "" {
+		return apperror.NewBadRequest("product code must contain letters or digits")
+	}
+
+	if err := s.ensureCodeAvailable(db, code, productId); err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(request.Name)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Product{}).
+			Where("uuid = ? AND deleted = false", productId).
+			Updates(map[string]interface{}{
+				"code":         code,
+				"name":         name,
+				"species":      strings.TrimSpace(request.Species),
+				"grade":        strings.TrimSpace(request.Grade),
+				"size_class":   strings.TrimSpace(request.SizeClass),
+				"unit":         productUnit(request.Unit),
+				"max_age_days": request.MaxAgeDays,
+				"updated_at":   time.Now(),
+			})
+
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to update product: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewNotFound("product not found or already deleted")
+		}
+
+		// Keep denormalised item names in step with the catalog
+		if err := tx.Model(&models.StockItem{}).
+			Where("product_id = ? AND deleted = false", productId).
+			Update("item_name", name).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to rename stock items: ", err)
+		}
+
+		if err := tx.Model(&models.StockSort{}).
+			Where("product_id = ? AND deleted = false", productId).
+			Update("sorted_item_name", name).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to rename stock sorts: ", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteProduct - Soft Delete, refused while stock references it
+// =====================================================
+func (s *ProductService) DeleteProduct(productId string) error {
+	db := config.GetDBConn()
+
+	var inUse int64
+	if err := db.Model(&models.StockSort{}).
+		Where("product_id = ? AND deleted = false AND current_weight > 0", productId).
+		Count(&inUse).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check product usage: ", err)
+	}
+
+	if inUse > 0 {
+		return apperror.NewConflict(fmt.Sprintf("product still has %d stock sort(s) with remaining weight", inUse))
+	}
+
+	result := db.Model(&models.Product{}).
+		Where("uuid = ? AND deleted = false", productId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to delete product: ", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("product not found or already deleted")
+	}
+
+	return nil
+}
+
+func (s *ProductService) ensureCodeAvailable(db *gorm.DB, code, excludeId string) error {
+	query := db.Model(&models.Product{}).Where("code = ? AND deleted = false", code)
+	if excludeId != "" {
+		query = query.Where("uuid <> ?", excludeId)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check product code: ", err)
+	}
+
+	if count > 0 {
+		return apperror.NewConflict(fmt.Sprintf("product code %s already exists", code))
+	}
+
+	return nil
+}
+
+// productResolver maps purchase and sort lines onto catalog products within
+// one transaction, caching lookups so repeated names hit the DB once.
+type productResolver struct {
+	tx    *gorm.DB
+	cache map[string]*models.Product
+}
+
+func newProductResolver(tx *gorm.DB) *productResolver {
+	return &productResolver{tx: tx, cache: make(map[string]*models.Product)}
+}
+
+// resolve returns the catalog product for a line. An explicit productId wins;
+// otherwise the free-text name is matched on its normalised code and a
+// catalog entry is created when none exists yet.
+func (r *productResolver) resolve(productId, name string) (*models.Product, error) {
+	product, err := r.find(productId, name)
+	if err != nil || product != nil {
+		return product, err
+	}
+
+	code := normalizeProductCode(name)
+	if code == "" {
+		return nil, apperror.NewBadRequest("item name or product is required")
+	}
+
+	now := time.Now()
+	created := models.Product{
+		Uuid:      uuid.New().String(),
+		Code:      code,
+		Name:      strings.TrimSpace(name),
+		Unit:      defaultProductUnit,
+		Deleted:   false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.tx.Create(&created).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to create product: ", err)
+	}
+
+	r.cache["code:"+code] = &created
+	return &created, nil
+}
+
+// find is resolve without creating: it returns nil when a free-text name
+// matches no catalog product. An unknown productId is still an error.
+func (r *productResolver) find(productId, name string) (*models.Product, error) {
+	if productId != "" {
+		if product, ok := r.cache["id:"+productId]; ok {
+			return product, nil
+		}
+
+		var product models.Product
+		if err := r.tx.Where("uuid = ? AND deleted = false", productId).First(&product).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperror.NewNotFound(fmt.Sprintf("product not found: %s", productId))
+			}
+			return nil, apperror.NewUnprocessableEntity("failed to fetch product: ", err)
+		}
+
+		r.cache["id:"+productId] = &product
+		return &product, nil
+	}
+
+	code := normalizeProductCode(name)
+	if code == "" {
+		return nil, nil
+	}
+
+	if product, ok := r.cache["code:"+code]; ok {
+		return product, nil
+	}
+
+	var products []models.Product
+	if err := r.tx.
+		Where("deleted = false AND (code = ? OR UPPER(regexp_replace(name, '[^A-Za-z0-9]', '', 'g')) = ?)", code, code).
+		Order("id ASC").
+		Limit(1).
+		Find(&products).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch product: ", err)
+	}
+
+	if len(products) == 0 {
+		return nil, nil
+	}
+
+	r.cache["code:"+code] = &products[0]
+	return &products[0], nil
+}
+
+func normalizeProductCode(value string) string {
+	return strings.ToUpper(productCodeStrip.ReplaceAllString(value, ""))
+}
+
+func productUnit(unit string) string {
+	if unit = strings.ToUpper(strings.TrimSpace(unit)); unit != "" {
+		return unit
+	}
+	return defaultProductUnit
+}
+
+func toProductResponse(product models.Product) models.ProductResponse {
+	return models.ProductResponse{
+		Uuid:       product.Uuid,
+		Code:       product.Code,
+		Name:       product.Name,
+		Species:    product.Species,
+		Grade:      product.Grade,
+		SizeClass:  product.SizeClass,
+		Unit:       product.Unit,
+		MaxAgeDays: product.MaxAgeDays,
+		CreatedAt:  product.CreatedAt,
+	}
+}
@@ -0,0 +1,872 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type PurchaseOrderService struct {
+	userRepo repository.UserRepository
+}
+
+func NewPurchaseOrderService(userRepo repository.UserRepository) repository.PurchaseOrderRepository {
+	return &PurchaseOrderService{userRepo: userRepo}
+}
+
+// CreatePurchaseOrder - Order with Expected Items
+// =====================================================
+func (s *PurchaseOrderService) CreatePurchaseOrder(request models.PurchaseOrderRequest) (*models.PurchaseOrderResponse, error) {
+	db := config.GetDBConn()
+
+	if err := s.validateSupplier(request.SupplierId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := models.PurchaseOrder{
+		Uuid:         uuid.New().String(),
+		SupplierId:   request.SupplierId,
+		OrderDate:    request.OrderDate,
+		ExpectedDate: request.ExpectedDate,
+		Status:       constants.PurchaseOrderOpen,
+		Notes:        strings.TrimSpace(request.Notes),
+		Deleted:      false,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&order).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create purchase order: ", err)
+		}
+
+		return s.createItems(tx, order.Uuid, request.Items)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseOrderById(order.Uuid)
+}
+
+// UpdatePurchaseOrder - Only Orders Without Receipts
+// =====================================================
+func (s *PurchaseOrderService) UpdatePurchaseOrder(orderId string, request models.PurchaseOrderRequest) error {
+	db := config.GetDBConn()
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Status != constants.PurchaseOrderOpen {
+		return apperror.NewConflict(fmt.Sprintf("purchase order is %s and can no longer be edited", order.Status))
+	}
+
+	if err = s.validateSupplier(request.SupplierId); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&models.PurchaseOrder{}).
+			Where("uuid = ? AND deleted = false AND status = ?", orderId, constants.PurchaseOrderOpen).
+			Updates(map[string]interface{}{
+				"supplier_id":   request.SupplierId,
+				"order_date":    request.OrderDate,
+				"expected_date": request.ExpectedDate,
+				"notes":         strings.TrimSpace(request.Notes),
+				"updated_at":    now,
+			})
+
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase order: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewConflict("purchase order was received in the meantime")
+		}
+
+		if err := tx.Model(&models.PurchaseOrderItem{}).
+			Where("purchase_order_id = ? AND deleted = false", orderId).
+			Updates(map[string]interface{}{
+				"deleted":    true,
+				"updated_at": now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete purchase order items: ", err)
+		}
+
+		return s.createItems(tx, orderId, request.Items)
+	})
+}
+
+// CancelPurchaseOrder - Stop Further Receipts
+// =====================================================
+func (s *PurchaseOrderService) CancelPurchaseOrder(orderId string) error {
+	db := config.GetDBConn()
+
+	result := db.Model(&models.PurchaseOrder{}).
+		Where("uuid = ? AND deleted = false AND status IN ?", orderId,
+			[]string{constants.PurchaseOrderOpen, constants.PurchaseOrderPartial}).
+		Updates(map[string]interface{}{
+			"status":     constants.PurchaseOrderCancelled,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to cancel purchase order: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.getOrder(db, orderId); err != nil {
+			return err
+		}
+		return apperror.NewConflict("only open or partially received purchase orders can be cancelled")
+	}
+
+	return nil
+}
+
+// DeletePurchaseOrder - Soft Delete Orders Without Receipts
+// =====================================================
+func (s *PurchaseOrderService) DeletePurchaseOrder(orderId string) error {
+	db := config.GetDBConn()
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return err
+	}
+	if order.Status == constants.PurchaseOrderPartial || order.Status == constants.PurchaseOrderReceived {
+		return apperror.NewConflict("purchase order has goods receipts; cancel it instead")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}
+
+		if err := tx.Model(&models.PurchaseOrderItem{}).
+			Where("purchase_order_id = ? AND deleted = false", orderId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete purchase order items: ", err)
+		}
+
+		if err := tx.Model(&models.PurchaseOrder{}).
+			Where("uuid = ? AND deleted = false", orderId).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete purchase order: ", err)
+		}
+
+		return nil
+	})
+}
+
+// GetPurchaseOrderById - Order with Items and Receipts
+// =====================================================
+func (s *PurchaseOrderService) GetPurchaseOrderById(orderId string) (*models.PurchaseOrderResponse, error) {
+	db := config.GetDBConn()
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.PurchaseOrder{*order})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllPurchaseOrders - Paginated with Filters
+// =====================================================
+func (s *PurchaseOrderService) GetAllPurchaseOrders(filter models.PurchaseOrderFilter) (*models.PurchaseOrderPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.PurchaseOrder{}).Where("deleted = false")
+
+	if filter.SupplierId != "" {
+		query = query.Where("supplier_id = ?", filter.SupplierId)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(order_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(order_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count purchase orders: ", err)
+	}
+
+	var orders []models.PurchaseOrder
+	if err := query.
+		Order("order_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&orders).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase orders: ", err)
+	}
+
+	responses, err := s.buildResponses(db, orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PurchaseOrderPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// ReceiveGoods - Partial or Full Receipt into Stock
+// =====================================================
+func (s *PurchaseOrderService) ReceiveGoods(orderId string, request models.GoodsReceiptRequest) (*models.GoodsReceiptResponse, error) {
+	db := config.GetDBConn()
+
+	var (
+		receipt models.GoodsReceipt
+		records *purchaseRecords
+	)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order until commit so a concurrent receipt waits and then
+		// sees the new status
+		order, err := s.getOrder(tx.Clauses(clause.Locking{Strength: "UPDATE"}), orderId)
+		if err != nil {
+			return err
+		}
+		if order.Status == constants.PurchaseOrderReceived || order.Status == constants.PurchaseOrderCancelled {
+			return apperror.NewConflict(fmt.Sprintf("purchase order is %s and cannot receive goods", order.Status))
+		}
+
+		var orderItems []models.PurchaseOrderItem
+		if err = tx.Where("purchase_order_id = ? AND deleted = false", orderId).Find(&orderItems).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch purchase order items: ", err)
+		}
+
+		itemMap := make(map[string]models.PurchaseOrderItem, len(orderItems))
+		for _, item := range orderItems {
+			itemMap[item.Uuid] = item
+		}
+
+		// Build the purchase the receipt turns into, priced from the order unless overridden
+		purchaseRequest := models.CreatePurchaseRequest{
+			SupplierID:   order.SupplierId,
+			PurchaseDate: request.ReceiptDate,
+			StockItems:   make([]models.StockItemRequest, 0, len(request.Items)),
+			Vessel:       request.Vessel,
+			LandingSite:  request.LandingSite,
+			CatchDate:    request.CatchDate,
+		}
+		seen := make(map[string]bool, len(request.Items))
+
+		for i, v := range request.Items {
+			orderItem, ok := itemMap[v.PurchaseOrderItemId]
+			if !ok {
+				return apperror.NewBadRequest(fmt.Sprintf("item %d does not belong to this purchase order", i+1))
+			}
+			if seen[v.PurchaseOrderItemId] {
+				return apperror.NewBadRequest(fmt.Sprintf("item %d: order line received twice in one receipt", i+1))
+			}
+			seen[v.PurchaseOrderItemId] = true
+
+			price := v.PricePerKilogram
+			if price == 0 {
+				price = orderItem.PricePerKilogram
+			}
+			request.Items[i].PricePerKilogram = price
+
+			purchaseRequest.StockItems = append(purchaseRequest.StockItems, models.StockItemRequest{
+				ProductId:        orderItem.ProductId,
+				ItemName:         orderItem.ItemName,
+				Weight:           v.Weight,
+				PricePerKilogram: price,
+			})
+		}
+
+		warnings, err := checkPurchasePrices(tx, request.ReceiptDate, purchaseRequest.StockItems)
+		if err != nil {
+			return err
+		}
+		if err = blockingPriceError(warnings); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		records, err = createPurchaseRecords(tx, purchaseRequest)
+		if err != nil {
+			return err
+		}
+
+		receipt = models.GoodsReceipt{
+			Uuid:            uuid.New().String(),
+			PurchaseOrderId: orderId,
+			PurchaseId:      records.purchase.Uuid,
+			StockEntryId:    records.stockEntry.Uuid,
+			ReceiptDate:     request.ReceiptDate,
+			Deleted:         false,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+
+		if err = tx.Create(&receipt).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create goods receipt: ", err)
+		}
+
+		receiptItems := make([]models.GoodsReceiptItem, 0, len(request.Items))
+		for i, v := range request.Items {
+			receiptItems = append(receiptItems, models.GoodsReceiptItem{
+				Uuid:                uuid.New().String(),
+				GoodsReceiptId:      receipt.Uuid,
+				PurchaseOrderItemId: v.PurchaseOrderItemId,
+				StockItemId:         records.stockItems[i].Uuid,
+				Weight:              v.Weight,
+				PricePerKilogram:    v.PricePerKilogram,
+				Deleted:             false,
+				CreatedAt:           now,
+				UpdatedAt:           now,
+			})
+
+			if err = tx.Model(&models.PurchaseOrderItem{}).
+				Where("uuid = ?", v.PurchaseOrderItemId).
+				Updates(map[string]interface{}{
+					"received_weight": gorm.Expr("received_weight + ?", v.Weight),
+					"updated_at":      now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to update received weight: ", err)
+			}
+		}
+
+		if err = tx.Create(&receiptItems).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create goods receipt items: ", err)
+		}
+
+		// An order is fully received once every line reached its ordered weight
+		status := constants.PurchaseOrderReceived
+		if !request.CloseOrder {
+			var outstanding int64
+			if err = tx.Model(&models.PurchaseOrderItem{}).
+				Where("purchase_order_id = ? AND deleted = false AND received_weight < weight", orderId).
+				Count(&outstanding).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to check outstanding items: ", err)
+			}
+			if outstanding > 0 {
+				status = constants.PurchaseOrderPartial
+			}
+		}
+
+		if err = tx.Model(&models.PurchaseOrder{}).
+			Where("uuid = ?", orderId).
+			Updates(map[string]interface{}{
+				"status":     status,
+				"updated_at": now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase order status: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalWeight := 0
+	for _, item := range records.stockItems {
+		totalWeight += item.Weight
+	}
+
+	return &models.GoodsReceiptResponse{
+		Uuid:        receipt.Uuid,
+		PurchaseId:  records.purchase.Uuid,
+		StockId:     records.stockEntry.Uuid,
+		StockCode:   fmt.Sprintf("STOCK%d", records.stockEntry.ID),
+		ReceiptDate: receipt.ReceiptDate,
+		TotalWeight: totalWeight,
+		TotalAmount: records.purchase.TotalAmount,
+	}, nil
+}
+
+// GetPurchaseVariance - Ordered vs Received per Supplier
+// =====================================================
+func (s *PurchaseOrderService) GetPurchaseVariance(filter models.PurchaseVarianceFilter) ([]models.PurchaseVarianceResponse, error) {
+	db := config.GetDBConn()
+
+	query := db.Table("purchase_order_items AS poi").
+		Select(`po.supplier_id,
+			poi.product_id,
+			MAX(poi.item_name) AS item_name,
+			SUM(poi.weight) AS ordered_weight,
+			SUM(poi.weight * poi.price_per_kilogram) AS ordered_amount,
+			COALESCE(SUM(r.weight), 0) AS received_weight,
+			COALESCE(SUM(r.amount), 0) AS received_amount`).
+		Joins("JOIN purchase_orders po ON po.uuid = poi.purchase_order_id").
+		Joins(`LEFT JOIN (
+			SELECT purchase_order_item_id, SUM(weight) AS weight, SUM(weight * price_per_kilogram) AS amount
+			FROM goods_receipt_items
+			WHERE deleted = false
+			GROUP BY purchase_order_item_id
+		) r ON r.purchase_order_item_id = poi.uuid`).
+		Where("poi.deleted = false AND po.deleted = false AND po.status <> ?", constants.PurchaseOrderCancelled)
+
+	if filter.SupplierId != "" {
+		query = query.Where("po.supplier_id = ?", filter.SupplierId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(po.order_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(po.order_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var rows []struct {
+		SupplierId string `gorm:"column:supplier_id"`
+		models.PurchaseVarianceItem
+	}
+	if err := query.Group("po.supplier_id, poi.product_id").Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase variance: ", err)
+	}
+
+	// Order count per supplier, counted once across products
+	var counts []struct {
+		SupplierId string `gorm:"column:supplier_id"`
+		OrderCount int    `gorm:"column:order_count"`
+	}
+	countQuery := db.Model(&models.PurchaseOrder{}).
+		Select("supplier_id, COUNT(*) AS order_count").
+		Where("deleted = false AND status <> ?", constants.PurchaseOrderCancelled)
+	if filter.SupplierId != "" {
+		countQuery = countQuery.Where("supplier_id = ?", filter.SupplierId)
+	}
+	if filter.StartDate != "" {
+		countQuery = countQuery.Where("DATE(order_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		countQuery = countQuery.Where("DATE(order_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+	if err := countQuery.Group("supplier_id").Scan(&counts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count purchase orders: ", err)
+	}
+
+	supplierIDs := make([]string, 0, len(counts))
+	countMap := make(map[string]int, len(counts))
+	for _, c := range counts {
+		supplierIDs = append(supplierIDs, c.SupplierId)
+		countMap[c.SupplierId] = c.OrderCount
+	}
+
+	var suppliers []models.User
+	if len(supplierIDs) > 0 {
+		if err := db.Where("uuid IN ?", supplierIDs).Find(&suppliers).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch suppliers: ", err)
+		}
+	}
+	supplierMap := make(map[string]models.User, len(suppliers))
+	for _, u := range suppliers {
+		supplierMap[u.Uuid] = u
+	}
+
+	reportMap := make(map[string]*models.PurchaseVarianceResponse)
+	order := make([]string, 0)
+
+	for _, row := range rows {
+		report, ok := reportMap[row.SupplierId]
+		if !ok {
+			supplier := supplierMap[row.SupplierId]
+			report = &models.PurchaseVarianceResponse{
+				Supplier: models.GetUserDetail{
+					Uuid:  supplier.Uuid,
+					Name:  supplier.Name,
+					Phone: supplier.Phone,
+				},
+				OrderCount: countMap[row.SupplierId],
+				Items:      make([]models.PurchaseVarianceItem, 0),
+			}
+			reportMap[row.SupplierId] = report
+			order = append(order, row.SupplierId)
+		}
+
+		item := row.PurchaseVarianceItem
+		item.WeightVariance = item.ReceivedWeight - item.OrderedWeight
+		if item.OrderedWeight > 0 {
+			item.OrderedAvgPrice = item.OrderedAmount / item.OrderedWeight
+		}
+		if item.ReceivedWeight > 0 {
+			item.ReceivedAvgPrice = item.ReceivedAmount / item.ReceivedWeight
+			item.PriceVariance = item.ReceivedAvgPrice - item.OrderedAvgPrice
+		}
+
+		report.OrderedWeight += item.OrderedWeight
+		report.ReceivedWeight += item.ReceivedWeight
+		report.OrderedAmount += item.OrderedAmount
+		report.ReceivedAmount += item.ReceivedAmount
+		report.Items = append(report.Items, item)
+	}
+
+	result := make([]models.PurchaseVarianceResponse, 0, len(order))
+	for _, supplierId := range order {
+		report := reportMap[supplierId]
+		report.WeightVariance = report.ReceivedWeight - report.OrderedWeight
+		report.AmountVariance = report.ReceivedAmount - report.OrderedAmount
+		if report.OrderedWeight > 0 {
+			report.FillRatePercent = float64(report.ReceivedWeight) * 100 / float64(report.OrderedWeight)
+		}
+
+		sort.Slice(report.Items, func(i, j int) bool { return report.Items[i].ItemName < report.Items[j].ItemName })
+		result = append(result, *report)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Supplier.Name < result[j].Supplier.Name })
+
+	return result, nil
+}
+
+func (s *PurchaseOrderService) getOrder(db *gorm.DB, orderId string) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := db.Where("uuid = ? AND deleted = false", orderId).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("purchase order not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase order: ", err)
+	}
+
+	return &order, nil
+}
+
+func (s *PurchaseOrderService) validateSupplier(supplierId string) error {
+	supplier, err := s.userRepo.GetUserById(supplierId)
+	if err != nil {
+		return apperror.NewNotFound(fmt.Sprintf("supplier not found: %v", err))
+	}
+
+	if supplier.Role != constants.SupplierRole {
+		return apperror.NewBadRequest("purchase orders can only be placed with SUPPLIER users")
+	}
+
+	return nil
+}
+
+func (s *PurchaseOrderService) createItems(tx *gorm.DB, orderId string, items []models.PurchaseOrderItemRequest) error {
+	now := time.Now()
+	products := newProductResolver(tx)
+	orderItems := make([]models.PurchaseOrderItem, 0, len(items))
+
+	for _, v := range items {
+		product, err := products.resolve(v.ProductId, v.ItemName)
+		if err != nil {
+			return err
+		}
+
+		orderItems = append(orderItems, models.PurchaseOrderItem{
+			Uuid:             uuid.New().String(),
+			PurchaseOrderId:  orderId,
+			ProductId:        product.Uuid,
+			ItemName:         product.Name,
+			Weight:           v.Weight,
+			PricePerKilogram: v.PricePerKilogram,
+			ReceivedWeight:   0,
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}
+
+	if err := tx.Create(&orderItems).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to create purchase order items: ", err)
+	}
+
+	return nil
+}
+
+func (s *PurchaseOrderService) buildResponses(db *gorm.DB, orders []models.PurchaseOrder) ([]models.PurchaseOrderResponse, error) {
+	responses := make([]models.PurchaseOrderResponse, 0, len(orders))
+	if len(orders) == 0 {
+		return responses, nil
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	supplierIDs := make([]string, 0, len(orders))
+	for _, o := range orders {
+		orderIDs = append(orderIDs, o.Uuid)
+		supplierIDs = append(supplierIDs, o.SupplierId)
+	}
+
+	var suppliers []models.User
+	if err := db.Where("uuid IN ?", distinct(supplierIDs)).Find(&suppliers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch suppliers: ", err)
+	}
+	supplierMap := make(map[string]models.User, len(suppliers))
+	for _, u := range suppliers {
+		supplierMap[u.Uuid] = u
+	}
+
+	var items []models.PurchaseOrderItem
+	if err := db.Where("purchase_order_id IN ? AND deleted = false", orderIDs).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase order items: ", err)
+	}
+
+	itemMap := make(map[string][]models.PurchaseOrderItemResponse)
+	totalMap := make(map[string]int)
+	for _, item := range items {
+		remaining := item.Weight - item.ReceivedWeight
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		itemMap[item.PurchaseOrderId] = append(itemMap[item.PurchaseOrderId], models.PurchaseOrderItemResponse{
+			Uuid:             item.Uuid,
+			ProductId:        item.ProductId,
+			ItemName:         item.ItemName,
+			Weight:           item.Weight,
+			PricePerKilogram: item.PricePerKilogram,
+			ReceivedWeight:   item.ReceivedWeight,
+			RemainingWeight:  remaining,
+		})
+		totalMap[item.PurchaseOrderId] += item.Weight * item.PricePerKilogram
+	}
+
+	var receipts []struct {
+		models.GoodsReceipt
+		StockEntryNo int `gorm:"column:stock_entry_no"`
+		TotalWeight  int `gorm:"column:total_weight"`
+		TotalAmount  int `gorm:"column:total_amount"`
+	}
+	if err := db.Table("goods_receipts AS gr").
+		Select(`gr.*, se.id AS stock_entry_no,
+			COALESCE(SUM(gri.weight), 0) AS total_weight,
+			COALESCE(SUM(gri.weight * gri.price_per_kilogram), 0) AS total_amount`).
+		Joins("LEFT JOIN stock_entries se ON se.uuid = gr.stock_entry_id").
+		Joins("LEFT JOIN goods_receipt_items gri ON gri.goods_receipt_id = gr.uuid AND gri.deleted = false").
+		Where("gr.purchase_order_id IN ? AND gr.deleted = false", orderIDs).
+		Group("gr.id, se.id").
+		Order("gr.receipt_date ASC").
+		Scan(&receipts).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch goods receipts: ", err)
+	}
+
+	receiptMap := make(map[string][]models.GoodsReceiptResponse)
+	for _, r := range receipts {
+		receiptMap[r.PurchaseOrderId] = append(receiptMap[r.PurchaseOrderId], models.GoodsReceiptResponse{
+			Uuid:        r.Uuid,
+			PurchaseId:  r.PurchaseId,
+			StockId:     r.StockEntryId,
+			StockCode:   fmt.Sprintf("STOCK%d", r.StockEntryNo),
+			ReceiptDate: r.ReceiptDate,
+			TotalWeight: r.TotalWeight,
+			TotalAmount: r.TotalAmount,
+		})
+	}
+
+	for _, o := range orders {
+		supplier := supplierMap[o.SupplierId]
+
+		orderItems := itemMap[o.Uuid]
+		if orderItems == nil {
+			orderItems = make([]models.PurchaseOrderItemResponse, 0)
+		}
+		orderReceipts := receiptMap[o.Uuid]
+		if orderReceipts == nil {
+			orderReceipts = make([]models.GoodsReceiptResponse, 0)
+		}
+
+		responses = append(responses, models.PurchaseOrderResponse{
+			Uuid:      o.Uuid,
+			OrderCode: fmt.Sprintf("PO%d", o.ID),
+			Supplier: models.GetUserDetail{
+				Uuid:  supplier.Uuid,
+				Name:  supplier.Name,
+				Phone: supplier.Phone,
+			},
+			OrderDate:    o.OrderDate,
+			ExpectedDate: o.ExpectedDate,
+			Status:       o.Status,
+			Notes:        o.Notes,
+			TotalAmount:  totalMap[o.Uuid],
+			Items:        orderItems,
+			Receipts:     orderReceipts,
+		})
+	}
+
+	return responses, nil
+}
+
+// findGoodsReceipt returns the live goods receipt a purchase was created
+// from, or nil for purchases entered directly.
+func findGoodsReceipt(tx *gorm.DB, purchaseId string) (*models.GoodsReceipt, []models.GoodsReceiptItem, error) {
+	var receipt models.GoodsReceipt
+	if err := tx.Where("purchase_id = ? AND deleted = false", purchaseId).First(&receipt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, apperror.NewUnprocessableEntity("failed to fetch goods receipt: ", err)
+	}
+
+	var items []models.GoodsReceiptItem
+	if err := tx.Where("goods_receipt_id = ? AND deleted = false", receipt.Uuid).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, nil, apperror.NewUnprocessableEntity("failed to fetch goods receipt items: ", err)
+	}
+
+	return &receipt, items, nil
+}
+
+// releaseGoodsReceipt undoes the receipt behind a deleted purchase: the
+// receipt is soft-deleted, its weight comes off the order lines and the
+// order can receive goods again.
+func releaseGoodsReceipt(tx *gorm.DB, purchaseId string) error {
+	receipt, items, err := findGoodsReceipt(tx, purchaseId)
+	if err != nil || receipt == nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, item := range items {
+		if err = tx.Model(&models.PurchaseOrderItem{}).
+			Where("uuid = ?", item.PurchaseOrderItemId).
+			Updates(map[string]interface{}{
+				"received_weight": gorm.Expr("GREATEST(received_weight - ?, 0)", item.Weight),
+				"updated_at":      now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update received weight: ", err)
+		}
+	}
+
+	if err = tx.Model(&models.GoodsReceiptItem{}).
+		Where("goods_receipt_id = ? AND deleted = false", receipt.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete goods receipt items: ", err)
+	}
+	if err = tx.Model(&models.GoodsReceipt{}).
+		Where("uuid = ?", receipt.Uuid).
+		Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete goods receipt: ", err)
+	}
+
+	return refreshPurchaseOrderStatus(tx, receipt.PurchaseOrderId)
+}
+
+// syncGoodsReceipt carries the replaced stock items of an edited purchase
+// onto the receipt it came from, line by line, and moves the difference in
+// weight onto the order lines.
+func syncGoodsReceipt(tx *gorm.DB, purchaseId string, receiptDate time.Time, stockItems []models.StockItem) error {
+	receipt, items, err := findGoodsReceipt(tx, purchaseId)
+	if err != nil || receipt == nil {
+		return err
+	}
+	if len(items) != len(stockItems) {
+		return apperror.NewBadRequest(fmt.Sprintf(
+			"purchase was received against a purchase order; keep one stock item for each of its %d received lines", len(items)))
+	}
+
+	now := time.Now()
+	for i, item := range items {
+		if err = tx.Model(&models.GoodsReceiptItem{}).
+			Where("uuid = ?", item.Uuid).
+			Updates(map[string]interface{}{
+				"stock_item_id":      stockItems[i].Uuid,
+				"weight":             stockItems[i].Weight,
+				"price_per_kilogram": stockItems[i].PricePerKilogram,
+				"updated_at":         now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update goods receipt item: ", err)
+		}
+
+		if delta := stockItems[i].Weight - item.Weight; delta != 0 {
+			if err = tx.Model(&models.PurchaseOrderItem{}).
+				Where("uuid = ?", item.PurchaseOrderItemId).
+				Updates(map[string]interface{}{
+					"received_weight": gorm.Expr("GREATEST(received_weight + ?, 0)", delta),
+					"updated_at":      now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to update received weight: ", err)
+			}
+		}
+	}
+
+	if err = tx.Model(&models.GoodsReceipt{}).
+		Where("uuid = ?", receipt.Uuid).
+		Updates(map[string]interface{}{"receipt_date": receiptDate, "updated_at": now}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update goods receipt: ", err)
+	}
+
+	return refreshPurchaseOrderStatus(tx, receipt.PurchaseOrderId)
+}
+
+// refreshPurchaseOrderStatus derives an order's status from its received
+// weights after a receipt changed. Cancelled orders stay cancelled.
+func refreshPurchaseOrderStatus(tx *gorm.DB, orderId string) error {
+	var order models.PurchaseOrder
+	if err := tx.Where("uuid = ?", orderId).First(&order).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch purchase order: ", err)
+	}
+	if order.Status == constants.PurchaseOrderCancelled {
+		return nil
+	}
+
+	var totals struct {
+		Received    int
+		Outstanding int
+	}
+	if err := tx.Model(&models.PurchaseOrderItem{}).
+		Select(`COALESCE(SUM(received_weight), 0) AS received,
+			COUNT(*) FILTER (WHERE received_weight < weight) AS outstanding`).
+		Where("purchase_order_id = ? AND deleted = false", orderId).
+		Scan(&totals).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to check outstanding items: ", err)
+	}
+
+	status := constants.PurchaseOrderReceived
+	switch {
+	case totals.Received == 0:
+		status = constants.PurchaseOrderOpen
+	case totals.Outstanding > 0:
+		status = constants.PurchaseOrderPartial
+	}
+	if status == order.Status {
+		return nil
+	}
+
+	if err := tx.Model(&models.PurchaseOrder{}).
+		Where("uuid = ?", orderId).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update purchase order status: ", err)
+	}
+
+	return nil
+}
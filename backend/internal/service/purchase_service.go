@@ -2,7 +2,9 @@ package service
 
 import (
 	"dashboard-app/pkg/apperror"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -28,11 +30,24 @@ func NewPurchaseService(userRepo repository.UserRepository) repository.PurchaseR
 func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (*models.PurchaseDataResponse, error) {
 	db := config.GetDBConn()
 
+	// Pre-fill missing prices from the price board
+	if err := applyDefaultBuyPrices(db, request.PurchaseDate, request.StockItems); err != nil {
+		return nil, err
+	}
+
 	// Validate request
 	if err := p.validatePurchaseRequest(request); err != nil {
 		return nil, apperror.NewBadRequest(fmt.Sprintf("validation error: %v", err))
 	}
 
+	warnings, err := checkPurchasePrices(db, request.PurchaseDate, request.StockItems)
+	if err != nil {
+		return nil, err
+	}
+	if err = blockingPriceError(warnings); err != nil {
+		return nil, err
+	}
+
 	// Verify supplier exists before starting transaction
 	user, err := p.userRepo.GetUserById(request.SupplierID)
 	if err != nil {
@@ -50,6 +65,86 @@ func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (
 		}
 	}()
 
+	records, err := createPurchaseRecords(tx, request)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	purchase, stockEntry, stockItems := records.purchase, records.stockEntry, records.stockItems
+	totalAmount := purchase.TotalAmount
+
+	// Commit transaction
+	if err = tx.Commit().Error; err != nil {
+		return nil, apperror.NewInternal("failed to commit transaction: ", err)
+	}
+
+	// Build response
+	userDetail := models.GetUserDetail{
+		Uuid:  user.Uuid,
+		Name:  user.Name,
+		Phone: user.Phone,
+	}
+
+	response := &models.PurchaseDataResponse{
+		PurchaseId:      purchase.Uuid,
+		PurchaseDate:    purchase.PurchaseDate.Format(time.RFC3339),
+		Supplier:        userDetail,
+		StockId:         stockEntry.Uuid,
+		StockCode:       fmt.Sprintf("STOCK%d", stockEntry.ID),
+		TotalAmount:     totalAmount,
+		PaidAmount:      0,
+		RemainingAmount: totalAmount,
+		PaymentStatus:   purchase.PaymentStatus,
+		LastPayment:     "",
+		Vessel:          purchase.Vessel,
+		LandingSite:     purchase.LandingSite,
+		CatchDate:       purchase.CatchDate,
+	}
+
+	// Build stock entry response
+	stockItemResponses := make([]models.StockItemResponse, 0, len(stockItems))
+	for _, item := range stockItems {
+		stockItemResponses = append(stockItemResponses, models.StockItemResponse{
+			Uuid:               item.Uuid,
+			StockEntryID:       stockEntry.Uuid,
+			ProductId:          item.ProductId,
+			ItemName:           item.ItemName,
+			Weight:             item.Weight,
+			PricePerKilogram:   item.PricePerKilogram,
+			TotalPayment:       item.TotalPayment,
+			IsSorted:           false,
+			StockSortResponses: []models.StockSortResponse{},
+		})
+	}
+
+	response.StockEntry = &models.StockEntriesResponse{
+		Uuid:              stockEntry.Uuid,
+		StockCode:         fmt.Sprintf("STOCK-%d", stockEntry.ID),
+		AgeInDay:          0,
+		PurchaseId:        purchase.Uuid,
+		Supplier:          userDetail,
+		StockItemResponse: stockItemResponses,
+	}
+
+	return response, nil
+}
+
+// purchaseRecords holds the rows written for one purchase.
+type purchaseRecords struct {
+	purchase   models.Purchase
+	stockEntry models.StockEntry
+	stockItems []models.StockItem
+}
+
+// createPurchaseRecords writes the stock entry, stock items, purchase and
+// supplier debt payment for a validated purchase request inside tx. The
+// purchase date must lie in an open period. Stock items are returned in the
+// order of request.StockItems.
+func createPurchaseRecords(tx *gorm.DB, request models.CreatePurchaseRequest) (*purchaseRecords, error) {
+	if err := checkPeriodsOpen(tx, request.PurchaseDate); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 
 	// Create stock entry
@@ -60,21 +155,27 @@ func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (
 		UpdatedAt: now,
 	}
 
-	if err = tx.Create(&stockEntry).Error; err != nil {
-		tx.Rollback()
+	if err := tx.Create(&stockEntry).Error; err != nil {
 		return nil, apperror.NewUnprocessableEntity("failed to create stock entry: ", err)
 	}
 
 	// Prepare stock items
 	stockItems := make([]models.StockItem, 0, len(request.StockItems))
+	products := newProductResolver(tx)
 	var totalAmount int
 
 	for _, v := range request.StockItems {
+		product, err := products.resolve(v.ProductId, v.ItemName)
+		if err != nil {
+			return nil, err
+		}
+
 		totalPayment := v.Weight * v.PricePerKilogram
 		stockItems = append(stockItems, models.StockItem{
 			Uuid:             uuid.New().String(),
 			StockEntryID:     stockEntry.Uuid,
-			ItemName:         v.ItemName,
+			ProductId:        product.Uuid,
+			ItemName:         product.Name,
 			Weight:           v.Weight,
 			PricePerKilogram: v.PricePerKilogram,
 			TotalPayment:     totalPayment,
@@ -88,8 +189,7 @@ func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (
 
 	// Batch insert stock items
 	if len(stockItems) > 0 {
-		if err = tx.Create(&stockItems).Error; err != nil {
-			tx.Rollback()
+		if err := tx.Create(&stockItems).Error; err != nil {
 			return nil, apperror.NewUnprocessableEntity("failed to create stock items: ", err)
 		}
 	}
@@ -103,13 +203,15 @@ func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (
 		TotalAmount:   totalAmount,
 		PaidAmount:    0,
 		StockId:       stockEntry.Uuid,
+		Vessel:        strings.TrimSpace(request.Vessel),
+		LandingSite:   strings.TrimSpace(request.LandingSite),
+		CatchDate:     request.CatchDate,
 		Deleted:       false,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 
-	if err = tx.Create(&purchase).Error; err != nil {
-		tx.Rollback()
+	if err := tx.Create(&purchase).Error; err != nil {
 		return nil, apperror.NewUnprocessableEntity("failed to create purchase: ", err)
 	}
 
@@ -126,61 +228,19 @@ func (p *PurchaseService) CreatePurchase(request models.CreatePurchaseRequest) (
 		UpdatedAt:   now,
 	}
 
-	if err = tx.Create(&payment).Error; err != nil {
-		tx.Rollback()
+	if err := tx.Create(&payment).Error; err != nil {
 		return nil, apperror.NewUnprocessableEntity("failed to create payment: ", err)
 	}
 
-	// Commit transaction
-	if err = tx.Commit().Error; err != nil {
-		return nil, apperror.NewInternal("failed to commit transaction: ", err)
-	}
-
-	// Build response
-	userDetail := models.GetUserDetail{
-		Uuid:  user.Uuid,
-		Name:  user.Name,
-		Phone: user.Phone,
-	}
-
-	response := &models.PurchaseDataResponse{
-		PurchaseId:      purchase.Uuid,
-		PurchaseDate:    purchase.PurchaseDate.Format(time.RFC3339),
-		Supplier:        userDetail,
-		StockId:         stockEntry.Uuid,
-		StockCode:       fmt.Sprintf("STOCK%d", stockEntry.ID),
-		TotalAmount:     totalAmount,
-		PaidAmount:      0,
-		RemainingAmount: totalAmount,
-		PaymentStatus:   purchase.PaymentStatus,
-		LastPayment:     "",
-	}
-
-	// Build stock entry response
-	stockItemResponses := make([]models.StockItemResponse, 0, len(stockItems))
-	for _, item := range stockItems {
-		stockItemResponses = append(stockItemResponses, models.StockItemResponse{
-			Uuid:               item.Uuid,
-			StockEntryID:       stockEntry.Uuid,
-			ItemName:           item.ItemName,
-			Weight:             item.Weight,
-			PricePerKilogram:   item.PricePerKilogram,
-			TotalPayment:       item.TotalPayment,
-			IsSorted:           false,
-			StockSortResponses: []models.StockSortResponse{},
-		})
-	}
-
-	response.StockEntry = &models.StockEntriesResponse{
-		Uuid:              stockEntry.Uuid,
-		StockCode:         fmt.Sprintf("STOCK-%d", stockEntry.ID),
-		AgeInDay:          0,
-		PurchaseId:        purchase.Uuid,
-		Supplier:          userDetail,
-		StockItemResponse: stockItemResponses,
+	if err := postPurchaseJournal(tx, purchase, fmt.Sprintf("STOCK%d", stockEntry.ID)); err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	return &purchaseRecords{
+		purchase:   purchase,
+		stockEntry: stockEntry,
+		stockItems: stockItems,
+	}, nil
 }
 
 // GetAllPurchases - Optimized with Single Query
@@ -211,6 +271,9 @@ func (p *PurchaseService) GetAllPurchases(filter models.PurchaseFilter) (*models
 			pur.total_amount,
 			pur.paid_amount,
 			pur.stock_id,
+			pur.vessel,
+			pur.landing_site,
+			pur.catch_date,
 			pur.created_at,
 			u.uuid AS supplier_uuid,
 			u.name AS supplier_name,
@@ -299,6 +362,9 @@ func (p *PurchaseService) GetAllPurchases(filter models.PurchaseFilter) (*models
 			RemainingAmount: totalAmount - pur.PaidAmount,
 			PaymentStatus:   pur.PaymentStatus,
 			LastPayment:     lastPayment,
+			Vessel:          pur.Vessel,
+			LandingSite:     pur.LandingSite,
+			CatchDate:       pur.CatchDate,
 			StockEntry:      nil,
 		})
 	}
@@ -394,8 +460,8 @@ func (p *PurchaseService) validatePurchaseRequest(request models.CreatePurchaseR
 	}
 
 	for i, item := range request.StockItems {
-		if item.ItemName == "" {
-			return apperror.NewBadRequest(fmt.Sprintf("item name is required for item %d", i+1))
+		if item.ItemName == "" && item.ProductId == "" {
+			return apperror.NewBadRequest(fmt.Sprintf("item name or product is required for item %d", i+1))
 		}
 		if item.Weight <= 0 {
 			return apperror.NewBadRequest(fmt.Sprintf("weight must be positive for item %d", i+1))
@@ -414,23 +480,31 @@ func (p *PurchaseService) UpdatePurchase(purchaseId string, request models.Updat
 	db := config.GetDBConn()
 
 	// Validate purchase exists
-	var exists bool
-	if err := db.Model(&models.Purchase{}).
-		Select("1").
-		Where("uuid = ? AND deleted = false", purchaseId).
-		Limit(1).
-		Find(&exists).Error; err != nil {
+	var purchase models.Purchase
+	if err := db.Where("uuid = ? AND deleted = false", purchaseId).First(&purchase).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("purchase not found")
+		}
 		return apperror.NewUnprocessableEntity("failed to check purchase: ", err)
 	}
 
-	if !exists {
-		return apperror.NewNotFound("purchase not found")
+	if err := checkPeriodsOpen(db, purchase.PurchaseDate, request.PurchaseDate); err != nil {
+		return err
 	}
 
 	updates := map[string]interface{}{
 		"purchase_date": request.PurchaseDate,
 		"updated_at":    time.Now(),
 	}
+	if request.Vessel != nil {
+		updates["vessel"] = strings.TrimSpace(*request.Vessel)
+	}
+	if request.LandingSite != nil {
+		updates["landing_site"] = strings.TrimSpace(*request.LandingSite)
+	}
+	if request.CatchDate != nil {
+		updates["catch_date"] = request.CatchDate
+	}
 
 	result := db.Model(&models.Purchase{}).
 		Where("uuid = ? AND deleted = false", purchaseId).
@@ -463,6 +537,9 @@ func (p *PurchaseService) GetPurchaseById(purchaseId string) (*models.PurchaseDa
 			pur.total_amount,
 			pur.paid_amount,
 			pur.stock_id,
+			pur.vessel,
+			pur.landing_site,
+			pur.catch_date,
 			u.uuid AS supplier_uuid,
 			u.name AS supplier_name,
 			u.phone AS supplier_phone,
@@ -497,6 +574,7 @@ func (p *PurchaseService) GetPurchaseById(purchaseId string) (*models.PurchaseDa
 		stockItemResponses = append(stockItemResponses, models.StockItemResponse{
 			Uuid:               item.Uuid,
 			StockEntryID:       detail.StockId,
+			ProductId:          item.ProductId,
 			ItemName:           item.ItemName,
 			Weight:             item.Weight,
 			PricePerKilogram:   item.PricePerKilogram,
@@ -528,6 +606,9 @@ func (p *PurchaseService) GetPurchaseById(purchaseId string) (*models.PurchaseDa
 		RemainingAmount: totalAmount - detail.PaidAmount,
 		PaymentStatus:   detail.PaymentStatus,
 		LastPayment:     lastPayment,
+		Vessel:          detail.Vessel,
+		LandingSite:     detail.LandingSite,
+		CatchDate:       detail.CatchDate,
 		StockEntry: &models.StockEntriesResponse{
 			Uuid:              detail.StockId,
 			StockCode:         fmt.Sprintf("STOCK-%d", detail.StockEntryID),
@@ -564,6 +645,11 @@ func (p *PurchaseService) DeletePurchase(purchaseId string) error {
 		return apperror.NewNotFound(fmt.Sprintf("purchase not found: %v", err))
 	}
 
+	if err := checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	now := time.Now()
 
 	// Soft delete all related records
@@ -603,6 +689,16 @@ func (p *PurchaseService) DeletePurchase(purchaseId string) error {
 		return apperror.NewUnprocessableEntity("failed to delete stock sorts: ", err)
 	}
 
+	if err := reverseJournals(tx, "reference_id = ?", purchaseId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := releaseGoodsReceipt(tx, purchaseId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return apperror.NewInternal("failed to commit transaction: ", err)
 	}
@@ -0,0 +1,687 @@
+package service
+
+import (
+	"context"
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type SalesOrderService struct {
+	sales *SalesService
+}
+
+func NewSalesOrderService() repository.SalesOrderRepository {
+	return &SalesOrderService{sales: &SalesService{}}
+}
+
+// CreateSalesOrder - Quotation or Order Reserving Stock
+// =====================================================
+func (s *SalesOrderService) CreateSalesOrder(ctx context.Context, request models.SalesOrderRequest) (*models.SalesOrderResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	expiresAt, err := s.prepare(db, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := models.SalesOrder{
+		Uuid:        uuid.New().String(),
+		Type:        request.Type,
+		CustomerId:  request.CustomerId,
+		OrderDate:   request.OrderDate,
+		ExpiresAt:   expiresAt,
+		Status:      constants.SalesOrderOpen,
+		Notes:       strings.TrimSpace(request.Notes),
+		TotalAmount: lineTotal(request.ItemSales),
+		Deleted:     false,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := checkFreeWeight(tx, request.ItemSales, "", true); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&order).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create sales order: ", err)
+		}
+
+		return s.createItems(tx, order.Uuid, request.ItemSales)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrderById(ctx, order.Uuid)
+}
+
+// UpdateSalesOrder - Replace Lines of an Open Order
+// =====================================================
+func (s *SalesOrderService) UpdateSalesOrder(ctx context.Context, orderId string, request models.SalesOrderRequest) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return err
+	}
+	if err = s.ensureOpen(order); err != nil {
+		return err
+	}
+
+	expiresAt, err := s.prepare(db, &request)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := checkFreeWeight(tx, request.ItemSales, orderId, true); err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		result := tx.Model(&models.SalesOrder{}).
+			Where("uuid = ? AND deleted = false AND status = ?", orderId, constants.SalesOrderOpen).
+			Updates(map[string]interface{}{
+				"type":         request.Type,
+				"customer_id":  request.CustomerId,
+				"order_date":   request.OrderDate,
+				"expires_at":   expiresAt,
+				"notes":        strings.TrimSpace(request.Notes),
+				"total_amount": lineTotal(request.ItemSales),
+				"updated_at":   now,
+			})
+
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to update sales order: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewConflict("sales order is no longer open")
+		}
+
+		if err := tx.Model(&models.SalesOrderItem{}).
+			Where("sales_order_id = ? AND deleted = false", orderId).
+			Updates(map[string]interface{}{
+				"deleted":    true,
+				"updated_at": now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete sales order items: ", err)
+		}
+
+		return s.createItems(tx, orderId, request.ItemSales)
+	})
+}
+
+// ConfirmSalesOrder - Turn a Quotation into a Sales Order
+// =====================================================
+func (s *SalesOrderService) ConfirmSalesOrder(ctx context.Context, orderId string) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return err
+	}
+	if err = s.ensureOpen(order); err != nil {
+		return err
+	}
+	if order.Type != constants.SalesOrderQuotation {
+		return apperror.NewConflict("only quotations can be confirmed")
+	}
+
+	// Confirming never shortens the reservation
+	expiresAt := time.Now().AddDate(0, 0, models.GetConfig().SalesOrder.OrderValidDays)
+	if order.ExpiresAt.After(expiresAt) {
+		expiresAt = order.ExpiresAt
+	}
+
+	if err = db.Model(&models.SalesOrder{}).
+		Where("uuid = ? AND deleted = false", orderId).
+		Updates(map[string]interface{}{
+			"type":       constants.SalesOrderOrder,
+			"expires_at": expiresAt,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to confirm quotation: ", err)
+	}
+
+	return nil
+}
+
+// CancelSalesOrder - Release the Reservation
+// =====================================================
+func (s *SalesOrderService) CancelSalesOrder(ctx context.Context, orderId string) error {
+	db := config.GetDBConn().WithContext(ctx)
+
+	result := db.Model(&models.SalesOrder{}).
+		Where("uuid = ? AND deleted = false AND status = ?", orderId, constants.SalesOrderOpen).
+		Updates(map[string]interface{}{
+			"status":     constants.SalesOrderCancelled,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to cancel sales order: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.getOrder(db, orderId); err != nil {
+			return err
+		}
+		return apperror.NewConflict("only open quotations and sales orders can be cancelled")
+	}
+
+	return nil
+}
+
+// ConvertSalesOrder - Invoice through the CreateSales Path
+// =====================================================
+func (s *SalesOrderService) ConvertSalesOrder(ctx context.Context, orderId string, request models.SalesOrderConvertRequest) (*models.SalesOrderResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.ensureOpen(order); err != nil {
+		return nil, err
+	}
+
+	var items []models.SalesOrderItem
+	if err = db.Where("sales_order_id = ? AND deleted = false", orderId).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sales order items: ", err)
+	}
+
+	// Quoted prices are kept, so every line is marked manual
+	saleRequest := models.SaleRequest{
+		CustomerId:  order.CustomerId,
+		SalesDate:   request.SalesDate,
+		ExportSale:  request.ExportSale,
+		ItemSales:   make([]models.ItemSalesRequest, 0, len(items)),
+		FiberList:   request.FiberList,
+		ItemAddOnn:  request.ItemAddOnn,
+		TotalAmount: order.TotalAmount,
+	}
+	for _, item := range items {
+		saleRequest.ItemSales = append(saleRequest.ItemSales, models.ItemSalesRequest{
+			StockSortId:      item.StockSortId,
+			Weight:           item.Weight,
+			PricePerKilogram: item.PricePerKilogram,
+			TotalAmount:      item.TotalAmount,
+			StockCode:        item.StockCode,
+			ManualPrice:      true,
+			PriceListId:      item.PriceListId,
+			ListPrice:        item.ListPrice,
+			DiscountPercent:  item.DiscountPercent,
+		})
+	}
+	for _, addOn := range request.ItemAddOnn {
+		saleRequest.TotalAmount += addOn.Price
+	}
+
+	if err = s.sales.checkPrices(db, saleRequest); err != nil {
+		return nil, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Close the order first so its own reservation no longer blocks the sale
+		result := tx.Model(&models.SalesOrder{}).
+			Where("uuid = ? AND deleted = false AND status = ?", orderId, constants.SalesOrderOpen).
+			Updates(map[string]interface{}{
+				"status":     constants.SalesOrderConverted,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return apperror.NewUnprocessableEntity("failed to convert sales order: ", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return apperror.NewConflict("sales order is no longer open")
+		}
+
+		sale, err := s.sales.createSaleTx(tx, saleRequest)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Model(&models.SalesOrder{}).
+			Where("uuid = ?", orderId).
+			Update("sale_id", sale.Uuid).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to link sale to sales order: ", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesOrderById(ctx, orderId)
+}
+
+// GetSalesOrderById - Order with Lines
+// =====================================================
+func (s *SalesOrderService) GetSalesOrderById(ctx context.Context, orderId string) (*models.SalesOrderResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	order, err := s.getOrder(db, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.SalesOrder{*order})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllSalesOrders - Paginated with Filters
+// =====================================================
+func (s *SalesOrderService) GetAllSalesOrders(ctx context.Context, filter models.SalesOrderFilter) (*models.SalesOrderPaginationResponse, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.SalesOrder{}).Where("deleted = false")
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.CustomerId != "" {
+		query = query.Where("customer_id = ?", filter.CustomerId)
+	}
+	switch filter.Status {
+	case "":
+	case constants.SalesOrderOpen:
+		query = query.Where("status = ? AND expires_at > NOW()", constants.SalesOrderOpen)
+	case constants.SalesOrderExpired:
+		query = query.Where("status = ? OR (status = ? AND expires_at <= NOW())",
+			constants.SalesOrderExpired, constants.SalesOrderOpen)
+	default:
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count sales orders: ", err)
+	}
+
+	var orders []models.SalesOrder
+	if err := query.
+		Order("order_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&orders).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sales orders: ", err)
+	}
+
+	responses, err := s.buildResponses(db, orders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SalesOrderPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// ExpireSalesOrders - Release Reservations Past Their Expiry
+// =====================================================
+func (s *SalesOrderService) ExpireSalesOrders(ctx context.Context) (int64, error) {
+	db := config.GetDBConn().WithContext(ctx)
+
+	result := db.Model(&models.SalesOrder{}).
+		Where("deleted = false AND status = ? AND expires_at <= NOW()", constants.SalesOrderOpen).
+		Updates(map[string]interface{}{
+			"status":     constants.SalesOrderExpired,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to expire sales orders: ", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// prepare validates the customer and lines, prices the lines from the
+// customer's price list and returns when the reservation expires.
+func (s *SalesOrderService) prepare(db *gorm.DB, request *models.SalesOrderRequest) (time.Time, error) {
+	var customer models.User
+	if err := db.Where("uuid = ? AND status = true", request.CustomerId).First(&customer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, apperror.NewNotFound("customer not found")
+		}
+		return time.Time{}, apperror.NewUnprocessableEntity("failed to fetch customer: ", err)
+	}
+	if customer.Role != constants.BuyerRole {
+		return time.Time{}, apperror.NewBadRequest("quotations and sales orders can only be made for BUYER users")
+	}
+
+	for i, item := range request.ItemSales {
+		if item.StockSortId == "" {
+			return time.Time{}, apperror.NewBadRequest(fmt.Sprintf("item %d: stock sort is required", i+1))
+		}
+		if item.Weight <= 0 {
+			return time.Time{}, apperror.NewBadRequest(fmt.Sprintf("item %d: weight must be positive", i+1))
+		}
+	}
+
+	if _, err := applyCustomerPriceList(db, request.CustomerId, request.OrderDate, request.ItemSales); err != nil {
+		return time.Time{}, err
+	}
+
+	for i, item := range request.ItemSales {
+		if item.PricePerKilogram <= 0 {
+			return time.Time{}, apperror.NewBadRequest(fmt.Sprintf("item %d: price per kilogram must be positive", i+1))
+		}
+		request.ItemSales[i].TotalAmount = item.Weight * item.PricePerKilogram
+	}
+
+	warnings, err := checkSalePrices(db, request.OrderDate, request.ItemSales)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err = blockingPriceError(warnings); err != nil {
+		return time.Time{}, err
+	}
+
+	validDays := models.GetConfig().SalesOrder.QuotationValidDays
+	if request.Type == constants.SalesOrderOrder {
+		validDays = models.GetConfig().SalesOrder.OrderValidDays
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, validDays)
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	}
+	if !expiresAt.After(time.Now()) {
+		return time.Time{}, apperror.NewBadRequest("expires_at must be in the future")
+	}
+
+	return expiresAt, nil
+}
+
+func (s *SalesOrderService) createItems(tx *gorm.DB, orderId string, items []models.ItemSalesRequest) error {
+	now := time.Now()
+	orderItems := make([]models.SalesOrderItem, 0, len(items))
+
+	for _, v := range items {
+		orderItems = append(orderItems, models.SalesOrderItem{
+			Uuid:             uuid.New().String(),
+			SalesOrderId:     orderId,
+			StockSortId:      v.StockSortId,
+			StockCode:        v.StockCode,
+			Weight:           v.Weight,
+			PricePerKilogram: v.PricePerKilogram,
+			TotalAmount:      v.TotalAmount,
+			PriceListId:      v.PriceListId,
+			ListPrice:        v.ListPrice,
+			DiscountPercent:  v.DiscountPercent,
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}
+
+	if err := tx.Create(&orderItems).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to create sales order items: ", err)
+	}
+
+	return nil
+}
+
+func (s *SalesOrderService) getOrder(db *gorm.DB, orderId string) (*models.SalesOrder, error) {
+	var order models.SalesOrder
+	if err := db.Where("uuid = ? AND deleted = false", orderId).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("sales order not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sales order: ", err)
+	}
+
+	return &order, nil
+}
+
+func (s *SalesOrderService) ensureOpen(order *models.SalesOrder) error {
+	status := effectiveSalesOrderStatus(*order)
+	if status != constants.SalesOrderOpen {
+		return apperror.NewConflict(fmt.Sprintf("sales order is %s", status))
+	}
+
+	return nil
+}
+
+func (s *SalesOrderService) buildResponses(db *gorm.DB, orders []models.SalesOrder) ([]models.SalesOrderResponse, error) {
+	responses := make([]models.SalesOrderResponse, 0, len(orders))
+	if len(orders) == 0 {
+		return responses, nil
+	}
+
+	orderIDs := make([]string, 0, len(orders))
+	customerIDs := make([]string, 0, len(orders))
+	saleIDs := make([]string, 0)
+	for _, o := range orders {
+		orderIDs = append(orderIDs, o.Uuid)
+		customerIDs = append(customerIDs, o.CustomerId)
+		if o.SaleId != "" {
+			saleIDs = append(saleIDs, o.SaleId)
+		}
+	}
+
+	var customers []models.User
+	if err := db.Where("uuid IN ?", distinct(customerIDs)).Find(&customers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch customers: ", err)
+	}
+	customerMap := make(map[string]models.User, len(customers))
+	for _, c := range customers {
+		customerMap[c.Uuid] = c
+	}
+
+	saleCodeMap := make(map[string]string, len(saleIDs))
+	if len(saleIDs) > 0 {
+		var sales []models.Sale
+		if err := db.Select("id, uuid").Where("uuid IN ?", saleIDs).Find(&sales).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch sales: ", err)
+		}
+		for _, sale := range sales {
+			saleCodeMap[sale.Uuid] = fmt.Sprintf("SELL%d", sale.ID)
+		}
+	}
+
+	var items []struct {
+		models.SalesOrderItem
+		StockSortName string `gorm:"column:stock_sort_name"`
+	}
+	if err := db.Table("sales_order_items AS soi").
+		Select("soi.*, ss.sorted_item_name AS stock_sort_name").
+		Joins("LEFT JOIN stock_sorts ss ON ss.uuid = soi.stock_sort_id").
+		Where("soi.sales_order_id IN ? AND soi.deleted = false", orderIDs).
+		Order("soi.id ASC").
+		Scan(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sales order items: ", err)
+	}
+
+	itemMap := make(map[string][]models.SalesOrderItemResponse)
+	for _, item := range items {
+		itemMap[item.SalesOrderId] = append(itemMap[item.SalesOrderId], models.SalesOrderItemResponse{
+			Uuid:             item.Uuid,
+			StockSortId:      item.StockSortId,
+			StockCode:        item.StockCode,
+			StockSortName:    item.StockSortName,
+			Weight:           item.Weight,
+			PricePerKilogram: item.PricePerKilogram,
+			TotalAmount:      item.TotalAmount,
+			PriceListId:      item.PriceListId,
+			ListPrice:        item.ListPrice,
+			DiscountPercent:  item.DiscountPercent,
+		})
+	}
+
+	for _, o := range orders {
+		customer := customerMap[o.CustomerId]
+		orderItems := itemMap[o.Uuid]
+		if orderItems == nil {
+			orderItems = make([]models.SalesOrderItemResponse, 0)
+		}
+
+		prefix := "SO"
+		if o.Type == constants.SalesOrderQuotation {
+			prefix = "QUO"
+		}
+
+		responses = append(responses, models.SalesOrderResponse{
+			Uuid:      o.Uuid,
+			OrderCode: fmt.Sprintf("%s%d", prefix, o.ID),
+			Type:      o.Type,
+			Customer: models.GetUserDetail{
+				Uuid:  customer.Uuid,
+				Name:  customer.Name,
+				Phone: customer.Phone,
+			},
+			OrderDate:   o.OrderDate,
+			ExpiresAt:   o.ExpiresAt,
+			Status:      effectiveSalesOrderStatus(o),
+			SaleId:      o.SaleId,
+			SaleCode:    saleCodeMap[o.SaleId],
+			Notes:       o.Notes,
+			TotalAmount: o.TotalAmount,
+			Items:       orderItems,
+		})
+	}
+
+	return responses, nil
+}
+
+// effectiveSalesOrderStatus reports open orders past their expiry as expired
+// before the expiry job has caught up with them.
+func effectiveSalesOrderStatus(order models.SalesOrder) string {
+	if order.Status == constants.SalesOrderOpen && !order.ExpiresAt.After(time.Now()) {
+		return constants.SalesOrderExpired
+	}
+
+	return order.Status
+}
+
+// reservedSortWeights returns the weight held by open, unexpired quotations
+// and sales orders per stock sort, leaving out excludeOrderId.
+func reservedSortWeights(db *gorm.DB, sortIDs []string, excludeOrderId string) (map[string]int, error) {
+	reserved := make(map[string]int)
+	if len(sortIDs) == 0 {
+		return reserved, nil
+	}
+
+	query := db.Table("sales_order_items AS soi").
+		Select("soi.stock_sort_id, COALESCE(SUM(soi.weight), 0) AS weight").
+		Joins("JOIN sales_orders so ON so.uuid = soi.sales_order_id").
+		Where("soi.deleted = false AND so.deleted = false AND so.status = ? AND so.expires_at > NOW()", constants.SalesOrderOpen).
+		Where("soi.stock_sort_id IN ?", sortIDs)
+	if excludeOrderId != "" {
+		query = query.Where("so.uuid <> ?", excludeOrderId)
+	}
+
+	var rows []struct {
+		StockSortId string `gorm:"column:stock_sort_id"`
+		Weight      int    `gorm:"column:weight"`
+	}
+	if err := query.Group("soi.stock_sort_id").Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch reserved weight: ", err)
+	}
+
+	for _, row := range rows {
+		reserved[row.StockSortId] = row.Weight
+	}
+
+	return reserved, nil
+}
+
+// checkFreeWeight rejects lines asking for more than the sort's current weight
+// minus what other quotations and orders reserved. Without strict, sorts with
+// nothing reserved are not checked, which keeps direct sales as they were.
+func checkFreeWeight(db *gorm.DB, items []models.ItemSalesRequest, excludeOrderId string, strict bool) error {
+	requested := make(map[string]int, len(items))
+	sortIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := requested[item.StockSortId]; !ok {
+			sortIDs = append(sortIDs, item.StockSortId)
+		}
+		requested[item.StockSortId] += item.Weight
+	}
+
+	if len(sortIDs) == 0 {
+		return nil
+	}
+
+	reserved, err := reservedSortWeights(db, sortIDs, excludeOrderId)
+	if err != nil {
+		return err
+	}
+	if !strict && len(reserved) == 0 {
+		return nil
+	}
+
+	var sorts []models.StockSort
+	if err = db.Where("uuid IN ? AND deleted = false", sortIDs).Find(&sorts).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+
+	sortMap := make(map[string]models.StockSort, len(sorts))
+	for _, srt := range sorts {
+		sortMap[srt.Uuid] = srt
+	}
+
+	for _, sortId := range sortIDs {
+		srt, ok := sortMap[sortId]
+		if !ok {
+			if strict {
+				return apperror.NewNotFound(fmt.Sprintf("stock sort not found: %s", sortId))
+			}
+			continue
+		}
+
+		if !strict && reserved[sortId] == 0 {
+			continue
+		}
+
+		free := srt.CurrentWeight - reserved[sortId]
+		if requested[sortId] > free {
+			return apperror.NewConflict(fmt.Sprintf(
+				"%s: %d kg requested but only %d kg free (%d kg reserved)",
+				srt.ItemName, requested[sortId], max(free, 0), reserved[sortId]))
+		}
+	}
+
+	return nil
+}
+
+func lineTotal(items []models.ItemSalesRequest) int {
+	total := 0
+	for _, item := range items {
+		total += item.TotalAmount
+	}
+	return total
+}
@@ -26,6 +26,14 @@ func NewSalesService() repository.SalesRepository {
 func (s *SalesService) CreateSales(ctx context.Context, request models.SaleRequest) error {
 	db := config.GetDBConn().WithContext(ctx)
 
+	if err := s.applyPriceList(db, &request); err != nil {
+		return err
+	}
+
+	if err := s.checkPrices(db, request); err != nil {
+		return err
+	}
+
 	tx := db.Begin()
 	if tx.Error != nil {
 		return apperror.NewUnprocessableEntity("failed to begin transaction: ", tx.Error)
@@ -36,42 +44,40 @@ func (s *SalesService) CreateSales(ctx context.Context, request models.SaleReque
 		}
 	}()
 
-	saleId := uuid.New().String()
+	if _, err := s.createSaleTx(tx, request); err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	var fiberList string
-	if !request.ExportSale && len(request.FiberList) > 0 {
-		fiberIDs := make([]string, 0, len(request.FiberList))
+	if err := tx.Commit().Error; err != nil {
+		return apperror.NewInternal("failed to commit transaction: ", err)
+	}
 
-		for _, v := range request.FiberList {
-			fiberIDs = append(fiberIDs, v.FiberId)
+	return nil
+}
 
-			if err := tx.Model(&models.Fiber{}).
-				Where("uuid = ? AND deleted = false", v.FiberId).
-				Updates(map[string]interface{}{
-					"status":        "USED",
-					"sale_id":       saleId,
-					"stock_sort_id": v.StockSortId,
-					"updated_at":    time.Now(),
-				}).Error; err != nil {
-				tx.Rollback()
-				return apperror.NewUnprocessableEntity("failed to update fibers: ", err)
-			}
+// createSaleTx writes a sale with its lines, add-ons, fiber allocations and
+// customer debt inside tx. Sale lines may not take weight reserved by open
+// quotations or sales orders, and the sale date must lie in an open period.
+func (s *SalesService) createSaleTx(tx *gorm.DB, request models.SaleRequest) (*models.Sale, error) {
+	if err := checkPeriodsOpen(tx, request.SalesDate); err != nil {
+		return nil, err
+	}
 
-			fiberAllocation := models.FiberAllocation{
-				Uuid:        uuid.New().String(),
-				FiberId:     v.FiberId,
-				SaleId:      saleId,
-				StockSortId: v.StockSortId,
-				Weight:      v.Weight,
-			}
+	if err := checkFreeWeight(tx, request.ItemSales, "", false); err != nil {
+		return nil, err
+	}
 
-			if err := tx.Create(&fiberAllocation).Error; err != nil {
-				tx.Rollback()
-				return apperror.NewUnprocessableEntity("failed to create allocated fiber: ", err)
-			}
+	saleId := uuid.New().String()
+
+	if !request.ExportSale && len(request.FiberList) > 0 {
+		if err := s.validateFiberAllocations(tx, saleId, request); err != nil {
+			return nil, err
 		}
 
-		fiberList = strings.Join(fiberIDs, ",")
+		if err := s.allocateFibers(tx, saleId, request.FiberList); err != nil {
+			return nil, err
+		}
 	}
 
 	sale := models.Sale{
@@ -83,19 +89,16 @@ func (s *SalesService) CreateSales(ctx context.Context, request models.SaleReque
 		RemainingAmount: request.TotalAmount,
 		PaymentStatus:   constants.PaymentNotMadeYet,
 		ExportSale:      request.ExportSale,
-		FiberList:       fiberList,
 		Deleted:         false,
 	}
 
 	if err := tx.Create(&sale).Error; err != nil {
-		tx.Rollback()
-		return apperror.NewUnprocessableEntity("failed to create sale: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to create sale: ", err)
 	}
 
 	if len(request.ItemSales) > 0 {
 		if err := s.batchCreateItemSales(tx, saleId, request.ItemSales); err != nil {
-			tx.Rollback()
-			return err
+			return nil, err
 		}
 	}
 
@@ -112,8 +115,7 @@ func (s *SalesService) CreateSales(ctx context.Context, request models.SaleReque
 		}
 
 		if err := tx.Create(&itemAddOns).Error; err != nil {
-			tx.Rollback()
-			return apperror.NewUnprocessableEntity("failed to create add-ons: ", err)
+			return nil, apperror.NewUnprocessableEntity("failed to create add-ons: ", err)
 		}
 	}
 
@@ -130,17 +132,39 @@ func (s *SalesService) CreateSales(ctx context.Context, request models.SaleReque
 	}
 
 	if err := tx.Create(&payment).Error; err != nil {
-		tx.Rollback()
-		return apperror.NewUnprocessableEntity("failed to create payment: ", err)
+		return nil, apperror.NewUnprocessableEntity("failed to create payment: ", err)
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return apperror.NewInternal("failed to commit transaction: ", err)
+	if err := postSaleJournal(tx, sale, request.ItemSales); err != nil {
+		return nil, err
+	}
+
+	return &sale, nil
+}
+
+// applyPriceList reprices the sale lines from the customer's price list and
+// moves the sale total by the same amount.
+func (s *SalesService) applyPriceList(db *gorm.DB, request *models.SaleRequest) error {
+	delta, err := applyCustomerPriceList(db, request.CustomerId, request.SalesDate, request.ItemSales)
+	if err != nil {
+		return err
 	}
 
+	request.TotalAmount += delta
 	return nil
 }
 
+// checkPrices rejects the sale when a line breaks a blocking price rule
+// (below cost or outside the price board band, see config pricing).
+func (s *SalesService) checkPrices(db *gorm.DB, request models.SaleRequest) error {
+	warnings, err := checkSalePrices(db, request.SalesDate, request.ItemSales)
+	if err != nil {
+		return err
+	}
+
+	return blockingPriceError(warnings)
+}
+
 func (s *SalesService) batchCreateItemSales(tx *gorm.DB, saleId string, items []models.ItemSalesRequest) error {
 	if len(items) == 0 {
 		return nil
@@ -178,6 +202,9 @@ func (s *SalesService) batchCreateItemSales(tx *gorm.DB, saleId string, items []
 			StockSortId:      v.StockSortId,
 			StockCode:        v.StockCode,
 			TotalAmount:      v.TotalAmount,
+			PriceListId:      v.PriceListId,
+			ListPrice:        v.ListPrice,
+			DiscountPercent:  v.DiscountPercent,
 			Deleted:          false,
 		})
 
@@ -216,6 +243,14 @@ func (s *SalesService) batchCreateItemSales(tx *gorm.DB, saleId string, items []
 func (s *SalesService) UpdateSales(ctx context.Context, id string, request models.SaleRequest) error {
 	db := config.GetDBConn().WithContext(ctx)
 
+	if err := s.applyPriceList(db, &request); err != nil {
+		return err
+	}
+
+	if err := s.checkPrices(db, request); err != nil {
+		return err
+	}
+
 	tx := db.Begin()
 	if tx.Error != nil {
 		return apperror.NewInternal("failed to begin transaction: %w", tx.Error)
@@ -232,6 +267,11 @@ func (s *SalesService) UpdateSales(ctx context.Context, id string, request model
 		return apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
 	}
 
+	if err := checkPeriodsOpen(tx, sale.PurchaseDate, request.SalesDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := s.updateFibers(tx, &sale, request); err != nil {
 		tx.Rollback()
 		return err
@@ -269,6 +309,11 @@ func (s *SalesService) UpdateSales(ctx context.Context, id string, request model
 		return apperror.NewUnprocessableEntity("failed to update payment: %w", err)
 	}
 
+	if err := repostSaleJournal(tx, sale, request.ItemSales); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return apperror.NewInternal("failed to commit transaction: ", err)
 	}
@@ -277,66 +322,168 @@ func (s *SalesService) UpdateSales(ctx context.Context, id string, request model
 }
 
 func (s *SalesService) updateFibers(tx *gorm.DB, sale *models.Sale, request models.SaleRequest) error {
-	if sale.FiberList != "" {
-		oldFiberIDs := strings.Split(sale.FiberList, ",")
-		if len(oldFiberIDs) > 0 {
-			fiberUpdates := map[string]interface{}{
-				"status":        "FREE",
-				"sale_id":       "",
-				"updated_at":    time.Now(),
-				"stock_sort_id": "",
-			}
-			if err := tx.Model(&models.Fiber{}).
-				Where("uuid IN ?", oldFiberIDs).
-				Updates(fiberUpdates).Error; err != nil {
-				return apperror.NewUnprocessableEntity("failed to free old fibers: ", err)
-			}
-
-			if err := tx.Model(&models.FiberAllocation{}).
-				Where("fiber_id IN ? AND sale_id = ?", oldFiberIDs, sale.Uuid).
-				Update("deleted", true).Error; err != nil {
-				return apperror.NewUnprocessableEntity("failed to free old fibers: ", err)
-			}
+	if !request.ExportSale && len(request.FiberList) > 0 {
+		if err := s.validateFiberAllocations(tx, sale.Uuid, request); err != nil {
+			return err
 		}
 	}
 
+	if err := s.releaseFibers(tx, sale.Uuid); err != nil {
+		return err
+	}
+
 	if !request.ExportSale && len(request.FiberList) > 0 {
-		newFiberIDs := make([]string, 0, len(request.FiberList))
-		for _, v := range request.FiberList {
-			newFiberIDs = append(newFiberIDs, v.FiberId)
-			if err := tx.Model(&models.Fiber{}).
-				Where("uuid = ? AND deleted = false", v.FiberId).
-				Updates(map[string]interface{}{
-					"status":        "USED",
-					"sale_id":       sale.Uuid,
-					"updated_at":    time.Now(),
-					"stock_sort_id": v.StockSortId,
-				}).Error; err != nil {
-				return apperror.NewUnprocessableEntity("failed to allocate new fibers: ", err)
-			}
+		return s.allocateFibers(tx, sale.Uuid, request.FiberList)
+	}
 
-			fiberAllocation := models.FiberAllocation{
-				Uuid:        uuid.New().String(),
-				FiberId:     v.FiberId,
-				SaleId:      sale.Uuid,
-				StockSortId: v.StockSortId,
-				Weight:      v.Weight,
-			}
+	return nil
+}
 
-			if err := tx.Create(&fiberAllocation).Error; err != nil {
-				tx.Rollback()
-				return apperror.NewUnprocessableEntity("failed to create allocated fiber: ", err)
-			}
+// validateFiberAllocations checks the requested fiber allocations before any
+// fiber is touched: the weight allocated per stock sort must equal the weight
+// sold from that sort, no fiber may be filled past its capacity (0 means
+// unlimited), and a fiber already used by another sale cannot be allocated.
+func (s *SalesService) validateFiberAllocations(tx *gorm.DB, saleId string, request models.SaleRequest) error {
+	soldWeights := make(map[string]int, len(request.ItemSales))
+	for _, item := range request.ItemSales {
+		soldWeights[item.StockSortId] += item.Weight
+	}
+
+	allocatedWeights := make(map[string]int, len(soldWeights))
+	fiberWeights := make(map[string]int, len(request.FiberList))
+	fiberIDs := make([]string, 0, len(request.FiberList))
+
+	for _, v := range request.FiberList {
+		if v.FiberId == "" {
+			return apperror.NewBadRequest("fiber allocation is missing fiber_id")
+		}
+		if v.Weight <= 0 {
+			return apperror.NewBadRequest(fmt.Sprintf("allocation weight for fiber %s must be greater than 0", v.FiberId))
+		}
+		if _, sold := soldWeights[v.StockSortId]; !sold {
+			return apperror.NewBadRequest(fmt.Sprintf("fiber %s is allocated to stock sort %s which is not part of the sale", v.FiberId, v.StockSortId))
 		}
 
-		sale.FiberList = strings.Join(newFiberIDs, ",")
-	} else {
-		sale.FiberList = ""
+		if _, seen := fiberWeights[v.FiberId]; !seen {
+			fiberIDs = append(fiberIDs, v.FiberId)
+		}
+		fiberWeights[v.FiberId] += v.Weight
+		allocatedWeights[v.StockSortId] += v.Weight
+	}
+
+	for stockSortId, sold := range soldWeights {
+		if allocated := allocatedWeights[stockSortId]; allocated != sold {
+			return apperror.NewBadRequest(fmt.Sprintf("stock sort %s: allocated %d kg to fibers but sold %d kg", stockSortId, allocated, sold))
+		}
+	}
+
+	var fibers []models.Fiber
+	if err := tx.Where("uuid IN ? AND deleted = false", fiberIDs).Find(&fibers).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch fibers: ", err)
+	}
+
+	fiberMap := make(map[string]models.Fiber, len(fibers))
+	for _, f := range fibers {
+		fiberMap[f.Uuid] = f
+	}
+
+	for _, fiberId := range fiberIDs {
+		fiber, exists := fiberMap[fiberId]
+		if !exists {
+			return apperror.NewNotFound(fmt.Sprintf("fiber not found: %s", fiberId))
+		}
+
+		if fiber.Status == "USED" && fiber.SaleId != "" && fiber.SaleId != saleId {
+			return apperror.NewConflict(fmt.Sprintf("fiber %s is already used by another sale", fiber.Name))
+		}
+
+		if fiber.Capacity > 0 && fiberWeights[fiberId] > fiber.Capacity {
+			return apperror.NewBadRequest(fmt.Sprintf("fiber %s: allocated %d kg exceeds capacity of %d kg", fiber.Name, fiberWeights[fiberId], fiber.Capacity))
+		}
+	}
+
+	return nil
+}
+
+// allocateFibers marks each requested fiber as USED by the sale and records
+// the allocation. fiber_allocations is the only link between a sale and its
+// fibers.
+func (s *SalesService) allocateFibers(tx *gorm.DB, saleId string, allocations []models.FiberAllocationRequest) error {
+	now := time.Now()
+	fiberAllocations := make([]models.FiberAllocation, 0, len(allocations))
+
+	for _, v := range allocations {
+		if err := tx.Model(&models.Fiber{}).
+			Where("uuid = ? AND deleted = false", v.FiberId).
+			Updates(map[string]interface{}{
+				"status":        "USED",
+				"sale_id":       saleId,
+				"stock_sort_id": v.StockSortId,
+				"updated_at":    now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to allocate fibers: ", err)
+		}
+
+		fiberAllocations = append(fiberAllocations, models.FiberAllocation{
+			Uuid:        uuid.New().String(),
+			FiberId:     v.FiberId,
+			SaleId:      saleId,
+			StockSortId: v.StockSortId,
+			Weight:      v.Weight,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+
+	if len(fiberAllocations) > 0 {
+		if err := tx.Create(&fiberAllocations).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create allocated fiber: ", err)
+		}
+	}
+
+	return nil
+}
+
+// releaseFibers frees every fiber currently allocated to the sale and
+// soft-deletes the allocations.
+func (s *SalesService) releaseFibers(tx *gorm.DB, saleId string) error {
+	var fiberIDs []string
+	if err := tx.Model(&models.FiberAllocation{}).
+		Where("sale_id = ? AND deleted = false", saleId).
+		Distinct().
+		Pluck("fiber_id", &fiberIDs).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch allocated fibers: ", err)
+	}
+
+	if len(fiberIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&models.Fiber{}).
+		Where("uuid IN ? AND deleted = false", fiberIDs).
+		Updates(map[string]interface{}{
+			"status":        "FREE",
+			"sale_id":       "",
+			"stock_sort_id": "",
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to free fibers: ", err)
+	}
+
+	if err := tx.Model(&models.FiberAllocation{}).
+		Where("sale_id = ? AND deleted = false", saleId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to free fibers: ", err)
 	}
 
 	return nil
 }
 
+// updateItemSales puts the sale's old lines back on stock before writing the
+// new ones, so the free-weight check counts the sale's own lines as free.
 func (s *SalesService) updateItemSales(tx *gorm.DB, saleId string, newItems []models.ItemSalesRequest) error {
 	var oldItems []struct {
 		models.ItemSales
@@ -387,6 +534,10 @@ func (s *SalesService) updateItemSales(tx *gorm.DB, saleId string, newItems []mo
 		}
 	}
 
+	if err := checkFreeWeight(tx, newItems, "", false); err != nil {
+		return err
+	}
+
 	if err := tx.Model(&models.ItemSales{}).
 		Where("sale_id = ?", saleId).
 		Update("deleted", true).Error; err != nil {
@@ -442,8 +593,7 @@ func (s *SalesService) DeleteSale(ctx context.Context, saleId string) error {
 
 	var saleData struct {
 		models.Sale
-		Items     []models.ItemSales `gorm:"foreignKey:SaleId;references:Uuid"`
-		FiberList string             `gorm:"column:fiber_list"`
+		Items []models.ItemSales `gorm:"foreignKey:SaleId;references:Uuid"`
 	}
 
 	if err := tx.Where("uuid = ?", saleId).
@@ -453,6 +603,11 @@ func (s *SalesService) DeleteSale(ctx context.Context, saleId string) error {
 		return apperror.NewNotFound(fmt.Sprintf("sale not found: %v", err))
 	}
 
+	if err := checkPeriodsOpen(tx, saleData.PurchaseDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if len(saleData.Items) > 0 {
 		stockSortIDs := make([]string, 0, len(saleData.Items))
 		weightMap := make(map[string]int)
@@ -493,32 +648,9 @@ func (s *SalesService) DeleteSale(ctx context.Context, saleId string) error {
 		}
 	}
 
-	if saleData.FiberList != "" {
-		fiberIDs := strings.Split(saleData.FiberList, ",")
-		if len(fiberIDs) > 0 {
-			cleanIDs := make([]string, 0, len(fiberIDs))
-			for _, id := range fiberIDs {
-				if trimmed := strings.TrimSpace(id); trimmed != "" {
-					cleanIDs = append(cleanIDs, trimmed)
-				}
-			}
-
-			if len(cleanIDs) > 0 {
-				if err := tx.Model(&models.Fiber{}).
-					Where("uuid IN ? AND deleted = false", cleanIDs).
-					Update("status", "FREE").Error; err != nil {
-					tx.Rollback()
-					return apperror.NewUnprocessableEntity("failed to free fibers: ", err)
-				}
-
-				if err := tx.Model(&models.FiberAllocation{}).
-					Where("fiber_id IN ? AND sale_id = ? AND deleted = false", cleanIDs, saleId).
-					Update("deleted", true).Error; err != nil {
-					tx.Rollback()
-					return apperror.NewUnprocessableEntity("failed to free fibers: ", err)
-				}
-			}
-		}
+	if err := s.releaseFibers(tx, saleId); err != nil {
+		tx.Rollback()
+		return err
 	}
 
 	updates := []struct {
@@ -540,6 +672,11 @@ func (s *SalesService) DeleteSale(ctx context.Context, saleId string) error {
 		}
 	}
 
+	if err := reverseJournals(tx, "reference_id = ?", saleId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return apperror.NewInternal("failed to commit transaction: ", err)
 	}
@@ -595,7 +732,7 @@ func (s *SalesService) GetSaleById(ctx context.Context, saleId string) (*models.
 		return nil, apperror.NewNotFound(fmt.Sprintf("add-onn not found: %v", err))
 	}
 
-	fiberUsedList, fiberGroupResponse, err := s.fetchFiberList(db, result.FiberList, result.Uuid, result.ExportSale, itemMap, stockMap)
+	fiberUsedList, fiberGroupResponse, err := s.fetchFiberList(db, result.Uuid, result.ExportSale, itemMap, stockMap)
 	if err != nil {
 		return nil, err
 	}
@@ -639,14 +776,13 @@ func (s *SalesService) fetchItemSalesWithStockSorts(db *gorm.DB, saleId string)
 
 func (s *SalesService) fetchFiberList(
 	db *gorm.DB,
-	fiberList string,
 	saleID string,
 	isExportSale bool,
 	itemMap map[string][]models.ItemSales,
 	stockMap map[string]models.StockSort,
 ) ([]models.FiberUsedList, []models.FiberItemAllocationResponse, error) {
 
-	if isExportSale || strings.TrimSpace(fiberList) == "" {
+	if isExportSale {
 		return []models.FiberUsedList{}, []models.FiberItemAllocationResponse{}, nil
 	}
 
@@ -781,6 +917,9 @@ func (s *SalesService) buildSaleResponse(
 			PricePerKilogram: item.PricePerKilogram,
 			Weight:           item.Weight,
 			TotalAmount:      item.TotalAmount,
+			PriceListId:      item.PriceListId,
+			ListPrice:        item.ListPrice,
+			DiscountPercent:  item.DiscountPercent,
 		})
 	}
 
@@ -1119,6 +1258,9 @@ func (s *SalesService) buildResponses(rawSales []models.RawSalesData, data model
 				PricePerKilogram: it.PricePerKilogram,
 				Weight:           it.Weight,
 				TotalAmount:      it.TotalAmount,
+				PriceListId:      it.PriceListId,
+				ListPrice:        it.ListPrice,
+				DiscountPercent:  it.DiscountPercent,
 			})
 		}
 
@@ -1173,6 +1315,9 @@ func (s *SalesService) buildResponses(rawSales []models.RawSalesData, data model
 						PricePerKilogram: it.PricePerKilogram,
 						Weight:           fa.Weight,
 						TotalAmount:      fa.Weight * it.PricePerKilogram,
+						PriceListId:      it.PriceListId,
+						ListPrice:        it.ListPrice,
+						DiscountPercent:  it.DiscountPercent,
 					},
 				)
 			}
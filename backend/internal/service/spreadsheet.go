@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"dashboard-app/pkg/apperror"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// readSpreadsheetRows reads every row of an uploaded .csv file or of the
+// first sheet of an .xlsx file, picking the format from the file name. CSV
+// files exported with semicolons, as spreadsheets using a decimal comma do,
+// are read as well.
+func readSpreadsheetRows(filename string, file io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		buffered := bufio.NewReader(file)
+		reader := csv.NewReader(buffered)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		head, _ := buffered.Peek(buffered.Size())
+		if firstLine, _, _ := bytes.Cut(head, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+			reader.Comma = ';'
+		}
+
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("failed to read csv: %v", err))
+		}
+		return records, nil
+	case ".xlsx":
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("failed to read xlsx: %v", err))
+		}
+		defer f.Close()
+
+		records, err := f.GetRows(f.GetSheetName(0))
+		if err != nil {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("failed to read xlsx: %v", err))
+		}
+		return records, nil
+	default:
+		return nil, apperror.NewBadRequest("unsupported file type: must be .csv or .xlsx")
+	}
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type StockAgingService struct{}
+
+func NewStockAgingService() repository.StockAgingRepository {
+	return &StockAgingService{}
+}
+
+// GetStockAging - Sorts with Remaining Weight Near or Past Their Shelf Life
+// =====================================================
+func (s *StockAgingService) GetStockAging(filter models.StockAgingFilter) (*models.StockAgingResponse, error) {
+	db := config.GetDBConn()
+
+	items, err := s.agingSorts(db, filter.ProductId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.StockAgingResponse{
+		Data: make([]models.StockAgingItem, 0, len(items)),
+	}
+
+	for _, item := range items {
+		if item.Status == constants.StockFresh {
+			continue
+		}
+		if filter.Status != "" && item.Status != filter.Status {
+			continue
+		}
+
+		switch item.Status {
+		case constants.StockExpired:
+			response.ExpiredWeight += item.CurrentWeight
+			response.ExpiredValue += item.CostValue
+		case constants.StockNearExpiry:
+			response.NearExpiryWeight += item.CurrentWeight
+			response.NearExpiryValue += item.CostValue
+		}
+		response.Data = append(response.Data, item)
+	}
+
+	return response, nil
+}
+
+// CheckStockAging - Raise Alerts for Aging Stock
+// =====================================================
+func (s *StockAgingService) CheckStockAging() (*models.StockAgingCheckResponse, error) {
+	db := config.GetDBConn()
+
+	items, err := s.agingSorts(db, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.StockAgingCheckResponse{}
+	for _, item := range items {
+		var notificationType, title, message string
+
+		switch item.Status {
+		case constants.StockExpired:
+			result.Expired++
+			notificationType = constants.NotificationStockExpired
+			title = fmt.Sprintf("%s %s has expired", item.StockCode, item.ItemName)
+			message = fmt.Sprintf("%d kg of %s from %s is %d days old, past its %d day limit",
+				item.CurrentWeight, item.ItemName, item.StockCode, item.AgeInDay, item.MaxAgeDays)
+		case constants.StockNearExpiry:
+			result.NearExpiry++
+			notificationType = constants.NotificationStockNearExpiry
+			title = fmt.Sprintf("%s %s is nearing expiry", item.StockCode, item.ItemName)
+			message = fmt.Sprintf("%d kg of %s from %s has %d day(s) left of its %d day limit",
+				item.CurrentWeight, item.ItemName, item.StockCode, item.DaysLeft, item.MaxAgeDays)
+		default:
+			continue
+		}
+
+		created, err := notify(db, notificationType, title, message, constants.ReferenceStockSort, item.StockSortId)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Created++
+		}
+	}
+
+	return result, nil
+}
+
+// WriteOffExpiredStock - Move Expired Weight into Shrinkage
+// =====================================================
+func (s *StockAgingService) WriteOffExpiredStock(request models.WriteOffExpiredRequest) (*models.WriteOffExpiredResponse, error) {
+	db := config.GetDBConn()
+
+	response := &models.WriteOffExpiredResponse{
+		Sorts: make([]models.StockAgingItem, 0),
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := checkPeriodsOpen(tx, time.Now()); err != nil {
+			return err
+		}
+
+		items, err := s.agingSorts(tx, "", request.StockSortIds)
+		if err != nil {
+			return err
+		}
+
+		itemIDs := make([]string, 0, len(items))
+		now := time.Now()
+
+		for _, item := range items {
+			if item.Status != constants.StockExpired {
+				if len(request.StockSortIds) > 0 {
+					return apperror.NewBadRequest(fmt.Sprintf("%s %s has not expired yet", item.StockCode, item.ItemName))
+				}
+				continue
+			}
+
+			// Weight promised to open sales orders stays on the sort
+			writeOff := item.CurrentWeight - item.ReservedWeight
+			if writeOff <= 0 {
+				continue
+			}
+
+			var srt models.StockSort
+			if err = tx.Where("uuid = ?", item.StockSortId).First(&srt).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+			}
+
+			// Split the written-off weight into its own shrinkage sort so the
+			// item's sorted weights still add up
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid = ?", srt.Uuid).
+				Updates(map[string]interface{}{
+					"weight":         srt.Weight - writeOff,
+					"current_weight": srt.CurrentWeight - writeOff,
+					"total_cost":     (srt.Weight - writeOff) * srt.PricePerKilogram,
+					"updated_at":     now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to update stock sort: ", err)
+			}
+
+			shrinkage := models.StockSort{
+				Uuid:             uuid.New().String(),
+				StockItemID:      srt.StockItemID,
+				ProductId:        srt.ProductId,
+				ItemName:         srt.ItemName,
+				Weight:           writeOff,
+				PricePerKilogram: srt.PricePerKilogram,
+				CurrentWeight:    0,
+				TotalCost:        writeOff * srt.PricePerKilogram,
+				IsShrinkage:      true,
+				Deleted:          false,
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			}
+			if err = tx.Create(&shrinkage).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create shrinkage sort: ", err)
+			}
+
+			var purchaseId string
+			if err = tx.Table("stock_items AS si").
+				Select("p.uuid").
+				Joins("INNER JOIN purchase p ON p.stock_id = si.stock_entry_id AND p.deleted = false").
+				Where("si.uuid = ?", srt.StockItemID).
+				Limit(1).
+				Scan(&purchaseId).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+			}
+
+			// Landed cost is spread again over the remaining fish below, so
+			// only the purchase price leaves inventory here
+			if err = postStockWriteOffJournal(tx, shrinkage, purchaseId,
+				fmt.Sprintf("Penghapusan Stok Kedaluwarsa STOCK%d %s", item.StockEntryNo, item.ItemName),
+				writeOff*srt.PricePerKilogram); err != nil {
+				return err
+			}
+
+			item.CurrentWeight = writeOff
+			item.CostValue = writeOff * item.PricePerKilogram
+			response.Sorts = append(response.Sorts, item)
+			response.Count++
+			response.TotalWeight += writeOff
+			response.TotalValue += item.CostValue
+			itemIDs = append(itemIDs, srt.StockItemID)
+		}
+
+		return refreshSortLandedCosts(tx, distinct(itemIDs))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// agingSorts loads sellable sorts with remaining weight and classifies them
+// against their product's shelf life, oldest first.
+func (s *StockAgingService) agingSorts(db *gorm.DB, productId string, sortIDs []string) ([]models.StockAgingItem, error) {
+	query := db.Table("stock_sorts AS ss").
+		Select(`
+			ss.uuid AS stock_sort_id,
+			ss.stock_item_id,
+			ss.product_id,
+			ss.sorted_item_name AS item_name,
+			ss.current_weight,
+			ss.price_per_kilogram + ss.landed_cost_per_kilogram AS price_per_kilogram,
+			se.id AS stock_entry_no,
+			p.purchase_date,
+			COALESCE(pr.max_age_days, 0) AS max_age_days
+		`).
+		Joins("INNER JOIN stock_items si ON si.uuid = ss.stock_item_id AND si.deleted = false").
+		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id AND se.deleted = false").
+		Joins("INNER JOIN purchase p ON p.stock_id = se.uuid AND p.deleted = false").
+		Joins("LEFT JOIN products pr ON pr.uuid = ss.product_id").
+		Where("ss.deleted = false AND ss.is_shrinkage = false AND ss.current_weight > 0")
+
+	if productId != "" {
+		query = query.Where("ss.product_id = ?", productId)
+	}
+	if len(sortIDs) > 0 {
+		query = query.Where("ss.uuid IN ?", sortIDs)
+	}
+
+	var items []models.StockAgingItem
+	if err := query.Scan(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock aging: ", err)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.StockSortId)
+	}
+	reserved, err := reservedSortWeights(db, ids, "")
+	if err != nil {
+		return nil, err
+	}
+
+	aging := models.GetConfig().StockAging
+	now := time.Now()
+
+	for i := range items {
+		item := &items[i]
+		if item.MaxAgeDays <= 0 {
+			item.MaxAgeDays = aging.DefaultMaxAgeDays
+		}
+
+		item.StockCode = fmt.Sprintf("STOCK%d", item.StockEntryNo)
+		item.AgeInDay = int(now.Sub(item.PurchaseDate).Hours() / 24)
+		item.DaysLeft = item.MaxAgeDays - item.AgeInDay
+		item.ReservedWeight = reserved[item.StockSortId]
+		item.CostValue = item.CurrentWeight * item.PricePerKilogram
+
+		switch {
+		case item.MaxAgeDays <= 0:
+			item.Status = constants.StockFresh
+		case item.DaysLeft < 0:
+			item.Status = constants.StockExpired
+		case item.DaysLeft <= aging.WarningDays:
+			item.Status = constants.StockNearExpiry
+		default:
+			item.Status = constants.StockFresh
+		}
+	}
+
+	sort.Slice(items, func(a, b int) bool {
+		return items[a].DaysLeft < items[b].DaysLeft
+	})
+
+	return items, nil
+}
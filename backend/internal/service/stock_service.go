@@ -4,6 +4,7 @@ import (
 	"dashboard-app/pkg/apperror"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"gorm.io/gorm"
 
 	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
 	"dashboard-app/internal/models"
 	"dashboard-app/internal/repository"
 )
@@ -137,6 +139,15 @@ func (s *StockService) applyStockFilters(query *gorm.DB, filter models.StockEntr
 		query = s.applyKeywordFilter(query, filter.Keyword, db)
 	}
 
+	if filter.LocationId != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM stock_items si
+			INNER JOIN stock_sorts ss ON ss.stock_item_id = si.uuid AND ss.deleted = false
+			WHERE si.stock_entry_id = se.uuid AND si.deleted = false
+			AND ss.location_id = ? AND ss.current_weight > 0
+		)`, filter.LocationId)
+	}
+
 	return query
 }
 
@@ -245,10 +256,12 @@ func (s *StockService) buildStockEntriesResponse(
 			itemResp := models.StockItemResponse{
 				Uuid:               item.Uuid,
 				StockEntryID:       item.StockEntryID,
+				ProductId:          item.ProductId,
 				ItemName:           item.ItemName,
 				Weight:             item.Weight,
 				PricePerKilogram:   item.PricePerKilogram,
 				TotalPayment:       item.TotalPayment,
+				LandedCost:         item.LandedCost,
 				IsSorted:           item.IsSorted,
 				StockSortResponses: make([]models.StockSortResponse, 0),
 			}
@@ -258,14 +271,17 @@ func (s *StockService) buildStockEntriesResponse(
 			for _, srt := range sorts {
 				itemResp.StockSortResponses = append(itemResp.StockSortResponses,
 					models.StockSortResponse{
-						Uuid:             srt.Uuid,
-						StockItemID:      srt.StockItemID,
-						ItemName:         srt.ItemName,
-						Weight:           srt.Weight,
-						PricePerKilogram: srt.PricePerKilogram,
-						CurrentWeight:    srt.CurrentWeight,
-						TotalCost:        srt.TotalCost,
-						IsShrinkage:      srt.IsShrinkage,
+						Uuid:                  srt.Uuid,
+						StockItemID:           srt.StockItemID,
+						ProductId:             srt.ProductId,
+						ItemName:              srt.ItemName,
+						Weight:                srt.Weight,
+						PricePerKilogram:      srt.PricePerKilogram,
+						CurrentWeight:         srt.CurrentWeight,
+						TotalCost:             srt.TotalCost,
+						LandedCostPerKilogram: srt.LandedCostPerKilogram,
+						IsShrinkage:           srt.IsShrinkage,
+						LocationId:            srt.LocationId,
 					})
 			}
 
@@ -343,6 +359,7 @@ func (s *StockService) GetStockEntryById(stockId string) (*models.StockEntriesRe
 		itemResp := models.StockItemResponse{
 			Uuid:               item.Uuid,
 			StockEntryID:       item.StockEntryID,
+			ProductId:          item.ProductId,
 			ItemName:           item.ItemName,
 			Weight:             item.Weight,
 			PricePerKilogram:   item.PricePerKilogram,
@@ -355,14 +372,17 @@ func (s *StockService) GetStockEntryById(stockId string) (*models.StockEntriesRe
 		for _, srt := range sorts {
 			itemResp.StockSortResponses = append(itemResp.StockSortResponses,
 				models.StockSortResponse{
-					Uuid:             srt.Uuid,
-					StockItemID:      srt.StockItemID,
-					ItemName:         srt.ItemName,
-					Weight:           srt.Weight,
-					PricePerKilogram: srt.PricePerKilogram,
-					CurrentWeight:    srt.CurrentWeight,
-					TotalCost:        srt.TotalCost,
-					IsShrinkage:      srt.IsShrinkage,
+					Uuid:                  srt.Uuid,
+					StockItemID:           srt.StockItemID,
+					ProductId:             srt.ProductId,
+					ItemName:              srt.ItemName,
+					Weight:                srt.Weight,
+					PricePerKilogram:      srt.PricePerKilogram,
+					CurrentWeight:         srt.CurrentWeight,
+					TotalCost:             srt.TotalCost,
+					LandedCostPerKilogram: srt.LandedCostPerKilogram,
+					IsShrinkage:           srt.IsShrinkage,
+					LocationId:            srt.LocationId,
 				})
 		}
 
@@ -377,6 +397,19 @@ func (s *StockService) GetStockEntryById(stockId string) (*models.StockEntriesRe
 func (s *StockService) UpdateStockById(stockId string, request models.CreatePurchaseRequest) (*models.PurchaseDataResponse, error) {
 	db := config.GetDBConn()
 
+	// Pre-fill missing prices from the price board
+	if err := applyDefaultBuyPrices(db, request.PurchaseDate, request.StockItems); err != nil {
+		return nil, err
+	}
+
+	warnings, err := checkPurchasePrices(db, request.PurchaseDate, request.StockItems)
+	if err != nil {
+		return nil, err
+	}
+	if err = blockingPriceError(warnings); err != nil {
+		return nil, err
+	}
+
 	tx := db.Begin()
 	if tx.Error != nil {
 		return nil, apperror.NewInternal("failed to begin transaction: %w", tx.Error)
@@ -409,6 +442,11 @@ func (s *StockService) UpdateStockById(stockId string, request models.CreatePurc
 		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase entry: ", err)
 	}
 
+	if err := checkPeriodsOpen(tx, purchase.PurchaseDate, request.PurchaseDate); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Delete old stock items (soft delete)
 	if err := tx.Model(&models.StockItem{}).
 		Where("stock_entry_id = ? AND deleted = false", stockEntry.Uuid).
@@ -419,15 +457,23 @@ func (s *StockService) UpdateStockById(stockId string, request models.CreatePurc
 
 	// Prepare new stock items
 	stockItems := make([]models.StockItem, 0, len(request.StockItems))
+	products := newProductResolver(tx)
 	var newTotalAmount int
 	now := time.Now()
 
 	for _, v := range request.StockItems {
+		product, err := products.resolve(v.ProductId, v.ItemName)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
 		totalPayment := v.Weight * v.PricePerKilogram
 		stockItems = append(stockItems, models.StockItem{
 			Uuid:             uuid.New().String(),
 			StockEntryID:     stockEntry.Uuid,
-			ItemName:         v.ItemName,
+			ProductId:        product.Uuid,
+			ItemName:         product.Name,
 			Weight:           v.Weight,
 			PricePerKilogram: v.PricePerKilogram,
 			IsSorted:         false,
@@ -447,12 +493,21 @@ func (s *StockService) UpdateStockById(stockId string, request models.CreatePurc
 		}
 	}
 
+	// Keep the purchase order's received weight in step with the new items
+	if err := syncGoodsReceipt(tx, purchase.Uuid, request.PurchaseDate, stockItems); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Update purchase
 	if err := tx.Model(&purchase).
 		Updates(map[string]interface{}{
 			"supplier_id":   request.SupplierID,
 			"purchase_date": request.PurchaseDate,
 			"total_amount":  newTotalAmount,
+			"vessel":        strings.TrimSpace(request.Vessel),
+			"landing_site":  strings.TrimSpace(request.LandingSite),
+			"catch_date":    request.CatchDate,
 			"updated_at":    now,
 		}).Error; err != nil {
 		tx.Rollback()
@@ -470,6 +525,21 @@ func (s *StockService) UpdateStockById(stockId string, request models.CreatePurc
 		return nil, apperror.NewUnprocessableEntity("failed to update payment: %w", err)
 	}
 
+	// Re-spread landed costs over the replaced stock items
+	if err := allocateLandedCosts(tx, purchase); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Replace the purchase posting with one for the new amount
+	purchase.SupplierID = request.SupplierID
+	purchase.PurchaseDate = request.PurchaseDate
+	purchase.TotalAmount = newTotalAmount
+	if err := repostPurchaseJournal(tx, purchase, fmt.Sprintf("STOCK%d", stockEntry.ID)); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return nil, apperror.NewInternal("failed to commit transaction: ", err)
 	}
@@ -514,6 +584,7 @@ func (s *StockService) UpdateStockById(stockId string, request models.CreatePurc
 			models.StockItemResponse{
 				Uuid:               item.Uuid,
 				StockEntryID:       stockEntry.Uuid,
+				ProductId:          item.ProductId,
 				ItemName:           item.ItemName,
 				Weight:             item.Weight,
 				PricePerKilogram:   item.PricePerKilogram,
@@ -535,10 +606,12 @@ func (s *StockService) GetStockItemById(stockItemId string) (*models.StockEntryR
 	var result struct {
 		ItemUuid         string `gorm:"column:item_uuid"`
 		StockEntryID     string `gorm:"column:stock_entry_id"`
+		ProductId        string `gorm:"column:product_id"`
 		ItemName         string `gorm:"column:item_name"`
 		Weight           int    `gorm:"column:weight"`
 		PricePerKilogram int    `gorm:"column:price_per_kilogram"`
 		TotalPayment     int    `gorm:"column:total_payment"`
+		LandedCost       int    `gorm:"column:landed_cost"`
 		IsSorted         bool   `gorm:"column:is_sorted"`
 		EntryUuid        string `gorm:"column:entry_uuid"`
 		EntryID          int    `gorm:"column:entry_id"`
@@ -548,10 +621,12 @@ func (s *StockService) GetStockItemById(stockItemId string) (*models.StockEntryR
 		Select(`
 			si.uuid AS item_uuid,
 			si.stock_entry_id,
+			si.product_id,
 			si.item_name,
 			si.weight,
 			si.price_per_kilogram,
 			si.total_payment,
+			si.landed_cost,
 			si.is_sorted,
 			se.uuid AS entry_uuid,
 			se.id AS entry_id
@@ -582,14 +657,17 @@ func (s *StockService) GetStockItemById(stockItemId string) (*models.StockEntryR
 	for _, srt := range stockSorts {
 		sortedWeight += srt.Weight
 		sortResponses = append(sortResponses, models.StockSortResponse{
-			Uuid:             srt.Uuid,
-			StockItemID:      srt.StockItemID,
-			ItemName:         srt.ItemName,
-			Weight:           srt.Weight,
-			PricePerKilogram: srt.PricePerKilogram,
-			CurrentWeight:    srt.CurrentWeight,
-			TotalCost:        srt.TotalCost,
-			IsShrinkage:      srt.IsShrinkage,
+			Uuid:                  srt.Uuid,
+			StockItemID:           srt.StockItemID,
+			ProductId:             srt.ProductId,
+			ItemName:              srt.ItemName,
+			Weight:                srt.Weight,
+			PricePerKilogram:      srt.PricePerKilogram,
+			CurrentWeight:         srt.CurrentWeight,
+			TotalCost:             srt.TotalCost,
+			LandedCostPerKilogram: srt.LandedCostPerKilogram,
+			IsShrinkage:           srt.IsShrinkage,
+			LocationId:            srt.LocationId,
 		})
 	}
 
@@ -599,10 +677,12 @@ func (s *StockService) GetStockItemById(stockItemId string) (*models.StockEntryR
 		StockItemResponse: models.StockItemResponse{
 			Uuid:               result.ItemUuid,
 			StockEntryID:       result.StockEntryID,
+			ProductId:          result.ProductId,
 			ItemName:           result.ItemName,
 			Weight:             result.Weight,
 			PricePerKilogram:   result.PricePerKilogram,
 			TotalPayment:       result.TotalPayment,
+			LandedCost:         result.LandedCost,
 			IsSorted:           result.IsSorted,
 			RemainingWeight:    result.Weight - sortedWeight,
 			AlreadySorted:      sortedWeight,
@@ -635,36 +715,76 @@ func (s *StockService) saveStockSort(request models.SubmitSortRequest, isUpdate
 		}
 	}()
 
-	// Delete old sorts if updating
+	// Reject impossible lines before touching any sort
+	var item models.StockItem
+	if err := tx.Where("uuid = ? AND deleted = false", request.StockItemId).First(&item).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("stock item not found")
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch stock item: ", err)
+	}
+	if err := checkSortLines(item, request.StockSortRequest); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Sort weights and prices set the stock value of the purchase's month
+	var purchase models.Purchase
+	if err := tx.Where("stock_id = ? AND deleted = false", item.StockEntryID).
+		First(&purchase).Error; err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFound("purchase not found for stock item")
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch purchase entry: ", err)
+	}
+	if err := checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Diff against existing sorts when updating so references stay valid
 	if isUpdate {
-		if err := tx.Model(&models.StockSort{}).
-			Where("stock_item_id = ? AND deleted = false", request.StockItemId).
-			Update("deleted", true).Error; err != nil {
+		if err := s.syncStockSorts(tx, request); err != nil {
 			tx.Rollback()
-			return apperror.NewUnprocessableEntity("failed to delete old sorts: ", err)
+			return err
 		}
 	}
 
 	// Batch insert new sorts
-	if len(request.StockSortRequest) > 0 {
+	if !isUpdate && len(request.StockSortRequest) > 0 {
 		stockSorts := make([]models.StockSort, 0, len(request.StockSortRequest))
+		products := newProductResolver(tx)
 		now := time.Now()
 
+		locationIDs := make([]string, 0, len(request.StockSortRequest))
+		for _, v := range request.StockSortRequest {
+			locationIDs = append(locationIDs, v.LocationId)
+		}
+		if err := checkLocations(tx, locationIDs); err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		for _, v := range request.StockSortRequest {
-			currentWeight := v.Weight
-			if isUpdate {
-				currentWeight = v.CurrentWeight
+			product, err := products.resolve(v.ProductId, v.SortedItemName)
+			if err != nil {
+				tx.Rollback()
+				return err
 			}
 
 			stockSorts = append(stockSorts, models.StockSort{
 				Uuid:             uuid.New().String(),
 				StockItemID:      request.StockItemId,
-				ItemName:         v.SortedItemName,
+				ProductId:        product.Uuid,
+				ItemName:         product.Name,
 				Weight:           v.Weight,
 				PricePerKilogram: v.PricePerKilogram,
-				CurrentWeight:    currentWeight,
+				CurrentWeight:    v.Weight,
 				TotalCost:        v.PricePerKilogram * v.Weight,
 				IsShrinkage:      v.IsShrinkage,
+				LocationId:       v.LocationId,
 				Deleted:          false,
 				CreatedAt:        now,
 				UpdatedAt:        now,
@@ -677,21 +797,270 @@ func (s *StockService) saveStockSort(request models.SubmitSortRequest, isUpdate
 		}
 	}
 
+	// The item counts as sorted only once its whole weight is allocated
+	isSorted, err := checkSortedWeight(tx, item)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Update stock item is_sorted flag
-	if err := tx.Model(&models.StockItem{}).
+	if err = tx.Model(&models.StockItem{}).
 		Where("uuid = ? AND deleted = false", request.StockItemId).
-		Update("is_sorted", true).Error; err != nil {
+		Update("is_sorted", isSorted).Error; err != nil {
 		tx.Rollback()
 		return apperror.NewUnprocessableEntity("failed to update stock item: %w", err)
 	}
 
-	if err := tx.Commit().Error; err != nil {
+	// Spread the item's landed cost over the new sorts
+	if err = refreshSortLandedCosts(tx, []string{request.StockItemId}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit().Error; err != nil {
 		return apperror.NewInternal("failed to commit transaction: ", err)
 	}
 
 	return nil
 }
 
+// syncStockSorts applies an edited sort list to a stock item. Existing sorts
+// are updated in place so sales, fibers and transfers keep pointing at them;
+// weight that has already left a sort stays gone when its weight changes.
+// Sorts that were sold, produced by processing or received from a transfer
+// cannot be removed.
+func (s *StockService) syncStockSorts(tx *gorm.DB, request models.SubmitSortRequest) error {
+	var existing []models.StockSort
+	if err := tx.Where("stock_item_id = ? AND deleted = false", request.StockItemId).Find(&existing).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+	existingMap := make(map[string]models.StockSort, len(existing))
+	sortIDs := make([]string, 0, len(existing))
+	for _, srt := range existing {
+		existingMap[srt.Uuid] = srt
+		sortIDs = append(sortIDs, srt.Uuid)
+	}
+
+	locationIDs := make([]string, 0, len(request.StockSortRequest))
+	kept := make(map[string]bool, len(request.StockSortRequest))
+	for _, v := range request.StockSortRequest {
+		locationIDs = append(locationIDs, v.LocationId)
+		if v.Uuid == "" {
+			continue
+		}
+		if _, ok := existingMap[v.Uuid]; !ok {
+			return apperror.NewNotFound(fmt.Sprintf("stock sort %s not found on this item", v.Uuid))
+		}
+		if kept[v.Uuid] {
+			return apperror.NewBadRequest("each stock sort can only appear once")
+		}
+		kept[v.Uuid] = true
+	}
+	if err := checkLocations(tx, locationIDs); err != nil {
+		return err
+	}
+
+	reserved, err := reservedSortWeights(tx, sortIDs, "")
+	if err != nil {
+		return err
+	}
+
+	// Processing outputs and transfer targets are created with their full
+	// weight, so only a reference shows they are part of another record
+	var used []struct {
+		StockSortId string `gorm:"column:stock_sort_id"`
+		Lines       int    `gorm:"column:lines"`
+	}
+	if len(sortIDs) > 0 {
+		if err = tx.Raw(`
+			SELECT stock_sort_id, COUNT(*) AS lines FROM item_sales
+			WHERE stock_sort_id IN ? AND deleted = false GROUP BY stock_sort_id
+			UNION ALL
+			SELECT stock_sort_id, COUNT(*) AS lines FROM fiber_allocations
+			WHERE stock_sort_id IN ? AND deleted = false GROUP BY stock_sort_id
+			UNION ALL
+			SELECT stock_sort_id, COUNT(*) AS lines FROM processing_outputs
+			WHERE stock_sort_id IN ? AND deleted = false GROUP BY stock_sort_id
+			UNION ALL
+			SELECT target_sort_id AS stock_sort_id, COUNT(*) AS lines FROM stock_transfer_items
+			WHERE target_sort_id IN ? AND deleted = false GROUP BY target_sort_id
+		`, sortIDs, sortIDs, sortIDs, sortIDs).Scan(&used).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to check stock sort usage: ", err)
+		}
+	}
+	inUse := make(map[string]bool, len(used))
+	for _, row := range used {
+		if row.Lines > 0 {
+			inUse[row.StockSortId] = true
+		}
+	}
+
+	now := time.Now()
+	removed := make([]string, 0)
+	for _, srt := range existing {
+		if kept[srt.Uuid] {
+			continue
+		}
+		if inUse[srt.Uuid] || srt.CurrentWeight != srt.Weight {
+			return apperror.NewConflict(fmt.Sprintf("%s is already used by sales, processing or transfers and cannot be removed", srt.ItemName))
+		}
+		if reserved[srt.Uuid] > 0 {
+			return apperror.NewConflict(fmt.Sprintf("%s is reserved by sales orders and cannot be removed", srt.ItemName))
+		}
+		removed = append(removed, srt.Uuid)
+	}
+
+	if len(removed) > 0 {
+		var fibers int64
+		if err = tx.Model(&models.Fiber{}).
+			Where("stock_sort_id IN ? AND deleted = false", removed).
+			Count(&fibers).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to check fibers: ", err)
+		}
+		if fibers > 0 {
+			return apperror.NewConflict("a removed sort is still held in a fiber; free the fiber first")
+		}
+
+		if err = tx.Model(&models.StockSort{}).
+			Where("uuid IN ?", removed).
+			Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete stock sorts: ", err)
+		}
+	}
+
+	products := newProductResolver(tx)
+	created := make([]models.StockSort, 0)
+	for _, v := range request.StockSortRequest {
+		product, err := products.resolve(v.ProductId, v.SortedItemName)
+		if err != nil {
+			return err
+		}
+
+		srt, ok := existingMap[v.Uuid]
+		if !ok {
+			created = append(created, models.StockSort{
+				Uuid:             uuid.New().String(),
+				StockItemID:      request.StockItemId,
+				ProductId:        product.Uuid,
+				ItemName:         product.Name,
+				Weight:           v.Weight,
+				PricePerKilogram: v.PricePerKilogram,
+				CurrentWeight:    v.Weight,
+				TotalCost:        v.PricePerKilogram * v.Weight,
+				IsShrinkage:      v.IsShrinkage,
+				LocationId:       v.LocationId,
+				Deleted:          false,
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			})
+			continue
+		}
+
+		// Sold, processed and written-off weight has already left the sort
+		gone := srt.Weight - srt.CurrentWeight
+		currentWeight := v.Weight - gone
+		if currentWeight < reserved[srt.Uuid] {
+			return apperror.NewBadRequest(fmt.Sprintf(
+				"%s cannot go below %d kg; %d kg has already left the sort and %d kg is reserved",
+				srt.ItemName, gone+reserved[srt.Uuid], gone, reserved[srt.Uuid]))
+		}
+
+		updates := map[string]interface{}{
+			"product_id":         product.Uuid,
+			"sorted_item_name":   product.Name,
+			"weight":             v.Weight,
+			"price_per_kilogram": v.PricePerKilogram,
+			"current_weight":     currentWeight,
+			"total_cost":         v.PricePerKilogram * v.Weight,
+			"is_shrinkage":       v.IsShrinkage,
+			"updated_at":         now,
+		}
+		if v.LocationId != "" {
+			updates["location_id"] = v.LocationId
+		}
+		if err = tx.Model(&models.StockSort{}).
+			Where("uuid = ?", srt.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update stock sort: ", err)
+		}
+	}
+
+	if len(created) > 0 {
+		if err = tx.Create(&created).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create stock sorts: ", err)
+		}
+	}
+
+	return nil
+}
+
+// sortWeightTolerance is how many kilograms the sorted total of an item may
+// differ from its purchased weight, from the configured percentage.
+func sortWeightTolerance(item models.StockItem) int {
+	return item.Weight * models.GetConfig().Sorting.WeightTolerancePercent / 100
+}
+
+// checkSortLines validates each submitted sort line on its own and reports
+// every bad line at once, numbered as the user entered them.
+func checkSortLines(item models.StockItem, lines []models.StockSortRequest) error {
+	limit := item.Weight + sortWeightTolerance(item)
+
+	var problems []string
+	for i, v := range lines {
+		name := v.SortedItemName
+		if v.IsShrinkage && name == "" {
+			name = "shrinkage"
+		}
+
+		switch {
+		case v.Weight <= 0:
+			problems = append(problems, fmt.Sprintf("line %d (%s): weight must be greater than 0", i+1, name))
+		case v.Weight > limit:
+			problems = append(problems, fmt.Sprintf(
+				"line %d (%s): weight %d kg exceeds the purchased %d kg", i+1, name, v.Weight, item.Weight))
+		}
+		if v.PricePerKilogram < 0 {
+			problems = append(problems, fmt.Sprintf("line %d (%s): price per kilogram cannot be negative", i+1, name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return apperror.NewBadRequest(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// checkSortedWeight rejects sorts that add up to more than was purchased,
+// allowing for the configured tolerance, and reports whether the item's
+// weight is fully allocated.
+func checkSortedWeight(tx *gorm.DB, item models.StockItem) (bool, error) {
+	var totals struct {
+		Sorted    int `gorm:"column:sorted"`
+		Shrinkage int `gorm:"column:shrinkage"`
+	}
+	if err := tx.Model(&models.StockSort{}).
+		Select(`
+			COALESCE(SUM(CASE WHEN is_shrinkage = false THEN weight ELSE 0 END), 0) AS sorted,
+			COALESCE(SUM(CASE WHEN is_shrinkage = true THEN weight ELSE 0 END), 0) AS shrinkage
+		`).
+		Where("stock_item_id = ? AND deleted = false", item.Uuid).
+		Scan(&totals).Error; err != nil {
+		return false, apperror.NewUnprocessableEntity("failed to sum stock sorts: ", err)
+	}
+
+	tolerance := sortWeightTolerance(item)
+	total := totals.Sorted + totals.Shrinkage
+	if total > item.Weight+tolerance {
+		return false, apperror.NewBadRequest(fmt.Sprintf(
+			"sorted %d kg plus %d kg shrinkage is %d kg, over the purchased %d kg by %d kg (tolerance %d kg)",
+			totals.Sorted, totals.Shrinkage, total, item.Weight, total-item.Weight, tolerance))
+	}
+
+	return total >= item.Weight-tolerance, nil
+}
+
 // DeleteStockEntryById - Optimized with Batch Operations
 // =====================================================
 func (s *StockService) DeleteStockEntryById(stockEntryId string) error {
@@ -719,6 +1088,11 @@ func (s *StockService) DeleteStockEntryById(stockEntryId string) error {
 		return apperror.NewUnprocessableEntity("failed to fetch old purchases: %w", err)
 	}
 
+	if err := checkPeriodsOpen(tx, purchase.PurchaseDate); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Batch soft delete all related records
 	updates := []struct {
 		model interface{}
@@ -753,6 +1127,16 @@ func (s *StockService) DeleteStockEntryById(stockEntryId string) error {
 		return apperror.NewUnprocessableEntity("failed to delete stock sorts: %w", err)
 	}
 
+	if err := reverseJournals(tx, "reference_id = ?", purchase.Uuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := releaseGoodsReceipt(tx, purchase.Uuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return apperror.NewInternal("failed to commit transaction: ", err)
 	}
@@ -762,29 +1146,48 @@ func (s *StockService) DeleteStockEntryById(stockEntryId string) error {
 
 // GetAllStockSorts - Optimized with Single JOIN
 // =====================================================
-func (s *StockService) GetAllStockSorts() ([]models.StockSortResponse, error) {
+func (s *StockService) GetAllStockSorts(filter models.StockSortFilter) ([]models.StockSortResponse, error) {
 	db := config.GetDBConn()
 
 	// Single optimized query with JOINs
-	var results []models.StockSortResponse
-	if err := db.Table("stock_sorts AS ss").
+	query := db.Table("stock_sorts AS ss").
 		Select(`
 			ss.id AS sort_id,
 			ss.uuid AS sort_uuid,
 			ss.stock_item_id,
+			ss.product_id,
 			ss.sorted_item_name AS item_name,
 			ss.weight,
 			ss.price_per_kilogram,
 			ss.current_weight,
 			ss.total_cost,
+			ss.landed_cost_per_kilogram,
 			ss.is_shrinkage,
+			COALESCE(ss.location_id, '') AS location_id,
+			COALESCE(l.name, '') AS location_name,
+			COALESCE(r.weight, 0) AS reserved_weight,
+			ss.current_weight - COALESCE(r.weight, 0) AS free_weight,
 			se.uuid AS entry_uuid,
 			se.id AS entry_id
 		`).
 		Joins("INNER JOIN stock_items si ON si.uuid = ss.stock_item_id AND si.deleted = false").
 		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id AND se.deleted = false").
-		Where("ss.deleted = false AND ss.is_shrinkage = false AND ss.current_weight != 0").
-		Scan(&results).Error; err != nil {
+		Joins("LEFT JOIN locations l ON l.uuid = ss.location_id").
+		Joins(`LEFT JOIN (
+			SELECT soi.stock_sort_id, SUM(soi.weight) AS weight
+			FROM sales_order_items soi
+			JOIN sales_orders so ON so.uuid = soi.sales_order_id
+			WHERE soi.deleted = false AND so.deleted = false AND so.status = ? AND so.expires_at > NOW()
+			GROUP BY soi.stock_sort_id
+		) r ON r.stock_sort_id = ss.uuid`, constants.SalesOrderOpen).
+		Where("ss.deleted = false AND ss.is_shrinkage = false AND ss.current_weight != 0")
+
+	if filter.LocationId != "" {
+		query = query.Where("ss.location_id = ?", filter.LocationId)
+	}
+
+	var results []models.StockSortResponse
+	if err := query.Scan(&results).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperror.NewNotFound("stock sort not found")
 		}
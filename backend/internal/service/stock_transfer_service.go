@@ -0,0 +1,546 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type StockTransferService struct{}
+
+func NewStockTransferService() repository.StockTransferRepository {
+	return &StockTransferService{}
+}
+
+// CreateStockTransfer - Move Stock and Fibers Between Locations
+// =====================================================
+func (s *StockTransferService) CreateStockTransfer(request models.StockTransferRequest) (*models.StockTransferResponse, error) {
+	db := config.GetDBConn()
+
+	var transfer models.StockTransfer
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := findLocation(tx, request.ToLocationId); err != nil {
+			return err
+		}
+		if request.FromLocationId != "" {
+			if _, err := findLocation(tx, request.FromLocationId); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		transfer = models.StockTransfer{
+			Uuid:           uuid.New().String(),
+			FromLocationId: request.FromLocationId,
+			ToLocationId:   request.ToLocationId,
+			TransferDate:   request.TransferDate,
+			Notes:          request.Notes,
+			Deleted:        false,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create stock transfer: ", err)
+		}
+
+		if err := s.moveSorts(tx, transfer, request.Items); err != nil {
+			return err
+		}
+
+		return s.moveFibers(tx, transfer, request.FiberIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetStockTransferById(transfer.Uuid)
+}
+
+// GetStockTransferById - Single Transfer Document
+// =====================================================
+func (s *StockTransferService) GetStockTransferById(transferId string) (*models.StockTransferResponse, error) {
+	db := config.GetDBConn()
+
+	var transfer models.StockTransfer
+	if err := db.Where("uuid = ? AND deleted = false", transferId).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("stock transfer not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock transfer: ", err)
+	}
+
+	responses, err := s.buildResponses(db, []models.StockTransfer{transfer})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllStockTransfers - Paginated Transfer Documents
+// =====================================================
+func (s *StockTransferService) GetAllStockTransfers(filter models.StockTransferFilter) (*models.StockTransferPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.StockTransfer{}).Where("deleted = false")
+
+	if filter.LocationId != "" {
+		query = query.Where("(from_location_id = ? OR to_location_id = ?)", filter.LocationId, filter.LocationId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(transfer_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(transfer_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count stock transfers: ", err)
+	}
+
+	var transfers []models.StockTransfer
+	if err := query.
+		Order("transfer_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&transfers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock transfers: ", err)
+	}
+
+	responses, err := s.buildResponses(db, transfers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockTransferPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// DeleteStockTransfer - Move Everything Back
+// =====================================================
+func (s *StockTransferService) DeleteStockTransfer(transferId string) error {
+	db := config.GetDBConn()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var transfer models.StockTransfer
+		if err := tx.Where("uuid = ? AND deleted = false", transferId).First(&transfer).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFound("stock transfer not found")
+			}
+			return apperror.NewUnprocessableEntity("failed to fetch stock transfer: ", err)
+		}
+
+		var items []models.StockTransferItem
+		if err := tx.Where("stock_transfer_id = ? AND deleted = false", transfer.Uuid).Find(&items).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch transfer items: ", err)
+		}
+
+		now := time.Now()
+		for _, item := range items {
+			if err := s.revertItem(tx, transfer, item, now); err != nil {
+				return err
+			}
+		}
+
+		var fibers []models.StockTransferFiber
+		if err := tx.Where("stock_transfer_id = ? AND deleted = false", transfer.Uuid).Find(&fibers).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch transfer fibers: ", err)
+		}
+
+		for _, f := range fibers {
+			result := tx.Model(&models.Fiber{}).
+				Where("uuid = ? AND location_id = ? AND deleted = false", f.FiberId, transfer.ToLocationId).
+				Updates(map[string]interface{}{
+					"location_id": f.FromLocationId,
+					"updated_at":  now,
+				})
+			if result.Error != nil {
+				return apperror.NewUnprocessableEntity("failed to move fiber back: ", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return apperror.NewConflict("a transferred fiber has been moved again; delete the later transfer first")
+			}
+		}
+
+		for _, model := range []interface{}{&models.StockTransferItem{}, &models.StockTransferFiber{}} {
+			if err := tx.Model(model).
+				Where("stock_transfer_id = ? AND deleted = false", transfer.Uuid).
+				Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to delete transfer lines: ", err)
+			}
+		}
+
+		if err := tx.Model(&models.StockTransfer{}).
+			Where("uuid = ?", transfer.Uuid).
+			Updates(map[string]interface{}{"deleted": true, "updated_at": now}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete stock transfer: ", err)
+		}
+
+		return nil
+	})
+}
+
+// moveSorts relocates whole sorts, or splits the moved weight off into a new
+// sort at the destination. Reserved weight stays with the original sort.
+func (s *StockTransferService) moveSorts(tx *gorm.DB, transfer models.StockTransfer, items []models.StockTransferItemRequest) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	sortIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		sortIDs = append(sortIDs, item.StockSortId)
+	}
+	if len(distinct(sortIDs)) != len(sortIDs) {
+		return apperror.NewBadRequest("each stock sort can only appear once per transfer")
+	}
+
+	var sorts []models.StockSort
+	if err := tx.Where("uuid IN ? AND deleted = false", sortIDs).Find(&sorts).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch stock sorts: ", err)
+	}
+	sortMap := make(map[string]models.StockSort, len(sorts))
+	for _, srt := range sorts {
+		sortMap[srt.Uuid] = srt
+	}
+
+	reserved, err := reservedSortWeights(tx, sortIDs, "")
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, item := range items {
+		srt, ok := sortMap[item.StockSortId]
+		if !ok {
+			return apperror.NewNotFound(fmt.Sprintf("stock sort %s not found", item.StockSortId))
+		}
+		if srt.IsShrinkage {
+			return apperror.NewBadRequest(fmt.Sprintf("%s is shrinkage and cannot be transferred", srt.ItemName))
+		}
+		if srt.LocationId != transfer.FromLocationId {
+			return apperror.NewBadRequest(fmt.Sprintf("%s is not stored at the source location", srt.ItemName))
+		}
+		if item.Weight > srt.CurrentWeight {
+			return apperror.NewBadRequest(fmt.Sprintf("%s has only %d kg left", srt.ItemName, srt.CurrentWeight))
+		}
+
+		targetSortId := srt.Uuid
+		if item.Weight == srt.CurrentWeight {
+			// Whole sort moves, reservations included
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid = ?", srt.Uuid).
+				Updates(map[string]interface{}{
+					"location_id": transfer.ToLocationId,
+					"updated_at":  now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to move stock sort: ", err)
+			}
+		} else {
+			if free := srt.CurrentWeight - reserved[srt.Uuid]; item.Weight > free {
+				return apperror.NewBadRequest(fmt.Sprintf(
+					"%s has only %d kg free; %d kg is reserved by sales orders", srt.ItemName, free, reserved[srt.Uuid]))
+			}
+
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid = ?", srt.Uuid).
+				Updates(map[string]interface{}{
+					"weight":         srt.Weight - item.Weight,
+					"current_weight": srt.CurrentWeight - item.Weight,
+					"total_cost":     (srt.Weight - item.Weight) * srt.PricePerKilogram,
+					"updated_at":     now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to update stock sort: ", err)
+			}
+
+			split := models.StockSort{
+				Uuid:                  uuid.New().String(),
+				StockItemID:           srt.StockItemID,
+				ProductId:             srt.ProductId,
+				ItemName:              srt.ItemName,
+				Weight:                item.Weight,
+				PricePerKilogram:      srt.PricePerKilogram,
+				CurrentWeight:         item.Weight,
+				TotalCost:             item.Weight * srt.PricePerKilogram,
+				LandedCostPerKilogram: srt.LandedCostPerKilogram,
+				IsShrinkage:           false,
+				LocationId:            transfer.ToLocationId,
+				Deleted:               false,
+				CreatedAt:             now,
+				UpdatedAt:             now,
+			}
+			if err = tx.Create(&split).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create stock sort: ", err)
+			}
+			targetSortId = split.Uuid
+		}
+
+		line := models.StockTransferItem{
+			Uuid:            uuid.New().String(),
+			StockTransferId: transfer.Uuid,
+			StockSortId:     srt.Uuid,
+			TargetSortId:    targetSortId,
+			ItemName:        srt.ItemName,
+			Weight:          item.Weight,
+			Deleted:         false,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err = tx.Create(&line).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create transfer item: ", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *StockTransferService) moveFibers(tx *gorm.DB, transfer models.StockTransfer, fiberIDs []string) error {
+	if len(fiberIDs) == 0 {
+		return nil
+	}
+	if len(distinct(fiberIDs)) != len(fiberIDs) {
+		return apperror.NewBadRequest("each fiber can only appear once per transfer")
+	}
+
+	var fibers []models.Fiber
+	if err := tx.Where("uuid IN ? AND deleted = false", fiberIDs).Find(&fibers).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch fibers: ", err)
+	}
+	if len(fibers) != len(fiberIDs) {
+		return apperror.NewNotFound("fiber not found")
+	}
+
+	now := time.Now()
+	for _, fiber := range fibers {
+		if fiber.LocationId != transfer.FromLocationId {
+			return apperror.NewBadRequest(fmt.Sprintf("fiber %s is not at the source location", fiber.Name))
+		}
+
+		record := models.StockTransferFiber{
+			Uuid:            uuid.New().String(),
+			StockTransferId: transfer.Uuid,
+			FiberId:         fiber.Uuid,
+			FromLocationId:  fiber.LocationId,
+			Deleted:         false,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := tx.Create(&record).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create transfer fiber: ", err)
+		}
+	}
+
+	if err := tx.Model(&models.Fiber{}).
+		Where("uuid IN ?", fiberIDs).
+		Updates(map[string]interface{}{
+			"location_id": transfer.ToLocationId,
+			"updated_at":  now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to move fibers: ", err)
+	}
+
+	return nil
+}
+
+// revertItem undoes one transfer line. Split sorts are merged back only while
+// none of their weight has been sold, reserved or moved on.
+func (s *StockTransferService) revertItem(tx *gorm.DB, transfer models.StockTransfer, item models.StockTransferItem, now time.Time) error {
+	var target models.StockSort
+	if err := tx.Where("uuid = ? AND deleted = false", item.TargetSortId).First(&target).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewConflict(fmt.Sprintf("%s was re-sorted after the transfer and cannot be moved back", item.ItemName))
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+	}
+	if target.LocationId != transfer.ToLocationId {
+		return apperror.NewConflict(fmt.Sprintf("%s has been moved again; delete the later transfer first", item.ItemName))
+	}
+
+	if item.TargetSortId == item.StockSortId {
+		if err := tx.Model(&models.StockSort{}).
+			Where("uuid = ?", target.Uuid).
+			Updates(map[string]interface{}{
+				"location_id": transfer.FromLocationId,
+				"updated_at":  now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to move stock sort back: ", err)
+		}
+		return nil
+	}
+
+	reserved, err := reservedSortWeights(tx, []string{target.Uuid}, "")
+	if err != nil {
+		return err
+	}
+	if target.CurrentWeight != target.Weight || reserved[target.Uuid] > 0 {
+		return apperror.NewConflict(fmt.Sprintf("%s moved by this transfer has already been sold or reserved", item.ItemName))
+	}
+
+	var source models.StockSort
+	if err = tx.Where("uuid = ? AND deleted = false", item.StockSortId).First(&source).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewConflict(fmt.Sprintf("%s was re-sorted after the transfer and cannot be moved back", item.ItemName))
+		}
+		return apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+	}
+
+	if err = tx.Model(&models.StockSort{}).
+		Where("uuid = ?", source.Uuid).
+		Updates(map[string]interface{}{
+			"weight":         source.Weight + target.Weight,
+			"current_weight": source.CurrentWeight + target.CurrentWeight,
+			"total_cost":     (source.Weight + target.Weight) * source.PricePerKilogram,
+			"updated_at":     now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update stock sort: ", err)
+	}
+
+	if err = tx.Model(&models.StockSort{}).
+		Where("uuid = ?", target.Uuid).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to delete stock sort: ", err)
+	}
+
+	return nil
+}
+
+func (s *StockTransferService) buildResponses(db *gorm.DB, transfers []models.StockTransfer) ([]models.StockTransferResponse, error) {
+	responses := make([]models.StockTransferResponse, 0, len(transfers))
+	if len(transfers) == 0 {
+		return responses, nil
+	}
+
+	transferIDs := make([]string, 0, len(transfers))
+	locationIDs := make([]string, 0, len(transfers)*2)
+	for _, t := range transfers {
+		transferIDs = append(transferIDs, t.Uuid)
+		locationIDs = append(locationIDs, t.ToLocationId)
+		if t.FromLocationId != "" {
+			locationIDs = append(locationIDs, t.FromLocationId)
+		}
+	}
+
+	var locations []models.Location
+	if err := db.Where("uuid IN ?", distinct(locationIDs)).Find(&locations).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch locations: ", err)
+	}
+	locationMap := make(map[string]models.LocationResponse, len(locations))
+	for _, l := range locations {
+		locationMap[l.Uuid] = models.LocationResponse{
+			Uuid:           l.Uuid,
+			Name:           l.Name,
+			LocationType:   l.LocationType,
+			Description:    l.Description,
+			MaxTemperature: l.MaxTemperature,
+			CreatedAt:      l.CreatedAt,
+		}
+	}
+
+	var items []struct {
+		models.StockTransferItem
+		StockEntryNo int `gorm:"column:stock_entry_no"`
+	}
+	if err := db.Table("stock_transfer_items AS sti").
+		Select("sti.*, se.id AS stock_entry_no").
+		Joins("INNER JOIN stock_sorts ss ON ss.uuid = sti.stock_sort_id").
+		Joins("INNER JOIN stock_items si ON si.uuid = ss.stock_item_id").
+		Joins("INNER JOIN stock_entries se ON se.uuid = si.stock_entry_id").
+		Where("sti.stock_transfer_id IN ? AND sti.deleted = false", transferIDs).
+		Order("sti.id ASC").
+		Scan(&items).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch transfer items: ", err)
+	}
+	itemMap := make(map[string][]models.StockTransferItemResponse, len(transfers))
+	weightMap := make(map[string]int, len(transfers))
+	for _, item := range items {
+		itemMap[item.StockTransferId] = append(itemMap[item.StockTransferId], models.StockTransferItemResponse{
+			Uuid:         item.Uuid,
+			StockSortId:  item.StockSortId,
+			TargetSortId: item.TargetSortId,
+			StockCode:    fmt.Sprintf("STOCK%d", item.StockEntryNo),
+			ItemName:     item.ItemName,
+			Weight:       item.Weight,
+		})
+		weightMap[item.StockTransferId] += item.Weight
+	}
+
+	var fibers []struct {
+		StockTransferId string `gorm:"column:stock_transfer_id"`
+		models.FiberResponse
+	}
+	if err := db.Table("stock_transfer_fibers AS stf").
+		Select(`
+			stf.stock_transfer_id,
+			f.uuid,
+			f.name,
+			f.fiber_type,
+			f.capacity,
+			f.status,
+			f.stock_sort_id,
+			COALESCE(f.location_id, '') AS location_id,
+			f.deleted,
+			f.created_at
+		`).
+		Joins("INNER JOIN fibers f ON f.uuid = stf.fiber_id").
+		Where("stf.stock_transfer_id IN ? AND stf.deleted = false", transferIDs).
+		Order("f.name ASC").
+		Scan(&fibers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch transfer fibers: ", err)
+	}
+	fiberMap := make(map[string][]models.FiberResponse, len(transfers))
+	for _, f := range fibers {
+		fiberMap[f.StockTransferId] = append(fiberMap[f.StockTransferId], f.FiberResponse)
+	}
+
+	for _, t := range transfers {
+		response := models.StockTransferResponse{
+			Uuid:         t.Uuid,
+			TransferCode: fmt.Sprintf("TRF%d", t.ID),
+			ToLocation:   locationMap[t.ToLocationId],
+			TransferDate: t.TransferDate,
+			Notes:        t.Notes,
+			TotalWeight:  weightMap[t.Uuid],
+			Items:        make([]models.StockTransferItemResponse, 0),
+			Fibers:       make([]models.FiberResponse, 0),
+			CreatedAt:    t.CreatedAt,
+		}
+		if from, ok := locationMap[t.FromLocationId]; ok {
+			response.FromLocation = &from
+		}
+		if lines, ok := itemMap[t.Uuid]; ok {
+			response.Items = lines
+		}
+		if moved, ok := fiberMap[t.Uuid]; ok {
+			response.Fibers = moved
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,507 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type SupplierReturnService struct{}
+
+func NewSupplierReturnService() repository.SupplierReturnRepository {
+	return &SupplierReturnService{}
+}
+
+// CreateSupplierReturn - Send Rejected Fish Back to the Supplier
+// =====================================================
+func (s *SupplierReturnService) CreateSupplierReturn(request models.SupplierReturnRequest) (*models.SupplierReturnResponse, error) {
+	db := config.GetDBConn()
+
+	if err := checkPeriodsOpen(db, request.ReturnDate); err != nil {
+		return nil, err
+	}
+
+	var record models.SupplierReturn
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var stockItem models.StockItem
+		var stockSort *models.StockSort
+
+		if request.StockSortId != "" {
+			var srt models.StockSort
+			if err := tx.Where("uuid = ? AND deleted = false", request.StockSortId).First(&srt).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return apperror.NewNotFound("stock sort not found")
+				}
+				return apperror.NewUnprocessableEntity("failed to fetch stock sort: ", err)
+			}
+			stockSort = &srt
+			request.StockItemId = srt.StockItemID
+		}
+
+		if err := tx.Where("uuid = ? AND deleted = false", request.StockItemId).First(&stockItem).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFound("stock item not found")
+			}
+			return apperror.NewUnprocessableEntity("failed to fetch stock item: ", err)
+		}
+
+		var purchase models.Purchase
+		if err := tx.Where("stock_id = ? AND deleted = false", stockItem.StockEntryID).First(&purchase).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFound("purchase not found for stock item")
+			}
+			return apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+		}
+
+		// Returned fish is charged back at the purchase price unless told otherwise
+		price := request.PricePerKilogram
+		if price == 0 {
+			price = stockItem.PricePerKilogram
+		}
+		amount := request.Weight * price
+		if amount > purchase.TotalAmount {
+			return apperror.NewBadRequest(fmt.Sprintf("return amount %d exceeds purchase amount %d", amount, purchase.TotalAmount))
+		}
+
+		itemName := stockItem.ItemName
+		if stockSort != nil {
+			itemName = stockSort.ItemName
+			if err := s.reduceStockSort(tx, *stockSort, request.Weight); err != nil {
+				return err
+			}
+		} else {
+			if err := s.reduceStockItem(tx, stockItem, request.Weight); err != nil {
+				return err
+			}
+			if err := allocateLandedCosts(tx, purchase); err != nil {
+				return err
+			}
+		}
+
+		// Debit the unpaid part first; anything already paid becomes supplier credit
+		totalAmount := purchase.TotalAmount - amount
+		remainingAmount := totalAmount - purchase.PaidAmount
+		creditAmount := 0
+		if remainingAmount < 0 {
+			creditAmount = -remainingAmount
+			remainingAmount = 0
+		}
+
+		now := time.Now()
+		if err := tx.Model(&models.Purchase{}).
+			Where("uuid = ?", purchase.Uuid).
+			Updates(map[string]interface{}{
+				"total_amount":     totalAmount,
+				"remaining_amount": remainingAmount,
+				"payment_status":   purchasePaymentStatus(purchase.PaidAmount, remainingAmount),
+				"updated_at":       now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase: ", err)
+		}
+
+		record = models.SupplierReturn{
+			Uuid:             uuid.New().String(),
+			PurchaseId:       purchase.Uuid,
+			SupplierId:       purchase.SupplierID,
+			StockEntryId:     stockItem.StockEntryID,
+			StockItemId:      stockItem.Uuid,
+			ItemName:         itemName,
+			ReturnDate:       request.ReturnDate,
+			Weight:           request.Weight,
+			PricePerKilogram: price,
+			TotalAmount:      amount,
+			DebitAmount:      amount - creditAmount,
+			CreditAmount:     creditAmount,
+			Reason:           strings.TrimSpace(request.Reason),
+			Deleted:          false,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		if stockSort != nil {
+			record.StockSortId = stockSort.Uuid
+		}
+
+		var stockEntry models.StockEntry
+		if err := tx.Where("uuid = ?", stockItem.StockEntryID).First(&stockEntry).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to fetch stock entry: ", err)
+		}
+
+		if creditAmount > 0 {
+			// Recorded like a supplier deposit so it can pay for later purchases
+			payment := models.Payment{
+				Uuid:        uuid.New().String(),
+				UserId:      purchase.SupplierID,
+				Description: fmt.Sprintf("Kredit Retur STOCK%d", stockEntry.ID),
+				Total:       creditAmount,
+				Type:        constants.Expense,
+				Deleted:     false,
+				CreatedAt:   request.ReturnDate,
+				UpdatedAt:   now,
+			}
+			if err := tx.Create(&payment).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to create supplier credit: ", err)
+			}
+			record.PaymentId = payment.Uuid
+		}
+
+		if err := tx.Create(&record).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to create supplier return: ", err)
+		}
+
+		return postSupplierReturnJournal(tx, record, fmt.Sprintf("STOCK%d", stockEntry.ID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSupplierReturnById(record.Uuid)
+}
+
+// DeleteSupplierReturn - Reverse Stock, Purchase and Credit
+// =====================================================
+func (s *SupplierReturnService) DeleteSupplierReturn(returnId string) error {
+	db := config.GetDBConn()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		record, err := s.getReturn(tx, returnId)
+		if err != nil {
+			return err
+		}
+
+		if err = checkPeriodsOpen(tx, record.ReturnDate); err != nil {
+			return err
+		}
+
+		if record.PaymentId != "" && record.CreditAmount > 0 {
+			deposit, err := supplierDeposit(tx, record.SupplierId)
+			if err != nil {
+				return err
+			}
+			// Credit already spent on later purchases cannot be taken back
+			if deposit < record.CreditAmount {
+				return apperror.NewConflict(fmt.Sprintf(
+					"supplier credit from this return has been used; deposit left %d of %d",
+					deposit, record.CreditAmount,
+				))
+			}
+		}
+
+		now := time.Now()
+		if record.StockSortId != "" {
+			if err = tx.Model(&models.StockSort{}).
+				Where("uuid = ?", record.StockSortId).
+				Updates(map[string]interface{}{
+					"current_weight": gorm.Expr("current_weight + ?", record.Weight),
+					"updated_at":     now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to restore stock sort: ", err)
+			}
+		} else {
+			if err = tx.Model(&models.StockItem{}).
+				Where("uuid = ?", record.StockItemId).
+				Updates(map[string]interface{}{
+					"weight":        gorm.Expr("weight + ?", record.Weight),
+					"total_payment": gorm.Expr("total_payment + ? * price_per_kilogram", record.Weight),
+					"updated_at":    now,
+				}).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to restore stock item: ", err)
+			}
+		}
+
+		var purchase models.Purchase
+		if err = tx.Where("uuid = ? AND deleted = false", record.PurchaseId).First(&purchase).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.NewNotFound("purchase not found for supplier return")
+			}
+			return apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+		}
+
+		totalAmount := purchase.TotalAmount + record.TotalAmount
+		remainingAmount := totalAmount - purchase.PaidAmount
+		if remainingAmount < 0 {
+			remainingAmount = 0
+		}
+
+		if err = tx.Model(&models.Purchase{}).
+			Where("uuid = ?", purchase.Uuid).
+			Updates(map[string]interface{}{
+				"total_amount":     totalAmount,
+				"remaining_amount": remainingAmount,
+				"payment_status":   purchasePaymentStatus(purchase.PaidAmount, remainingAmount),
+				"updated_at":       now,
+			}).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to update purchase: ", err)
+		}
+
+		if record.StockSortId == "" {
+			if err = allocateLandedCosts(tx, purchase); err != nil {
+				return err
+			}
+		}
+
+		updates := map[string]interface{}{
+			"deleted":    true,
+			"updated_at": now,
+		}
+
+		if record.PaymentId != "" {
+			if err = tx.Model(&models.Payment{}).
+				Where("uuid = ?", record.PaymentId).
+				Updates(updates).Error; err != nil {
+				return apperror.NewUnprocessableEntity("failed to delete supplier credit: ", err)
+			}
+		}
+
+		if err = tx.Model(&models.SupplierReturn{}).
+			Where("uuid = ?", record.Uuid).
+			Updates(updates).Error; err != nil {
+			return apperror.NewUnprocessableEntity("failed to delete supplier return: ", err)
+		}
+
+		return reverseJournals(tx, "source_type = ? AND source_id = ?", constants.JournalSupplierReturn, record.Uuid)
+	})
+}
+
+// GetSupplierReturnById - Single Return Document
+// =====================================================
+func (s *SupplierReturnService) GetSupplierReturnById(returnId string) (*models.SupplierReturnResponse, error) {
+	db := config.GetDBConn()
+
+	record, err := s.getReturn(db, returnId)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.buildResponses(db, []models.SupplierReturn{*record})
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
+}
+
+// GetAllSupplierReturns - Paginated with Filters
+// =====================================================
+func (s *SupplierReturnService) GetAllSupplierReturns(filter models.SupplierReturnFilter) (*models.SupplierReturnPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Model(&models.SupplierReturn{}).Where("deleted = false")
+
+	if filter.SupplierId != "" {
+		query = query.Where("supplier_id = ?", filter.SupplierId)
+	}
+	if filter.PurchaseId != "" {
+		query = query.Where("purchase_id = ?", filter.PurchaseId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(return_date) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(return_date) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count supplier returns: ", err)
+	}
+
+	var records []models.SupplierReturn
+	if err := query.
+		Order("return_date DESC, id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Find(&records).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch supplier returns: ", err)
+	}
+
+	responses, err := s.buildResponses(db, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SupplierReturnPaginationResponse{
+		Size:   filter.Size,
+		PageNo: filter.PageNo,
+		Total:  int(total),
+		Data:   responses,
+	}, nil
+}
+
+// reduceStockItem takes returned weight off the part of an item that was not sorted yet
+func (s *SupplierReturnService) reduceStockItem(tx *gorm.DB, item models.StockItem, weight int) error {
+	var sortedWeight int
+	if err := tx.Model(&models.StockSort{}).
+		Select("COALESCE(SUM(weight), 0)").
+		Where("stock_item_id = ? AND deleted = false", item.Uuid).
+		Scan(&sortedWeight).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to fetch sorted weight: ", err)
+	}
+
+	if unsorted := item.Weight - sortedWeight; weight > unsorted {
+		return apperror.NewBadRequest(fmt.Sprintf(
+			"%s has only %d kg unsorted; return sorted fish against its stock sort", item.ItemName, unsorted))
+	}
+
+	if err := tx.Model(&models.StockItem{}).
+		Where("uuid = ?", item.Uuid).
+		Updates(map[string]interface{}{
+			"weight":        item.Weight - weight,
+			"total_payment": (item.Weight - weight) * item.PricePerKilogram,
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return apperror.NewUnprocessableEntity("failed to update stock item: ", err)
+	}
+
+	return nil
+}
+
+// reduceStockSort takes returned weight off a sort without touching reserved weight
+func (s *SupplierReturnService) reduceStockSort(tx *gorm.DB, srt models.StockSort, weight int) error {
+	reserved, err := reservedSortWeights(tx, []string{srt.Uuid}, "")
+	if err != nil {
+		return err
+	}
+
+	if free := srt.CurrentWeight - reserved[srt.Uuid]; weight > free {
+		return apperror.NewBadRequest(fmt.Sprintf(
+			"%s has only %d kg available to return (%d kg reserved)", srt.ItemName, free, reserved[srt.Uuid]))
+	}
+
+	result := tx.Model(&models.StockSort{}).
+		Where("uuid = ? AND current_weight >= ?", srt.Uuid, weight).
+		Updates(map[string]interface{}{
+			"current_weight": gorm.Expr("current_weight - ?", weight),
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to update stock sort: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewConflict("stock sort weight changed in the meantime")
+	}
+
+	return nil
+}
+
+// purchasePaymentStatus derives the payment status after the purchase total changed
+func purchasePaymentStatus(paidAmount, remainingAmount int) string {
+	switch {
+	case remainingAmount <= 0:
+		return constants.PaymentInFull
+	case paidAmount > 0:
+		return constants.PartialPayment
+	default:
+		return constants.PaymentNotMadeYet
+	}
+}
+
+func (s *SupplierReturnService) getReturn(db *gorm.DB, returnId string) (*models.SupplierReturn, error) {
+	var record models.SupplierReturn
+	if err := db.Where("uuid = ? AND deleted = false", returnId).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("supplier return not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch supplier return: ", err)
+	}
+
+	return &record, nil
+}
+
+func (s *SupplierReturnService) buildResponses(db *gorm.DB, records []models.SupplierReturn) ([]models.SupplierReturnResponse, error) {
+	responses := make([]models.SupplierReturnResponse, 0, len(records))
+	if len(records) == 0 {
+		return responses, nil
+	}
+
+	supplierIDs := make([]string, 0, len(records))
+	entryIDs := make([]string, 0, len(records))
+	for _, r := range records {
+		supplierIDs = append(supplierIDs, r.SupplierId)
+		entryIDs = append(entryIDs, r.StockEntryId)
+	}
+
+	var suppliers []models.User
+	if err := db.Where("uuid IN ?", distinct(supplierIDs)).Find(&suppliers).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch suppliers: ", err)
+	}
+	supplierMap := make(map[string]models.User, len(suppliers))
+	for _, u := range suppliers {
+		supplierMap[u.Uuid] = u
+	}
+
+	var entries []models.StockEntry
+	if err := db.Where("uuid IN ?", distinct(entryIDs)).Find(&entries).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock entries: ", err)
+	}
+	entryMap := make(map[string]int, len(entries))
+	for _, e := range entries {
+		entryMap[e.Uuid] = e.ID
+	}
+
+	for _, r := range records {
+		supplier := supplierMap[r.SupplierId]
+		responses = append(responses, models.SupplierReturnResponse{
+			Uuid:       r.Uuid,
+			ReturnCode: fmt.Sprintf("RET%d", r.ID),
+			PurchaseId: r.PurchaseId,
+			Supplier: models.GetUserDetail{
+				Uuid:  supplier.Uuid,
+				Name:  supplier.Name,
+				Phone: supplier.Phone,
+			},
+			StockId:          r.StockEntryId,
+			StockCode:        fmt.Sprintf("STOCK%d", entryMap[r.StockEntryId]),
+			StockItemId:      r.StockItemId,
+			StockSortId:      r.StockSortId,
+			ItemName:         r.ItemName,
+			ReturnDate:       r.ReturnDate,
+			Weight:           r.Weight,
+			PricePerKilogram: r.PricePerKilogram,
+			TotalAmount:      r.TotalAmount,
+			DebitAmount:      r.DebitAmount,
+			CreditAmount:     r.CreditAmount,
+			Reason:           r.Reason,
+		})
+	}
+
+	return responses, nil
+}
+
+// supplierDeposit returns how much a supplier holds as deposit: what was paid
+// to them beyond what they were owed, the same figure as GetUserBalanceDeposit.
+func supplierDeposit(db *gorm.DB, supplierId string) (int, error) {
+	var balance int
+	if err := db.Model(&models.Payment{}).
+		Select(`COALESCE(SUM(
+			CASE
+				WHEN type = ? THEN total
+				WHEN type = ? THEN -total
+				ELSE 0
+			END
+		), 0)`, constants.Income, constants.Expense).
+		Where("user_id = ? AND deleted = false", supplierId).
+		Scan(&balance).Error; err != nil {
+		return 0, apperror.NewUnprocessableEntity("failed to fetch supplier deposit: ", err)
+	}
+
+	return -balance, nil
+}
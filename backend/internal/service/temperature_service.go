@@ -0,0 +1,648 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+// maxTemperatureRows caps how many readings one logger file may contain.
+const maxTemperatureRows = 10000
+
+// temperatureTimeLayouts are the timestamp formats accepted from logger
+// exports; timestamps without a zone are taken as Jakarta time.
+var temperatureTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+}
+
+const temperatureSelect = `
+	tr.uuid,
+	COALESCE(tr.location_id, '') AS location_id,
+	COALESCE(l.name, '') AS location_name,
+	COALESCE(tr.fiber_id, '') AS fiber_id,
+	COALESCE(f.name, '') AS fiber_name,
+	COALESCE(tr.sale_id, '') AS sale_id,
+	CASE WHEN s.id IS NOT NULL THEN CONCAT('SELL', s.id) ELSE '' END AS sale_code,
+	tr.recorded_at,
+	tr.temperature,
+	tr.threshold,
+	tr.is_excursion,
+	tr.source,
+	tr.notes,
+	(SELECT COUNT(*) FROM temperature_reading_sorts trs WHERE trs.reading_id = tr.uuid) AS linked_sorts
+`
+
+type TemperatureService struct{}
+
+func NewTemperatureService() repository.TemperatureRepository {
+	return &TemperatureService{}
+}
+
+// CreateTemperatureReading - Manual Entry
+// =====================================================
+func (s *TemperatureService) CreateTemperatureReading(request models.TemperatureReadingRequest) (*models.TemperatureReadingResponse, error) {
+	db := config.GetDBConn()
+
+	var readingId string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		readings, err := s.recordReadings(tx, []models.TemperatureReadingRequest{request}, constants.TemperatureManual)
+		if err != nil {
+			return err
+		}
+		readingId = readings[0].Uuid
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.TemperatureReadingResponse
+	if err = temperatureReadingQuery(db).Where("tr.uuid = ?", readingId).Scan(&result).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch temperature reading: ", err)
+	}
+
+	return &result, nil
+}
+
+// ImportTemperatureReadings - CSV/XLSX Logger Export, All-or-Nothing
+// =====================================================
+func (s *TemperatureService) ImportTemperatureReadings(filename string, file io.Reader, locationId, fiberId string) (*models.TemperatureImportResponse, error) {
+	db := config.GetDBConn()
+
+	records, err := readSpreadsheetRows(filename, file)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) < 2 {
+		return nil, apperror.NewBadRequest("file must contain a header row and at least one reading")
+	}
+	if len(records)-1 > maxTemperatureRows {
+		return nil, apperror.NewBadRequest(fmt.Sprintf("file contains more than %d readings", maxTemperatureRows))
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	for _, required := range []string{"recorded_at", "temperature"} {
+		if _, ok := columns[required]; !ok {
+			return nil, apperror.NewBadRequest(fmt.Sprintf("header row must contain a %s column", required))
+		}
+	}
+
+	cell := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	// Rows may name their location or fiber; the form fields apply otherwise
+	locationIDs, err := s.namesToIDs(db, &models.Location{}, "location", records[1:], cell)
+	if err != nil {
+		return nil, err
+	}
+	fiberIDs, err := s.namesToIDs(db, &models.Fiber{}, "fiber", records[1:], cell)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.TemperatureImportResponse{
+		TotalRows: len(records) - 1,
+		Rows:      make([]models.TemperatureImportRow, 0, len(records)-1),
+	}
+
+	requests := make([]models.TemperatureReadingRequest, 0, len(records)-1)
+	for i, record := range records[1:] {
+		var errs []string
+		request := models.TemperatureReadingRequest{
+			LocationId: locationId,
+			FiberId:    fiberId,
+			Notes:      cell(record, "notes"),
+		}
+
+		if name := cell(record, "location"); name != "" {
+			id, ok := locationIDs[strings.ToLower(name)]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("location %q not found", name))
+			}
+			request.LocationId, request.FiberId = id, ""
+		}
+		if name := cell(record, "fiber"); name != "" {
+			id, ok := fiberIDs[strings.ToLower(name)]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("fiber %q not found", name))
+			}
+			request.FiberId, request.LocationId = id, ""
+		}
+		if cell(record, "location") != "" && cell(record, "fiber") != "" {
+			errs = append(errs, "a reading belongs to either a location or a fiber, not both")
+		}
+		if len(errs) == 0 && request.LocationId == "" && request.FiberId == "" {
+			errs = append(errs, "location or fiber is required")
+		}
+
+		recordedAt, ok := parseReadingTime(cell(record, "recorded_at"))
+		if !ok {
+			errs = append(errs, fmt.Sprintf("recorded_at %q is not a valid timestamp", cell(record, "recorded_at")))
+		}
+		request.RecordedAt = recordedAt
+
+		value := strings.Replace(cell(record, "temperature"), ",", ".", 1)
+		temperature, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("temperature %q is not a number", value))
+		}
+		request.Temperature = &temperature
+
+		if len(errs) > 0 {
+			resp.InvalidRows++
+		}
+		resp.Rows = append(resp.Rows, models.TemperatureImportRow{Row: i + 2, Errors: errs})
+		requests = append(requests, request)
+	}
+
+	if resp.InvalidRows > 0 {
+		return resp, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		readings, err := s.recordReadings(tx, requests, constants.TemperatureLogger)
+		if err != nil {
+			return err
+		}
+		for _, reading := range readings {
+			if reading.IsExcursion {
+				resp.Excursions++
+			}
+		}
+		resp.CreatedCount = len(readings)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Success = true
+	return resp, nil
+}
+
+// GetAllTemperatureReadings - Paginated Temperature Log
+// =====================================================
+func (s *TemperatureService) GetAllTemperatureReadings(filter models.TemperatureReadingFilter) (*models.TemperatureReadingPaginationResponse, error) {
+	db := config.GetDBConn()
+
+	if filter.Size <= 0 {
+		filter.Size = 10
+	}
+	if filter.PageNo <= 0 {
+		filter.PageNo = 1
+	}
+	offset := (filter.PageNo - 1) * filter.Size
+
+	query := db.Table("temperature_readings AS tr").Where("tr.deleted = false")
+
+	if filter.LocationId != "" {
+		query = query.Where("tr.location_id = ?", filter.LocationId)
+	}
+	if filter.FiberId != "" {
+		query = query.Where("tr.fiber_id = ?", filter.FiberId)
+	}
+	if filter.StockSortId != "" {
+		query = query.Where("tr.uuid IN (SELECT reading_id FROM temperature_reading_sorts WHERE stock_sort_id = ?)", filter.StockSortId)
+	}
+	if filter.SaleId != "" {
+		query = query.Where("tr.sale_id = ?", filter.SaleId)
+	}
+	if filter.StartDate != "" {
+		query = query.Where("DATE(tr.recorded_at) >= CAST(? AS DATE)", filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query = query.Where("DATE(tr.recorded_at) <= CAST(? AS DATE)", filter.EndDate)
+	}
+
+	var excursions int64
+	excursionQuery := *query
+	if err := excursionQuery.Where("tr.is_excursion = true").Count(&excursions).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count temperature excursions: ", err)
+	}
+
+	if filter.ExcursionsOnly {
+		query = query.Where("tr.is_excursion = true")
+	}
+
+	var total int64
+	countQuery := *query
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to count temperature readings: ", err)
+	}
+
+	results := make([]models.TemperatureReadingResponse, 0)
+	if err := joinTemperatureDetails(query).
+		Order("tr.recorded_at DESC, tr.id DESC").
+		Offset(offset).
+		Limit(filter.Size).
+		Scan(&results).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch temperature readings: ", err)
+	}
+
+	return &models.TemperatureReadingPaginationResponse{
+		Size:       filter.Size,
+		PageNo:     filter.PageNo,
+		Total:      int(total),
+		Excursions: int(excursions),
+		Data:       results,
+	}, nil
+}
+
+// DeleteTemperatureReading - Soft Delete a Mistaken Reading
+// =====================================================
+func (s *TemperatureService) DeleteTemperatureReading(readingId string) error {
+	result := config.GetDBConn().Model(&models.TemperatureReading{}).
+		Where("uuid = ? AND deleted = false", readingId).
+		Updates(map[string]interface{}{
+			"deleted":    true,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return apperror.NewUnprocessableEntity("failed to delete temperature reading: ", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperror.NewNotFound("temperature reading not found")
+	}
+
+	return nil
+}
+
+// recordReadings stores readings, flags excursions against the location's
+// limit and links each reading to the sorts and sale it concerns. One alert
+// is raised per location or fiber with excursions in the batch.
+func (s *TemperatureService) recordReadings(tx *gorm.DB, requests []models.TemperatureReadingRequest, source string) ([]models.TemperatureReading, error) {
+	coldChain := models.GetConfig().ColdChain
+
+	locationIDs := make([]string, 0, len(requests))
+	fiberIDs := make([]string, 0, len(requests))
+	for _, r := range requests {
+		if r.LocationId != "" {
+			locationIDs = append(locationIDs, r.LocationId)
+		}
+		if r.FiberId != "" {
+			fiberIDs = append(fiberIDs, r.FiberId)
+		}
+	}
+
+	var locations []models.Location
+	if len(locationIDs) > 0 {
+		if err := tx.Where("uuid IN ? AND deleted = false", distinct(locationIDs)).Find(&locations).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch locations: ", err)
+		}
+	}
+	locationMap := make(map[string]models.Location, len(locations))
+	for _, l := range locations {
+		locationMap[l.Uuid] = l
+	}
+
+	var fibers []models.Fiber
+	if len(fiberIDs) > 0 {
+		if err := tx.Where("uuid IN ? AND deleted = false", distinct(fiberIDs)).Find(&fibers).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch fibers: ", err)
+		}
+	}
+	fiberMap := make(map[string]models.Fiber, len(fibers))
+	for _, f := range fibers {
+		fiberMap[f.Uuid] = f
+	}
+
+	type excursion struct {
+		name      string
+		first     models.TemperatureReading
+		count     int
+		highest   float64
+		threshold float64
+	}
+	excursions := make(map[string]*excursion)
+	order := make([]string, 0)
+
+	now := time.Now()
+	readings := make([]models.TemperatureReading, 0, len(requests))
+	for _, r := range requests {
+		reading := models.TemperatureReading{
+			Uuid:        uuid.New().String(),
+			LocationId:  r.LocationId,
+			FiberId:     r.FiberId,
+			RecordedAt:  r.RecordedAt,
+			Temperature: *r.Temperature,
+			Threshold:   coldChain.MaxTemperature,
+			Source:      source,
+			Notes:       strings.TrimSpace(r.Notes),
+			Deleted:     false,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		var sortIDs []string
+		var name string
+		if r.LocationId != "" {
+			location, ok := locationMap[r.LocationId]
+			if !ok {
+				return nil, apperror.NewNotFound("location not found")
+			}
+			if location.MaxTemperature != nil {
+				reading.Threshold = *location.MaxTemperature
+			}
+			name = location.Name
+
+			ids, err := s.sortsAtLocation(tx, r.LocationId, r.RecordedAt)
+			if err != nil {
+				return nil, err
+			}
+			sortIDs = ids
+		} else {
+			fiber, ok := fiberMap[r.FiberId]
+			if !ok {
+				return nil, apperror.NewNotFound("fiber not found")
+			}
+			name = "fiber " + fiber.Name
+
+			saleId, sortId, err := s.fiberSaleAt(tx, r.FiberId, r.RecordedAt, coldChain.FiberLinkHours)
+			if err != nil {
+				return nil, err
+			}
+			reading.SaleId = saleId
+			if sortId != "" {
+				sortIDs = []string{sortId}
+			}
+		}
+		reading.IsExcursion = reading.Temperature > reading.Threshold
+
+		if err := tx.Create(&reading).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to create temperature reading: ", err)
+		}
+
+		if len(sortIDs) > 0 {
+			links := make([]models.TemperatureReadingSort, 0, len(sortIDs))
+			for _, id := range sortIDs {
+				links = append(links, models.TemperatureReadingSort{
+					ReadingId:   reading.Uuid,
+					StockSortId: id,
+					CreatedAt:   now,
+				})
+			}
+			if err := tx.Create(&links).Error; err != nil {
+				return nil, apperror.NewUnprocessableEntity("failed to link temperature reading: ", err)
+			}
+		}
+
+		if reading.IsExcursion {
+			key := r.LocationId + r.FiberId
+			e, ok := excursions[key]
+			if !ok {
+				e = &excursion{name: name, first: reading, highest: reading.Temperature, threshold: reading.Threshold}
+				excursions[key] = e
+				order = append(order, key)
+			}
+			e.count++
+			if reading.Temperature > e.highest {
+				e.highest = reading.Temperature
+			}
+		}
+
+		readings = append(readings, reading)
+	}
+
+	for _, key := range order {
+		e := excursions[key]
+		title := fmt.Sprintf("Temperature excursion at %s", e.name)
+		message := fmt.Sprintf("%d reading(s) above %.1f°C at %s, highest %.1f°C, first at %s",
+			e.count, e.threshold, e.name, e.highest, e.first.RecordedAt.In(constants.JakartaTz).Format("2006-01-02 15:04"))
+
+		if _, err := notify(tx, constants.NotificationTemperatureExcursion, title, message,
+			constants.ReferenceTemperatureReading, e.first.Uuid); err != nil {
+			return nil, err
+		}
+	}
+
+	return readings, nil
+}
+
+// sortsAtLocation returns the sorts that were stored at a location at the
+// given moment. The current location is walked back through stock transfers:
+// before a transfer the sort was at the transfer's source location. Sorts are
+// only linked while they still held fish, i.e. they have weight left or were
+// sold from on or after that day.
+func (s *TemperatureService) sortsAtLocation(tx *gorm.DB, locationId string, at time.Time) ([]string, error) {
+	var candidates []struct {
+		Uuid       string `gorm:"column:uuid"`
+		LocationId string `gorm:"column:location_id"`
+	}
+	if err := tx.Raw(`
+		SELECT ss.uuid, COALESCE(ss.location_id, '') AS location_id
+		FROM stock_sorts ss
+		INNER JOIN stock_items si ON si.uuid = ss.stock_item_id AND si.deleted = false
+		INNER JOIN purchase p ON p.stock_id = si.stock_entry_id AND p.deleted = false
+		WHERE ss.deleted = false
+		AND ss.is_shrinkage = false
+		AND p.purchase_date <= ?
+		AND (
+			ss.location_id = ?
+			OR ss.uuid IN (
+				SELECT sti.target_sort_id
+				FROM stock_transfer_items sti
+				INNER JOIN stock_transfers st ON st.uuid = sti.stock_transfer_id AND st.deleted = false
+				WHERE sti.deleted = false
+				AND (st.from_location_id = ? OR st.to_location_id = ?)
+			)
+		)
+		AND (
+			ss.current_weight > 0
+			OR EXISTS (
+				SELECT 1
+				FROM item_sales it
+				INNER JOIN sales s ON s.uuid = it.sale_id AND s.deleted = false
+				WHERE it.stock_sort_id = ss.uuid
+				AND it.deleted = false
+				AND DATE(s.purchase_date) >= DATE(?)
+			)
+		)
+	`, at, locationId, locationId, locationId, at).Scan(&candidates).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock sorts at location: ", err)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sortIDs := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		sortIDs = append(sortIDs, c.Uuid)
+	}
+
+	// The first transfer after the reading tells where the sort was before it
+	var moves []struct {
+		TargetSortId   string `gorm:"column:target_sort_id"`
+		FromLocationId string `gorm:"column:from_location_id"`
+	}
+	if err := tx.Raw(`
+		SELECT DISTINCT ON (sti.target_sort_id)
+			sti.target_sort_id,
+			COALESCE(st.from_location_id, '') AS from_location_id
+		FROM stock_transfer_items sti
+		INNER JOIN stock_transfers st ON st.uuid = sti.stock_transfer_id AND st.deleted = false
+		WHERE sti.deleted = false
+		AND sti.target_sort_id IN ?
+		AND st.transfer_date > ?
+		ORDER BY sti.target_sort_id, st.transfer_date ASC, st.id ASC
+	`, sortIDs, at).Scan(&moves).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to fetch stock transfers: ", err)
+	}
+	locationAt := make(map[string]string, len(candidates))
+	for _, c := range candidates {
+		locationAt[c.Uuid] = c.LocationId
+	}
+	for _, m := range moves {
+		locationAt[m.TargetSortId] = m.FromLocationId
+	}
+
+	result := make([]string, 0, len(candidates))
+	for _, id := range sortIDs {
+		if locationAt[id] == locationId {
+			result = append(result, id)
+		}
+	}
+
+	return result, nil
+}
+
+// fiberSaleAt finds the sale a fiber was carrying at the given moment: its
+// latest allocation made within the link window before the reading.
+func (s *TemperatureService) fiberSaleAt(tx *gorm.DB, fiberId string, at time.Time, linkHours int) (string, string, error) {
+	var allocation models.FiberAllocation
+	err := tx.Table("fiber_allocations AS fa").
+		Select("fa.*").
+		Joins("INNER JOIN sales s ON s.uuid = fa.sale_id AND s.deleted = false").
+		Where("fa.fiber_id = ? AND fa.deleted = false", fiberId).
+		Where("fa.created_at <= ? AND fa.created_at >= ?", at, at.Add(-time.Duration(linkHours)*time.Hour)).
+		Order("fa.created_at DESC, fa.id DESC").
+		Take(&allocation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", "", nil
+		}
+		return "", "", apperror.NewUnprocessableEntity("failed to fetch fiber allocation: ", err)
+	}
+
+	return allocation.SaleId, allocation.StockSortId, nil
+}
+
+// namesToIDs resolves the location or fiber names used in an import file.
+func (s *TemperatureService) namesToIDs(db *gorm.DB, model interface{}, column string, records [][]string, cell func([]string, string) string) (map[string]string, error) {
+	names := make([]string, 0)
+	for _, record := range records {
+		if name := cell(record, column); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+
+	result := make(map[string]string)
+	if len(names) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		Uuid string `gorm:"column:uuid"`
+		Name string `gorm:"column:name"`
+	}
+	if err := db.Model(model).
+		Select("uuid, LOWER(name) AS name").
+		Where("LOWER(name) IN ? AND deleted = false", distinct(names)).
+		Scan(&rows).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity(fmt.Sprintf("failed to fetch %ss: ", column), err)
+	}
+	for _, row := range rows {
+		result[row.Name] = row.Uuid
+	}
+
+	return result, nil
+}
+
+func temperatureReadingQuery(db *gorm.DB) *gorm.DB {
+	return joinTemperatureDetails(db.Table("temperature_readings AS tr").Where("tr.deleted = false"))
+}
+
+func joinTemperatureDetails(query *gorm.DB) *gorm.DB {
+	return query.
+		Select(temperatureSelect).
+		Joins("LEFT JOIN locations l ON l.uuid = tr.location_id").
+		Joins("LEFT JOIN fibers f ON f.uuid = tr.fiber_id").
+		Joins("LEFT JOIN sales s ON s.uuid = tr.sale_id")
+}
+
+func parseReadingTime(value string) (time.Time, bool) {
+	for _, layout := range temperatureTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, constants.JakartaTz); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// temperatureHistory returns the readings taken where the given sorts were
+// stored, up to until when set, plus the fiber readings recorded against the
+// given sales, oldest first.
+func temperatureHistory(db *gorm.DB, sortIDs, saleIDs []string, until *time.Time) ([]models.TemperatureReadingResponse, int, error) {
+	readings := make([]models.TemperatureReadingResponse, 0)
+	if len(sortIDs) == 0 && len(saleIDs) == 0 {
+		return readings, 0, nil
+	}
+
+	conditions := make([]string, 0, 2)
+	args := make([]interface{}, 0, 3)
+	if len(sortIDs) > 0 {
+		condition := "tr.uuid IN (SELECT reading_id FROM temperature_reading_sorts WHERE stock_sort_id IN ?)"
+		args = append(args, distinct(sortIDs))
+		if until != nil {
+			condition = "(" + condition + " AND tr.recorded_at <= ?)"
+			args = append(args, *until)
+		}
+		conditions = append(conditions, condition)
+	}
+	if len(saleIDs) > 0 {
+		conditions = append(conditions, "tr.sale_id IN ?")
+		args = append(args, distinct(saleIDs))
+	}
+
+	if err := temperatureReadingQuery(db).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Order("tr.recorded_at ASC, tr.id ASC").
+		Scan(&readings).Error; err != nil {
+		return nil, 0, apperror.NewUnprocessableEntity("failed to fetch temperature history: ", err)
+	}
+
+	excursions := 0
+	for _, r := range readings {
+		if r.IsExcursion {
+			excursions++
+		}
+	}
+
+	return readings, excursions, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"dashboard-app/internal/config"
+	"dashboard-app/internal/constants"
+	"dashboard-app/internal/models"
+	"dashboard-app/internal/repository"
+)
+
+type TraceabilityService struct{}
+
+func NewTraceabilityService() repository.TraceabilityRepository {
+	return &TraceabilityService{}
+}
+
+// traceQuery walks purchase -> stock entry -> stock item -> stock sort ->
+// item sales -> sale -> customer. Sorts without sales are kept so a recall
+// also shows fish still in the warehouse.
+const traceQuery = `
+	SELECT
+		p.uuid AS purchase_id,
+		se.uuid AS stock_id,
+		se.id AS stock_entry_no,
+		sup.uuid AS supplier_id,
+		sup.name AS supplier_name,
+		sup.phone AS supplier_phone,
+		p.purchase_date,
+		COALESCE(p.vessel, '') AS vessel,
+		COALESCE(p.landing_site, '') AS landing_site,
+		p.catch_date,
+		si.uuid AS stock_item_id,
+		si.item_name,
+		si.weight AS purchased_weight,
+		COALESCE(ss.uuid, '') AS stock_sort_id,
+		COALESCE(ss.sorted_item_name, '') AS sorted_item_name,
+		COALESCE(ss.weight, 0) AS sorted_weight,
+		COALESCE(s.uuid, '') AS sale_id,
+		COALESCE(s.id, 0) AS sale_no,
+		s.purchase_date AS sale_date,
+		COALESCE(cus.uuid, '') AS customer_id,
+		COALESCE(cus.name, '') AS customer_name,
+		COALESCE(cus.phone, '') AS customer_phone,
+		COALESCE(it.weight, 0) AS sold_weight
+	FROM purchase p
+	INNER JOIN "user" sup ON sup.uuid = p.supplier_id
+	INNER JOIN stock_entries se ON se.uuid = p.stock_id
+	INNER JOIN stock_items si ON si.stock_entry_id = se.uuid AND si.deleted = false
+	LEFT JOIN stock_sorts ss ON ss.stock_item_id = si.uuid AND ss.deleted = false
+	LEFT JOIN (
+		item_sales it
+		INNER JOIN sales s ON s.uuid = it.sale_id AND s.deleted = false
+	) ON it.stock_sort_id = ss.uuid AND it.deleted = false
+	LEFT JOIN "user" cus ON cus.uuid = s.customer_id
+	WHERE p.deleted = false
+`
+
+// TracePurchase - Forward Trace from a Supplier Delivery to Customers
+// =====================================================
+func (s *TraceabilityService) TracePurchase(purchaseId string) (*models.TraceabilityResponse, error) {
+	db := config.GetDBConn()
+
+	var purchase models.Purchase
+	if err := db.Where("uuid = ? AND deleted = false", purchaseId).First(&purchase).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("purchase not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch purchase: ", err)
+	}
+
+	var lines []models.TraceabilityLine
+	if err := db.Raw(traceQuery+" AND p.uuid = ? ORDER BY si.id, ss.id, s.id", purchaseId).
+		Scan(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to trace purchase: ", err)
+	}
+
+	reference := ""
+	if len(lines) > 0 {
+		reference = fmt.Sprintf("STOCK%d", lines[0].StockEntryNo)
+	}
+
+	return s.buildResponse(db, constants.TraceForward, reference, lines, nil)
+}
+
+// TraceSale - Backward Trace from a Sale to Supplier Deliveries
+// =====================================================
+func (s *TraceabilityService) TraceSale(saleId string) (*models.TraceabilityResponse, error) {
+	db := config.GetDBConn()
+
+	var sale models.Sale
+	if err := db.Where("uuid = ? AND deleted = false", saleId).First(&sale).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFound("sale not found")
+		}
+		return nil, apperror.NewUnprocessableEntity("failed to fetch sale: ", err)
+	}
+
+	var lines []models.TraceabilityLine
+	if err := db.Raw(traceQuery+" AND s.uuid = ? ORDER BY p.purchase_date, si.id, ss.id", saleId).
+		Scan(&lines).Error; err != nil {
+		return nil, apperror.NewUnprocessableEntity("failed to trace sale: ", err)
+	}
+
+	// Storage readings count until the end of the sale day; fiber readings after that
+	until := truncateDate(sale.PurchaseDate).Add(24 * time.Hour)
+	return s.buildResponse(db, constants.TraceBackward, fmt.Sprintf("SELL%d", sale.ID), lines, &until)
+}
+
+func (s *TraceabilityService) buildResponse(db *gorm.DB, direction, reference string, lines []models.TraceabilityLine, until *time.Time) (*models.TraceabilityResponse, error) {
+	response := &models.TraceabilityResponse{
+		Direction: direction,
+		Reference: reference,
+		Suppliers: make([]models.GetUserDetail, 0),
+		Customers: make([]models.GetUserDetail, 0),
+		Lines:     make([]models.TraceabilityLine, 0, len(lines)),
+	}
+
+	saleIDs := make([]string, 0, len(lines))
+	sortIDs := make([]string, 0, len(lines))
+	storedSortIDs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.SaleId != "" {
+			saleIDs = append(saleIDs, line.SaleId)
+			sortIDs = append(sortIDs, line.StockSortId)
+		}
+		if line.StockSortId != "" {
+			storedSortIDs = append(storedSortIDs, line.StockSortId)
+		}
+	}
+
+	// Cold-chain history of everything in the trace
+	temperatures, excursions, err := temperatureHistory(db, storedSortIDs, distinct(saleIDs), until)
+	if err != nil {
+		return nil, err
+	}
+	response.Temperatures = temperatures
+	response.TemperatureExcursions = excursions
+
+	// Fibers carry the sold fish, so they are part of the recall as well
+	fiberMap := make(map[string][]string)
+	if len(saleIDs) > 0 {
+		var fibers []struct {
+			SaleId      string `gorm:"column:sale_id"`
+			StockSortId string `gorm:"column:stock_sort_id"`
+			Name        string `gorm:"column:name"`
+		}
+		if err := db.Table("fiber_allocations AS fa").
+			Select("fa.sale_id, fa.stock_sort_id, f.name").
+			Joins("INNER JOIN fibers f ON f.uuid = fa.fiber_id").
+			Where("fa.deleted = false AND fa.sale_id IN ? AND fa.stock_sort_id IN ?", distinct(saleIDs), distinct(sortIDs)).
+			Order("f.name ASC").
+			Scan(&fibers).Error; err != nil {
+			return nil, apperror.NewUnprocessableEntity("failed to fetch fiber allocations: ", err)
+		}
+
+		for _, f := range fibers {
+			key := f.SaleId + "|" + f.StockSortId
+			fiberMap[key] = append(fiberMap[key], f.Name)
+		}
+	}
+
+	seenSuppliers := make(map[string]bool)
+	seenCustomers := make(map[string]bool)
+	for _, line := range lines {
+		line.StockCode = fmt.Sprintf("STOCK%d", line.StockEntryNo)
+		line.Fibers = make([]string, 0)
+
+		if line.SaleId != "" {
+			line.SaleCode = fmt.Sprintf("SELL%d", line.SaleNo)
+			if names, ok := fiberMap[line.SaleId+"|"+line.StockSortId]; ok {
+				line.Fibers = names
+			}
+			response.TotalSoldWeight += line.SoldWeight
+
+			if !seenCustomers[line.CustomerId] {
+				seenCustomers[line.CustomerId] = true
+				response.Customers = append(response.Customers, models.GetUserDetail{
+					Uuid:  line.CustomerId,
+					Name:  line.CustomerName,
+					Phone: line.CustomerPhone,
+				})
+			}
+		}
+
+		if !seenSuppliers[line.SupplierId] {
+			seenSuppliers[line.SupplierId] = true
+			response.Suppliers = append(response.Suppliers, models.GetUserDetail{
+				Uuid:  line.SupplierId,
+				Name:  line.SupplierName,
+				Phone: line.SupplierPhone,
+			})
+		}
+
+		response.Lines = append(response.Lines, line)
+	}
+
+	return response, nil
+}
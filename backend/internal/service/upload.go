@@ -0,0 +1,62 @@
+package service
+
+import (
+	"bytes"
+	"dashboard-app/pkg/apperror"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"dashboard-app/internal/models"
+)
+
+// storedUpload is a file written to the upload directory by storeUpload.
+type storedUpload struct {
+	Path        string
+	ContentType string
+	Size        int64
+}
+
+// storeUpload writes an uploaded file to subDir of the upload directory as
+// name plus the extension of its type. The type is detected from the content
+// rather than the file name and must be one of types, which maps content
+// types to extensions; otherwise the upload is refused with typeError.
+func storeUpload(file io.Reader, subDir, name string, types map[string]string, typeError string) (*storedUpload, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, apperror.NewBadRequest("failed to read uploaded file")
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := types[contentType]
+	if !ok {
+		return nil, apperror.NewBadRequest(typeError)
+	}
+
+	dir := filepath.Join(models.GetConfig().Storage.UploadDir, subDir)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, apperror.NewInternal("failed to prepare upload directory: ", err)
+	}
+
+	path := filepath.Join(dir, name+ext)
+	out, err := os.Create(path)
+	if err != nil {
+		return nil, apperror.NewInternal("failed to store uploaded file: ", err)
+	}
+	size, err := io.Copy(out, io.MultiReader(bytes.NewReader(head), file))
+	if err != nil {
+		out.Close()
+		os.Remove(path)
+		return nil, apperror.NewInternal("failed to store uploaded file: ", err)
+	}
+	if err = out.Close(); err != nil {
+		os.Remove(path)
+		return nil, apperror.NewInternal("failed to store uploaded file: ", err)
+	}
+
+	return &storedUpload{Path: path, ContentType: contentType, Size: size}, nil
+}
@@ -212,9 +212,15 @@ const StockSortManagementPage: React.FC = () => {
             warning = "Total berat yang disortir melebihi sisa berat yang tersedia! Apakah Anda tetap ingin melanjutkan?";
         }
 
+        // Existing sorts keep their ID so the backend edits them in place
+        const existingSortIds = new Set(
+            (stockInfo?.stock_item?.stock_sorts ?? []).map((s) => s.uuid)
+        );
+
         const payload: SubmitSortRequest = {
             stock_item_uuid: stockItemId,
             stock_sort_request: validResults.map((form) => ({
+                uuid: existingSortIds.has(form.uuid ?? "") ? form.uuid : undefined,
                 sorted_item_name: form.sorted_item_name,
                 weight: form.weight,
                 price_per_kilogram: form.price_per_kilogram,
@@ -3,10 +3,21 @@ import { X, Trash2, ChevronDown } from "lucide-react";
 import { ManualEntryFormRequest } from "../../types/payment";
 import { formatRupiah } from "../../utils/FormatRupiah";
 import { cleanNumber } from "../../utils/CleanNumber";
+import { CashAccountResponse } from "../../types/cashAccount";
+import {
+    CostCenterResponse,
+    ExpenseCategoryResponse,
+} from "../../types/expense";
+import PaymentAccountFields from "./PaymentAccountFields";
 
 interface ManualEntryFormProps {
     index: number;
     entry: ManualEntryFormRequest;
+    cashAccounts: CashAccountResponse[];
+    expenseCategories: ExpenseCategoryResponse[];
+    costCenters: CostCenterResponse[];
+    // Categories apply to operational expenses, not to buyers and suppliers
+    showExpenseFields: boolean;
     onChange: (
         id: string,
         field: keyof ManualEntryFormRequest,
@@ -18,6 +29,10 @@ interface ManualEntryFormProps {
 const ManualEntryForm: React.FC<ManualEntryFormProps> = ({
     index,
     entry,
+    cashAccounts,
+    expenseCategories,
+    costCenters,
+    showExpenseFields,
     onChange,
     onRemove,
 }) => {
@@ -81,13 +96,25 @@ const ManualEntryForm: React.FC<ManualEntryFormProps> = ({
                     <div className="relative">
                         <select
                             value={entry.type}
-                            onChange={(e) =>
+                            onChange={(e) => {
                                 onChange(
                                     entry.tempId,
                                     "type",
                                     e.target.value as "INCOME" | "EXPENSE"
-                                )
-                            }
+                                );
+                                if (e.target.value !== "EXPENSE") {
+                                    onChange(
+                                        entry.tempId,
+                                        "expense_category_id",
+                                        ""
+                                    );
+                                    onChange(
+                                        entry.tempId,
+                                        "cost_center_id",
+                                        ""
+                                    );
+                                }
+                            }}
                             className="appearance-none w-full px-3 py-2 border border-gray-300 rounded-lg focus:ring-blue-500 focus:border-blue-500 bg-white pr-8 cursor-pointer"
                         >
                             <option value="INCOME">Income</option>
@@ -130,6 +157,84 @@ const ManualEntryForm: React.FC<ManualEntryFormProps> = ({
                     </button>
                 </div>
             </div>
+
+            <div className="mt-4">
+                <PaymentAccountFields
+                    accounts={cashAccounts}
+                    cashAccountId={entry.cash_account_id}
+                    paymentMethod={entry.payment_method}
+                    onChange={(field, value) =>
+                        onChange(entry.tempId, field, value)
+                    }
+                />
+            </div>
+
+            {showExpenseFields && entry.type === "EXPENSE" && (
+                <div className="mt-4 grid grid-cols-1 md:grid-cols-2 gap-4">
+                    <div>
+                        <label className="block text-sm font-medium text-gray-700 mb-1">
+                            Kategori Biaya
+                        </label>
+                        <div className="relative">
+                            <select
+                                value={entry.expense_category_id}
+                                onChange={(e) =>
+                                    onChange(
+                                        entry.tempId,
+                                        "expense_category_id",
+                                        e.target.value
+                                    )
+                                }
+                                className="appearance-none w-full px-3 py-2 border border-gray-300 rounded-lg focus:ring-blue-500 focus:border-blue-500 bg-white pr-8 cursor-pointer"
+                            >
+                                <option value="">Tanpa kategori</option>
+                                {expenseCategories.map((category) => (
+                                    <option
+                                        key={category.uuid}
+                                        value={category.uuid}
+                                    >
+                                        {category.name}
+                                    </option>
+                                ))}
+                            </select>
+                            <ChevronDown
+                                className="absolute right-3 top-1/2 transform -translate-y-1/2 text-gray-400 pointer-events-none"
+                                size={16}
+                            />
+                        </div>
+                    </div>
+
+                    <div>
+                        <label className="block text-sm font-medium text-gray-700 mb-1">
+                            Pusat Biaya
+                        </label>
+                        <div className="relative">
+                            <select
+                                value={entry.cost_center_id}
+                                onChange={(e) =>
+                                    onChange(
+                                        entry.tempId,
+                                        "cost_center_id",
+                                        e.target.value
+                                    )
+                                }
+                                className="appearance-none w-full px-3 py-2 border border-gray-300 rounded-lg focus:ring-blue-500 focus:border-blue-500 bg-white pr-8 cursor-pointer"
+                            >
+                                <option value="">Tidak ada</option>
+                                {costCenters.map((center) => (
+                                    <option key={center.uuid} value={center.uuid}>
+                                        {center.code} - {center.name}
+                                    </option>
+                                ))}
+                            </select>
+                            <ChevronDown
+                                className="absolute right-3 top-1/2 transform -translate-y-1/2 text-gray-400 pointer-events-none"
+                                size={16}
+                            />
+                        </div>
+                    </div>
+                </div>
+            )}
         </div>
     );
 };
@@ -0,0 +1,87 @@
+import React from "react";
+import { ChevronDown } from "lucide-react";
+import {
+    CashAccountResponse,
+    PAYMENT_METHOD_OPTIONS,
+    PaymentMethod,
+} from "../../types/cashAccount";
+import { formatRupiah } from "../../utils/FormatRupiah";
+
+interface PaymentAccountFieldsProps {
+    accounts: CashAccountResponse[];
+    cashAccountId: string;
+    paymentMethod: PaymentMethod | "";
+    onChange: (
+        field: "cash_account_id" | "payment_method",
+        value: string
+    ) => void;
+    disabled?: boolean;
+}
+
+const selectClass =
+    "appearance-none w-full px-3 py-2.5 border border-gray-300 rounded-lg focus:ring-blue-500 focus:border-blue-500 bg-white pr-8 cursor-pointer text-gray-900";
+
+const PaymentAccountFields: React.FC<PaymentAccountFieldsProps> = ({
+    accounts,
+    cashAccountId,
+    paymentMethod,
+    onChange,
+    disabled,
+}) => (
+    <div className="grid grid-cols-1 md:grid-cols-2 gap-4">
+        <div>
+            <label className="block text-sm font-medium text-gray-700 mb-1">
+                Akun Kas / Bank
+            </label>
+            <div className="relative">
+                <select
+                    value={cashAccountId}
+                    onChange={(e) => onChange("cash_account_id", e.target.value)}
+                    className={selectClass}
+                    disabled={disabled}
+                >
+                    <option value="">Pilih akun</option>
+                    {accounts.map((account) => (
+                        <option key={account.uuid} value={account.uuid}>
+                            {account.account_type === "BANK"
+                                ? `${account.name} (${account.bank_name} ${account.account_number})`
+                                : account.name}{" "}
+                            - {formatRupiah(account.balance)}
+                        </option>
+                    ))}
+                </select>
+                <ChevronDown
+                    className="absolute right-3 top-1/2 transform -translate-y-1/2 text-gray-400 pointer-events-none"
+                    size={16}
+                />
+            </div>
+        </div>
+
+        <div>
+            <label className="block text-sm font-medium text-gray-700 mb-1">
+                Metode Pembayaran
+            </label>
+            <div className="relative">
+                <select
+                    value={paymentMethod}
+                    onChange={(e) => onChange("payment_method", e.target.value)}
+                    className={selectClass}
+                    disabled={disabled}
+                >
+                    <option value="">Pilih metode</option>
+                    {PAYMENT_METHOD_OPTIONS.map((option) => (
+                        <option key={option.key} value={option.key}>
+                            {option.label}
+                        </option>
+                    ))}
+                </select>
+                <ChevronDown
+                    className="absolute right-3 top-1/2 transform -translate-y-1/2 text-gray-400 pointer-events-none"
+                    size={16}
+                />
+            </div>
+        </div>
+    </div>
+);
+
+export default PaymentAccountFields;
@@ -10,6 +10,8 @@ import { getDefaultDate } from "../../utils/DefaultDate";
 import { paymentService } from "../../services/paymentService";
 import { SummaryBox, ProgressBox } from "../Box";
 import { MaxDate } from "../../utils/MaxDate";
+import PaymentAccountFields from "../PaymentComponents/PaymentAccountFields";
+import { useCashAccounts } from "../../hooks/cashAccount/useCashAccounts";
 
 interface RecordPaymentModalProps {
     purchase: Purchasing;
@@ -31,12 +33,15 @@ const RecordPaymentModal: React.FC<RecordPaymentModalProps> = ({
         purchase_date: getDefaultDate(),
         stock_code: "",
         total: 0,
+        cash_account_id: "",
+        payment_method: "",
     });
     const [isSubmitting, setIsSubmitting] = useState<boolean>(false);
     const [paymentAmountDisplay, setPaymentAmountDisplay] =
         useState<string>("");
 
     const { showToast } = useToast();
+    const { data: cashAccounts } = useCashAccounts();
 
     const rawPaymentAmount = useMemo(() => formData.total, [formData.total]);
     const remainingBefore = purchase.remaining_amount;
@@ -91,6 +96,13 @@ const RecordPaymentModal: React.FC<RecordPaymentModalProps> = ({
         setFormData({ ...formData, purchase_date: date });
     };
 
+    const handleAccountChange = (
+        field: "cash_account_id" | "payment_method",
+        value: string
+    ) => {
+        setFormData({ ...formData, [field]: value });
+    };
+
     const handleSubmit = async (e: React.FormEvent) => {
         e.preventDefault();
 
@@ -107,6 +119,10 @@ const RecordPaymentModal: React.FC<RecordPaymentModalProps> = ({
             );
             return;
         }
+        if (!formData.cash_account_id || !formData.payment_method) {
+            showToast("Pilih akun kas/bank dan metode pembayaran.", "error");
+            return;
+        }
 
         setIsSubmitting(true);
 
@@ -255,6 +271,14 @@ const RecordPaymentModal: React.FC<RecordPaymentModalProps> = ({
                                     />
                                 </div>
                             </div>
+
+                            <PaymentAccountFields
+                                accounts={cashAccounts}
+                                cashAccountId={formData.cash_account_id || ""}
+                                paymentMethod={formData.payment_method || ""}
+                                onChange={handleAccountChange}
+                                disabled={isSubmitting}
+                            />
                         </div>
 
                         <div className="grid grid-cols-2 gap-4 border p-4 rounded-xl bg-blue-50/50">
@@ -13,6 +13,8 @@ import { getDefaultDate } from "../../utils/DefaultDate";
 import { paymentService } from "../../services/paymentService";
 import { SummaryBox, ProgressBox } from "../Box";
 import { MaxDate } from "../../utils/MaxDate";
+import PaymentAccountFields from "../PaymentComponents/PaymentAccountFields";
+import { useCashAccounts } from "../../hooks/cashAccount/useCashAccounts";
 import { authService } from "../../services/authService";
 
 interface RecordSalesPaymentModalProps {
@@ -37,12 +39,15 @@ const RecordSalesPaymentModal: React.FC<RecordSalesPaymentModalProps> = ({
         sales_date: getDefaultDate(),
         sales_code: "",
         total: 0,
+        cash_account_id: "",
+        payment_method: "",
     });
     const [isSubmitting, setIsSubmitting] = useState<boolean>(false);
     const [paymentAmountDisplay, setPaymentAmountDisplay] =
         useState<string>("");
 
     const { showToast } = useToast();
+    const { data: cashAccounts } = useCashAccounts();
 
     const rawPaymentAmount = useMemo(() => formData.total, [formData.total]);
     const remainingBefore = sale.remaining_amount;
@@ -95,6 +100,13 @@ const RecordSalesPaymentModal: React.FC<RecordSalesPaymentModalProps> = ({
         setFormData({ ...formData, sales_date: date });
     };
 
+    const handleAccountChange = (
+        field: "cash_account_id" | "payment_method",
+        value: string
+    ) => {
+        setFormData({ ...formData, [field]: value });
+    };
+
     const handleSubmit = async (e: React.FormEvent) => {
         e.preventDefault();
 
@@ -111,6 +123,10 @@ const RecordSalesPaymentModal: React.FC<RecordSalesPaymentModalProps> = ({
             );
             return;
         }
+        if (!formData.cash_account_id || !formData.payment_method) {
+            showToast("Pilih akun kas/bank dan metode pembayaran.", "error");
+            return;
+        }
 
         setIsSubmitting(true);
 
@@ -259,6 +275,14 @@ const RecordSalesPaymentModal: React.FC<RecordSalesPaymentModalProps> = ({
                                     />
                                 </div>
                             </div>
+
+                            <PaymentAccountFields
+                                accounts={cashAccounts}
+                                cashAccountId={formData.cash_account_id || ""}
+                                paymentMethod={formData.payment_method || ""}
+                                onChange={handleAccountChange}
+                                disabled={isSubmitting}
+                            />
                         </div>
 
                         <div className="grid grid-cols-2 gap-4 border p-4 rounded-xl bg-blue-50/50">
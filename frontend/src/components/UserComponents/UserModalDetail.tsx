@@ -13,6 +13,8 @@ import { paymentService } from "../../services/paymentService";
 import { useToast } from "../../contexts/ToastContext";
 import PaymentModalDelete from "../PaymentComponents/PaymentModalDelete";
 import { formatNPWP } from "../../utils/FormatNPWP";
+import { useCashAccounts } from "../../hooks/cashAccount/useCashAccounts";
+import { useExpenseOptions } from "../../hooks/expense/useExpenseOptions";
 
 interface UserModalDetailProps {
     user: User;
@@ -39,6 +41,10 @@ const initialManualEntry: ManualEntryFormRequest = {
     total: 0,
     type: "INCOME",
     description: "",
+    cash_account_id: "",
+    payment_method: "",
+    expense_category_id: "",
+    cost_center_id: "",
 };
 
 const UserModalDetail: React.FC<UserModalDetailProps> = ({
@@ -59,6 +65,8 @@ const UserModalDetail: React.FC<UserModalDetailProps> = ({
     );
 
     const { showToast } = useToast();
+    const { data: cashAccounts } = useCashAccounts();
+    const { categories: expenseCategories, costCenters } = useExpenseOptions();
 
     const getRoleBadge = (role: string) => {
         let style = "bg-gray-100 text-gray-800";
@@ -132,6 +140,14 @@ const UserModalDetail: React.FC<UserModalDetailProps> = ({
             );
             return;
         }
+        if (
+            validEntries.some((f) => !f.cash_account_id || !f.payment_method)
+        ) {
+            setManualError(
+                "Harap pilih akun kas/bank dan metode pembayaran untuk setiap entri."
+            );
+            return;
+        }
 
         setIsSubmittingManual(true);
 
@@ -327,6 +343,13 @@ const UserModalDetail: React.FC<UserModalDetailProps> = ({
                                     key={form.tempId}
                                     index={index}
                                     entry={form}
+                                    cashAccounts={cashAccounts}
+                                    expenseCategories={expenseCategories}
+                                    costCenters={costCenters}
+                                    showExpenseFields={
+                                        user.role !== "BUYER" &&
+                                        user.role !== "SUPPLIER"
+                                    }
                                     onChange={handleFormChange}
                                     onRemove={handleRemoveForm}
                                 />
@@ -0,0 +1,50 @@
+import { useState, useEffect, useCallback } from "react";
+import { cashAccountService } from "../../services/cashAccountService";
+import { CashAccountResponse } from "../../types/cashAccount";
+
+interface UseCashAccountsResult {
+    data: CashAccountResponse[];
+    loading: boolean;
+    error: string;
+    refetch: () => Promise<void>;
+}
+
+// useCashAccounts loads the active cash and bank accounts a payment can be
+// paid from or into.
+export const useCashAccounts = (): UseCashAccountsResult => {
+    const [data, setData] = useState<CashAccountResponse[]>([]);
+    const [loading, setLoading] = useState(true);
+    const [error, setError] = useState("");
+
+    const fetchCashAccounts = useCallback(async () => {
+        setLoading(true);
+        setError("");
+
+        try {
+            const response = await cashAccountService.getAllCashAccounts({
+                active_only: true,
+            });
+
+            if (response.status_code === 200) {
+                setData(response.data || []);
+            } else {
+                setError(response.message || "Failed to fetch cash accounts");
+            }
+        } catch (err) {
+            setError("Failed to fetch cash accounts. Please try again.");
+        } finally {
+            setLoading(false);
+        }
+    }, []);
+
+    useEffect(() => {
+        fetchCashAccounts();
+    }, [fetchCashAccounts]);
+
+    return {
+        data,
+        loading,
+        error,
+        refetch: fetchCashAccounts,
+    };
+};
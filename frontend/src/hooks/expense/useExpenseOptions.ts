@@ -0,0 +1,69 @@
+import { useState, useEffect, useCallback } from "react";
+import { expenseService } from "../../services/expenseService";
+import {
+    CostCenterResponse,
+    ExpenseCategoryResponse,
+} from "../../types/expense";
+
+interface UseExpenseOptionsResult {
+    categories: ExpenseCategoryResponse[];
+    costCenters: CostCenterResponse[];
+    loading: boolean;
+    error: string;
+    refetch: () => Promise<void>;
+}
+
+// useExpenseOptions loads the active expense categories and cost centers an
+// operational expense can be booked on.
+export const useExpenseOptions = (): UseExpenseOptionsResult => {
+    const [categories, setCategories] = useState<ExpenseCategoryResponse[]>(
+        []
+    );
+    const [costCenters, setCostCenters] = useState<CostCenterResponse[]>([]);
+    const [loading, setLoading] = useState(true);
+    const [error, setError] = useState("");
+
+    const fetchOptions = useCallback(async () => {
+        setLoading(true);
+        setError("");
+
+        try {
+            const [categoryResponse, costCenterResponse] = await Promise.all([
+                expenseService.getAllExpenseCategories({ active_only: true }),
+                expenseService.getAllCostCenters({ active_only: true }),
+            ]);
+
+            if (categoryResponse.status_code === 200) {
+                setCategories(categoryResponse.data || []);
+            } else {
+                setError(
+                    categoryResponse.message ||
+                        "Failed to fetch expense categories"
+                );
+            }
+            if (costCenterResponse.status_code === 200) {
+                setCostCenters(costCenterResponse.data || []);
+            } else {
+                setError(
+                    costCenterResponse.message || "Failed to fetch cost centers"
+                );
+            }
+        } catch (err) {
+            setError("Failed to fetch expense options. Please try again.");
+        } finally {
+            setLoading(false);
+        }
+    }, []);
+
+    useEffect(() => {
+        fetchOptions();
+    }, [fetchOptions]);
+
+    return {
+        categories,
+        costCenters,
+        loading,
+        error,
+        refetch: fetchOptions,
+    };
+};
@@ -0,0 +1,23 @@
+import { ApiResponse } from "../types";
+import { apiCall } from "./";
+import { CashAccountFilter, CashAccountResponse } from "../types/cashAccount";
+
+export const cashAccountService = {
+    getAllCashAccounts: async (
+        filters: CashAccountFilter = {}
+    ): Promise<ApiResponse<CashAccountResponse[]>> => {
+        const queryParams = new URLSearchParams();
+
+        if (filters.account_type) {
+            queryParams.append("account_type", filters.account_type);
+        }
+        if (filters.active_only) {
+            queryParams.append("active_only", "true");
+        }
+
+        const response = await apiCall<ApiResponse<CashAccountResponse[]>>(
+            `/cash-accounts?${queryParams.toString()}`
+        );
+        return response;
+    },
+};
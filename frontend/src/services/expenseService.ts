@@ -0,0 +1,37 @@
+import { ApiResponse } from "../types";
+import { apiCall } from "./";
+import {
+    CostCenterResponse,
+    ExpenseCategoryResponse,
+    ExpenseFilter,
+} from "../types/expense";
+
+const buildQuery = (filters: ExpenseFilter): string => {
+    const queryParams = new URLSearchParams();
+
+    if (filters.active_only) {
+        queryParams.append("active_only", "true");
+    }
+
+    return queryParams.toString();
+};
+
+export const expenseService = {
+    getAllExpenseCategories: async (
+        filters: ExpenseFilter = {}
+    ): Promise<ApiResponse<ExpenseCategoryResponse[]>> => {
+        const response = await apiCall<ApiResponse<ExpenseCategoryResponse[]>>(
+            `/expense-categories?${buildQuery(filters)}`
+        );
+        return response;
+    },
+
+    getAllCostCenters: async (
+        filters: ExpenseFilter = {}
+    ): Promise<ApiResponse<CostCenterResponse[]>> => {
+        const response = await apiCall<ApiResponse<CostCenterResponse[]>>(
+            `/cost-centers?${buildQuery(filters)}`
+        );
+        return response;
+    },
+};
@@ -30,6 +30,8 @@ export interface StockDistributionData {
 export interface UserData {
     name: string;
     total: number;
+    returned_weight?: number;
+    returned_amount?: number;
 }
 
 export interface SalesSupplierDetail {
@@ -0,0 +1,28 @@
+export type CashAccountType = "CASH" | "BANK";
+
+export type PaymentMethod = "CASH" | "TRANSFER" | "GIRO";
+
+export const PAYMENT_METHOD_OPTIONS: { key: PaymentMethod; label: string }[] = [
+    { key: "CASH", label: "Tunai" },
+    { key: "TRANSFER", label: "Transfer" },
+    { key: "GIRO", label: "Giro" },
+];
+
+export interface CashAccountResponse {
+    uuid: string;
+    name: string;
+    account_type: CashAccountType;
+    bank_name: string;
+    account_number: string;
+    account_holder: string;
+    ledger_account_id: string;
+    ledger_account_code: string;
+    is_active: boolean;
+    balance: number;
+    created_at: string;
+}
+
+export interface CashAccountFilter {
+    account_type?: CashAccountType;
+    active_only?: boolean;
+}
@@ -0,0 +1,22 @@
+export interface ExpenseCategoryResponse {
+    uuid: string;
+    name: string;
+    description: string;
+    ledger_account_id: string;
+    ledger_account_code: string;
+    is_active: boolean;
+    created_at: string;
+}
+
+export interface CostCenterResponse {
+    uuid: string;
+    code: string;
+    name: string;
+    description: string;
+    is_active: boolean;
+    created_at: string;
+}
+
+export interface ExpenseFilter {
+    active_only?: boolean;
+}
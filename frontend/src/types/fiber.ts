@@ -14,17 +14,23 @@ type FiberStatus = "FREE" | "USED";
 
 export interface FiberRequest {
     name: string;
+    fiber_type?: string;
+    capacity?: number;
     status: FiberStatus;
+    location_id?: string;
 }
 
 export interface FiberResponse {
     uuid: string;
     name: string;
+    fiber_type: string;
+    capacity: number;
     status: FiberStatus;
     stock_sort_id: string;
     sale_code: string;
     deleted: boolean;
     sale_id: string;
+    location_id?: string;
     created_at: string;
 }
 
@@ -35,8 +41,33 @@ export interface FiberPaginationResponse {
     data: FiberResponse[];
 }
 
+export interface FiberNamePattern {
+    prefix: string;
+    start: number;
+    end: number;
+    padding: number;
+    fiber_type?: string;
+    capacity?: number;
+}
+
 export interface BulkFiberRequest {
     data: FiberRequest[];
+    pattern?: FiberNamePattern;
+}
+
+export interface FiberImportRow {
+    row: number;
+    name: string;
+    errors: string[] | null;
+}
+
+export interface BulkFiberResponse {
+    success: boolean;
+    total_rows: number;
+    created_count: number;
+    invalid_rows: number;
+    rows: FiberImportRow[];
+    fibers: FiberResponse[];
 }
 
 export interface FiberFilter {
@@ -44,6 +75,7 @@ export interface FiberFilter {
     page_no?: number;
     name?: string;
     status?: string;
+    location_id?: string;
 }
 
 export interface FiberList {
@@ -1,3 +1,5 @@
+import { PaymentMethod } from "./cashAccount";
+
 export type PaymentStatus =
     | "ALL"
     | "PAYMENT_NOT_MADE_YET"
@@ -42,6 +44,11 @@ export interface PaymentResponse {
     description: string;
     sales_id: string;
     purchase_id: string;
+    cash_account_id: string;
+    payment_method: PaymentMethod | "";
+    expense_category_id: string;
+    cost_center_id: string;
+    recurring_expense_id: string;
     is_deleted: boolean;
     created_at: string;
     updated_at: string;
@@ -52,13 +59,21 @@ export interface ManualEntryFormRequest {
     total: number;
     type: PaymentType;
     description: string;
+    cash_account_id: string;
+    payment_method: PaymentMethod | "";
+    // Only for expenses of staff accounts
+    expense_category_id: string;
+    cost_center_id: string;
 }
 
+// cash_account_id and payment_method are left out when paying from a deposit
 export interface CreatePaymentReqeust {
     purchase_id: string;
     purchase_date: string;
     stock_code: string;
     total: number;
+    cash_account_id?: string;
+    payment_method?: PaymentMethod | "";
 }
 
 export interface CreatePaymentSalesRequest {
@@ -66,6 +81,8 @@ export interface CreatePaymentSalesRequest {
     sales_date: string;
     sales_code: string;
     total: number;
+    cash_account_id?: string;
+    payment_method?: PaymentMethod | "";
 }
 
 export interface UserBalanceDepositResponse {
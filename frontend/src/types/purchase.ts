@@ -25,6 +25,9 @@ export interface Purchasing {
     remaining_amount: number;
     payment_status: PaymentStatus;
     last_payment: string | null;
+    vessel?: string;
+    landing_site?: string;
+    catch_date?: string | null;
 }
 
 export interface Supplier {
@@ -37,6 +40,9 @@ export interface CreatePurchasingRequest {
     supplier_id: string;
     purchase_date: string;
     stock_items: CreateStockItem[];
+    vessel?: string;
+    landing_site?: string;
+    catch_date?: string;
 }
 
 export interface PurchaseFilters {
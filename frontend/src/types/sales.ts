@@ -11,6 +11,9 @@ export interface SoldItem {
     price_per_kilogram: number;
     weight: number;
     total_amount: number;
+    price_list_id?: string;
+    list_price?: number;
+    discount_percent?: number;
 }
 
 export interface SoldAddon {
@@ -43,6 +46,10 @@ export interface CreateSaleItemRequest {
     price_per_kilogram: number;
     total_amount: number;
     stock_code: string;
+    manual_price?: boolean;
+    price_list_id?: string;
+    list_price?: number;
+    discount_percent?: number;
 }
 
 export interface CreateAddOnRequest {
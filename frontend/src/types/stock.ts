@@ -24,6 +24,7 @@ export interface StockItem {
     weight: number;
     price_per_kilogram: number;
     total_payment: number;
+    landed_cost?: number;
     is_sorted: boolean;
     stock_sorts: StockSortResponse[];
 }
@@ -39,7 +40,12 @@ export interface StockSortResponse {
     stock_code: string;
     current_weight: number;
     total_cost: number;
+    landed_cost_per_kilogram?: number;
     is_shrinkage: boolean;
+    location_id?: string;
+    location_name?: string;
+    reserved_weight?: number;
+    free_weight?: number;
 }
 
 export interface CreateStockItem {
@@ -60,6 +66,7 @@ export interface StockEntriesFilters {
     purchase_date?: string;
     age_in_day?: string;
     keyword?: string;
+    location_id?: string;
 }
 
 export interface StockSortInfoCardResponse {
@@ -76,6 +83,7 @@ export interface CurrentStockItemInfo {
     already_sortir: number;
     remaining_weight: number;
     total_payment: number;
+    landed_cost?: number;
     is_sorted: boolean;
     stock_sorts: StockSortResponse[];
 }
@@ -92,6 +100,7 @@ export interface StockSortRequest {
     price_per_kilogram: number;
     current_weight: number;
     is_shrinkage: boolean;
+    location_id?: string;
 }
 
 export interface StockConfirmRequest {